@@ -0,0 +1,136 @@
+// Package gocachestore adapts a cache.Cache to the store interface of github.com/eko/gocache, so
+// that banshee backends can be used with gocache's caches, chains, loaders and marshalers.
+//
+// It lives in its own module so that only users of gocache depend on it.
+package gocachestore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/eko/gocache/lib/v4/store"
+	"github.com/zeroxsolutions/barbatos/cache"
+)
+
+// StoreType is the type reported by GetType.
+const StoreType = "banshee"
+
+// ErrNotSupported is returned for gocache features without a banshee counterpart: tagging values
+// on Set and invalidating them by tag.
+var ErrNotSupported = errors.New("gocachestore: operation not supported")
+
+// ErrUnsupportedKey is returned for keys that are neither strings, byte slices nor fmt.Stringer
+// implementations. gocache's caches convert other keys to strings before reaching the store.
+var ErrUnsupportedKey = errors.New("gocachestore: unsupported key type")
+
+// Store implements gocache's store.StoreInterface on top of a cache.Cache.
+type Store struct {
+	cache cache.Cache
+}
+
+var _ store.StoreInterface = (*Store)(nil)
+
+// New creates a gocache store backed by c.
+//
+// Behavior:
+//   - Get returns the stored value as a string, which gocache's marshaler accepts, and a
+//     *store.NotFound error on a miss
+//   - Set stores strings and byte slices as-is; store.WithExpiration maps to SetWithExpiration
+//   - GetWithTTL reports a TTL of 0, as the cache.Cache interface doesn't expose TTLs
+//   - Invalidate and Set with tags fail with ErrNotSupported; Invalidate without tags does nothing
+//   - Clear deletes every key of c, not only the keys written through the store
+//
+// Parameters:
+//   - c: Backend cache
+//
+// Returns:
+//   - store.StoreInterface: The gocache store
+//
+// Example:
+//
+//	users := marshaler.New(gocache.New[any](gocachestore.New(redisCache)))
+//	err := users.Set(ctx, "user:42", user, store.WithExpiration(time.Hour))
+func New(c cache.Cache) store.StoreInterface {
+	return &Store{cache: c}
+}
+
+// Get returns the value stored under key.
+func (s *Store) Get(ctx context.Context, key any) (any, error) {
+	k, err := keyString(key)
+	if err != nil {
+		return nil, err
+	}
+	value, err := s.cache.Get(ctx, k)
+	if err != nil {
+		if errors.Is(err, cache.ErrCacheNil) {
+			return nil, store.NotFoundWithCause(err)
+		}
+		return nil, err
+	}
+	return value, nil
+}
+
+// GetWithTTL returns the value stored under key with a TTL of 0, meaning unknown.
+func (s *Store) GetWithTTL(ctx context.Context, key any) (any, time.Duration, error) {
+	value, err := s.Get(ctx, key)
+	return value, 0, err
+}
+
+// Set stores value under key, with the expiration given by store.WithExpiration if any.
+func (s *Store) Set(ctx context.Context, key any, value any, options ...store.Option) error {
+	k, err := keyString(key)
+	if err != nil {
+		return err
+	}
+	opts := store.ApplyOptions(options...)
+	if len(opts.Tags) > 0 {
+		return ErrNotSupported
+	}
+	if opts.Expiration > 0 {
+		return s.cache.SetWithExpiration(ctx, k, value, opts.Expiration)
+	}
+	return s.cache.Set(ctx, k, value)
+}
+
+// Delete deletes key.
+func (s *Store) Delete(ctx context.Context, key any) error {
+	k, err := keyString(key)
+	if err != nil {
+		return err
+	}
+	return s.cache.Del(ctx, k)
+}
+
+// Invalidate fails with ErrNotSupported when tags are given, and does nothing otherwise.
+func (s *Store) Invalidate(ctx context.Context, options ...store.InvalidateOption) error {
+	if len(store.ApplyInvalidateOptions(options...).Tags) > 0 {
+		return ErrNotSupported
+	}
+	return nil
+}
+
+// Clear deletes every key of the backend.
+func (s *Store) Clear(ctx context.Context) error {
+	return s.cache.DelWithPattern(ctx, "*")
+}
+
+// GetType returns StoreType.
+func (s *Store) GetType() string {
+	return StoreType
+}
+
+// keyString converts a gocache key to a cache key.
+func keyString(key any) (string, error) {
+	switch k := key.(type) {
+	case string:
+		return k, nil
+	case []byte:
+		return string(k), nil
+	case fmt.Stringer:
+		return k.String(), nil
+	default:
+		return "", fmt.Errorf("%w: %T", ErrUnsupportedKey, key)
+	}
+}
@@ -0,0 +1,133 @@
+package gocachestore_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	gocache "github.com/eko/gocache/lib/v4/cache"
+	"github.com/eko/gocache/lib/v4/marshaler"
+	"github.com/eko/gocache/lib/v4/store"
+	"github.com/zeroxsolutions/banshee/adapter/gocachestore"
+	"github.com/zeroxsolutions/banshee/memory"
+)
+
+// manualClock is a banshee.Clock that only moves when the test changes now.
+type manualClock struct {
+	now time.Time
+}
+
+func (c *manualClock) Now() time.Time {
+	return c.now
+}
+
+// TestStore runs the store contract against the adapter over the in-memory backend.
+func TestStore(t *testing.T) {
+	ctx := context.Background()
+	clock := &manualClock{now: time.Now()}
+	s := gocachestore.New(memory.NewWithClock(clock))
+
+	if s.GetType() != gocachestore.StoreType {
+		t.Fatal("unexpected type:", s.GetType())
+	}
+
+	t.Run("Miss", func(t *testing.T) {
+		_, err := s.Get(ctx, "missing")
+		if !errors.Is(err, &store.NotFound{}) {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("SetGet", func(t *testing.T) {
+		for key, value := range map[string]any{"string": "value", "bytes": []byte("value")} {
+			if err := s.Set(ctx, key, value); err != nil {
+				t.Fatal(err)
+			}
+			got, err := s.Get(ctx, []byte(key))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != "value" {
+				t.Fatalf("%s: got %v", key, got)
+			}
+		}
+	})
+
+	t.Run("Expiration", func(t *testing.T) {
+		if err := s.Set(ctx, "expiring", "value", store.WithExpiration(time.Minute)); err != nil {
+			t.Fatal(err)
+		}
+		if got, ttl, err := s.GetWithTTL(ctx, "expiring"); err != nil || got != "value" || ttl != 0 {
+			t.Fatal(got, ttl, err)
+		}
+		clock.now = clock.now.Add(time.Minute)
+		if _, err := s.Get(ctx, "expiring"); !errors.Is(err, &store.NotFound{}) {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		if err := s.Set(ctx, "deleted", "value"); err != nil {
+			t.Fatal(err)
+		}
+		if err := s.Delete(ctx, "deleted"); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := s.Get(ctx, "deleted"); !errors.Is(err, &store.NotFound{}) {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("Tags", func(t *testing.T) {
+		if err := s.Set(ctx, "tagged", "value", store.WithTags([]string{"users"})); !errors.Is(err, gocachestore.ErrNotSupported) {
+			t.Fatal(err)
+		}
+		if err := s.Invalidate(ctx, store.WithInvalidateTags([]string{"users"})); !errors.Is(err, gocachestore.ErrNotSupported) {
+			t.Fatal(err)
+		}
+		if err := s.Invalidate(ctx); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("UnsupportedKey", func(t *testing.T) {
+		if _, err := s.Get(ctx, 42); !errors.Is(err, gocachestore.ErrUnsupportedKey) {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("Clear", func(t *testing.T) {
+		if err := s.Set(ctx, "cleared", "value"); err != nil {
+			t.Fatal(err)
+		}
+		if err := s.Clear(ctx); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := s.Get(ctx, "cleared"); !errors.Is(err, &store.NotFound{}) {
+			t.Fatal(err)
+		}
+	})
+}
+
+// TestStore_Marshaler verifies that gocache's cache and marshaler work on top of the adapter.
+func TestStore_Marshaler(t *testing.T) {
+	type user struct {
+		Name string
+		Age  int
+	}
+
+	ctx := context.Background()
+	m := marshaler.New(gocache.New[any](gocachestore.New(memory.New())))
+
+	if err := m.Set(ctx, "user:1", user{Name: "alice", Age: 30}, store.WithExpiration(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	got, err := m.Get(ctx, "user:1", new(user))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if u, ok := got.(*user); !ok || *u != (user{Name: "alice", Age: 30}) {
+		t.Fatal("unexpected value:", got)
+	}
+}
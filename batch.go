@@ -0,0 +1,219 @@
+package banshee
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/zeroxsolutions/banshee/internal/valueutil"
+	"github.com/zeroxsolutions/barbatos/cache"
+	"github.com/zeroxsolutions/strike/builderutil"
+)
+
+// ErrNilValue is returned by Batch.Set and Batch.SetWithExpiration for nil values, including nil
+// pointers and nil slices; record deleting the key with Batch.Del instead. The Redis cache returns
+// the same error for the nil values it rejects.
+var ErrNilValue = errors.New("banshee: nil value")
+
+// batchOp is one write staged in a Batch: a delete of keys, or a write of value to keys[0].
+type batchOp struct {
+	del        bool
+	keys       []string
+	value      string
+	expiration time.Duration
+}
+
+// stagedValue is the state of a key after the staged writes, used to serve reads.
+type stagedValue struct {
+	value   string
+	deleted bool
+}
+
+// Batch is a unit of work: writes are recorded locally and only reach the cache on Commit.
+// A Batch is safe for concurrent use, but is meant to be filled and committed by one caller.
+type Batch struct {
+	cache   cache.Cache
+	options *BatchOptions
+
+	mu     sync.Mutex
+	ops    []batchOp
+	staged map[string]stagedValue
+}
+
+// NewBatch creates an empty unit of work over c.
+//
+// Behavior:
+//   - Set, SetWithExpiration and Del only record the write; nothing touches c before Commit
+//   - Values are converted to strings when staged, so later changes to them are not seen; nil
+//     values are rejected with ErrNilValue
+//   - Commit applies the writes in order in a single transaction when c implements TxCache, as
+//     the Redis cache does, so other clients observe all of them or none
+//   - On other caches Commit applies the writes one by one and stops at the first error, leaving
+//     the writes before it applied: there is no rollback
+//   - Discard drops the recorded writes without touching c
+//
+// Parameters:
+//   - c: Cache the writes are applied to
+//   - opts: Optional BatchOptions builders created with NewBatchOptions
+//
+// Returns:
+//   - *Batch: The empty batch
+//   - error: An error if building the options fails
+//
+// Example:
+//
+//	batch, err := banshee.NewBatch(redisCache)
+//	if err != nil {
+//	    return err
+//	}
+//	_ = batch.Set("order:42", order)
+//	_ = batch.Del("cart:7")
+//	err = batch.Commit(ctx)
+func NewBatch(c cache.Cache, opts ...builderutil.Lister[BatchOptions]) (*Batch, error) {
+	options, err := builderutil.Build(opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &Batch{cache: c, options: options, staged: map[string]stagedValue{}}, nil
+}
+
+// Set records storing value under key without expiration.
+//
+// Parameters:
+//   - key: Key to store
+//   - value: Value to store
+//
+// Returns:
+//   - error: ErrNilValue, or an error if the value can't be converted to a string
+func (b *Batch) Set(key string, value interface{}) error {
+	return b.SetWithExpiration(key, value, 0)
+}
+
+// SetWithExpiration records storing value under key with the given expiration (0 for none).
+//
+// Parameters:
+//   - key: Key to store
+//   - value: Value to store
+//   - expiration: Time to live of the key once committed
+//
+// Returns:
+//   - error: ErrNilValue, or an error if the value can't be converted to a string
+func (b *Batch) SetWithExpiration(key string, value interface{}, expiration time.Duration) error {
+	if valueutil.IsNil(value) {
+		return ErrNilValue
+	}
+	raw, err := valueutil.Stringify(value)
+	if err != nil {
+		return err
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.ops = append(b.ops, batchOp{keys: []string{key}, value: raw, expiration: expiration})
+	b.staged[key] = stagedValue{value: raw}
+	return nil
+}
+
+// Del records deleting keys.
+//
+// Parameters:
+//   - keys: Keys to delete
+//
+// Returns:
+//   - error: Always nil; present for symmetry with cache.Cache
+func (b *Batch) Del(keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.ops = append(b.ops, batchOp{del: true, keys: append([]string(nil), keys...)})
+	for _, key := range keys {
+		b.staged[key] = stagedValue{deleted: true}
+	}
+	return nil
+}
+
+// Get reads key. With SetReadStaged(true), keys written in the batch read as their staged state;
+// otherwise, and for keys the batch doesn't touch, the cache is read.
+//
+// Parameters:
+//   - ctx: Context for timeout control and request cancellation
+//   - key: Key to read
+//
+// Returns:
+//   - string: The value
+//   - error: cache.ErrCacheNil if the key is missing or deleted in the batch, or the cache error
+func (b *Batch) Get(ctx context.Context, key string) (string, error) {
+	if b.options.ReadStaged {
+		b.mu.Lock()
+		staged, ok := b.staged[key]
+		b.mu.Unlock()
+		if ok {
+			if staged.deleted {
+				return "", cache.ErrCacheNil
+			}
+			return staged.value, nil
+		}
+	}
+	return b.cache.Get(ctx, key)
+}
+
+// Len returns the number of writes recorded and not yet committed or discarded.
+func (b *Batch) Len() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.ops)
+}
+
+// Commit applies the recorded writes to the cache in the order they were recorded and empties
+// the batch, whether or not applying them succeeded. See NewBatch for the atomicity guarantees.
+//
+// Parameters:
+//   - ctx: Context for timeout control and request cancellation
+//
+// Returns:
+//   - error: An error if applying the writes fails
+func (b *Batch) Commit(ctx context.Context) error {
+	b.mu.Lock()
+	ops := b.ops
+	b.ops = nil
+	b.staged = map[string]stagedValue{}
+	b.mu.Unlock()
+
+	if len(ops) == 0 {
+		return nil
+	}
+	if txCache, ok := b.cache.(TxCache); ok {
+		return txCache.Tx(ctx, func(tx CacheTx) error {
+			for _, op := range ops {
+				if err := applyBatchOp(ctx, tx, op); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	}
+	for _, op := range ops {
+		if err := applyBatchOp(ctx, b.cache, op); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Discard drops the recorded writes. The cache is left untouched.
+func (b *Batch) Discard() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.ops = nil
+	b.staged = map[string]stagedValue{}
+}
+
+// applyBatchOp applies one staged write to w, which is either a cache.Cache or a CacheTx.
+func applyBatchOp(ctx context.Context, w CacheTx, op batchOp) error {
+	if op.del {
+		return w.Del(ctx, op.keys...)
+	}
+	return w.SetWithExpiration(ctx, op.keys[0], op.value, op.expiration)
+}
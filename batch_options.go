@@ -0,0 +1,52 @@
+package banshee
+
+// BatchOptions holds the settings of a Batch.
+// This struct is populated through BatchOptionsBuilder and consumed by NewBatch.
+type BatchOptions struct {
+	ReadStaged bool // ReadStaged makes Batch.Get serve values staged in the batch before reading the cache.
+}
+
+// BatchOptionsBuilder provides a builder pattern for constructing BatchOptions.
+// This builder implements the builderutil.Lister interface to work with the functional options pattern.
+type BatchOptionsBuilder struct {
+	Opts []func(*BatchOptions) error // Opts contains the list of option functions to be applied
+}
+
+// SetReadStaged configures whether Batch.Get sees the writes staged in the batch. When enabled, a
+// key set in the batch reads as its staged value and a key deleted in the batch reads as
+// cache.ErrCacheNil, as if the batch were already committed. The default of false always reads
+// the cache.
+//
+// Parameters:
+//   - readStaged: Whether reads see staged writes
+//
+// Returns:
+//   - *BatchOptionsBuilder: The builder instance for method chaining
+func (b *BatchOptionsBuilder) SetReadStaged(readStaged bool) *BatchOptionsBuilder {
+	b.Opts = append(b.Opts, func(o *BatchOptions) error {
+		o.ReadStaged = readStaged
+		return nil
+	})
+	return b
+}
+
+// List returns the slice of option functions accumulated by the builder.
+// This method implements the builderutil.Lister interface.
+//
+// Returns:
+//   - []func(*BatchOptions) error: A slice of option functions that can be applied to configure BatchOptions
+func (b *BatchOptionsBuilder) List() []func(*BatchOptions) error {
+	return b.Opts
+}
+
+// NewBatchOptions creates and returns a new instance of BatchOptionsBuilder.
+//
+// Returns:
+//   - *BatchOptionsBuilder: A new instance of BatchOptionsBuilder ready to be configured
+//
+// Example:
+//
+//	opts := banshee.NewBatchOptions().SetReadStaged(true)
+func NewBatchOptions() *BatchOptionsBuilder {
+	return &BatchOptionsBuilder{}
+}
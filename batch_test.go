@@ -0,0 +1,134 @@
+package banshee_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/zeroxsolutions/banshee"
+	"github.com/zeroxsolutions/banshee/memory"
+	"github.com/zeroxsolutions/barbatos/cache"
+)
+
+// TestBatch_Commit verifies that staged writes reach the cache only on Commit, in order.
+func TestBatch_Commit(t *testing.T) {
+	ctx := context.Background()
+	backend := memory.New()
+	if err := backend.Set(ctx, "stale", "v0"); err != nil {
+		t.Fatal(err)
+	}
+
+	batch, err := banshee.NewBatch(backend)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := batch.Set("a", "1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := batch.SetWithExpiration("b", 2, 0); err != nil {
+		t.Fatal(err)
+	}
+	if err := batch.Del("stale"); err != nil {
+		t.Fatal(err)
+	}
+	if err := batch.Set("a", "3"); err != nil {
+		t.Fatal(err)
+	}
+	if err := batch.Set("c", (*string)(nil)); !errors.Is(err, banshee.ErrNilValue) {
+		t.Fatalf("nil value staged: %v", err)
+	}
+
+	if _, err := backend.Get(ctx, "a"); !errors.Is(err, cache.ErrCacheNil) {
+		t.Fatalf("a written before Commit: %v", err)
+	}
+	if value, err := backend.Get(ctx, "stale"); err != nil || value != "v0" {
+		t.Fatalf("stale deleted before Commit: %q, %v", value, err)
+	}
+	if value, err := batch.Get(ctx, "a"); !errors.Is(err, cache.ErrCacheNil) {
+		t.Fatalf("Get without ReadStaged = %q, %v", value, err)
+	}
+
+	if err := batch.Commit(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if batch.Len() != 0 {
+		t.Fatalf("Len after Commit = %d", batch.Len())
+	}
+	for key, want := range map[string]string{"a": "3", "b": "2"} {
+		if value, err := backend.Get(ctx, key); err != nil || value != want {
+			t.Fatalf("%s = %q, %v; want %q", key, value, err, want)
+		}
+	}
+	if _, err := backend.Get(ctx, "stale"); !errors.Is(err, cache.ErrCacheNil) {
+		t.Fatalf("stale not deleted: %v", err)
+	}
+	if _, err := backend.Get(ctx, "c"); !errors.Is(err, cache.ErrCacheNil) {
+		t.Fatalf("rejected nil value committed: %v", err)
+	}
+}
+
+// TestBatch_ReadStaged verifies that reads see staged writes and deletes when enabled.
+func TestBatch_ReadStaged(t *testing.T) {
+	ctx := context.Background()
+	backend := memory.New()
+	for key, value := range map[string]string{"kept": "k", "removed": "r"} {
+		if err := backend.Set(ctx, key, value); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	batch, err := banshee.NewBatch(backend, banshee.NewBatchOptions().SetReadStaged(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := batch.Set("new", "n"); err != nil {
+		t.Fatal(err)
+	}
+	if err := batch.Del("removed"); err != nil {
+		t.Fatal(err)
+	}
+
+	if value, err := batch.Get(ctx, "new"); err != nil || value != "n" {
+		t.Fatalf("new = %q, %v", value, err)
+	}
+	if _, err := batch.Get(ctx, "removed"); !errors.Is(err, cache.ErrCacheNil) {
+		t.Fatalf("removed: %v", err)
+	}
+	if value, err := batch.Get(ctx, "kept"); err != nil || value != "k" {
+		t.Fatalf("kept = %q, %v", value, err)
+	}
+}
+
+// TestBatch_Discard verifies that a discarded batch leaves no trace in the cache.
+func TestBatch_Discard(t *testing.T) {
+	ctx := context.Background()
+	backend := memory.New()
+	if err := backend.Set(ctx, "kept", "k"); err != nil {
+		t.Fatal(err)
+	}
+
+	batch, err := banshee.NewBatch(backend, banshee.NewBatchOptions().SetReadStaged(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := batch.Set("new", "n"); err != nil {
+		t.Fatal(err)
+	}
+	if err := batch.Del("kept"); err != nil {
+		t.Fatal(err)
+	}
+	batch.Discard()
+
+	if err := batch.Commit(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := backend.Get(ctx, "new"); !errors.Is(err, cache.ErrCacheNil) {
+		t.Fatalf("new written after Discard: %v", err)
+	}
+	if value, err := backend.Get(ctx, "kept"); err != nil || value != "k" {
+		t.Fatalf("kept = %q, %v", value, err)
+	}
+	if _, err := batch.Get(ctx, "new"); !errors.Is(err, cache.ErrCacheNil) {
+		t.Fatalf("staged read survived Discard: %v", err)
+	}
+}
@@ -0,0 +1,134 @@
+// Package bulk provides multi-key maintenance and verification helpers that work on any cache.Cache.
+// The helpers compose the basic cache operations on the client side, so they are convenient for
+// cleanup jobs and tooling but are not atomic: keys may change between the individual steps.
+package bulk
+
+import (
+	"context"
+	"errors"
+
+	"github.com/zeroxsolutions/banshee"
+	"github.com/zeroxsolutions/barbatos/cache"
+)
+
+// batchSize is the number of keys read and deleted per batch.
+const batchSize = 100
+
+// DelWhere deletes the keys matching pattern whose value satisfies predicate and returns how many
+// keys were deleted.
+//
+// Keys are processed in batches: the values of a batch are read, the predicate is applied on the
+// client, and the matching keys of the batch are deleted with a single Del call. The context is
+// checked between batches. DelWhere uses the optional capabilities of c when available:
+//   - banshee.PatternDeleteGuard: a cache that refuses pattern-based deletes makes DelWhere fail
+//     with its error before any key is read
+//   - banshee.KeyWalker: keys are listed one batch at a time instead of all at once with c.Keys
+//   - banshee.MultiGetter: the values of a batch are read with one MGet instead of one Get per key
+//   - banshee.CountingDeleter: the count reports the keys the cache actually deleted; otherwise
+//     it reports the matching keys passed to Del
+//
+// DelWhere is not atomic. A key whose value changes after it was read is still deleted if the old
+// value matched, and keys created during the call may be missed. Keys that disappear before they
+// are read are skipped.
+//
+// Parameters:
+//   - ctx: Context for timeout control and request cancellation
+//   - c: Cache to clean up
+//   - pattern: Pattern of the candidate keys, as accepted by c.Keys
+//   - predicate: Function reporting whether a key should be deleted given its value
+//
+// Returns:
+//   - int64: Number of keys deleted
+//   - error: The guard error, or an error from the cache or the context; keys deleted before it
+//     are counted
+//
+// Example:
+//
+//	deleted, err := bulk.DelWhere(ctx, redisCache, "job:*", func(key, value string) bool {
+//	    return value == "done"
+//	})
+func DelWhere(ctx context.Context, c cache.Cache, pattern string, predicate func(key, value string) bool) (int64, error) {
+	if guard, ok := c.(banshee.PatternDeleteGuard); ok {
+		if err := guard.CheckPatternDelete(); err != nil {
+			return 0, err
+		}
+	}
+
+	var deleted int64
+	process := func(keys []string) error {
+		for start := 0; start < len(keys); start += batchSize {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			end := start + batchSize
+			if end > len(keys) {
+				end = len(keys)
+			}
+			n, err := delBatchWhere(ctx, c, keys[start:end], predicate)
+			deleted += n
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if walker, ok := c.(banshee.KeyWalker); ok {
+		err := walker.WalkKeys(ctx, pattern, process)
+		return deleted, err
+	}
+	keys, err := c.Keys(ctx, pattern)
+	if err != nil {
+		return 0, err
+	}
+	err = process(keys)
+	return deleted, err
+}
+
+// delBatchWhere reads the values of keys, deletes those satisfying predicate and returns how many
+// were deleted.
+func delBatchWhere(ctx context.Context, c cache.Cache, keys []string, predicate func(key, value string) bool) (int64, error) {
+	values, err := readBatch(ctx, c, keys)
+	if err != nil {
+		return 0, err
+	}
+
+	var matches []string
+	for _, key := range keys {
+		value, ok := values[key]
+		if ok && predicate(key, value) {
+			matches = append(matches, key)
+		}
+	}
+	if len(matches) == 0 {
+		return 0, nil
+	}
+
+	if counter, ok := c.(banshee.CountingDeleter); ok {
+		return counter.DelCount(ctx, matches...)
+	}
+	if err := c.Del(ctx, matches...); err != nil {
+		return 0, err
+	}
+	return int64(len(matches)), nil
+}
+
+// readBatch returns the values of the keys that exist, keyed by key, with one MGet when c is a
+// banshee.MultiGetter and one Get per key otherwise.
+func readBatch(ctx context.Context, c cache.Cache, keys []string) (map[string]string, error) {
+	if getter, ok := c.(banshee.MultiGetter); ok {
+		return getter.MGet(ctx, keys...)
+	}
+	values := make(map[string]string, len(keys))
+	for _, key := range keys {
+		value, err := c.Get(ctx, key)
+		if errors.Is(err, cache.ErrCacheNil) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		values[key] = value
+	}
+	return values, nil
+}
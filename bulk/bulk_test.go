@@ -0,0 +1,182 @@
+package bulk_test
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/zeroxsolutions/banshee/bulk"
+	"github.com/zeroxsolutions/banshee/internal/fakecache"
+	"github.com/zeroxsolutions/barbatos/cache"
+)
+
+// TestDelWhere verifies that only keys matching both the pattern and the value predicate are deleted.
+func TestDelWhere(t *testing.T) {
+	c := fakecache.New()
+
+	ctx := context.Background()
+
+	for i := 0; i < 250; i++ {
+		status := "pending"
+		if i%2 == 0 {
+			status = "done"
+		}
+		if err := c.Set(ctx, "job:"+strconv.Itoa(i), status); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := c.Set(ctx, "other:1", "done"); err != nil {
+		t.Fatal(err)
+	}
+
+	deleted, err := bulk.DelWhere(ctx, c, "job:*", func(key, value string) bool {
+		return value == "done"
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if deleted != 125 {
+		t.Log("expected 125 deleted keys, got", deleted)
+		t.FailNow()
+	}
+
+	for i := 0; i < 250; i++ {
+		_, err := c.Get(ctx, "job:"+strconv.Itoa(i))
+		if i%2 == 0 && err != cache.ErrCacheNil {
+			t.Log("done job not deleted:", i)
+			t.FailNow()
+		}
+		if i%2 == 1 && err != nil {
+			t.Log("pending job deleted:", i)
+			t.FailNow()
+		}
+	}
+
+	if _, err := c.Get(ctx, "other:1"); err != nil {
+		t.Log("key outside the pattern deleted")
+		t.FailNow()
+	}
+}
+
+// TestDelWhere_Cancelled verifies that a cancelled context stops the deletion.
+func TestDelWhere_Cancelled(t *testing.T) {
+	c := fakecache.New()
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	if err := c.Set(ctx, "job:1", "done"); err != nil {
+		t.Fatal(err)
+	}
+
+	cancel()
+
+	if _, err := bulk.DelWhere(ctx, c, "job:*", func(key, value string) bool { return true }); err != context.Canceled {
+		t.Log(err)
+		t.FailNow()
+	}
+}
+
+// capableCache adds the optional capabilities DelWhere uses to a fakecache.Cache and records how
+// they are called.
+type capableCache struct {
+	*fakecache.Cache
+	guard   error
+	walks   int
+	mgets   int
+	missing string
+}
+
+func (c *capableCache) CheckPatternDelete() error {
+	return c.guard
+}
+
+func (c *capableCache) WalkKeys(ctx context.Context, pattern string, fn func(keys []string) error) error {
+	c.walks++
+	keys, err := c.Keys(ctx, pattern)
+	if err != nil {
+		return err
+	}
+	return fn(keys)
+}
+
+func (c *capableCache) MGet(ctx context.Context, keys ...string) (map[string]string, error) {
+	c.mgets++
+	values := map[string]string{}
+	for _, key := range keys {
+		if value, err := c.Get(ctx, key); err == nil {
+			values[key] = value
+		}
+	}
+	return values, nil
+}
+
+// DelCount deletes keys, first removing c.missing to mimic a key deleted concurrently.
+func (c *capableCache) DelCount(ctx context.Context, keys ...string) (int64, error) {
+	if c.missing != "" {
+		if err := c.Del(ctx, c.missing); err != nil {
+			return 0, err
+		}
+	}
+	var count int64
+	for _, key := range keys {
+		if _, err := c.Get(ctx, key); err == nil {
+			count++
+		}
+	}
+	return count, c.Del(ctx, keys...)
+}
+
+// TestDelWhere_Capabilities verifies that DelWhere walks keys in batches, reads them with MGet and
+// reports the count returned by DelCount.
+func TestDelWhere_Capabilities(t *testing.T) {
+	c := &capableCache{Cache: fakecache.New(), missing: "job:0"}
+
+	ctx := context.Background()
+
+	for i := 0; i < 250; i++ {
+		if err := c.Set(ctx, "job:"+strconv.Itoa(i), "done"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	deleted, err := bulk.DelWhere(ctx, c, "job:*", func(key, value string) bool { return true })
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if deleted != 249 {
+		t.Log("expected 249 deleted keys, got", deleted)
+		t.FailNow()
+	}
+
+	if c.walks != 1 || c.mgets != 3 {
+		t.Log("expected 1 walk and 3 MGet calls, got", c.walks, c.mgets)
+		t.FailNow()
+	}
+}
+
+// TestDelWhere_Guard verifies that a cache refusing pattern-based deletes stops DelWhere before
+// any key is deleted.
+func TestDelWhere_Guard(t *testing.T) {
+	disabled := errors.New("disabled")
+	c := &capableCache{Cache: fakecache.New(), guard: disabled}
+
+	ctx := context.Background()
+
+	if err := c.Set(ctx, "job:1", "done"); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := bulk.DelWhere(ctx, c, "job:*", func(key, value string) bool { return true }); !errors.Is(err, disabled) {
+		t.Log(err)
+		t.FailNow()
+	}
+
+	if _, err := c.Get(ctx, "job:1"); err != nil {
+		t.Log("key deleted despite the guard")
+		t.FailNow()
+	}
+}
@@ -0,0 +1,175 @@
+package bulk
+
+import (
+	"context"
+	"errors"
+	"sort"
+
+	"github.com/zeroxsolutions/barbatos/cache"
+	"github.com/zeroxsolutions/strike/builderutil"
+)
+
+// DefaultMaxDifferences is the number of differences Diff collects when no limit is configured.
+const DefaultMaxDifferences = 1000
+
+// DiffReport lists the differences found by Diff. Each list is sorted.
+type DiffReport struct {
+	Compared   int      // Compared is the number of distinct keys examined.
+	OnlyInA    []string // OnlyInA holds keys present in a but not in b.
+	OnlyInB    []string // OnlyInB holds keys present in b but not in a.
+	Mismatched []string // Mismatched holds keys present in both caches with different values.
+	Truncated  bool     // Truncated is true when the difference limit was reached and the comparison stopped early.
+}
+
+// Equal reports whether no difference was found.
+func (r DiffReport) Equal() bool {
+	return len(r.OnlyInA) == 0 && len(r.OnlyInB) == 0 && len(r.Mismatched) == 0
+}
+
+// DiffOptions holds the settings of a Diff call.
+// This struct is populated through DiffOptionsBuilder.
+type DiffOptions struct {
+	MaxDifferences int // MaxDifferences is the number of differences after which Diff stops.
+}
+
+// DiffOptionsBuilder provides a builder pattern for constructing DiffOptions.
+// This builder implements the builderutil.Lister interface to work with the functional options pattern.
+type DiffOptionsBuilder struct {
+	Opts []func(*DiffOptions) error // Opts contains the list of option functions to be applied
+}
+
+// SetMaxDifferences configures how many differences Diff collects before stopping, which bounds
+// the report size and the work done on badly diverged caches.
+//
+// Parameters:
+//   - max: Maximum number of differences, must be positive
+//
+// Returns:
+//   - *DiffOptionsBuilder: The builder instance for method chaining
+func (b *DiffOptionsBuilder) SetMaxDifferences(max int) *DiffOptionsBuilder {
+	b.Opts = append(b.Opts, func(o *DiffOptions) error {
+		if max <= 0 {
+			return errors.New("bulk: max differences must be positive")
+		}
+		o.MaxDifferences = max
+		return nil
+	})
+	return b
+}
+
+// List returns the slice of option functions accumulated by the builder.
+// This method implements the builderutil.Lister interface.
+//
+// Returns:
+//   - []func(*DiffOptions) error: A slice of option functions that can be applied to configure DiffOptions
+func (b *DiffOptionsBuilder) List() []func(*DiffOptions) error {
+	return b.Opts
+}
+
+// NewDiffOptions creates and returns a new instance of DiffOptionsBuilder.
+//
+// Returns:
+//   - *DiffOptionsBuilder: A new instance of DiffOptionsBuilder ready to be configured
+//
+// Example:
+//
+//	opts := bulk.NewDiffOptions().SetMaxDifferences(100)
+func NewDiffOptions() *DiffOptionsBuilder {
+	return &DiffOptionsBuilder{}
+}
+
+// Diff compares the keys matching pattern in two caches, e.g. to validate a migration or a
+// dual-write setup, and reports the keys found in only one of them and the keys whose values
+// differ.
+//
+// The comparison is a best-effort, point-in-time check: keys are listed with Keys and values read
+// one by one, so writes happening during the call can show up as spurious differences or hide real
+// ones. Run it while writes are paused, or repeat it and only trust differences that persist. TTLs
+// are not compared.
+//
+// Keys are compared in sorted order and the comparison stops once MaxDifferences differences
+// (DefaultMaxDifferences unless configured) have been collected, setting Truncated.
+//
+// Parameters:
+//   - ctx: Context for timeout control and request cancellation
+//   - a: First cache, typically the source of a migration
+//   - b: Second cache, typically the destination
+//   - pattern: Pattern of the keys to compare, as accepted by Keys
+//   - opts: Optional DiffOptions builders created with NewDiffOptions
+//
+// Returns:
+//   - DiffReport: The differences found
+//   - error: An error if building the options fails, a cache fails, or the context is done
+//
+// Example:
+//
+//	report, err := bulk.Diff(ctx, oldCache, newCache, "user:*")
+//	if err == nil && !report.Equal() {
+//	    log.Printf("%d missing, %d mismatched", len(report.OnlyInA), len(report.Mismatched))
+//	}
+func Diff(ctx context.Context, a, b cache.Cache, pattern string, opts ...builderutil.Lister[DiffOptions]) (DiffReport, error) {
+	options, err := builderutil.Build(append([]builderutil.Lister[DiffOptions]{NewDiffOptions().SetMaxDifferences(DefaultMaxDifferences)}, opts...)...)
+	if err != nil {
+		return DiffReport{}, err
+	}
+
+	keysA, err := a.Keys(ctx, pattern)
+	if err != nil {
+		return DiffReport{}, err
+	}
+	keysB, err := b.Keys(ctx, pattern)
+	if err != nil {
+		return DiffReport{}, err
+	}
+
+	inB := make(map[string]bool, len(keysB))
+	for _, key := range keysB {
+		inB[key] = true
+	}
+	all := append([]string{}, keysB...)
+	for _, key := range keysA {
+		if !inB[key] {
+			all = append(all, key)
+		}
+	}
+	sort.Strings(all)
+
+	var report DiffReport
+	differences := 0
+	for i, key := range all {
+		if i%batchSize == 0 {
+			if err := ctx.Err(); err != nil {
+				return report, err
+			}
+		}
+		if differences >= options.MaxDifferences {
+			report.Truncated = true
+			break
+		}
+		report.Compared++
+
+		valueA, errA := a.Get(ctx, key)
+		if errA != nil && !errors.Is(errA, cache.ErrCacheNil) {
+			return report, errA
+		}
+		valueB, errB := b.Get(ctx, key)
+		if errB != nil && !errors.Is(errB, cache.ErrCacheNil) {
+			return report, errB
+		}
+
+		switch {
+		case errA != nil && errB != nil:
+			// Expired in both caches since the keys were listed.
+		case errB != nil:
+			report.OnlyInA = append(report.OnlyInA, key)
+			differences++
+		case errA != nil:
+			report.OnlyInB = append(report.OnlyInB, key)
+			differences++
+		case valueA != valueB:
+			report.Mismatched = append(report.Mismatched, key)
+			differences++
+		}
+	}
+	return report, nil
+}
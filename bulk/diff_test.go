@@ -0,0 +1,82 @@
+package bulk_test
+
+import (
+	"context"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/zeroxsolutions/banshee/bulk"
+	"github.com/zeroxsolutions/banshee/memory"
+)
+
+// TestDiff compares two in-memory caches with intentional differences.
+func TestDiff(t *testing.T) {
+	a := memory.New()
+	b := memory.New()
+
+	ctx := context.Background()
+
+	seed := map[*memory.Cache]map[string]string{
+		a: {"user:1": "alice", "user:2": "bob", "user:3": "carol", "user:4": "dave", "order:1": "x"},
+		b: {"user:1": "alice", "user:2": "bobby", "user:4": "dave", "user:5": "eve"},
+	}
+	for c, values := range seed {
+		for key, value := range values {
+			if err := c.Set(ctx, key, value); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	report, err := bulk.Diff(ctx, a, b, "user:*")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := bulk.DiffReport{
+		Compared:   5,
+		OnlyInA:    []string{"user:3"},
+		OnlyInB:    []string{"user:5"},
+		Mismatched: []string{"user:2"},
+	}
+
+	if !reflect.DeepEqual(report, want) || report.Equal() {
+		t.Logf("unexpected report: %+v", report)
+		t.FailNow()
+	}
+}
+
+// TestDiff_MaxDifferences verifies that the comparison stops once the limit is reached.
+func TestDiff_MaxDifferences(t *testing.T) {
+	a := memory.New()
+	b := memory.New()
+
+	ctx := context.Background()
+
+	for i := 0; i < 10; i++ {
+		if err := a.Set(ctx, "key:"+strconv.Itoa(i), i); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	report, err := bulk.Diff(ctx, a, b, "*", bulk.NewDiffOptions().SetMaxDifferences(3))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(report.OnlyInA) != 3 || !report.Truncated {
+		t.Logf("unexpected report: %+v", report)
+		t.FailNow()
+	}
+
+	report, err = bulk.Diff(ctx, a, a, "*")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !report.Equal() || report.Compared != 10 || report.Truncated {
+		t.Logf("unexpected report: %+v", report)
+		t.FailNow()
+	}
+}
@@ -0,0 +1,173 @@
+package banshee
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/zeroxsolutions/banshee/internal/valueutil"
+	"github.com/zeroxsolutions/barbatos/cache"
+	"github.com/zeroxsolutions/strike/builderutil"
+)
+
+// ErrByteRateExceeded is returned by the writes of a ByteRateLimitedCache that exceed its byte
+// rate when it rejects them, and by writes larger than its burst in any mode.
+var ErrByteRateExceeded = errors.New("banshee: byte rate exceeded")
+
+// ByteRateLimitedCache is a cache.Cache decorator capping the number of bytes written per second,
+// e.g. to keep a metered Redis within its egress budget.
+type ByteRateLimitedCache struct {
+	cache   cache.Cache
+	rate    float64
+	burst   int64
+	options *ByteRateOptions
+
+	mu     sync.Mutex
+	tokens float64   // tokens is the remaining budget in bytes; negative while writes wait.
+	last   time.Time // last is when tokens was last refilled.
+}
+
+var _ cache.Cache = (*ByteRateLimitedCache)(nil)
+
+// NewByteRateLimitedCache wraps inner so that writes stay within bytesPerSecond, using a token
+// bucket holding up to one burst of bytes.
+//
+// Accounting:
+//   - Only the values of Set and SetWithExpiration are counted, by the size of the string the
+//     backend stores for them (strings and []byte as-is, encoding.BinaryMarshaler marshaled,
+//     other values formatted); keys and protocol overhead are not
+//   - Reads and deletes pass through without being counted
+//   - A write over the budget waits for it to refill, or fails with ErrByteRateExceeded with
+//     SetReject(true); a waiting write gives its bytes back if its context is done first
+//   - Writes reserve their bytes in arrival order, so a large write can't be starved by small ones
+//
+// Parameters:
+//   - inner: Underlying cache
+//   - bytesPerSecond: Sustained write rate, in bytes per second
+//   - opts: Optional ByteRateOptions builders created with NewByteRateOptions
+//
+// Returns:
+//   - *ByteRateLimitedCache: The rate-limited cache
+//   - error: An error if bytesPerSecond is not positive or building the options fails
+//
+// Example:
+//
+//	metered, err := banshee.NewByteRateLimitedCache(redisCache, 1<<20,
+//	    banshee.NewByteRateOptions().SetBurst(8<<20))
+func NewByteRateLimitedCache(inner cache.Cache, bytesPerSecond int64, opts ...builderutil.Lister[ByteRateOptions]) (*ByteRateLimitedCache, error) {
+	if bytesPerSecond <= 0 {
+		return nil, errors.New("banshee: bytes per second must be positive")
+	}
+	options, err := builderutil.Build(opts...)
+	if err != nil {
+		return nil, err
+	}
+	if options.Clock == nil {
+		options.Clock = SystemClock
+	}
+	burst := options.Burst
+	if burst == 0 {
+		burst = bytesPerSecond
+	}
+	return &ByteRateLimitedCache{
+		cache:   inner,
+		rate:    float64(bytesPerSecond),
+		burst:   burst,
+		options: options,
+		tokens:  float64(burst),
+		last:    options.Clock.Now(),
+	}, nil
+}
+
+// IsConnected reports the connection status of the underlying cache.
+func (b *ByteRateLimitedCache) IsConnected(ctx context.Context) bool {
+	return b.cache.IsConnected(ctx)
+}
+
+// Keys returns the keys matching pattern in the underlying cache.
+func (b *ByteRateLimitedCache) Keys(ctx context.Context, pattern string) ([]string, error) {
+	return b.cache.Keys(ctx, pattern)
+}
+
+// Get retrieves the value stored under key from the underlying cache.
+func (b *ByteRateLimitedCache) Get(ctx context.Context, key string) (string, error) {
+	return b.cache.Get(ctx, key)
+}
+
+// Set stores value under key once the byte budget allows it.
+func (b *ByteRateLimitedCache) Set(ctx context.Context, key string, value interface{}) error {
+	if err := b.take(ctx, value); err != nil {
+		return err
+	}
+	return b.cache.Set(ctx, key, value)
+}
+
+// SetWithExpiration stores value under key with the given expiration once the byte budget
+// allows it.
+func (b *ByteRateLimitedCache) SetWithExpiration(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
+	if err := b.take(ctx, value); err != nil {
+		return err
+	}
+	return b.cache.SetWithExpiration(ctx, key, value, expiration)
+}
+
+// Del deletes keys from the underlying cache.
+func (b *ByteRateLimitedCache) Del(ctx context.Context, keys ...string) error {
+	return b.cache.Del(ctx, keys...)
+}
+
+// DelWithPattern deletes the keys matching pattern from the underlying cache.
+func (b *ByteRateLimitedCache) DelWithPattern(ctx context.Context, pattern string) error {
+	return b.cache.DelWithPattern(ctx, pattern)
+}
+
+// Close closes the underlying cache.
+func (b *ByteRateLimitedCache) Close() error {
+	return b.cache.Close()
+}
+
+// take withdraws the size of value from the budget, waiting for it to refill unless writes over
+// the rate are rejected.
+func (b *ByteRateLimitedCache) take(ctx context.Context, value interface{}) error {
+	s, err := valueutil.Stringify(value)
+	if err != nil {
+		return err
+	}
+	n := float64(len(s))
+	if int64(len(s)) > b.burst {
+		return ErrByteRateExceeded
+	}
+
+	b.mu.Lock()
+	now := b.options.Clock.Now()
+	b.tokens += now.Sub(b.last).Seconds() * b.rate
+	if b.tokens > float64(b.burst) {
+		b.tokens = float64(b.burst)
+	}
+	b.last = now
+	if b.tokens >= n {
+		b.tokens -= n
+		b.mu.Unlock()
+		return nil
+	}
+	if b.options.Reject {
+		b.mu.Unlock()
+		return ErrByteRateExceeded
+	}
+	wait := time.Duration((n - b.tokens) / b.rate * float64(time.Second))
+	b.tokens -= n
+	b.mu.Unlock()
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		b.mu.Lock()
+		b.tokens += n
+		b.mu.Unlock()
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,96 @@
+package banshee
+
+import (
+	"errors"
+)
+
+// ByteRateOptions holds the settings of a ByteRateLimitedCache.
+// This struct is populated through ByteRateOptionsBuilder and consumed by NewByteRateLimitedCache.
+type ByteRateOptions struct {
+	Burst  int64 // Burst is the number of bytes that can be written at once; bytesPerSecond when 0.
+	Reject bool  // Reject makes writes over the rate fail with ErrByteRateExceeded instead of waiting.
+	Clock  Clock // Clock measures the refill of the budget; SystemClock when nil.
+}
+
+// ByteRateOptionsBuilder provides a builder pattern for constructing ByteRateOptions.
+// This builder implements the builderutil.Lister interface to work with the functional options pattern.
+type ByteRateOptionsBuilder struct {
+	Opts []func(*ByteRateOptions) error // Opts contains the list of option functions to be applied
+}
+
+// SetBurst configures the size of the byte budget, i.e. how many bytes can be written at once
+// after a quiet period. It also bounds the size of a single write: larger values are always
+// rejected. The default is one second worth of bytes.
+//
+// Parameters:
+//   - burst: Budget size in bytes, must be positive
+//
+// Returns:
+//   - *ByteRateOptionsBuilder: The builder instance for method chaining
+func (b *ByteRateOptionsBuilder) SetBurst(burst int64) *ByteRateOptionsBuilder {
+	b.Opts = append(b.Opts, func(o *ByteRateOptions) error {
+		if burst <= 0 {
+			return errors.New("banshee: burst must be positive")
+		}
+		o.Burst = burst
+		return nil
+	})
+	return b
+}
+
+// SetReject configures what happens to a write exceeding the rate: with false, the default, it
+// waits until the budget allows it or its context is done; with true, it fails immediately with
+// ErrByteRateExceeded.
+//
+// Parameters:
+//   - reject: true to reject writes over the rate, false to delay them
+//
+// Returns:
+//   - *ByteRateOptionsBuilder: The builder instance for method chaining
+func (b *ByteRateOptionsBuilder) SetReject(reject bool) *ByteRateOptionsBuilder {
+	b.Opts = append(b.Opts, func(o *ByteRateOptions) error {
+		o.Reject = reject
+		return nil
+	})
+	return b
+}
+
+// SetClock configures the clock measuring how much budget has been refilled, so tests can
+// exercise the limit without sleeping. Delayed writes still wait in real time.
+//
+// Parameters:
+//   - clock: Clock telling the current time, must not be nil
+//
+// Returns:
+//   - *ByteRateOptionsBuilder: The builder instance for method chaining
+func (b *ByteRateOptionsBuilder) SetClock(clock Clock) *ByteRateOptionsBuilder {
+	b.Opts = append(b.Opts, func(o *ByteRateOptions) error {
+		if clock == nil {
+			return errors.New("banshee: clock must not be nil")
+		}
+		o.Clock = clock
+		return nil
+	})
+	return b
+}
+
+// List returns the slice of option functions accumulated by the builder.
+// This method implements the builderutil.Lister interface.
+//
+// Returns:
+//   - []func(*ByteRateOptions) error: A slice of option functions that can be applied to configure ByteRateOptions
+func (b *ByteRateOptionsBuilder) List() []func(*ByteRateOptions) error {
+	return b.Opts
+}
+
+// NewByteRateOptions creates and returns a new instance of ByteRateOptionsBuilder.
+//
+// Returns:
+//   - *ByteRateOptionsBuilder: A new instance of ByteRateOptionsBuilder ready to be configured
+//
+// Example:
+//
+//	opts := banshee.NewByteRateOptions().SetBurst(4 << 20).SetReject(true)
+func NewByteRateOptions() *ByteRateOptionsBuilder {
+	return &ByteRateOptionsBuilder{}
+}
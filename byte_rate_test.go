@@ -0,0 +1,108 @@
+package banshee_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/zeroxsolutions/banshee"
+	"github.com/zeroxsolutions/banshee/memory"
+)
+
+// TestByteRateLimitedCache_Throttle verifies that a burst of large writes is spread over time
+// according to the byte rate.
+func TestByteRateLimitedCache_Throttle(t *testing.T) {
+	ctx := context.Background()
+	c, err := banshee.NewByteRateLimitedCache(memory.New(), 10000)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	value := strings.Repeat("x", 2500)
+	start := time.Now()
+	for i := 0; i < 8; i++ {
+		if err := c.Set(ctx, "key", value); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// 20000 bytes at 10000 bytes per second, the first 10000 covered by the burst.
+	if elapsed := time.Since(start); elapsed < 900*time.Millisecond || elapsed > 2*time.Second {
+		t.Fatal("unexpected throttling:", elapsed)
+	}
+	if got, err := c.Get(ctx, "key"); err != nil || got != value {
+		t.Fatal("the write should go through once allowed:", err)
+	}
+}
+
+// TestByteRateLimitedCache_Reject verifies rejection over the rate, refilling over time, and that
+// reads, deletes and oversized writes behave as documented.
+func TestByteRateLimitedCache_Reject(t *testing.T) {
+	ctx := context.Background()
+	clock := &setClock{now: time.Now()}
+	c, err := banshee.NewByteRateLimitedCache(memory.New(), 100,
+		banshee.NewByteRateOptions().SetBurst(200).SetReject(true).SetClock(clock))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	value := strings.Repeat("x", 100)
+	for i := 0; i < 2; i++ {
+		if err := c.SetWithExpiration(ctx, "key", value, time.Minute); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := c.Set(ctx, "key", value); !errors.Is(err, banshee.ErrByteRateExceeded) {
+		t.Fatal(err)
+	}
+	if _, err := c.Get(ctx, "key"); err != nil {
+		t.Fatal("reads should not be limited:", err)
+	}
+	if err := c.Del(ctx, "key"); err != nil {
+		t.Fatal("deletes should not be limited:", err)
+	}
+
+	clock.now = clock.now.Add(time.Second)
+	if err := c.Set(ctx, "key", value); err != nil {
+		t.Fatal("the budget should have refilled:", err)
+	}
+
+	if err := c.Set(ctx, "key", strings.Repeat("x", 201)); !errors.Is(err, banshee.ErrByteRateExceeded) {
+		t.Fatal("writes larger than the burst should be rejected:", err)
+	}
+}
+
+// TestByteRateLimitedCache_Cancel verifies that a waiting write stops with its context and gives
+// its bytes back.
+func TestByteRateLimitedCache_Cancel(t *testing.T) {
+	clock := &setClock{now: time.Now()}
+	c, err := banshee.NewByteRateLimitedCache(memory.New(), 100, banshee.NewByteRateOptions().SetClock(clock))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	value := strings.Repeat("x", 100)
+	if err := c.Set(context.Background(), "key", value); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := c.Set(ctx, "key", value); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatal(err)
+	}
+
+	// Without the cancelled reservation, one second of refill is enough for the next write.
+	clock.now = clock.now.Add(time.Second)
+	quick, cancelQuick := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancelQuick()
+	if err := c.Set(quick, "key", value); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := banshee.NewByteRateLimitedCache(memory.New(), 0); err == nil {
+		t.Fatal("expected an error for a zero rate")
+	}
+}
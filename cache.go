@@ -0,0 +1,410 @@
+// Package banshee defines the optional capability interfaces shared by the banshee cache backends.
+// The core cache.Cache interface lives in github.com/zeroxsolutions/barbatos/cache; the interfaces
+// here describe additional operations that only some backends support. Callers discover them with
+// a type assertion and fall back gracefully when a backend does not implement them. The package
+// also hosts decorators that work on any cache.Cache and use these interfaces when available,
+// such as WithSlidingExpiration and NewKeyTransformCache.
+//
+// Example:
+//
+//	if counters, ok := c.(banshee.CounterCache); ok {
+//	    seats, applied, err := counters.IncrByCeil(ctx, "seats:flight:42", 1, 180)
+//	    // ...
+//	}
+package banshee
+
+import (
+	"context"
+	"time"
+)
+
+// CounterCache is implemented by caches that support atomic counter operations.
+// All methods operate on integer values stored as strings and are atomic on the server,
+// so concurrent callers never lose updates.
+type CounterCache interface {
+
+	// IncrByCeil increments the counter at key by delta only if the result stays less than
+	// or equal to ceil. A missing key counts as 0. It returns the counter value after the
+	// call (unchanged when the increment was refused) and whether the increment was applied.
+	IncrByCeil(ctx context.Context, key string, delta, ceil int64) (int64, bool, error)
+
+	// IncrFirstSeen increments the counter at key by 1 and, when the increment creates the key,
+	// gives it a TTL of window. It returns the new count and whether this was the first
+	// occurrence within the window, i.e. whether the count is 1.
+	IncrFirstSeen(ctx context.Context, key string, window time.Duration) (int64, bool, error)
+}
+
+// QuotaConsumer is implemented by caches that can check and consume a per-key quota, resetting at
+// the end of a fixed window, as a single atomic step.
+type QuotaConsumer interface {
+
+	// ConsumeQuota consumes cost units of the quota of key, limited to limit units per window,
+	// unless that would exceed the limit. It reports whether the units were consumed, how many
+	// remain, and how long until the window resets.
+	ConsumeQuota(ctx context.Context, key string, limit int64, window time.Duration, cost int64) (allowed bool, remaining int64, resetAfter time.Duration, err error)
+}
+
+// RateMeter is implemented by caches that can measure the live rate of events per key over a
+// sliding window.
+type RateMeter interface {
+
+	// Rate records one event for key and returns the number of events per second over the last
+	// window, this one included.
+	Rate(ctx context.Context, key string, window time.Duration) (float64, error)
+}
+
+// HeartbeatCache is implemented by caches that can record a heartbeat and read the previous one as
+// a single atomic step, e.g. for worker leases.
+type HeartbeatCache interface {
+
+	// Heartbeat stores the current time under key with the given TTL and returns the time of the
+	// previous heartbeat, or cache.ErrCacheNil on the first one (the heartbeat is still stored).
+	Heartbeat(ctx context.Context, key string, ttl time.Duration) (previous time.Time, err error)
+}
+
+// ReliableQueue is implemented by caches that provide a work queue with at-least-once delivery:
+// a reserved job stays invisible to other consumers until it is acknowledged, and is delivered
+// again once its visibility timeout expires.
+type ReliableQueue interface {
+
+	// Enqueue appends jobs to the tail of queue.
+	Enqueue(ctx context.Context, queue string, jobs ...string) error
+
+	// Reserve removes the job at the head of queue and hides it for visibility, returning it with
+	// the token that acknowledges it, or cache.ErrCacheNil when no job is ready.
+	Reserve(ctx context.Context, queue string, visibility time.Duration) (job string, ackToken string, err error)
+
+	// Ack acknowledges a reserved job so that it is never delivered again.
+	Ack(ctx context.Context, queue, ackToken string) error
+}
+
+// PublishingCache is implemented by caches that can write a value and announce the change on a
+// Pub/Sub channel as a single atomic step, so a crash between the two can't drop the notification.
+type PublishingCache interface {
+
+	// SetAndPublish stores value under key with the given TTL (0 for none) and publishes key on
+	// channel atomically. Delivery remains best-effort: subscribers that are not connected at
+	// publish time never receive the message.
+	SetAndPublish(ctx context.Context, key string, value interface{}, ttl time.Duration, channel string) error
+}
+
+// Expirer is implemented by caches that can change the expiration of an existing key without
+// rewriting its value.
+type Expirer interface {
+
+	// Expire sets the time-to-live of key to ttl. It reports whether the key exists; a missing key
+	// is not an error. A ttl that isn't positive is rejected with an error and leaves key untouched.
+	Expire(ctx context.Context, key string, ttl time.Duration) (bool, error)
+
+	// ExpireAt makes key expire at the given time. It reports whether the key exists; a missing key
+	// is not an error. A time that isn't in the future is rejected with an error and leaves key
+	// untouched.
+	ExpireAt(ctx context.Context, key string, at time.Time) (bool, error)
+}
+
+// GetDelCache is implemented by caches that can read a value and delete it in a single atomic
+// operation, so that concurrent callers can't both obtain it.
+type GetDelCache interface {
+
+	// GetDel returns the value stored under key and deletes the key, or cache.ErrCacheNil when
+	// the key doesn't exist.
+	GetDel(ctx context.Context, key string) (string, error)
+}
+
+// ConfigCache is implemented by caches exposing the runtime configuration of their server.
+type ConfigCache interface {
+
+	// ConfigGet returns the configuration parameters matching parameter, which may be a glob
+	// pattern, keyed by parameter name.
+	ConfigGet(ctx context.Context, parameter string) (map[string]string, error)
+
+	// ConfigSet changes a configuration parameter at runtime. Implementations may refuse it
+	// unless explicitly enabled.
+	ConfigSet(ctx context.Context, parameter, value string) error
+}
+
+// GetExCache is implemented by caches that can read a value and change its expiration in a
+// single atomic operation.
+type GetExCache interface {
+
+	// GetEx returns the value stored under key and, when ttl is positive, resets its expiration
+	// to ttl. A ttl of 0 leaves the expiration unchanged.
+	GetEx(ctx context.Context, key string, ttl time.Duration) (string, error)
+
+	// GetExPersist returns the value stored under key and removes its expiration.
+	GetExPersist(ctx context.Context, key string) (string, error)
+}
+
+// GetSetCache is implemented by caches that can write a value and return the one it replaced in
+// a single atomic operation.
+type GetSetCache interface {
+
+	// GetSet stores value under key without expiration and returns the value it replaced, or
+	// cache.ErrCacheNil when the key didn't exist (the value is still stored).
+	GetSet(ctx context.Context, key string, value interface{}) (string, error)
+
+	// GetSetKeepTTL is like GetSet but keeps the key's remaining TTL.
+	GetSetKeepTTL(ctx context.Context, key string, value interface{}) (string, error)
+}
+
+// RotateCache is implemented by caches that can replace a value while keeping its expiration, in
+// a single atomic operation.
+type RotateCache interface {
+
+	// RotateKeepTTL stores newValue under key, keeping the key's remaining TTL, and returns the
+	// value it replaced. It returns cache.ErrCacheNil and stores nothing if key doesn't exist.
+	RotateKeepTTL(ctx context.Context, key string, newValue interface{}) (string, error)
+}
+
+// HashCache is implemented by caches that store Redis-style hashes: maps of string fields to
+// string values under a single key.
+type HashCache interface {
+
+	// HGetAll returns every field of the hash stored under key, or cache.ErrCacheNil if the key
+	// doesn't exist.
+	HGetAll(ctx context.Context, key string) (map[string]string, error)
+
+	// HGetAllMany returns the fields of the hashes stored under keys, keyed by key, fetching
+	// them in a single round trip where possible. Missing keys are omitted from the result.
+	HGetAllMany(ctx context.Context, keys []string) (map[string]map[string]string, error)
+
+	// HSetMany writes the fields of several hashes, keyed by key. All the fields of one key are
+	// written at once, so readers never see a partially written hash; writes to different keys are
+	// not atomic together.
+	HSetMany(ctx context.Context, entries map[string]map[string]interface{}) error
+}
+
+// ListCache is implemented by caches that store Redis-style lists: ordered sequences of strings
+// under a single key, typically used as work queues.
+type ListCache interface {
+
+	// LPopN atomically removes and returns up to count items from the head of the list stored
+	// under key. It returns fewer items when the list is shorter, and cache.ErrCacheNil when the
+	// key doesn't exist.
+	LPopN(ctx context.Context, key string, count int64) ([]string, error)
+}
+
+// SortedSetCache is implemented by caches that store Redis-style sorted sets: unique string
+// members under a single key, each with a float64 score the members are ordered by. Score bounds
+// are inclusive; math.Inf(-1) and math.Inf(1) select unbounded ranges.
+type SortedSetCache interface {
+
+	// ZAdd adds member to the sorted set stored under key with score, or updates its score if it
+	// is already a member. A missing key is created.
+	ZAdd(ctx context.Context, key string, score float64, member string) error
+
+	// ZRangeByScore returns the members whose score lies between min and max, ordered by score.
+	// A missing key yields an empty slice.
+	ZRangeByScore(ctx context.Context, key string, min, max float64) ([]string, error)
+
+	// ZRemRangeByScore removes the members whose score lies between min and max and returns how
+	// many were removed.
+	ZRemRangeByScore(ctx context.Context, key string, min, max float64) (int64, error)
+
+	// ZRem removes members from the sorted set stored under key and returns how many were removed.
+	ZRem(ctx context.Context, key string, members ...string) (int64, error)
+
+	// ZRange returns the members ranked start to stop (inclusive, 0-based, in ascending score
+	// order). Negative ranks count from the highest score, -1 being the last member.
+	ZRange(ctx context.Context, key string, start, stop int64) ([]string, error)
+
+	// ZCard returns the number of members of the sorted set stored under key; 0 for a missing key.
+	ZCard(ctx context.Context, key string) (int64, error)
+
+	// ZPopMin atomically removes and returns the member with the lowest score, or
+	// cache.ErrCacheNil if the sorted set is empty.
+	ZPopMin(ctx context.Context, key string) (string, float64, error)
+
+	// ZPopMax atomically removes and returns the member with the highest score, or
+	// cache.ErrCacheNil if the sorted set is empty.
+	ZPopMax(ctx context.Context, key string) (string, float64, error)
+}
+
+// PatternGetter is implemented by caches that can read every value whose key matches a pattern
+// in a bounded number of round trips, instead of one Get per key returned by Keys.
+type PatternGetter interface {
+
+	// GetByPattern returns the values of the keys matching pattern, keyed by key. The read is not
+	// atomic: keys written or deleted concurrently may or may not be included.
+	GetByPattern(ctx context.Context, pattern string) (map[string]string, error)
+}
+
+// ExistenceChecker is implemented by caches that can tell whether keys exist without transferring
+// their values.
+type ExistenceChecker interface {
+
+	// Exists returns how many of the given keys exist; a key passed twice is counted twice.
+	Exists(ctx context.Context, keys ...string) (int64, error)
+}
+
+// MultiGetter is implemented by caches that can read several keys in a single round trip, instead
+// of one Get per key.
+type MultiGetter interface {
+
+	// MGet returns the values of the given keys, keyed by key. Missing keys are omitted from the
+	// map, so they can be told apart from keys holding an empty string.
+	MGet(ctx context.Context, keys ...string) (map[string]string, error)
+}
+
+// CountingDeleter is implemented by caches that can report how many keys a delete removed.
+type CountingDeleter interface {
+
+	// DelCount deletes keys like Del and returns how many of them existed.
+	DelCount(ctx context.Context, keys ...string) (int64, error)
+}
+
+// KeyWalker is implemented by caches that can list the keys matching a pattern in batches,
+// instead of returning them all at once from Keys.
+type KeyWalker interface {
+
+	// WalkKeys calls fn with successive batches of the keys matching pattern and stops at the
+	// first error fn returns. The walk is not atomic: keys may be reported more than once, and
+	// keys added or removed during the walk may or may not be included.
+	WalkKeys(ctx context.Context, pattern string, fn func(keys []string) error) error
+}
+
+// PatternDeleteGuard is implemented by caches that can switch off pattern-based deletes. Helpers
+// that delete or expire keys by pattern on the client side consult it before touching any key.
+type PatternDeleteGuard interface {
+
+	// CheckPatternDelete returns an error when pattern-based deletes are switched off.
+	CheckPatternDelete() error
+}
+
+// MultiSetter is implemented by caches that can write several keys in a single round trip, e.g.
+// to warm a cache up in bulk.
+type MultiSetter interface {
+
+	// MSet stores every value of pairs under its key, without expiration.
+	MSet(ctx context.Context, pairs map[string]interface{}) error
+
+	// MSetWithExpiration stores every value of pairs under its key with the given expiration (0
+	// for none). Each key is set atomically, the batch as a whole is not.
+	MSetWithExpiration(ctx context.Context, pairs map[string]interface{}, expiration time.Duration) error
+}
+
+// KeyTTL is a key together with its remaining time to live.
+type KeyTTL struct {
+	Key string        // Key is the cache key.
+	TTL time.Duration // TTL is the time left before the key expires.
+}
+
+// TTLReader is implemented by caches that can tell how long a key has left before it expires.
+type TTLReader interface {
+
+	// TTL returns the remaining time-to-live of key with millisecond precision. Missing keys fail
+	// with cache.ErrCacheNil; keys without expiry fail with an implementation-defined sentinel.
+	TTL(ctx context.Context, key string) (time.Duration, error)
+}
+
+// KeySampler is implemented by caches that can return a random sample of their keys, e.g. to
+// estimate value sizes or TTL coverage without walking the whole keyspace.
+type KeySampler interface {
+
+	// SampleKeys returns up to n distinct keys picked at random. The sample is approximate and
+	// may hold fewer than n keys, notably on small databases.
+	SampleKeys(ctx context.Context, n int) ([]string, error)
+}
+
+// PatternExpirer is implemented by caches that can set the expiration of every key matching a
+// pattern, e.g. all the keys of a tenant, without rewriting their values.
+type PatternExpirer interface {
+
+	// ExpirePattern sets the time-to-live of every key matching pattern to ttl and returns how
+	// many keys were updated. It is not atomic across keys.
+	ExpirePattern(ctx context.Context, pattern string, ttl time.Duration) (int64, error)
+}
+
+// ExpiryInspector is implemented by caches that can list the keys closest to expiring, e.g. for
+// tools deciding which entries to refresh.
+type ExpiryInspector interface {
+
+	// KeysExpiringSoon returns the keys matching pattern that expire within the given duration,
+	// soonest first, up to limit entries (all of them when limit is not positive). Keys without
+	// a TTL are never included.
+	KeysExpiringSoon(ctx context.Context, pattern string, within time.Duration, limit int) ([]KeyTTL, error)
+}
+
+// SetNXCache is implemented by caches that can store a value only if its key doesn't exist yet,
+// as a single atomic step, which is the building block of deduplication and locking.
+type SetNXCache interface {
+
+	// SetNX stores value under key with the given TTL (0 for none) only if key doesn't exist, and
+	// reports whether it was stored.
+	SetNX(ctx context.Context, key string, value interface{}, ttl time.Duration) (bool, error)
+
+	// SetNXMany behaves like SetNX for each key, in a single round trip where possible, and
+	// reports per key whether it was stored. Each key is set atomically, the batch as a whole is not.
+	SetNXMany(ctx context.Context, keys []string, value interface{}, ttl time.Duration) ([]bool, error)
+}
+
+// SetXXCache is implemented by caches that can overwrite a value only if its key still exists, as
+// a single atomic step, so that an update racing with a delete can't bring the key back.
+type SetXXCache interface {
+
+	// SetXX stores value under key with the given TTL (0 for none) only if key exists, and
+	// reports whether it was stored.
+	SetXX(ctx context.Context, key string, value interface{}, ttl time.Duration) (bool, error)
+}
+
+// CacheTx queues the writes of a transaction started with TxCache.Tx. The methods only record the
+// writes; their errors report invalid arguments, not the outcome of the transaction.
+type CacheTx interface {
+
+	// Set queues storing value under key without expiration.
+	Set(ctx context.Context, key string, value interface{}) error
+
+	// SetWithExpiration queues storing value under key with the given expiration (0 for none).
+	SetWithExpiration(ctx context.Context, key string, value interface{}, expiration time.Duration) error
+
+	// Del queues deleting keys.
+	Del(ctx context.Context, keys ...string) error
+}
+
+// IntResult holds the outcome of a command queued in a pipeline. Val and Err are only meaningful
+// once the pipeline ran.
+type IntResult interface {
+
+	// Val returns the integer reply of the command.
+	Val() int64
+
+	// Err returns the error of the command.
+	Err() error
+}
+
+// CachePipeline queues the commands of a pipeline started with PipelineCache.Pipeline. The
+// methods only record the commands; their errors report invalid arguments, not the outcome of
+// the commands.
+type CachePipeline interface {
+
+	// Set queues storing value under key without expiration.
+	Set(ctx context.Context, key string, value interface{}) error
+
+	// SetWithExpiration queues storing value under key with the given expiration (0 for none).
+	SetWithExpiration(ctx context.Context, key string, value interface{}, expiration time.Duration) error
+
+	// Del queues deleting keys.
+	Del(ctx context.Context, keys ...string) error
+
+	// Incr queues incrementing the integer stored under key by one; the result holds the new
+	// value once the pipeline ran.
+	Incr(ctx context.Context, key string) IntResult
+}
+
+// PipelineCache is implemented by caches that can send a batch of heterogeneous commands in a
+// single round trip. Unlike TxCache, the commands aren't applied atomically.
+type PipelineCache interface {
+
+	// Pipeline calls fn to queue commands and then sends them together. If fn returns an error,
+	// nothing is sent and the error is returned.
+	Pipeline(ctx context.Context, fn func(p CachePipeline) error) error
+}
+
+// TxCache is implemented by caches that can apply a group of writes atomically: other clients
+// observe either none or all of them.
+type TxCache interface {
+
+	// Tx calls fn to queue writes and then applies them in a single transaction. If fn returns an
+	// error, nothing is applied and the error is returned.
+	Tx(ctx context.Context, fn func(tx CacheTx) error) error
+}
@@ -0,0 +1,252 @@
+// Package cachetest provides a conformance suite checking that a cache.Cache implementation
+// behaves like the others in this module, so the in-memory cache, RedisCache and any other
+// backend can be swapped without changing what callers observe.
+//
+// To plug in a backend, call RunConformance from a test of its package with a constructor
+// returning a fresh, connected cache:
+//
+//	func TestConformance(t *testing.T) {
+//	    cachetest.RunConformance(t, func() cache.Cache {
+//	        return memory.New()
+//	    })
+//	}
+//
+// Backends sharing state between instances, such as a Redis server, are supported: every key the
+// suite writes lives under a random namespace that is deleted when the suite finishes.
+package cachetest
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/zeroxsolutions/barbatos/cache"
+	"github.com/zeroxsolutions/strike/ssutil"
+)
+
+// expiration is the TTL of the keys the suite expects to expire, and expirationWait how long it
+// waits for them to do so. They are long enough for backends expiring keys in the background.
+const (
+	expiration     = 200 * time.Millisecond
+	expirationWait = 600 * time.Millisecond
+)
+
+// RunConformance runs the cache.Cache contract against the caches returned by newCache, one
+// subtest per behavior:
+//   - Get of a missing or expired key fails with cache.ErrCacheNil
+//   - Set stores values converted to strings, overwrites, keeps empty strings, and clears any TTL
+//   - Non-string values are converted as by the Redis client: bools as "1"/"0", floats without
+//     exponent, and times in RFC 3339 with nanoseconds
+//   - SetWithExpiration expires keys after the TTL; 0 means no expiration
+//   - Del removes the given keys and ignores missing ones
+//   - Keys and DelWithPattern follow the Redis glob syntax (*, ?, [abc], [a-z])
+//
+// newCache is called once per subtest; the suite closes each cache it gets.
+//
+// Parameters:
+//   - t: The test running the suite
+//   - newCache: Constructor of the cache under test
+//
+// Example:
+//
+//	cachetest.RunConformance(t, func() cache.Cache { return memory.New() })
+func RunConformance(t *testing.T, newCache func() cache.Cache) {
+	ctx := context.Background()
+	ns := "cachetest:" + ssutil.MakeString(10) + ":"
+
+	run := func(name string, fn func(t *testing.T, c cache.Cache)) {
+		t.Run(name, func(t *testing.T) {
+			c := newCache()
+			t.Cleanup(func() {
+				if err := c.DelWithPattern(ctx, ns+"*"); err != nil {
+					t.Error("cleanup:", err)
+				}
+				if err := c.Close(); err != nil {
+					t.Error("close:", err)
+				}
+			})
+			fn(t, c)
+		})
+	}
+
+	run("IsConnected", func(t *testing.T, c cache.Cache) {
+		if !c.IsConnected(ctx) {
+			t.Fatal("a new cache should be connected")
+		}
+	})
+
+	run("GetMissing", func(t *testing.T, c cache.Cache) {
+		assertMissing(t, c, ns+"missing")
+	})
+
+	run("SetGet", func(t *testing.T, c cache.Cache) {
+		key := ns + "key"
+
+		if err := c.Set(ctx, key, "value"); err != nil {
+			t.Fatal(err)
+		}
+		assertValue(t, c, key, "value")
+
+		if err := c.Set(ctx, key, 42); err != nil {
+			t.Fatal(err)
+		}
+		assertValue(t, c, key, "42")
+
+		if err := c.Set(ctx, key, ""); err != nil {
+			t.Fatal(err)
+		}
+		assertValue(t, c, key, "")
+	})
+
+	run("SetConversion", func(t *testing.T, c cache.Cache) {
+		at := time.Date(2024, 2, 29, 13, 4, 5, 123456789, time.UTC)
+		conversions := []struct {
+			name     string
+			value    interface{}
+			expected string
+		}{
+			{"True", true, "1"},
+			{"False", false, "0"},
+			{"Int64", int64(-7), "-7"},
+			{"Uint", uint(7), "7"},
+			{"Float", 0.5, "0.5"},
+			{"LargeFloat", 1e21, "1000000000000000000000"},
+			{"Time", at, "2024-02-29T13:04:05.123456789Z"},
+			{"Duration", 1500 * time.Millisecond, "1500000000"},
+			{"Bytes", []byte("raw"), "raw"},
+		}
+
+		for _, conversion := range conversions {
+			key := ns + "conversion:" + conversion.name
+			if err := c.Set(ctx, key, conversion.value); err != nil {
+				t.Fatal(conversion.name, err)
+			}
+			assertValue(t, c, key, conversion.expected)
+		}
+	})
+
+	run("SetWithExpiration", func(t *testing.T, c cache.Cache) {
+		short := ns + "short"
+		forever := ns + "forever"
+		cleared := ns + "cleared"
+
+		if err := c.SetWithExpiration(ctx, short, "value", expiration); err != nil {
+			t.Fatal(err)
+		}
+		if err := c.SetWithExpiration(ctx, forever, "value", 0); err != nil {
+			t.Fatal(err)
+		}
+		if err := c.SetWithExpiration(ctx, cleared, "value", expiration); err != nil {
+			t.Fatal(err)
+		}
+		if err := c.Set(ctx, cleared, "persistent"); err != nil {
+			t.Fatal(err)
+		}
+		assertValue(t, c, short, "value")
+
+		time.Sleep(expirationWait)
+
+		assertMissing(t, c, short)
+		assertValue(t, c, forever, "value")
+		assertValue(t, c, cleared, "persistent")
+		assertKeys(t, c, ns+"*", forever, cleared)
+	})
+
+	run("Del", func(t *testing.T, c cache.Cache) {
+		first := ns + "first"
+		second := ns + "second"
+		kept := ns + "kept"
+
+		for _, key := range []string{first, second, kept} {
+			if err := c.Set(ctx, key, "value"); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		if err := c.Del(ctx, first, second, ns+"missing"); err != nil {
+			t.Fatal(err)
+		}
+
+		assertMissing(t, c, first)
+		assertMissing(t, c, second)
+		assertValue(t, c, kept, "value")
+
+		if err := c.Del(ctx, ns+"missing"); err != nil {
+			t.Fatal("deleting a missing key should not fail:", err)
+		}
+	})
+
+	run("Keys", func(t *testing.T, c cache.Cache) {
+		for _, key := range []string{"user:1", "user:2", "user:10", "users", "order:a", "order:b"} {
+			if err := c.Set(ctx, ns+key, "value"); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		assertKeys(t, c, ns+"user:*", ns+"user:1", ns+"user:10", ns+"user:2")
+		assertKeys(t, c, ns+"user:?", ns+"user:1", ns+"user:2")
+		assertKeys(t, c, ns+"order:[ab]", ns+"order:a", ns+"order:b")
+		assertKeys(t, c, ns+"order:[b-z]", ns+"order:b")
+		assertKeys(t, c, ns+"users", ns+"users")
+		assertKeys(t, c, ns+"nothing:*")
+	})
+
+	run("DelWithPattern", func(t *testing.T, c cache.Cache) {
+		for _, key := range []string{"tmp:1", "tmp:2", "keep:1"} {
+			if err := c.Set(ctx, ns+key, "value"); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		if err := c.DelWithPattern(ctx, ns+"tmp:*"); err != nil {
+			t.Fatal(err)
+		}
+
+		assertMissing(t, c, ns+"tmp:1")
+		assertMissing(t, c, ns+"tmp:2")
+		assertValue(t, c, ns+"keep:1", "value")
+
+		if err := c.DelWithPattern(ctx, ns+"nothing:*"); err != nil {
+			t.Fatal("a pattern matching nothing should not fail:", err)
+		}
+	})
+}
+
+// assertValue fails the test unless key holds expected.
+func assertValue(t *testing.T, c cache.Cache, key, expected string) {
+	t.Helper()
+	value, err := c.Get(context.Background(), key)
+	if err != nil || value != expected {
+		t.Fatalf("Get(%q) = %q, %v; expected %q", key, value, err, expected)
+	}
+}
+
+// assertMissing fails the test unless Get of key reports cache.ErrCacheNil.
+func assertMissing(t *testing.T, c cache.Cache, key string) {
+	t.Helper()
+	value, err := c.Get(context.Background(), key)
+	if !errors.Is(err, cache.ErrCacheNil) {
+		t.Fatalf("Get(%q) = %q, %v; expected cache.ErrCacheNil", key, value, err)
+	}
+}
+
+// assertKeys fails the test unless the keys matching pattern are exactly expected, in any order.
+func assertKeys(t *testing.T, c cache.Cache, pattern string, expected ...string) {
+	t.Helper()
+	keys, err := c.Keys(context.Background(), pattern)
+	if err != nil {
+		t.Fatalf("Keys(%q): %v", pattern, err)
+	}
+	sort.Strings(keys)
+	sort.Strings(expected)
+	if len(keys) != len(expected) {
+		t.Fatalf("Keys(%q) = %q; expected %q", pattern, keys, expected)
+	}
+	for i := range keys {
+		if keys[i] != expected[i] {
+			t.Fatalf("Keys(%q) = %q; expected %q", pattern, keys, expected)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+// Package cas stores values addressed by a hash of their content. The package derives the key,
+// so call sites can't get the hashing wrong, and identical content always maps to a single entry.
+//
+// Keys have the following format:
+//
+//	<prefix> + <lowercase algorithm name> + ":" + <hex digest>
+//
+// for example "cas:sha-256:9f86d0...". Values are stored as-is, so they can also be read with a
+// plain cache.Cache.Get.
+package cas
+
+import (
+	"context"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	// Register the default and SHA-512 family algorithms with the crypto package.
+	_ "crypto/sha256"
+	_ "crypto/sha512"
+
+	"github.com/zeroxsolutions/barbatos/cache"
+	"github.com/zeroxsolutions/strike/builderutil"
+)
+
+// Cache wraps a cache.Cache to store values under keys derived from their content.
+type Cache struct {
+	cache   cache.Cache
+	options *Options
+}
+
+// New creates a content-addressable Cache over c, hashing with SHA-256 and prefixing keys with
+// "cas:" unless configured otherwise.
+//
+// Parameters:
+//   - c: Underlying cache used for storage
+//   - opts: Optional Options builders created with NewOptions
+//
+// Returns:
+//   - *Cache: The content-addressable cache
+//   - error: An error if building the options fails
+//
+// Example:
+//
+//	templates, err := cas.New(redisCache, cas.NewOptions().SetPrefix("templates:"))
+//	key, err := templates.SetByContent(ctx, rendered, time.Hour)
+func New(c cache.Cache, opts ...builderutil.Lister[Options]) (*Cache, error) {
+	options, err := builderutil.Build(append([]builderutil.Lister[Options]{defaultOptions()}, opts...)...)
+	if err != nil {
+		return nil, err
+	}
+	return &Cache{cache: c, options: options}, nil
+}
+
+// Key returns the key SetByContent stores content under, without touching the cache.
+//
+// Parameters:
+//   - content: Content to address
+//
+// Returns:
+//   - string: The content key
+func (c *Cache) Key(content []byte) string {
+	h := c.options.Algorithm.New()
+	h.Write(content)
+	return c.options.Prefix + strings.ToLower(c.options.Algorithm.String()) + ":" + hex.EncodeToString(h.Sum(nil))
+}
+
+// SetByContent stores content under the key derived from its hash and returns that key.
+// Storing content that is already cached rewrites the same entry, refreshing its TTL.
+// A ttl of 0 stores the content without expiration.
+//
+// Parameters:
+//   - ctx: Context for timeout control and request cancellation
+//   - content: Content to store
+//   - ttl: Duration after which the entry expires
+//
+// Returns:
+//   - string: The key the content is stored under
+//   - error: An error from the underlying cache
+//
+// Example:
+//
+//	key, err := templates.SetByContent(ctx, []byte(html), time.Hour)
+func (c *Cache) SetByContent(ctx context.Context, content []byte, ttl time.Duration) (string, error) {
+	key := c.Key(content)
+	if err := c.cache.SetWithExpiration(ctx, key, content, ttl); err != nil {
+		return "", err
+	}
+	return key, nil
+}
+
+// GetByKey retrieves content stored by SetByContent.
+//
+// Parameters:
+//   - ctx: Context for timeout control and request cancellation
+//   - key: Key returned by SetByContent or Key
+//
+// Returns:
+//   - []byte: The stored content
+//   - error: cache.ErrCacheNil if the entry doesn't exist, or an error from the underlying cache
+//
+// Example:
+//
+//	html, err := templates.GetByKey(ctx, key)
+func (c *Cache) GetByKey(ctx context.Context, key string) ([]byte, error) {
+	value, err := c.cache.Get(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(value), nil
+}
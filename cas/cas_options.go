@@ -0,0 +1,92 @@
+package cas
+
+import (
+	"crypto"
+	"fmt"
+
+	"github.com/zeroxsolutions/strike/builderutil"
+)
+
+const (
+	// DefaultAlgorithm is the hash algorithm used when none is configured.
+	DefaultAlgorithm = crypto.SHA256
+
+	// DefaultPrefix is the key prefix used when none is configured.
+	DefaultPrefix = "cas:"
+)
+
+// Options holds the settings of a content-addressable Cache.
+// This struct is populated through OptionsBuilder and consumed by New.
+type Options struct {
+	Algorithm crypto.Hash // Algorithm is the hash function deriving keys from content.
+	Prefix    string      // Prefix is prepended to every key, before the algorithm name.
+}
+
+// OptionsBuilder provides a builder pattern for constructing Options.
+// This builder implements the builderutil.Lister interface to work with the functional options pattern.
+type OptionsBuilder struct {
+	Opts []func(*Options) error // Opts contains the list of option functions to be applied
+}
+
+// SetAlgorithm configures the hash function deriving keys from content. The algorithm must be
+// linked into the binary; SHA-256 and the SHA-512 family are always available, others require
+// importing their package (e.g. _ "golang.org/x/crypto/blake2b"). The algorithm is part of the
+// key, so changing it never makes old entries collide with new ones.
+//
+// Parameters:
+//   - algorithm: Hash function, e.g. crypto.SHA512
+//
+// Returns:
+//   - *OptionsBuilder: The builder instance for method chaining
+func (b *OptionsBuilder) SetAlgorithm(algorithm crypto.Hash) *OptionsBuilder {
+	b.Opts = append(b.Opts, func(o *Options) error {
+		if !algorithm.Available() {
+			return fmt.Errorf("cas: hash algorithm %v is not available", algorithm)
+		}
+		o.Algorithm = algorithm
+		return nil
+	})
+	return b
+}
+
+// SetPrefix configures the prefix of every key, e.g. "templates:" to keep rendered templates in
+// their own namespace.
+//
+// Parameters:
+//   - prefix: Key prefix
+//
+// Returns:
+//   - *OptionsBuilder: The builder instance for method chaining
+func (b *OptionsBuilder) SetPrefix(prefix string) *OptionsBuilder {
+	b.Opts = append(b.Opts, func(o *Options) error {
+		o.Prefix = prefix
+		return nil
+	})
+	return b
+}
+
+// List returns the slice of option functions accumulated by the builder.
+// This method implements the builderutil.Lister interface.
+//
+// Returns:
+//   - []func(*Options) error: A slice of option functions that can be applied to configure Options
+func (b *OptionsBuilder) List() []func(*Options) error {
+	return b.Opts
+}
+
+// NewOptions creates and returns a new instance of OptionsBuilder.
+//
+// Returns:
+//   - *OptionsBuilder: A new instance of OptionsBuilder ready to be configured
+//
+// Example:
+//
+//	opts := cas.NewOptions().SetAlgorithm(crypto.SHA512).SetPrefix("templates:")
+func NewOptions() *OptionsBuilder {
+	return &OptionsBuilder{}
+}
+
+// defaultOptions returns the builder holding the Cache defaults.
+func defaultOptions() builderutil.Lister[Options] {
+	return NewOptions().SetAlgorithm(DefaultAlgorithm).SetPrefix(DefaultPrefix)
+}
@@ -0,0 +1,86 @@
+package cas_test
+
+import (
+	"context"
+	"crypto"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/zeroxsolutions/banshee/cas"
+	"github.com/zeroxsolutions/banshee/memory"
+	"github.com/zeroxsolutions/barbatos/cache"
+)
+
+// TestCache_SetByContent verifies that identical content yields the same key and a single entry,
+// and that different content yields different keys.
+func TestCache_SetByContent(t *testing.T) {
+	backend := memory.New()
+	contentCache, err := cas.New(backend)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+
+	first, err := contentCache.SetByContent(ctx, []byte("<h1>hello</h1>"), time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := contentCache.SetByContent(ctx, []byte("<h1>hello</h1>"), time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := contentCache.SetByContent(ctx, []byte("<h1>bye</h1>"), time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if first != second {
+		t.Fatalf("identical content got different keys: %q, %q", first, second)
+	}
+	if first == other {
+		t.Fatal("different content got the same key")
+	}
+	if first != "cas:sha-256:4db7ef630005c462450ea587722b1a7cff53dfdcd35d7dd40bcf8e97e50826ee" {
+		t.Fatal("unexpected key:", first)
+	}
+
+	keys, err := backend.Keys(ctx, "cas:*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(keys) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(keys))
+	}
+
+	content, err := contentCache.GetByKey(ctx, first)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(content) != "<h1>hello</h1>" {
+		t.Fatal("unexpected content:", string(content))
+	}
+
+	if _, err := contentCache.GetByKey(ctx, "cas:sha-256:missing"); !errors.Is(err, cache.ErrCacheNil) {
+		t.Fatal("expected cache.ErrCacheNil, got", err)
+	}
+}
+
+// TestCache_Options verifies the configurable algorithm and prefix, and rejects unavailable algorithms.
+func TestCache_Options(t *testing.T) {
+	contentCache, err := cas.New(memory.New(), cas.NewOptions().SetAlgorithm(crypto.SHA512).SetPrefix("templates:"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	key := contentCache.Key([]byte("content"))
+	if !strings.HasPrefix(key, "templates:sha-512:") || len(key) != len("templates:sha-512:")+128 {
+		t.Fatal("unexpected key:", key)
+	}
+
+	if _, err := cas.New(memory.New(), cas.NewOptions().SetAlgorithm(crypto.MD4)); err == nil {
+		t.Fatal("expected an error for an unavailable algorithm")
+	}
+}
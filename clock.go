@@ -0,0 +1,24 @@
+package banshee
+
+import "time"
+
+// Clock tells the current time. Components that compute expirations or throttle by time, such as
+// memory.Cache, SlidingCache and presence.Tracker, accept a Clock so tests can advance time
+// deterministically instead of sleeping; mock.MockClock in the mock module is a controllable
+// implementation. Production code uses SystemClock.
+type Clock interface {
+
+	// Now returns the current time.
+	Now() time.Time
+}
+
+// SystemClock is the Clock reading the system time with time.Now.
+var SystemClock Clock = systemClock{}
+
+// systemClock implements Clock with time.Now.
+type systemClock struct{}
+
+// Now returns time.Now().
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
@@ -0,0 +1,131 @@
+package banshee
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/zeroxsolutions/barbatos/cache"
+)
+
+// errCollapsedGetAborted is returned to the callers waiting on an underlying Get that neither
+// returned nor panicked, i.e. whose goroutine exited with runtime.Goexit.
+var errCollapsedGetAborted = errors.New("banshee: collapsed get aborted")
+
+// collapsedGet is an underlying Get shared by the concurrent callers of one key.
+type collapsedGet struct {
+	done      chan struct{}
+	value     string
+	err       error
+	recovered interface{} // recovered is the value the underlying Get panicked with.
+}
+
+// CollapsingCache is a cache.Cache decorator merging concurrent Gets of the same key into a single
+// underlying Get, so a burst of reads of a hot key costs one round trip instead of one per reader.
+type CollapsingCache struct {
+	cache cache.Cache
+
+	mu       sync.Mutex
+	inFlight map[string]*collapsedGet
+}
+
+var _ cache.Cache = (*CollapsingCache)(nil)
+
+// NewCollapsingCache wraps inner so that concurrent Gets of one key share one underlying Get.
+//
+// Behavior:
+//   - Only calls overlapping in time are collapsed: a Get arriving while another Get of the same
+//     key is in flight waits for it and receives its value, or its error, including
+//     cache.ErrCacheNil; a Get arriving after it completed issues a new one
+//   - Nothing is cached: once the shared Get returns, the next Get reads inner again
+//   - The shared Get runs with the context of the caller that started it, so its cancellation
+//     fails every waiter with the context error
+//   - A panic in the shared Get propagates in the caller that started it and is raised again in
+//     every waiter
+//   - Writes, deletes and the other methods pass through; a write racing with an in-flight Get
+//     may not be seen by the callers waiting on it
+//
+// Parameters:
+//   - inner: Underlying cache
+//
+// Returns:
+//   - *CollapsingCache: The collapsing cache
+//
+// Example:
+//
+//	collapsed := banshee.NewCollapsingCache(redisCache)
+//	flags, err := collapsed.Get(ctx, "config:flags")
+func NewCollapsingCache(inner cache.Cache) *CollapsingCache {
+	return &CollapsingCache{cache: inner, inFlight: map[string]*collapsedGet{}}
+}
+
+// IsConnected reports the connection status of the underlying cache.
+func (c *CollapsingCache) IsConnected(ctx context.Context) bool {
+	return c.cache.IsConnected(ctx)
+}
+
+// Keys returns the keys matching pattern in the underlying cache.
+func (c *CollapsingCache) Keys(ctx context.Context, pattern string) ([]string, error) {
+	return c.cache.Keys(ctx, pattern)
+}
+
+// Get retrieves the value stored under key, sharing the underlying Get with concurrent callers
+// of the same key.
+func (c *CollapsingCache) Get(ctx context.Context, key string) (string, error) {
+	c.mu.Lock()
+	if call, ok := c.inFlight[key]; ok {
+		c.mu.Unlock()
+		<-call.done
+		if call.recovered != nil {
+			panic(call.recovered)
+		}
+		return call.value, call.err
+	}
+	call := &collapsedGet{done: make(chan struct{})}
+	c.inFlight[key] = call
+	c.mu.Unlock()
+
+	returned := false
+	defer func() {
+		if !returned {
+			call.err = errCollapsedGetAborted
+			call.recovered = recover()
+		}
+		c.mu.Lock()
+		delete(c.inFlight, key)
+		c.mu.Unlock()
+		close(call.done)
+		if call.recovered != nil {
+			panic(call.recovered)
+		}
+	}()
+	call.value, call.err = c.cache.Get(ctx, key)
+	returned = true
+	return call.value, call.err
+}
+
+// Set stores value under key in the underlying cache.
+func (c *CollapsingCache) Set(ctx context.Context, key string, value interface{}) error {
+	return c.cache.Set(ctx, key, value)
+}
+
+// SetWithExpiration stores value under key with the given expiration in the underlying cache.
+func (c *CollapsingCache) SetWithExpiration(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
+	return c.cache.SetWithExpiration(ctx, key, value, expiration)
+}
+
+// Del deletes keys from the underlying cache.
+func (c *CollapsingCache) Del(ctx context.Context, keys ...string) error {
+	return c.cache.Del(ctx, keys...)
+}
+
+// DelWithPattern deletes the keys matching pattern from the underlying cache.
+func (c *CollapsingCache) DelWithPattern(ctx context.Context, pattern string) error {
+	return c.cache.DelWithPattern(ctx, pattern)
+}
+
+// Close closes the underlying cache.
+func (c *CollapsingCache) Close() error {
+	return c.cache.Close()
+}
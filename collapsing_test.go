@@ -0,0 +1,127 @@
+package banshee_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/zeroxsolutions/banshee"
+	"github.com/zeroxsolutions/banshee/internal/fakecache"
+	"github.com/zeroxsolutions/barbatos/cache"
+)
+
+// gatedCache counts the Gets reaching the fake cache and holds them until the gate is closed.
+type gatedCache struct {
+	cache.Cache
+	gate  chan struct{}
+	calls int32
+}
+
+func (g *gatedCache) Get(ctx context.Context, key string) (string, error) {
+	atomic.AddInt32(&g.calls, 1)
+	<-g.gate
+	return g.Cache.Get(ctx, key)
+}
+
+// TestCollapsingCache verifies that concurrent Gets of one key share one underlying call and its
+// outcome, and that later Gets read again.
+func TestCollapsingCache(t *testing.T) {
+	ctx := context.Background()
+	inner := &gatedCache{Cache: fakecache.New(), gate: make(chan struct{})}
+	collapsed := banshee.NewCollapsingCache(inner)
+
+	if err := collapsed.Set(ctx, "hot", "value"); err != nil {
+		t.Fatal(err)
+	}
+
+	const readers = 50
+	var wg sync.WaitGroup
+	for _, key := range []string{"hot", "missing"} {
+		for i := 0; i < readers; i++ {
+			wg.Add(1)
+			go func(key string) {
+				defer wg.Done()
+				value, err := collapsed.Get(ctx, key)
+				switch key {
+				case "hot":
+					if err != nil || value != "value" {
+						t.Error("unexpected result:", value, err)
+					}
+				default:
+					if !errors.Is(err, cache.ErrCacheNil) {
+						t.Error("the miss should be shared:", err)
+					}
+				}
+			}(key)
+		}
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	close(inner.gate)
+	wg.Wait()
+
+	if calls := atomic.LoadInt32(&inner.calls); calls != 2 {
+		t.Fatal("expected one underlying Get per key, got", calls)
+	}
+
+	if _, err := collapsed.Get(ctx, "hot"); err != nil {
+		t.Fatal(err)
+	}
+	if calls := atomic.LoadInt32(&inner.calls); calls != 3 {
+		t.Fatal("a Get after the shared one completed should read again, got", calls)
+	}
+}
+
+// panickingCache panics in every Get once the gate is closed.
+type panickingCache struct {
+	cache.Cache
+	gate chan struct{}
+}
+
+func (p *panickingCache) Get(ctx context.Context, key string) (string, error) {
+	<-p.gate
+	panic("boom")
+}
+
+// TestCollapsingCache_Panic verifies that a panic in the shared Get reaches the caller that started
+// it and every waiter, instead of handing them an empty value.
+func TestCollapsingCache_Panic(t *testing.T) {
+	ctx := context.Background()
+	inner := &panickingCache{Cache: fakecache.New(), gate: make(chan struct{})}
+	collapsed := banshee.NewCollapsingCache(inner)
+
+	const readers = 10
+	var (
+		wg        sync.WaitGroup
+		mu        sync.Mutex
+		recovered []interface{}
+	)
+	for i := 0; i < readers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() {
+				mu.Lock()
+				recovered = append(recovered, recover())
+				mu.Unlock()
+			}()
+			_, _ = collapsed.Get(ctx, "hot")
+		}()
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	close(inner.gate)
+	wg.Wait()
+
+	if len(recovered) != readers {
+		t.Fatal("expected every reader to return, got", len(recovered))
+	}
+	for _, r := range recovered {
+		if r != "boom" {
+			t.Fatal("expected every reader to panic with boom, got", r)
+		}
+	}
+}
@@ -0,0 +1,284 @@
+// Package conditional caches values fetched from sources supporting conditional requests, such
+// as HTTP servers returning ETags: once a cached value turns stale, its ETag is sent back to the
+// source (e.g. as If-None-Match), and a "not modified" answer keeps the cached value instead of
+// downloading it again.
+//
+// Entries are stored in the following format:
+//
+//	"\x00conditional:v1:" + <unix nanoseconds until which the entry is fresh> + ":" +
+//	    <byte length of the ETag> + ":" + <ETag> + <value>
+//
+// The ETag is length-prefixed, so it may contain any byte, including ':'. The key itself expires
+// Options.Retention after the entry turns stale. The header makes entries unreadable as plain
+// values: keys should be written and read exclusively through this package.
+//
+// When the underlying cache implements banshee.Expirer, a "not modified" answer doesn't rewrite
+// the entry: its expiration is extended in place and the new freshness is kept under the side key
+// key + "\x00fresh", as <unix nanoseconds until which the entry is fresh> + ":" + <ETag>.
+package conditional
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/zeroxsolutions/banshee"
+	"github.com/zeroxsolutions/barbatos/cache"
+	"github.com/zeroxsolutions/strike/builderutil"
+)
+
+// header prefixes every entry. The leading NUL byte keeps ordinary text values from being
+// mistaken for entries.
+const header = "\x00conditional:v1:"
+
+// freshSuffix is appended to a key to form the side key holding the freshness of a revalidated
+// entry.
+const freshSuffix = "\x00fresh"
+
+// ErrNotEntry is returned by Decode for values that weren't written by Encode.
+var ErrNotEntry = errors.New("conditional: value is not a conditional entry")
+
+// ErrUnexpectedNotModified is returned by GetOrRefreshConditional when the loader reports that a
+// value wasn't modified although no ETag was sent to it.
+var ErrUnexpectedNotModified = errors.New("conditional: not modified without a previous ETag")
+
+// Loader fetches the value of a key from its source. prevETag is the ETag of the cached value,
+// or "" when nothing is cached; the loader sends it as a condition, e.g. If-None-Match, and
+// reports notModified when the source confirms the cached value is current, e.g. with a 304.
+// Otherwise it returns the value together with its ETag, which may be "" when the source sent
+// none.
+type Loader func(prevETag string) (value, etag string, notModified bool, err error)
+
+// Entry is a cached value together with its ETag and the time until which it is fresh.
+type Entry struct {
+	Value      string    // Value is the cached value.
+	ETag       string    // ETag identifies the version of the value at the source.
+	FreshUntil time.Time // FreshUntil is the time after which the value must be revalidated.
+}
+
+// Encode returns the stored representation of entry, in the format described in the package
+// documentation.
+//
+// Parameters:
+//   - entry: Entry to encode
+//
+// Returns:
+//   - string: The encoded entry
+func Encode(entry Entry) string {
+	var b strings.Builder
+	b.Grow(len(header) + 40 + len(entry.ETag) + len(entry.Value))
+	b.WriteString(header)
+	b.WriteString(strconv.FormatInt(entry.FreshUntil.UnixNano(), 10))
+	b.WriteByte(':')
+	b.WriteString(strconv.Itoa(len(entry.ETag)))
+	b.WriteByte(':')
+	b.WriteString(entry.ETag)
+	b.WriteString(entry.Value)
+	return b.String()
+}
+
+// Decode parses a value written by Encode.
+//
+// Parameters:
+//   - raw: Stored value
+//
+// Returns:
+//   - Entry: The decoded entry
+//   - error: ErrNotEntry if raw isn't an encoded entry
+func Decode(raw string) (Entry, error) {
+	if !strings.HasPrefix(raw, header) {
+		return Entry{}, ErrNotEntry
+	}
+	freshUntil, rest, ok := strings.Cut(raw[len(header):], ":")
+	if !ok {
+		return Entry{}, ErrNotEntry
+	}
+	nanos, err := strconv.ParseInt(freshUntil, 10, 64)
+	if err != nil {
+		return Entry{}, ErrNotEntry
+	}
+	length, rest, ok := strings.Cut(rest, ":")
+	if !ok {
+		return Entry{}, ErrNotEntry
+	}
+	n, err := strconv.Atoi(length)
+	if err != nil || n < 0 || n > len(rest) {
+		return Entry{}, ErrNotEntry
+	}
+	return Entry{Value: rest[n:], ETag: rest[:n], FreshUntil: time.Unix(0, nanos)}, nil
+}
+
+// Cache wraps a cache.Cache to cache values revalidated with ETags.
+type Cache struct {
+	cache   cache.Cache
+	options *Options
+}
+
+// New creates a Cache storing its entries in c.
+//
+// Parameters:
+//   - c: Underlying cache used for storage
+//   - opts: Optional Options builders applied in order
+//
+// Returns:
+//   - *Cache: A conditional cache over c
+//   - error: An error if the options are invalid
+//
+// Example:
+//
+//	conditionalCache, err := conditional.New(redisCache, conditional.NewOptions().SetRetention(7*24*time.Hour))
+func New(c cache.Cache, opts ...builderutil.Lister[Options]) (*Cache, error) {
+	options, err := builderutil.Build(append([]builderutil.Lister[Options]{defaultOptions()}, opts...)...)
+	if err != nil {
+		return nil, err
+	}
+	return &Cache{cache: c, options: options}, nil
+}
+
+// GetOrRefreshConditional returns the value cached under key, fetching it with loader when it is
+// missing or stale.
+//
+// Behavior:
+//   - A fresh entry, written less than ttl ago, is returned without calling loader
+//   - A stale entry's ETag is passed to loader; when loader reports notModified, the cached value
+//     is returned and stays fresh for another ttl, without being downloaded again. With a
+//     banshee.Expirer cache, the value isn't rewritten either: only its expiration and a small
+//     freshness side key are updated
+//   - Otherwise, and when nothing is cached, the value and ETag returned by loader are cached
+//     and stay fresh for ttl
+//   - Entries are kept Options.Retention after turning stale, so that they can be revalidated
+//   - Loader errors are returned as is and leave the cache untouched; values stored under key
+//     by other means are treated as missing and overwritten
+//   - Concurrent calls for a stale key may each call loader; wrap the cache with
+//     banshee.NewCollapsingCache or serialize the calls if that matters
+//
+// Parameters:
+//   - ctx: Context for timeout control and request cancellation
+//   - key: Cache key of the entry
+//   - ttl: How long a fetched or revalidated value is served without calling loader, must be
+//     positive
+//   - loader: Function fetching the value from its source
+//
+// Returns:
+//   - string: The cached or fetched value
+//   - error: The loader error, ErrUnexpectedNotModified, an error for an invalid ttl, or an
+//     error from the underlying cache
+//
+// Example:
+//
+//	body, err := conditionalCache.GetOrRefreshConditional(ctx, "feed:"+url, 5*time.Minute,
+//	    func(prevETag string) (string, string, bool, error) {
+//	        req, _ := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+//	        if prevETag != "" {
+//	            req.Header.Set("If-None-Match", prevETag)
+//	        }
+//	        resp, err := http.DefaultClient.Do(req)
+//	        if err != nil {
+//	            return "", "", false, err
+//	        }
+//	        defer resp.Body.Close()
+//	        if resp.StatusCode == http.StatusNotModified {
+//	            return "", "", true, nil
+//	        }
+//	        data, err := io.ReadAll(resp.Body)
+//	        return string(data), resp.Header.Get("ETag"), false, err
+//	    })
+func (c *Cache) GetOrRefreshConditional(ctx context.Context, key string, ttl time.Duration, loader Loader) (string, error) {
+	if ttl <= 0 {
+		return "", errors.New("conditional: ttl must be positive")
+	}
+	now := c.options.Clock.Now()
+
+	var cached *Entry
+	raw, err := c.cache.Get(ctx, key)
+	switch {
+	case err == nil:
+		if entry, err := Decode(raw); err == nil {
+			if now.Before(entry.FreshUntil) {
+				return entry.Value, nil
+			}
+			fresh, err := c.revalidatedFresh(ctx, key, entry.ETag, now)
+			if err != nil {
+				return "", err
+			}
+			if fresh {
+				return entry.Value, nil
+			}
+			cached = &entry
+		}
+	case !errors.Is(err, cache.ErrCacheNil):
+		return "", err
+	}
+
+	var prevETag string
+	if cached != nil {
+		prevETag = cached.ETag
+	}
+	value, etag, notModified, err := loader(prevETag)
+	if err != nil {
+		return "", err
+	}
+	if notModified {
+		if cached == nil || cached.ETag == "" {
+			return "", ErrUnexpectedNotModified
+		}
+		revalidated, err := c.revalidate(ctx, key, cached.ETag, now.Add(ttl), ttl+c.options.Retention)
+		if err != nil {
+			return "", err
+		}
+		if revalidated {
+			return cached.Value, nil
+		}
+		value, etag = cached.Value, cached.ETag
+	}
+
+	entry := Entry{Value: value, ETag: etag, FreshUntil: now.Add(ttl)}
+	if err := c.cache.SetWithExpiration(ctx, key, Encode(entry), ttl+c.options.Retention); err != nil {
+		return "", err
+	}
+	return value, nil
+}
+
+// revalidate keeps the entry under key fresh until freshUntil without rewriting its value, by
+// extending the key's expiration and recording the freshness in its side key. It reports false,
+// leaving the caller to rewrite the entry, when the cache isn't a banshee.Expirer or the key has
+// vanished since it was read.
+func (c *Cache) revalidate(ctx context.Context, key, etag string, freshUntil time.Time, expiration time.Duration) (bool, error) {
+	expirer, ok := c.cache.(banshee.Expirer)
+	if !ok {
+		return false, nil
+	}
+	exists, err := expirer.Expire(ctx, key, expiration)
+	if err != nil || !exists {
+		return false, err
+	}
+	fresh := strconv.FormatInt(freshUntil.UnixNano(), 10) + ":" + etag
+	if err := c.cache.SetWithExpiration(ctx, key+freshSuffix, fresh, expiration); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// revalidatedFresh reports whether the side key of key records that the entry with the given
+// ETag is still fresh at now. Side keys left over from a previous version of the value are
+// ignored, since their ETag differs.
+func (c *Cache) revalidatedFresh(ctx context.Context, key, etag string, now time.Time) (bool, error) {
+	raw, err := c.cache.Get(ctx, key+freshSuffix)
+	if errors.Is(err, cache.ErrCacheNil) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	freshUntil, freshETag, ok := strings.Cut(raw, ":")
+	if !ok || freshETag != etag {
+		return false, nil
+	}
+	nanos, err := strconv.ParseInt(freshUntil, 10, 64)
+	if err != nil {
+		return false, nil
+	}
+	return now.Before(time.Unix(0, nanos)), nil
+}
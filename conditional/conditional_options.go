@@ -0,0 +1,93 @@
+package conditional
+
+import (
+	"errors"
+	"time"
+
+	"github.com/zeroxsolutions/banshee"
+	"github.com/zeroxsolutions/strike/builderutil"
+)
+
+// DefaultRetention is how long a stale entry is kept for revalidation when no retention is
+// configured.
+const DefaultRetention = 24 * time.Hour
+
+// Options holds the settings of a conditional Cache.
+// This struct is populated through OptionsBuilder and consumed by New.
+type Options struct {
+	Retention time.Duration // Retention is how long an entry is kept after it turns stale, so its ETag can be revalidated.
+	Clock     banshee.Clock // Clock decides when entries turn stale.
+}
+
+// OptionsBuilder provides a builder pattern for constructing Options.
+// This builder implements the builderutil.Lister interface to work with the functional options pattern.
+type OptionsBuilder struct {
+	Opts []func(*Options) error // Opts contains the list of option functions to be applied
+}
+
+// SetRetention configures how long an entry is kept after it turns stale. A stale entry is never
+// returned without asking the loader, but its ETag lets the loader answer "not modified" instead
+// of downloading the value again; once the retention elapses too, the next call downloads it in
+// full. Longer retentions save more downloads at the cost of memory.
+//
+// Parameters:
+//   - retention: Time a stale entry is kept, must not be negative; 0 drops entries once stale
+//
+// Returns:
+//   - *OptionsBuilder: The builder instance for method chaining
+func (b *OptionsBuilder) SetRetention(retention time.Duration) *OptionsBuilder {
+	b.Opts = append(b.Opts, func(o *Options) error {
+		if retention < 0 {
+			return errors.New("conditional: retention must not be negative")
+		}
+		o.Retention = retention
+		return nil
+	})
+	return b
+}
+
+// SetClock configures the clock deciding when entries turn stale. The keys still expire on the
+// cache's own clock; use a cache sharing the clock, such as memory.NewWithClock, in tests.
+//
+// Parameters:
+//   - clock: Clock telling the current time, must not be nil
+//
+// Returns:
+//   - *OptionsBuilder: The builder instance for method chaining
+func (b *OptionsBuilder) SetClock(clock banshee.Clock) *OptionsBuilder {
+	b.Opts = append(b.Opts, func(o *Options) error {
+		if clock == nil {
+			return errors.New("conditional: clock must not be nil")
+		}
+		o.Clock = clock
+		return nil
+	})
+	return b
+}
+
+// List returns the slice of option functions accumulated by the builder.
+// This method implements the builderutil.Lister interface.
+//
+// Returns:
+//   - []func(*Options) error: A slice of option functions that can be applied to configure Options
+func (b *OptionsBuilder) List() []func(*Options) error {
+	return b.Opts
+}
+
+// NewOptions creates and returns a new instance of OptionsBuilder.
+//
+// Returns:
+//   - *OptionsBuilder: A new instance of OptionsBuilder ready to be configured
+//
+// Example:
+//
+//	opts := conditional.NewOptions().SetRetention(7 * 24 * time.Hour)
+func NewOptions() *OptionsBuilder {
+	return &OptionsBuilder{}
+}
+
+// defaultOptions returns the builder holding the package defaults.
+// New applies it before any caller-supplied builders.
+func defaultOptions() builderutil.Lister[Options] {
+	return NewOptions().SetRetention(DefaultRetention).SetClock(banshee.SystemClock)
+}
@@ -0,0 +1,229 @@
+package conditional_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/zeroxsolutions/banshee/conditional"
+	"github.com/zeroxsolutions/banshee/memory"
+	"github.com/zeroxsolutions/barbatos/cache"
+)
+
+// manualClock is a banshee.Clock that only moves when the test changes now.
+type manualClock struct {
+	now time.Time
+}
+
+func (c *manualClock) Now() time.Time {
+	return c.now
+}
+
+// source is a Loader backed by a versioned value, answering "not modified" to the current ETag.
+type source struct {
+	value string
+	etag  string
+	calls []string // calls records the prevETag of every call.
+}
+
+func (s *source) load(prevETag string) (string, string, bool, error) {
+	s.calls = append(s.calls, prevETag)
+	if prevETag != "" && prevETag == s.etag {
+		return "", "", true, nil
+	}
+	return s.value, s.etag, false, nil
+}
+
+// TestEncodeDecode verifies that entries round-trip, whatever bytes their ETag holds, and that
+// other values are rejected.
+func TestEncodeDecode(t *testing.T) {
+	entry := conditional.Entry{Value: "body:with:colons", ETag: `W/"a:1"`, FreshUntil: time.Unix(1700000000, 42)}
+
+	decoded, err := conditional.Decode(conditional.Encode(entry))
+	if err != nil || decoded.Value != entry.Value || decoded.ETag != entry.ETag || !decoded.FreshUntil.Equal(entry.FreshUntil) {
+		t.Fatal(decoded, err)
+	}
+
+	for _, raw := range []string{"plain", "\x00conditional:v1:x:0:", "\x00conditional:v1:1:9:short"} {
+		if _, err := conditional.Decode(raw); !errors.Is(err, conditional.ErrNotEntry) {
+			t.Fatalf("Decode(%q) = %v", raw, err)
+		}
+	}
+}
+
+// TestGetOrRefreshConditional verifies the fresh, not-modified and modified paths.
+func TestGetOrRefreshConditional(t *testing.T) {
+	ctx := context.Background()
+	clock := &manualClock{now: time.Now()}
+	store := memory.NewWithClock(clock)
+
+	c, err := conditional.New(store, conditional.NewOptions().SetClock(clock).SetRetention(time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	src := &source{value: "v1", etag: `"1"`}
+	get := func() string {
+		t.Helper()
+		value, err := c.GetOrRefreshConditional(ctx, "feed", time.Minute, src.load)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return value
+	}
+
+	if value := get(); value != "v1" || len(src.calls) != 1 || src.calls[0] != "" {
+		t.Fatal("a missing entry should be fetched unconditionally:", value, src.calls)
+	}
+
+	if value := get(); value != "v1" || len(src.calls) != 1 {
+		t.Fatal("a fresh entry should be served without calling the loader:", value, src.calls)
+	}
+
+	// Stale and unchanged: the loader answers 304 and the cached value stays fresh again.
+	clock.now = clock.now.Add(2 * time.Minute)
+	if value := get(); value != "v1" || len(src.calls) != 2 || src.calls[1] != `"1"` {
+		t.Fatal("a stale entry should be revalidated with its ETag:", value, src.calls)
+	}
+	if value := get(); value != "v1" || len(src.calls) != 2 {
+		t.Fatal("a revalidated entry should be fresh again:", value, src.calls)
+	}
+
+	// Stale and changed: the new value and ETag replace the entry.
+	src.value, src.etag = "v2", `"2"`
+	clock.now = clock.now.Add(2 * time.Minute)
+	if value := get(); value != "v2" || len(src.calls) != 3 || src.calls[2] != `"1"` {
+		t.Fatal("a modified value should be refreshed:", value, src.calls)
+	}
+	clock.now = clock.now.Add(2 * time.Minute)
+	if value := get(); value != "v2" || len(src.calls) != 4 || src.calls[3] != `"2"` {
+		t.Fatal("the new ETag should be sent on the next revalidation:", value, src.calls)
+	}
+
+	// Past the retention the entry is gone and fetched unconditionally.
+	clock.now = clock.now.Add(2 * time.Hour)
+	if value := get(); value != "v2" || len(src.calls) != 5 || src.calls[4] != "" {
+		t.Fatal("an expired entry should be fetched unconditionally:", value, src.calls)
+	}
+}
+
+// TestGetOrRefreshConditional_Errors verifies that loader errors leave the cached entry untouched
+// and that a "not modified" answer without an ETag is rejected.
+func TestGetOrRefreshConditional_Errors(t *testing.T) {
+	ctx := context.Background()
+	clock := &manualClock{now: time.Now()}
+	store := memory.NewWithClock(clock)
+
+	c, err := conditional.New(store, conditional.NewOptions().SetClock(clock))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	notModified := func(string) (string, string, bool, error) { return "", "", true, nil }
+	if _, err := c.GetOrRefreshConditional(ctx, "feed", time.Minute, notModified); !errors.Is(err, conditional.ErrUnexpectedNotModified) {
+		t.Fatal(err)
+	}
+	if _, err := store.Get(ctx, "feed"); !errors.Is(err, cache.ErrCacheNil) {
+		t.Fatal("nothing should be cached:", err)
+	}
+
+	src := &source{value: "v1", etag: `"1"`}
+	if _, err := c.GetOrRefreshConditional(ctx, "feed", time.Minute, src.load); err != nil {
+		t.Fatal(err)
+	}
+
+	clock.now = clock.now.Add(2 * time.Minute)
+	errSource := errors.New("source down")
+	failing := func(string) (string, string, bool, error) { return "", "", false, errSource }
+	if _, err := c.GetOrRefreshConditional(ctx, "feed", time.Minute, failing); !errors.Is(err, errSource) {
+		t.Fatal(err)
+	}
+	if value, err := c.GetOrRefreshConditional(ctx, "feed", time.Minute, src.load); err != nil || value != "v1" || src.calls[len(src.calls)-1] != `"1"` {
+		t.Fatal("a failed refresh should keep the entry for revalidation:", value, err, src.calls)
+	}
+
+	if _, err := c.GetOrRefreshConditional(ctx, "feed", 0, src.load); err == nil {
+		t.Fatal("a non-positive ttl should be rejected")
+	}
+	if _, err := conditional.New(store, conditional.NewOptions().SetRetention(-time.Second)); err == nil {
+		t.Fatal("a negative retention should be rejected")
+	}
+}
+
+// writeCountingCache counts the writes of each key; embedding *memory.Cache keeps it a
+// banshee.Expirer.
+type writeCountingCache struct {
+	*memory.Cache
+	writes map[string]int
+}
+
+func (c *writeCountingCache) SetWithExpiration(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
+	c.writes[key]++
+	return c.Cache.SetWithExpiration(ctx, key, value, expiration)
+}
+
+// plainCache hides every capability of the cache it wraps.
+type plainCache struct {
+	cache.Cache
+}
+
+// TestGetOrRefreshConditional_NotModifiedKeepsValue verifies that a "not modified" answer extends
+// an entry without rewriting its value when the cache is a banshee.Expirer, and still rewrites it
+// otherwise.
+func TestGetOrRefreshConditional_NotModifiedKeepsValue(t *testing.T) {
+	ctx := context.Background()
+	clock := &manualClock{now: time.Now()}
+	store := &writeCountingCache{Cache: memory.NewWithClock(clock), writes: map[string]int{}}
+
+	c, err := conditional.New(store, conditional.NewOptions().SetClock(clock).SetRetention(time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	src := &source{value: "v1", etag: `"1"`}
+	get := func() string {
+		t.Helper()
+		value, err := c.GetOrRefreshConditional(ctx, "feed", time.Minute, src.load)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return value
+	}
+
+	get()
+	for i := 0; i < 3; i++ {
+		clock.now = clock.now.Add(90 * time.Second)
+		if value := get(); value != "v1" || len(src.calls) != i+2 {
+			t.Fatal("a stale entry should be revalidated:", value, src.calls)
+		}
+		if value := get(); value != "v1" || len(src.calls) != i+2 {
+			t.Fatal("a revalidated entry should be fresh again:", value, src.calls)
+		}
+	}
+	if store.writes["feed"] != 1 {
+		t.Fatal("revalidations should not rewrite the value:", store.writes)
+	}
+
+	// Revalidations outlive the entry's original expiration, since they extend it.
+	if _, err := store.Cache.Get(ctx, "feed"); err != nil {
+		t.Fatal("a revalidated entry should be kept:", err)
+	}
+
+	// A new version makes the side key of the previous one irrelevant.
+	src.value, src.etag = "v2", `"2"`
+	clock.now = clock.now.Add(90 * time.Second)
+	if value := get(); value != "v2" || store.writes["feed"] != 2 {
+		t.Fatal("a modified value should be rewritten:", value, store.writes)
+	}
+
+	// Without banshee.Expirer, revalidations fall back to rewriting the entry.
+	plain, err := conditional.New(plainCache{store}, conditional.NewOptions().SetClock(clock).SetRetention(time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+	clock.now = clock.now.Add(90 * time.Second)
+	if value, err := plain.GetOrRefreshConditional(ctx, "feed", time.Minute, src.load); err != nil || value != "v2" || store.writes["feed"] != 3 {
+		t.Fatal("a plain cache should have the entry rewritten:", value, err, store.writes)
+	}
+}
@@ -0,0 +1,200 @@
+// Package counters maintains time-bucketed counters, such as requests per minute, in a cache.
+//
+// Each bucket is a counter stored under a key with the following format:
+//
+//	<name> + ":" + <bucket start in UTC>
+//
+// where the bucket start is formatted as "2006-01-02T15:04" for granularities of a minute or
+// more, and as "2006-01-02T15:04:05" below a minute, for example "logins:2024-05-01T12:04".
+// Buckets are aligned on multiples of the granularity since the Unix epoch, so a granularity
+// dividing a day yields buckets aligned on UTC midnight. A name should always be used with the
+// same granularity: a 1-minute and a 5-minute bucket starting at the same instant share a key.
+package counters
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/zeroxsolutions/banshee"
+	"github.com/zeroxsolutions/barbatos/cache"
+	"github.com/zeroxsolutions/strike/builderutil"
+)
+
+// ErrUnsupported is returned by New when the cache doesn't implement banshee.CounterCache, which
+// Buckets needs to increment buckets and expire them atomically.
+var ErrUnsupported = errors.New("counters: cache must implement banshee.CounterCache")
+
+// ErrInvalidGranularity is returned for granularities that aren't a positive whole number of
+// seconds, which the bucket key format can't represent.
+var ErrInvalidGranularity = errors.New("counters: granularity must be a positive whole number of seconds")
+
+// store is the set of capabilities Buckets relies on.
+type store interface {
+	cache.Cache
+	banshee.CounterCache
+}
+
+// multiGetter is implemented by caches able to read many keys in one round trip. Buckets uses it
+// when available and reads bucket by bucket otherwise.
+type multiGetter interface {
+	MGet(ctx context.Context, keys ...string) (map[string]string, error)
+}
+
+// Buckets increments and sums time-bucketed counters stored in a cache.
+type Buckets struct {
+	cache   store
+	options *Options
+}
+
+// New creates Buckets storing counters in c.
+//
+// Parameters:
+//   - c: Cache implementing banshee.CounterCache, such as redis.RedisCache or memory.Cache
+//   - opts: Optional Options builders created with NewOptions
+//
+// Returns:
+//   - *Buckets: The bucketed counters
+//   - error: ErrUnsupported if c lacks a required capability, or an error if building the options
+//     fails
+//
+// Example:
+//
+//	logins, err := counters.New(redisCache, counters.NewOptions().SetRetention(2*time.Hour))
+//	err = logins.IncrBucket(ctx, "logins", time.Now(), time.Minute)
+//	lastHour, err := logins.RangeSum(ctx, "logins", time.Now().Add(-time.Hour), time.Now(), time.Minute)
+func New(c cache.Cache, opts ...builderutil.Lister[Options]) (*Buckets, error) {
+	s, ok := c.(store)
+	if !ok {
+		return nil, ErrUnsupported
+	}
+	options, err := builderutil.Build(append([]builderutil.Lister[Options]{defaultOptions()}, opts...)...)
+	if err != nil {
+		return nil, err
+	}
+	return &Buckets{cache: s, options: options}, nil
+}
+
+// IncrBucket increments the bucket of name containing t. The first increment of a bucket sets its
+// TTL to the configured retention; later increments leave it unchanged. The increment and the TTL
+// are applied in one atomic step with IncrFirstSeen, so a bucket can't be left without a TTL by a
+// failure between the two.
+//
+// Parameters:
+//   - ctx: Context for timeout control and request cancellation
+//   - name: Name of the counter
+//   - t: Instant to count, usually time.Now()
+//   - granularity: Width of the buckets, a whole number of seconds
+//
+// Returns:
+//   - error: ErrInvalidGranularity, or an error from the cache, e.g. if the bucket would overflow
+func (b *Buckets) IncrBucket(ctx context.Context, name string, t time.Time, granularity time.Duration) error {
+	key, err := Key(name, t, granularity)
+	if err != nil {
+		return err
+	}
+	_, _, err = b.cache.IncrFirstSeen(ctx, key, b.options.Retention)
+	return err
+}
+
+// RangeSum returns the sum of the buckets of name overlapping [from, to], both ends included.
+// Missing and expired buckets count as 0. The buckets are read in a single round trip when the
+// cache supports reading many keys at once.
+//
+// Parameters:
+//   - ctx: Context for timeout control and request cancellation
+//   - name: Name of the counter
+//   - from: Start of the range
+//   - to: End of the range
+//   - granularity: Width of the buckets, the same as given to IncrBucket
+//
+// Returns:
+//   - int64: The sum of the buckets
+//   - error: ErrInvalidGranularity, an error from the cache, or if a bucket isn't an integer
+func (b *Buckets) RangeSum(ctx context.Context, name string, from, to time.Time, granularity time.Duration) (int64, error) {
+	if err := validate(granularity); err != nil {
+		return 0, err
+	}
+	var keys []string
+	for start := from.Truncate(granularity); !start.After(to); start = start.Add(granularity) {
+		keys = append(keys, key(name, start, granularity))
+	}
+	if len(keys) == 0 {
+		return 0, nil
+	}
+	values, err := b.get(ctx, keys)
+	if err != nil {
+		return 0, err
+	}
+	var sum int64
+	for _, value := range values {
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return 0, err
+		}
+		sum += n
+	}
+	return sum, nil
+}
+
+// Key returns the key of the bucket of name containing t.
+//
+// Parameters:
+//   - name: Name of the counter
+//   - t: Instant within the bucket
+//   - granularity: Width of the buckets, a whole number of seconds
+//
+// Returns:
+//   - string: The bucket key, e.g. "logins:2024-05-01T12:04"
+//   - error: ErrInvalidGranularity if granularity isn't a positive whole number of seconds
+func Key(name string, t time.Time, granularity time.Duration) (string, error) {
+	if err := validate(granularity); err != nil {
+		return "", err
+	}
+	return key(name, t.Truncate(granularity), granularity), nil
+}
+
+// get returns the values of the existing keys, with one MGET when the cache supports it.
+func (b *Buckets) get(ctx context.Context, keys []string) ([]string, error) {
+	if getter, ok := b.cache.(multiGetter); ok {
+		found, err := getter.MGet(ctx, keys...)
+		if err != nil {
+			return nil, err
+		}
+		values := make([]string, 0, len(found))
+		for _, value := range found {
+			values = append(values, value)
+		}
+		return values, nil
+	}
+	values := make([]string, 0, len(keys))
+	for _, k := range keys {
+		value, err := b.cache.Get(ctx, k)
+		if errors.Is(err, cache.ErrCacheNil) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+	}
+	return values, nil
+}
+
+// key formats the key of the bucket starting at start.
+func key(name string, start time.Time, granularity time.Duration) string {
+	layout := "2006-01-02T15:04"
+	if granularity < time.Minute {
+		layout = "2006-01-02T15:04:05"
+	}
+	return name + ":" + start.UTC().Format(layout)
+}
+
+// validate checks that granularity can be represented by the bucket key format.
+func validate(granularity time.Duration) error {
+	if granularity <= 0 || granularity%time.Second != 0 {
+		return ErrInvalidGranularity
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package counters
+
+import (
+	"errors"
+	"time"
+
+	"github.com/zeroxsolutions/strike/builderutil"
+)
+
+// DefaultRetention is how long buckets are kept when no retention is configured.
+const DefaultRetention = 24 * time.Hour
+
+// Options holds the settings of Buckets.
+// This struct is populated through OptionsBuilder and consumed by New.
+type Options struct {
+	Retention time.Duration // Retention is the TTL set on a bucket when it is first written.
+}
+
+// OptionsBuilder provides a builder pattern for constructing Options.
+// This builder implements the builderutil.Lister interface to work with the functional options pattern.
+type OptionsBuilder struct {
+	Opts []func(*Options) error // Opts contains the list of option functions to be applied
+}
+
+// SetRetention configures how long a bucket is kept after its first increment. RangeSum counts
+// expired buckets as 0, so retention bounds how far back sums are meaningful; it should be at
+// least the longest range queried plus one granularity.
+//
+// Parameters:
+//   - retention: Bucket TTL, must be positive
+//
+// Returns:
+//   - *OptionsBuilder: The builder instance for method chaining
+func (b *OptionsBuilder) SetRetention(retention time.Duration) *OptionsBuilder {
+	b.Opts = append(b.Opts, func(o *Options) error {
+		if retention <= 0 {
+			return errors.New("counters: retention must be positive")
+		}
+		o.Retention = retention
+		return nil
+	})
+	return b
+}
+
+// List returns the slice of option functions accumulated by the builder.
+// This method implements the builderutil.Lister interface.
+//
+// Returns:
+//   - []func(*Options) error: A slice of option functions that can be applied to configure Options
+func (b *OptionsBuilder) List() []func(*Options) error {
+	return b.Opts
+}
+
+// NewOptions creates and returns a new instance of OptionsBuilder.
+//
+// Returns:
+//   - *OptionsBuilder: A new instance of OptionsBuilder ready to be configured
+//
+// Example:
+//
+//	opts := counters.NewOptions().SetRetention(7 * 24 * time.Hour)
+func NewOptions() *OptionsBuilder {
+	return &OptionsBuilder{}
+}
+
+// defaultOptions returns the builder holding the Buckets defaults.
+func defaultOptions() builderutil.Lister[Options] {
+	return NewOptions().SetRetention(DefaultRetention)
+}
@@ -0,0 +1,200 @@
+package counters_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/zeroxsolutions/banshee/counters"
+	"github.com/zeroxsolutions/banshee/memory"
+)
+
+// manualClock is a banshee.Clock that only moves when the test changes now.
+type manualClock struct {
+	now time.Time
+}
+
+func (c *manualClock) Now() time.Time {
+	return c.now
+}
+
+// mGetCache adds an MGet counting its calls to the in-memory cache.
+type mGetCache struct {
+	*memory.Cache
+	calls int
+}
+
+func (c *mGetCache) MGet(ctx context.Context, keys ...string) (map[string]string, error) {
+	c.calls++
+	values := map[string]string{}
+	for _, key := range keys {
+		if value, err := c.Get(ctx, key); err == nil {
+			values[key] = value
+		}
+	}
+	return values, nil
+}
+
+// TestKey verifies the documented bucket key format and the bucket boundaries.
+func TestKey(t *testing.T) {
+	at := time.Date(2024, 5, 1, 12, 4, 59, 999999999, time.UTC)
+
+	cases := []struct {
+		t           time.Time
+		granularity time.Duration
+		want        string
+	}{
+		{at, time.Minute, "logins:2024-05-01T12:04"},
+		{at.Add(time.Nanosecond), time.Minute, "logins:2024-05-01T12:05"},
+		{at, 5 * time.Minute, "logins:2024-05-01T12:00"},
+		{at, time.Hour, "logins:2024-05-01T12:00"},
+		{at, 24 * time.Hour, "logins:2024-05-01T00:00"},
+		{at, 10 * time.Second, "logins:2024-05-01T12:04:50"},
+		{at.In(time.FixedZone("UTC+2", 2*60*60)), time.Minute, "logins:2024-05-01T12:04"},
+	}
+	for _, c := range cases {
+		got, err := counters.Key("logins", c.t, c.granularity)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != c.want {
+			t.Errorf("Key(%v, %v) = %q, want %q", c.t, c.granularity, got, c.want)
+		}
+	}
+
+	for _, granularity := range []time.Duration{0, -time.Minute, 1500 * time.Millisecond} {
+		if _, err := counters.Key("logins", at, granularity); !errors.Is(err, counters.ErrInvalidGranularity) {
+			t.Errorf("granularity %v: %v", granularity, err)
+		}
+	}
+}
+
+// TestBuckets verifies increments, range sums across bucket edges, and retention.
+func TestBuckets(t *testing.T) {
+	ctx := context.Background()
+	start := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	clock := &manualClock{now: start}
+	c := memory.NewWithClock(clock)
+
+	buckets, err := counters.New(c, counters.NewOptions().SetRetention(10*time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 12:00:00, 12:00:59 and 12:01:00 straddle the edge between the first two buckets.
+	for _, offset := range []time.Duration{0, 59 * time.Second, time.Minute, 2*time.Minute + 30*time.Second} {
+		if err := buckets.IncrBucket(ctx, "logins", start.Add(offset), time.Minute); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	sums := []struct {
+		from, to time.Duration
+		want     int64
+	}{
+		{0, 0, 2},
+		{0, 59 * time.Second, 2},
+		{30 * time.Second, time.Minute, 3},
+		{time.Minute, 2 * time.Minute, 2},
+		{0, 3 * time.Minute, 4},
+		{3 * time.Minute, 10 * time.Minute, 0},
+		{time.Minute, 0, 0},
+	}
+	for _, s := range sums {
+		got, err := buckets.RangeSum(ctx, "logins", start.Add(s.from), start.Add(s.to), time.Minute)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != s.want {
+			t.Errorf("RangeSum(+%v, +%v) = %d, want %d", s.from, s.to, got, s.want)
+		}
+	}
+
+	// The retention runs from each bucket's first write and isn't extended by later writes.
+	clock.now = start.Add(5 * time.Minute)
+	if err := buckets.IncrBucket(ctx, "logins", start.Add(2*time.Minute), time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	clock.now = start.Add(10 * time.Minute)
+	if got, err := buckets.RangeSum(ctx, "logins", start, start.Add(3*time.Minute), time.Minute); err != nil || got != 0 {
+		t.Fatal(got, err)
+	}
+
+	// An expired bucket starts over, with a new retention.
+	if err := buckets.IncrBucket(ctx, "logins", start.Add(2*time.Minute), time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	clock.now = start.Add(19 * time.Minute)
+	if got, err := buckets.RangeSum(ctx, "logins", start, start.Add(3*time.Minute), time.Minute); err != nil || got != 1 {
+		t.Fatal(got, err)
+	}
+}
+
+// TestBuckets_MGet verifies that RangeSum reads all buckets in one round trip when the cache
+// supports MGet.
+func TestBuckets_MGet(t *testing.T) {
+	ctx := context.Background()
+	start := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	c := &mGetCache{Cache: memory.New()}
+
+	buckets, err := counters.New(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 5; i++ {
+		if err := buckets.IncrBucket(ctx, "requests", start.Add(time.Duration(i)*time.Hour), time.Hour); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if got, err := buckets.RangeSum(ctx, "requests", start, start.Add(10*time.Hour), time.Hour); err != nil || got != 5 {
+		t.Fatal(got, err)
+	}
+	if c.calls != 1 {
+		t.Fatal("unexpected MGet calls:", c.calls)
+	}
+}
+
+// expireCountingCache counts the Expire calls made on the in-memory cache.
+type expireCountingCache struct {
+	*memory.Cache
+	calls int
+}
+
+func (c *expireCountingCache) Expire(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	c.calls++
+	return c.Cache.Expire(ctx, key, ttl)
+}
+
+// TestBuckets_AtomicExpiration verifies that a new bucket gets its TTL in the same step as its
+// first increment, without a separate Expire that a failure could skip.
+func TestBuckets_AtomicExpiration(t *testing.T) {
+	ctx := context.Background()
+	start := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	clock := &manualClock{now: start}
+	c := &expireCountingCache{Cache: memory.NewWithClock(clock)}
+
+	buckets, err := counters.New(c, counters.NewOptions().SetRetention(time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := buckets.IncrBucket(ctx, "logins", start, time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	if c.calls != 0 {
+		t.Fatal("unexpected Expire calls:", c.calls)
+	}
+
+	clock.now = start.Add(time.Minute)
+	if got, err := buckets.RangeSum(ctx, "logins", start, start, time.Minute); err != nil || got != 0 {
+		t.Fatal("the bucket should have expired:", got, err)
+	}
+}
+
+// TestNew_Errors verifies option validation.
+func TestNew_Errors(t *testing.T) {
+	if _, err := counters.New(memory.New(), counters.NewOptions().SetRetention(0)); err == nil {
+		t.Fatal("expected an error for a zero retention")
+	}
+}
@@ -0,0 +1,134 @@
+// Package dedup detects repeated events within a time window, e.g. webhook deliveries retried by
+// the sender or messages redelivered by a queue.
+//
+// Every sighting is recorded under a key with the following format:
+//
+//	"seen:" + <scope> + ":" + <id>
+//
+// which expires after the window, so the next sighting after that counts as a first one again.
+package dedup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/zeroxsolutions/banshee"
+	"github.com/zeroxsolutions/barbatos/cache"
+)
+
+// keyPrefix is the prefix of the keys recording sightings.
+const keyPrefix = "seen:"
+
+// ErrUnsupported is returned when the cache doesn't implement banshee.SetNXCache. Recording a
+// sighting with a Get followed by a Set would let two concurrent callers both see a first time.
+var ErrUnsupported = errors.New("dedup: cache does not implement banshee.SetNXCache")
+
+// ErrUnavailable is matched (via errors.Is) by the *UnavailableError returned when the cache fails,
+// so callers can decide between failing open (processing the event) and failing closed.
+var ErrUnavailable = errors.New("dedup: cache unavailable")
+
+// UnavailableError reports a cache failure while recording a sighting. The result is then unknown:
+// the id may or may not have been seen before.
+type UnavailableError struct {
+	Err error // Err is the error returned by the cache.
+}
+
+// Error implements the error interface.
+func (e *UnavailableError) Error() string {
+	return fmt.Sprintf("dedup: cache unavailable: %v", e.Err)
+}
+
+// Is reports whether target is ErrUnavailable, so errors.Is matches any cache failure.
+func (e *UnavailableError) Is(target error) bool {
+	return target == ErrUnavailable
+}
+
+// Unwrap returns the cache error.
+func (e *UnavailableError) Unwrap() error {
+	return e.Err
+}
+
+// SeenRecently atomically records a sighting of id in scope and reports whether it is the first
+// one within window.
+//
+// Behavior:
+//   - The first sighting stores a marker expiring after window and returns true
+//   - Later sightings within the window return false and don't extend it
+//   - Once the marker expires, the next sighting is a first one again
+//
+// Parameters:
+//   - ctx: Context for timeout control and request cancellation
+//   - c: Cache implementing banshee.SetNXCache
+//   - scope: Namespace of the ids, e.g. "webhook"
+//   - id: Identifier of the event
+//   - window: Time during which repeated sightings are reported
+//
+// Returns:
+//   - bool: True if this is the first sighting of id within window
+//   - error: ErrUnsupported if c doesn't implement banshee.SetNXCache, or an *UnavailableError if
+//     the cache fails
+//
+// Example:
+//
+//	first, err := dedup.SeenRecently(ctx, redisCache, "webhook", deliveryID, 24*time.Hour)
+//	if errors.Is(err, dedup.ErrUnavailable) {
+//	    first = true // fail open: processing twice is better than not at all
+//	} else if err != nil {
+//	    return err
+//	}
+//	if !first {
+//	    return nil
+//	}
+func SeenRecently(ctx context.Context, c cache.Cache, scope, id string, window time.Duration) (bool, error) {
+	setter, ok := c.(banshee.SetNXCache)
+	if !ok {
+		return false, ErrUnsupported
+	}
+	first, err := setter.SetNX(ctx, key(scope, id), 1, window)
+	if err != nil {
+		return false, &UnavailableError{Err: err}
+	}
+	return first, nil
+}
+
+// SeenRecentlyMany is the batch variant of SeenRecently, recording all ids in a single round trip
+// where the cache supports it. Results are in the order of ids; an id repeated within the batch is
+// reported as first only at its first position.
+//
+// Parameters:
+//   - ctx: Context for timeout control and request cancellation
+//   - c: Cache implementing banshee.SetNXCache
+//   - scope: Namespace of the ids, e.g. "webhook"
+//   - ids: Identifiers of the events
+//   - window: Time during which repeated sightings are reported
+//
+// Returns:
+//   - []bool: For each id, true if this is its first sighting within window
+//   - error: ErrUnsupported if c doesn't implement banshee.SetNXCache, or an *UnavailableError if
+//     the cache fails
+//
+// Example:
+//
+//	first, err := dedup.SeenRecentlyMany(ctx, redisCache, "message", messageIDs, time.Hour)
+func SeenRecentlyMany(ctx context.Context, c cache.Cache, scope string, ids []string, window time.Duration) ([]bool, error) {
+	setter, ok := c.(banshee.SetNXCache)
+	if !ok {
+		return nil, ErrUnsupported
+	}
+	keys := make([]string, len(ids))
+	for i, id := range ids {
+		keys[i] = key(scope, id)
+	}
+	first, err := setter.SetNXMany(ctx, keys, 1, window)
+	if err != nil {
+		return nil, &UnavailableError{Err: err}
+	}
+	return first, nil
+}
+
+// key returns the key recording sightings of id in scope.
+func key(scope, id string) string {
+	return keyPrefix + scope + ":" + id
+}
@@ -0,0 +1,98 @@
+package dedup_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/zeroxsolutions/banshee/dedup"
+	"github.com/zeroxsolutions/banshee/memory"
+	"github.com/zeroxsolutions/barbatos/cache"
+)
+
+// manualClock is a banshee.Clock that only moves when the test changes now.
+type manualClock struct {
+	now time.Time
+}
+
+func (c *manualClock) Now() time.Time {
+	return c.now
+}
+
+// plainCache is a cache.Cache without the SetNXCache capability.
+type plainCache struct {
+	cache.Cache
+}
+
+// TestSeenRecently verifies first and subsequent sightings and the expiry of the window.
+func TestSeenRecently(t *testing.T) {
+	ctx := context.Background()
+	clock := &manualClock{now: time.Now()}
+	c := memory.NewWithClock(clock)
+
+	for i, want := range []bool{true, false, false} {
+		first, err := dedup.SeenRecently(ctx, c, "webhook", "1", time.Minute)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if first != want {
+			t.Fatalf("sighting %d: got first=%v, want %v", i, first, want)
+		}
+	}
+
+	if first, err := dedup.SeenRecently(ctx, c, "other", "1", time.Minute); err != nil || !first {
+		t.Fatal("scopes should be independent", first, err)
+	}
+
+	clock.now = clock.now.Add(time.Minute)
+
+	if first, err := dedup.SeenRecently(ctx, c, "webhook", "1", time.Minute); err != nil || !first {
+		t.Fatal("sighting after the window should be first", first, err)
+	}
+}
+
+// TestSeenRecentlyMany verifies the per-id results of the batch variant.
+func TestSeenRecentlyMany(t *testing.T) {
+	ctx := context.Background()
+	c := memory.New()
+
+	if _, err := dedup.SeenRecently(ctx, c, "message", "b", time.Minute); err != nil {
+		t.Fatal(err)
+	}
+
+	first, err := dedup.SeenRecentlyMany(ctx, c, "message", []string{"a", "b", "c", "a"}, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []bool{true, false, true, false}; !reflect.DeepEqual(first, want) {
+		t.Fatalf("got %v, want %v", first, want)
+	}
+}
+
+// TestSeenRecently_Errors verifies that unsupported caches and cache failures are distinguishable.
+func TestSeenRecently_Errors(t *testing.T) {
+	ctx := context.Background()
+
+	if _, err := dedup.SeenRecently(ctx, plainCache{memory.New()}, "webhook", "1", time.Minute); !errors.Is(err, dedup.ErrUnsupported) {
+		t.Fatal(err)
+	}
+	if _, err := dedup.SeenRecentlyMany(ctx, plainCache{memory.New()}, "webhook", []string{"1"}, time.Minute); !errors.Is(err, dedup.ErrUnsupported) {
+		t.Fatal(err)
+	}
+
+	c := memory.New()
+	if err := c.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := dedup.SeenRecently(ctx, c, "webhook", "1", time.Minute)
+	if !errors.Is(err, dedup.ErrUnavailable) || !errors.Is(err, memory.ErrClosed) {
+		t.Fatal(err)
+	}
+	_, err = dedup.SeenRecentlyMany(ctx, c, "webhook", []string{"1"}, time.Minute)
+	if !errors.Is(err, dedup.ErrUnavailable) {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,209 @@
+// Package envelope wraps a cache.Cache so that every value is stored inside a small metadata
+// envelope recording when, by whom, and with which schema version it was written. This makes
+// stale or unexpected cache entries debuggable without changing call sites: Get transparently
+// unwraps the envelope, while GetWithMeta also returns the metadata.
+//
+// Enveloped values are stored as:
+//
+//	"\x00envelope:v2:" + <byte length of the metadata> + ":" +
+//	    JSON({"c": <unix nanoseconds>, "w": <writer>, "s": <schema version>}) + <value>
+//
+// The value follows the metadata verbatim, so binary payloads such as gzip or protobuf data are
+// stored byte for byte.
+//
+// Values without the prefix are treated as legacy values written before the envelope mode was
+// enabled: they are returned unchanged with a zero Meta, so the mode can be rolled out over an
+// existing keyspace.
+package envelope
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/zeroxsolutions/banshee/internal/valueutil"
+	"github.com/zeroxsolutions/barbatos/cache"
+	"github.com/zeroxsolutions/strike/builderutil"
+)
+
+// prefix marks enveloped values. The leading NUL byte keeps ordinary text values from being
+// mistaken for envelopes.
+const prefix = "\x00envelope:v2:"
+
+// ErrMalformedEnvelope is returned by reads when a value carries an envelope prefix but its
+// metadata can't be parsed.
+var ErrMalformedEnvelope = errors.New("envelope: malformed envelope")
+
+// ErrSchemaMismatch is returned by reads when the cache was built with SetRejectSchemaMismatch(true)
+// and the stored value was written with a different schema version.
+var ErrSchemaMismatch = errors.New("envelope: schema version mismatch")
+
+// Meta describes how an enveloped value was written.
+type Meta struct {
+	CreatedAt     time.Time // CreatedAt is the time the value was written.
+	Writer        string    // Writer is the identity configured on the writing cache.
+	SchemaVersion int       // SchemaVersion is the schema version configured on the writing cache.
+	Legacy        bool      // Legacy is true for values written without an envelope; other fields are zero.
+}
+
+// record is the JSON metadata stored after the prefix, together with the value following it.
+type record struct {
+	CreatedAt     int64  `json:"c"`
+	Writer        string `json:"w,omitempty"`
+	SchemaVersion int    `json:"s"`
+	Value         string `json:"-"`
+}
+
+// Cache is a cache.Cache decorator storing values inside metadata envelopes.
+type Cache struct {
+	cache   cache.Cache
+	options *Options
+}
+
+var _ cache.Cache = (*Cache)(nil)
+
+// New creates an envelope Cache over c.
+//
+// Parameters:
+//   - c: Underlying cache used for storage
+//   - opts: Optional Options builders created with NewOptions
+//
+// Returns:
+//   - *Cache: The enveloping cache
+//   - error: An error if building the options fails
+//
+// Example:
+//
+//	envCache, err := envelope.New(redisCache, envelope.NewOptions().SetWriter("billing-api").SetSchemaVersion(3))
+//	value, meta, err := envCache.GetWithMeta(ctx, "invoice:42")
+//	log.Printf("written by %s at %s (schema v%d)", meta.Writer, meta.CreatedAt, meta.SchemaVersion)
+func New(c cache.Cache, opts ...builderutil.Lister[Options]) (*Cache, error) {
+	options, err := builderutil.Build(opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &Cache{cache: c, options: options}, nil
+}
+
+// IsConnected reports the connection status of the underlying cache.
+func (c *Cache) IsConnected(ctx context.Context) bool {
+	return c.cache.IsConnected(ctx)
+}
+
+// Keys returns the keys matching pattern in the underlying cache.
+func (c *Cache) Keys(ctx context.Context, pattern string) ([]string, error) {
+	return c.cache.Keys(ctx, pattern)
+}
+
+// Get retrieves the value stored under key, stripping its envelope.
+// Legacy values are returned unchanged.
+//
+// Returns:
+//   - string: The unwrapped value
+//   - error: cache.ErrCacheNil if the key doesn't exist, ErrMalformedEnvelope, ErrSchemaMismatch
+//     when strict and the schema versions differ, or an error from the underlying cache
+func (c *Cache) Get(ctx context.Context, key string) (string, error) {
+	value, _, err := c.GetWithMeta(ctx, key)
+	return value, err
+}
+
+// GetWithMeta retrieves the value stored under key together with its envelope metadata.
+// Legacy values are returned unchanged with Meta.Legacy set.
+//
+// Parameters:
+//   - ctx: Context for timeout control and request cancellation
+//   - key: Cache key to read
+//
+// Returns:
+//   - string: The unwrapped value
+//   - Meta: The metadata recorded when the value was written
+//   - error: cache.ErrCacheNil if the key doesn't exist, ErrMalformedEnvelope, ErrSchemaMismatch
+//     when strict and the schema versions differ (the value and Meta are still returned), or an
+//     error from the underlying cache
+func (c *Cache) GetWithMeta(ctx context.Context, key string) (string, Meta, error) {
+	raw, err := c.cache.Get(ctx, key)
+	if err != nil {
+		return "", Meta{}, err
+	}
+	rec, err := decode(raw)
+	if err != nil {
+		return "", Meta{}, err
+	}
+	if rec == nil {
+		return raw, Meta{Legacy: true}, nil
+	}
+	meta := Meta{
+		CreatedAt:     time.Unix(0, rec.CreatedAt),
+		Writer:        rec.Writer,
+		SchemaVersion: rec.SchemaVersion,
+	}
+	if c.options.RejectSchemaMismatch && rec.SchemaVersion != c.options.SchemaVersion {
+		return rec.Value, meta, ErrSchemaMismatch
+	}
+	return rec.Value, meta, nil
+}
+
+// Set stores value under key inside an envelope, without expiration.
+func (c *Cache) Set(ctx context.Context, key string, value interface{}) error {
+	return c.SetWithExpiration(ctx, key, value, 0)
+}
+
+// SetWithExpiration stores value under key inside an envelope stamped with the current time,
+// the configured writer, and the configured schema version.
+func (c *Cache) SetWithExpiration(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
+	raw, err := valueutil.Stringify(value)
+	if err != nil {
+		return err
+	}
+	doc, err := json.Marshal(record{
+		CreatedAt:     time.Now().UnixNano(),
+		Writer:        c.options.Writer,
+		SchemaVersion: c.options.SchemaVersion,
+	})
+	if err != nil {
+		return err
+	}
+	return c.cache.SetWithExpiration(ctx, key, prefix+strconv.Itoa(len(doc))+":"+string(doc)+raw, expiration)
+}
+
+// decode parses an enveloped value into its record, with the value in Value. It returns a nil
+// record for values without an envelope prefix, and an error wrapping ErrMalformedEnvelope for
+// values with the prefix whose metadata can't be parsed.
+func decode(raw string) (*record, error) {
+	var rec record
+	if !strings.HasPrefix(raw, prefix) {
+		return nil, nil
+	}
+	length, rest, ok := strings.Cut(raw[len(prefix):], ":")
+	if !ok {
+		return nil, ErrMalformedEnvelope
+	}
+	n, err := strconv.Atoi(length)
+	if err != nil || n < 0 || n > len(rest) {
+		return nil, ErrMalformedEnvelope
+	}
+	if err := json.Unmarshal([]byte(rest[:n]), &rec); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrMalformedEnvelope, err)
+	}
+	rec.Value = rest[n:]
+	return &rec, nil
+}
+
+// Del deletes keys from the underlying cache.
+func (c *Cache) Del(ctx context.Context, keys ...string) error {
+	return c.cache.Del(ctx, keys...)
+}
+
+// DelWithPattern deletes the keys matching pattern from the underlying cache.
+func (c *Cache) DelWithPattern(ctx context.Context, pattern string) error {
+	return c.cache.DelWithPattern(ctx, pattern)
+}
+
+// Close closes the underlying cache.
+func (c *Cache) Close() error {
+	return c.cache.Close()
+}
@@ -0,0 +1,84 @@
+package envelope
+
+// Options holds the settings of an envelope Cache.
+// This struct is populated through OptionsBuilder and consumed by New.
+type Options struct {
+	Writer               string // Writer identifies the process writing values (e.g. service name and host).
+	SchemaVersion        int    // SchemaVersion is stamped on every write and compared on reads when strict.
+	RejectSchemaMismatch bool   // RejectSchemaMismatch makes reads of other schema versions fail with ErrSchemaMismatch.
+}
+
+// OptionsBuilder provides a builder pattern for constructing Options.
+// This builder implements the builderutil.Lister interface to work with the functional options pattern.
+type OptionsBuilder struct {
+	Opts []func(*Options) error // Opts contains the list of option functions to be applied
+}
+
+// SetWriter configures the writer identity recorded in every envelope.
+//
+// Parameters:
+//   - writer: Identity of the writing process, e.g. "billing-api@pod-7"
+//
+// Returns:
+//   - *OptionsBuilder: The builder instance for method chaining
+func (b *OptionsBuilder) SetWriter(writer string) *OptionsBuilder {
+	b.Opts = append(b.Opts, func(o *Options) error {
+		o.Writer = writer
+		return nil
+	})
+	return b
+}
+
+// SetSchemaVersion configures the schema version recorded in every envelope.
+// Bump it whenever the encoding of the cached values changes.
+//
+// Parameters:
+//   - version: Schema version of the values written through the cache
+//
+// Returns:
+//   - *OptionsBuilder: The builder instance for method chaining
+func (b *OptionsBuilder) SetSchemaVersion(version int) *OptionsBuilder {
+	b.Opts = append(b.Opts, func(o *Options) error {
+		o.SchemaVersion = version
+		return nil
+	})
+	return b
+}
+
+// SetRejectSchemaMismatch configures whether reads of enveloped values written with a different
+// schema version fail with ErrSchemaMismatch. Without it, mismatches are only visible through the
+// Meta returned by GetWithMeta.
+//
+// Parameters:
+//   - reject: true to surface schema mismatches as errors
+//
+// Returns:
+//   - *OptionsBuilder: The builder instance for method chaining
+func (b *OptionsBuilder) SetRejectSchemaMismatch(reject bool) *OptionsBuilder {
+	b.Opts = append(b.Opts, func(o *Options) error {
+		o.RejectSchemaMismatch = reject
+		return nil
+	})
+	return b
+}
+
+// List returns the slice of option functions accumulated by the builder.
+// This method implements the builderutil.Lister interface.
+//
+// Returns:
+//   - []func(*Options) error: A slice of option functions that can be applied to configure Options
+func (b *OptionsBuilder) List() []func(*Options) error {
+	return b.Opts
+}
+
+// NewOptions creates and returns a new instance of OptionsBuilder.
+//
+// Returns:
+//   - *OptionsBuilder: A new instance of OptionsBuilder ready to be configured
+//
+// Example:
+//
+//	opts := envelope.NewOptions().SetWriter("billing-api").SetSchemaVersion(3)
+func NewOptions() *OptionsBuilder {
+	return &OptionsBuilder{}
+}
@@ -0,0 +1,183 @@
+package envelope_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/zeroxsolutions/banshee/envelope"
+	"github.com/zeroxsolutions/banshee/internal/fakecache"
+)
+
+// TestCache_MetaRoundTrip verifies that metadata written with a value is returned by GetWithMeta
+// and that Get returns only the unwrapped value.
+func TestCache_MetaRoundTrip(t *testing.T) {
+	backend := fakecache.New()
+
+	envCache, err := envelope.New(backend, envelope.NewOptions().SetWriter("billing-api").SetSchemaVersion(3))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+
+	before := time.Now()
+	if err := envCache.SetWithExpiration(ctx, "invoice:42", "paid", time.Minute); err != nil {
+		t.Fatal(err)
+	}
+
+	value, meta, err := envCache.GetWithMeta(ctx, "invoice:42")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if value != "paid" || meta.Writer != "billing-api" || meta.SchemaVersion != 3 || meta.Legacy {
+		t.Log("unexpected value or meta:", value, meta)
+		t.FailNow()
+	}
+
+	if meta.CreatedAt.Before(before) || meta.CreatedAt.After(time.Now()) {
+		t.Log("unexpected creation time:", meta.CreatedAt)
+		t.FailNow()
+	}
+
+	plain, err := envCache.Get(ctx, "invoice:42")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if plain != "paid" {
+		t.FailNow()
+	}
+
+	raw, err := backend.Get(ctx, "invoice:42")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if raw == "paid" || !strings.Contains(raw, "billing-api") {
+		t.Log("value was not enveloped:", raw)
+		t.FailNow()
+	}
+}
+
+// TestCache_Legacy verifies that values written without an envelope still read correctly.
+func TestCache_Legacy(t *testing.T) {
+	backend := fakecache.New()
+
+	envCache, err := envelope.New(backend, envelope.NewOptions().SetRejectSchemaMismatch(true).SetSchemaVersion(2))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+
+	if err := backend.Set(ctx, "legacy", "old-value"); err != nil {
+		t.Fatal(err)
+	}
+
+	value, meta, err := envCache.GetWithMeta(ctx, "legacy")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if value != "old-value" || !meta.Legacy {
+		t.Log("unexpected legacy read:", value, meta)
+		t.FailNow()
+	}
+}
+
+// TestCache_SchemaMismatch verifies that schema-version mismatches are surfaced only when requested.
+func TestCache_SchemaMismatch(t *testing.T) {
+	backend := fakecache.New()
+
+	writer, err := envelope.New(backend, envelope.NewOptions().SetSchemaVersion(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lenient, err := envelope.New(backend, envelope.NewOptions().SetSchemaVersion(2))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	strict, err := envelope.New(backend, envelope.NewOptions().SetSchemaVersion(2).SetRejectSchemaMismatch(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+
+	if err := writer.Set(ctx, "profile", "v1-encoding"); err != nil {
+		t.Fatal(err)
+	}
+
+	_, meta, err := lenient.GetWithMeta(ctx, "profile")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if meta.SchemaVersion != 1 {
+		t.FailNow()
+	}
+
+	if _, err := strict.Get(ctx, "profile"); err != envelope.ErrSchemaMismatch {
+		t.Log(err)
+		t.FailNow()
+	}
+}
+
+// TestCache_BinaryRoundTrip verifies that values that aren't valid UTF-8, such as gzip data, are
+// stored and returned byte for byte.
+func TestCache_BinaryRoundTrip(t *testing.T) {
+	envCache, err := envelope.New(fakecache.New(), envelope.NewOptions().SetWriter("billing-api"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	payload := "\x1f\x8b\xff\xfe\x00"
+
+	if err := envCache.Set(ctx, "blob", []byte(payload)); err != nil {
+		t.Fatal(err)
+	}
+
+	value, meta, err := envCache.GetWithMeta(ctx, "blob")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if value != payload || meta.Writer != "billing-api" {
+		t.Logf("unexpected value or meta: %q %v", value, meta)
+		t.FailNow()
+	}
+}
+
+// TestCache_Malformed verifies that values carrying the envelope prefix with unparsable metadata
+// are reported as malformed envelopes.
+func TestCache_Malformed(t *testing.T) {
+	backend := fakecache.New()
+
+	envCache, err := envelope.New(backend)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+
+	for _, raw := range []string{
+		"\x00envelope:v2:",
+		"\x00envelope:v2:x:{}",
+		"\x00envelope:v2:99:{}",
+		"\x00envelope:v2:5:{bad}value",
+	} {
+		if err := backend.Set(ctx, "profile", raw); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := envCache.Get(ctx, "profile"); !errors.Is(err, envelope.ErrMalformedEnvelope) {
+			t.Fatalf("Get(%q) = %v", raw, err)
+		}
+	}
+}
@@ -0,0 +1,163 @@
+package banshee
+
+import (
+	"context"
+	"errors"
+	"math"
+	"time"
+
+	"github.com/zeroxsolutions/barbatos/cache"
+	"github.com/zeroxsolutions/strike/builderutil"
+)
+
+// ErrExpiringSetUnsupported is returned by NewExpiringSet when the cache implements neither
+// SortedSetCache nor Expirer, which an expiring set is stored with.
+var ErrExpiringSetUnsupported = errors.New("banshee: expiring set requires a cache implementing SortedSetCache and Expirer")
+
+// expiringSetStore is the set of capabilities an ExpiringSet relies on.
+type expiringSetStore interface {
+	cache.Cache
+	SortedSetCache
+	Expirer
+}
+
+// ExpiringSet stores sets whose members expire individually, such as the devices active on an
+// account in the last 15 minutes. Each set is a sorted set scored by the expiry of its members in
+// unix milliseconds; expired members are pruned by every read.
+type ExpiringSet struct {
+	cache   expiringSetStore
+	options *ExpiringSetOptions
+}
+
+// NewExpiringSet creates an ExpiringSet over c.
+//
+// Behavior:
+//   - Add sets or extends the expiry of one member; members never outlive their own TTL
+//   - Members, Contains and Cardinality remove expired members before answering
+//   - The set key itself expires with its latest member, so abandoned sets vanish from the cache
+//
+// Parameters:
+//   - c: Cache implementing SortedSetCache and Expirer, such as redis.RedisCache or memory.Cache
+//   - opts: Optional ExpiringSetOptions builders created with NewExpiringSetOptions
+//
+// Returns:
+//   - *ExpiringSet: The expiring set helper
+//   - error: ErrExpiringSetUnsupported if c lacks a required capability, or an error if building
+//     the options fails
+//
+// Example:
+//
+//	devices, err := banshee.NewExpiringSet(redisCache)
+//	err = devices.Add(ctx, "devices:account:7", deviceID, 15*time.Minute)
+//	active, err := devices.Members(ctx, "devices:account:7")
+func NewExpiringSet(c cache.Cache, opts ...builderutil.Lister[ExpiringSetOptions]) (*ExpiringSet, error) {
+	store, ok := c.(expiringSetStore)
+	if !ok {
+		return nil, ErrExpiringSetUnsupported
+	}
+	options, err := builderutil.Build(append([]builderutil.Lister[ExpiringSetOptions]{defaultExpiringSetOptions()}, opts...)...)
+	if err != nil {
+		return nil, err
+	}
+	return &ExpiringSet{cache: store, options: options}, nil
+}
+
+// Add adds member to the set stored under key for ttl, or resets the expiry of an existing member
+// to ttl from now. When member becomes the latest to expire, the TTL of key is raised to match.
+// Two concurrent Adds may both see themselves as latest and leave key expiring with the shorter
+// one; the next Add of the longer-lived member repairs it.
+//
+// Parameters:
+//   - ctx: Context for timeout control and request cancellation
+//   - key: Cache key of the set
+//   - member: Member to add
+//   - ttl: Time after which member expires, must be positive
+//
+// Returns:
+//   - error: An error if ttl is not positive or the cache fails
+func (s *ExpiringSet) Add(ctx context.Context, key, member string, ttl time.Duration) error {
+	if ttl <= 0 {
+		return errors.New("banshee: expiring set TTL must be positive")
+	}
+	expiry := float64(s.options.Clock.Now().Add(ttl).UnixMilli())
+	if err := s.cache.ZAdd(ctx, key, expiry, member); err != nil {
+		return err
+	}
+	later, err := s.cache.ZRangeByScore(ctx, key, math.Nextafter(expiry, math.Inf(1)), math.Inf(1))
+	if err != nil {
+		return err
+	}
+	if len(later) > 0 {
+		return nil
+	}
+	_, err = s.cache.Expire(ctx, key, ttl)
+	return err
+}
+
+// Members returns the unexpired members of the set stored under key, ordered by expiry, soonest
+// first. A missing key yields an empty slice.
+//
+// Parameters:
+//   - ctx: Context for timeout control and request cancellation
+//   - key: Cache key of the set
+//
+// Returns:
+//   - []string: The unexpired members
+//   - error: An error from the cache
+func (s *ExpiringSet) Members(ctx context.Context, key string) ([]string, error) {
+	now, err := s.prune(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	return s.cache.ZRangeByScore(ctx, key, math.Nextafter(now, math.Inf(1)), math.Inf(1))
+}
+
+// Contains reports whether member is an unexpired member of the set stored under key. It reads
+// every unexpired member, so it is meant for sets of moderate size.
+//
+// Parameters:
+//   - ctx: Context for timeout control and request cancellation
+//   - key: Cache key of the set
+//   - member: Member to look for
+//
+// Returns:
+//   - bool: true if member is in the set and hasn't expired
+//   - error: An error from the cache
+func (s *ExpiringSet) Contains(ctx context.Context, key, member string) (bool, error) {
+	members, err := s.Members(ctx, key)
+	if err != nil {
+		return false, err
+	}
+	for _, m := range members {
+		if m == member {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// Cardinality returns the number of unexpired members of the set stored under key; 0 for a
+// missing key.
+//
+// Parameters:
+//   - ctx: Context for timeout control and request cancellation
+//   - key: Cache key of the set
+//
+// Returns:
+//   - int64: Number of unexpired members
+//   - error: An error from the cache
+func (s *ExpiringSet) Cardinality(ctx context.Context, key string) (int64, error) {
+	if _, err := s.prune(ctx, key); err != nil {
+		return 0, err
+	}
+	return s.cache.ZCard(ctx, key)
+}
+
+// prune removes the members of key that expired by now and returns now as a score.
+func (s *ExpiringSet) prune(ctx context.Context, key string) (float64, error) {
+	now := float64(s.options.Clock.Now().UnixMilli())
+	if _, err := s.cache.ZRemRangeByScore(ctx, key, math.Inf(-1), now); err != nil {
+		return 0, err
+	}
+	return now, nil
+}
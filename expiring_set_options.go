@@ -0,0 +1,64 @@
+package banshee
+
+import (
+	"errors"
+
+	"github.com/zeroxsolutions/strike/builderutil"
+)
+
+// ExpiringSetOptions holds the settings of an ExpiringSet.
+// This struct is populated through ExpiringSetOptionsBuilder and consumed by NewExpiringSet.
+type ExpiringSetOptions struct {
+	Clock Clock // Clock dates member expiries and decides which members have expired.
+}
+
+// ExpiringSetOptionsBuilder provides a builder pattern for constructing ExpiringSetOptions.
+// This builder implements the builderutil.Lister interface to work with the functional options pattern.
+type ExpiringSetOptionsBuilder struct {
+	Opts []func(*ExpiringSetOptions) error // Opts contains the list of option functions to be applied
+}
+
+// SetClock configures the clock dating member expiries. The container TTL is enforced by the
+// cache, so with a fake clock use a cache sharing it, such as memory.NewWithClock.
+//
+// Parameters:
+//   - clock: Clock telling the current time, must not be nil
+//
+// Returns:
+//   - *ExpiringSetOptionsBuilder: The builder instance for method chaining
+func (b *ExpiringSetOptionsBuilder) SetClock(clock Clock) *ExpiringSetOptionsBuilder {
+	b.Opts = append(b.Opts, func(o *ExpiringSetOptions) error {
+		if clock == nil {
+			return errors.New("banshee: clock must not be nil")
+		}
+		o.Clock = clock
+		return nil
+	})
+	return b
+}
+
+// List returns the slice of option functions accumulated by the builder.
+// This method implements the builderutil.Lister interface.
+//
+// Returns:
+//   - []func(*ExpiringSetOptions) error: A slice of option functions that can be applied to configure ExpiringSetOptions
+func (b *ExpiringSetOptionsBuilder) List() []func(*ExpiringSetOptions) error {
+	return b.Opts
+}
+
+// NewExpiringSetOptions creates and returns a new instance of ExpiringSetOptionsBuilder.
+//
+// Returns:
+//   - *ExpiringSetOptionsBuilder: A new instance of ExpiringSetOptionsBuilder ready to be configured
+//
+// Example:
+//
+//	opts := banshee.NewExpiringSetOptions().SetClock(clock)
+func NewExpiringSetOptions() *ExpiringSetOptionsBuilder {
+	return &ExpiringSetOptionsBuilder{}
+}
+
+// defaultExpiringSetOptions returns the builder holding the ExpiringSet defaults.
+func defaultExpiringSetOptions() builderutil.Lister[ExpiringSetOptions] {
+	return NewExpiringSetOptions().SetClock(SystemClock)
+}
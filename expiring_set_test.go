@@ -0,0 +1,96 @@
+package banshee_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/zeroxsolutions/banshee"
+	"github.com/zeroxsolutions/banshee/memory"
+)
+
+// setClock is a banshee.Clock that only moves when the test changes now. The mock module offers
+// the same as mock.MockClock, which this module's tests can't import.
+type setClock struct {
+	now time.Time
+}
+
+func (c *setClock) Now() time.Time {
+	return c.now
+}
+
+// TestExpiringSet verifies that members expire individually and that the set key vanishes with
+// its latest member.
+func TestExpiringSet(t *testing.T) {
+	ctx := context.Background()
+	clock := &setClock{now: time.Now()}
+	c := memory.NewWithClock(clock)
+
+	devices, err := banshee.NewExpiringSet(c, banshee.NewExpiringSetOptions().SetClock(clock))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := devices.Add(ctx, "devices", "laptop", 15*time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	if err := devices.Add(ctx, "devices", "phone", 5*time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	if err := devices.Add(ctx, "devices", "tablet", 10*time.Minute); err != nil {
+		t.Fatal(err)
+	}
+
+	members, err := devices.Members(ctx, "devices")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(members, []string{"phone", "tablet", "laptop"}) {
+		t.Fatal("unexpected members:", members)
+	}
+
+	clock.now = clock.now.Add(5 * time.Minute)
+	if found, err := devices.Contains(ctx, "devices", "phone"); err != nil || found {
+		t.Fatal("phone should have expired:", found, err)
+	}
+	if found, err := devices.Contains(ctx, "devices", "tablet"); err != nil || !found {
+		t.Fatal("tablet should still be a member:", found, err)
+	}
+	if n, err := devices.Cardinality(ctx, "devices"); err != nil || n != 2 {
+		t.Fatal(n, err)
+	}
+
+	// Re-adding tablet for a short TTL must not shorten the life of the set below laptop's.
+	if err := devices.Add(ctx, "devices", "tablet", time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	clock.now = clock.now.Add(time.Minute)
+	if members, err := devices.Members(ctx, "devices"); err != nil || !reflect.DeepEqual(members, []string{"laptop"}) {
+		t.Fatal(members, err)
+	}
+
+	clock.now = clock.now.Add(9 * time.Minute)
+	if n, err := devices.Cardinality(ctx, "devices"); err != nil || n != 0 {
+		t.Fatal(n, err)
+	}
+	if keys, err := c.Keys(ctx, "*"); err != nil || len(keys) != 0 {
+		t.Fatal("the set key should have expired:", keys, err)
+	}
+}
+
+// TestExpiringSet_Errors verifies argument and capability checks.
+func TestExpiringSet_Errors(t *testing.T) {
+	if _, err := banshee.NewExpiringSet(plainCache{memory.New()}); !errors.Is(err, banshee.ErrExpiringSetUnsupported) {
+		t.Fatal(err)
+	}
+
+	devices, err := banshee.NewExpiringSet(memory.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := devices.Add(context.Background(), "devices", "laptop", 0); err == nil {
+		t.Fatal("expected an error for a zero TTL")
+	}
+}
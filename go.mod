@@ -1,3 +1,8 @@
 module github.com/zeroxsolutions/banshee
 
 go 1.18
+
+require (
+	github.com/zeroxsolutions/barbatos v0.0.1
+	github.com/zeroxsolutions/strike v0.0.1
+)
@@ -0,0 +1,29 @@
+module github.com/zeroxsolutions/banshee/grpchealth
+
+go 1.21
+
+require (
+	github.com/stretchr/testify v1.9.0
+	github.com/zeroxsolutions/banshee v0.0.2
+	github.com/zeroxsolutions/banshee/mock v0.0.2
+	github.com/zeroxsolutions/barbatos v0.0.1
+	github.com/zeroxsolutions/strike v0.0.1
+	google.golang.org/grpc v1.64.0
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	github.com/stretchr/objx v0.5.2 // indirect
+	golang.org/x/net v0.22.0 // indirect
+	golang.org/x/sys v0.18.0 // indirect
+	golang.org/x/text v0.14.0 // indirect
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20240318140521-94a12d6c2237 // indirect
+	google.golang.org/protobuf v1.33.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+replace (
+	github.com/zeroxsolutions/banshee => ../
+	github.com/zeroxsolutions/banshee/mock => ../mock
+)
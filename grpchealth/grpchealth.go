@@ -0,0 +1,118 @@
+// Package grpchealth reflects the health of a cache in the standard grpc.health.v1 service, so
+// that service meshes and load balancers probing gRPC health stop routing to an instance whose
+// cache is unreachable.
+//
+// It lives in its own module so that only users of gRPC depend on it.
+package grpchealth
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/zeroxsolutions/banshee/health"
+	"github.com/zeroxsolutions/barbatos/cache"
+	"github.com/zeroxsolutions/strike/builderutil"
+	"google.golang.org/grpc"
+	grpchealth "google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// Register registers a grpc.health.v1 service on s and starts a background prober updating the
+// status of serviceName from health checks of c.
+//
+// Behavior:
+//   - serviceName starts NOT_SERVING and is reported SERVING after SetSuccessThreshold consecutive
+//     successful checks (1 by default)
+//   - A serving service is reported NOT_SERVING only after SetFailureThreshold consecutive failed
+//     checks (3 by default), so isolated slow checks don't make the status flap
+//   - A check fails when c.IsConnected reports false or doesn't answer within SetTimeout
+//   - When ctx is done, the prober stops and serviceName is reported NOT_SERVING
+//   - Closing c doesn't stop the prober, which can't observe it: a closed cache fails its checks,
+//     so serviceName turns NOT_SERVING after SetFailureThreshold checks, but the prober keeps
+//     running until ctx is done; cancel ctx when closing the cache
+//
+// Parameters:
+//   - ctx: Context whose cancellation stops the prober
+//   - s: gRPC server to register the health service on, before it starts serving
+//   - c: Cache to check
+//   - serviceName: Service name reported to health clients; "" is the server's overall health
+//   - interval: Time between checks, must be positive
+//   - opts: Optional Options builders created with NewOptions
+//
+// Returns:
+//   - *grpchealth.Server: The registered health server, to report other services on it
+//   - error: An error if interval isn't positive or building the options fails
+//
+// Example:
+//
+//	s := grpc.NewServer()
+//	_, err := grpchealth.Register(ctx, s, redisCache, "orders", 5*time.Second,
+//	    grpchealth.NewOptions().SetFailureThreshold(3))
+func Register(ctx context.Context, s *grpc.Server, c cache.Cache, serviceName string, interval time.Duration, opts ...builderutil.Lister[Options]) (*grpchealth.Server, error) {
+	if interval <= 0 {
+		return nil, errors.New("grpchealth: interval must be positive")
+	}
+	options, err := builderutil.Build(append([]builderutil.Lister[Options]{defaultOptions()}, opts...)...)
+	if err != nil {
+		return nil, err
+	}
+
+	server := grpchealth.NewServer()
+	server.SetServingStatus(serviceName, healthpb.HealthCheckResponse_NOT_SERVING)
+	healthpb.RegisterHealthServer(s, server)
+
+	p := &prober{server: server, cache: c, serviceName: serviceName, options: options}
+	go p.run(ctx, interval)
+	return server, nil
+}
+
+// prober checks the cache periodically and updates the serving status with hysteresis.
+type prober struct {
+	server      *grpchealth.Server
+	cache       cache.Cache
+	serviceName string
+	options     *Options
+
+	serving   bool
+	successes int
+	failures  int
+}
+
+// run checks the cache immediately and then every interval until ctx is done.
+func (p *prober) run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		p.probe(ctx)
+		select {
+		case <-ctx.Done():
+			p.server.SetServingStatus(p.serviceName, healthpb.HealthCheckResponse_NOT_SERVING)
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// probe runs one check and flips the status once the relevant threshold is reached.
+func (p *prober) probe(ctx context.Context) {
+	healthy := health.Check(ctx, p.cache, p.options.Timeout).Status == health.StatusOK
+	if ctx.Err() != nil {
+		return
+	}
+	if healthy {
+		p.successes++
+		p.failures = 0
+		if !p.serving && p.successes >= p.options.SuccessThreshold {
+			p.serving = true
+			p.server.SetServingStatus(p.serviceName, healthpb.HealthCheckResponse_SERVING)
+		}
+		return
+	}
+	p.failures++
+	p.successes = 0
+	if p.serving && p.failures >= p.options.FailureThreshold {
+		p.serving = false
+		p.server.SetServingStatus(p.serviceName, healthpb.HealthCheckResponse_NOT_SERVING)
+	}
+}
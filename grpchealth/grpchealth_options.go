@@ -0,0 +1,112 @@
+package grpchealth
+
+import (
+	"errors"
+	"time"
+
+	"github.com/zeroxsolutions/banshee/health"
+	"github.com/zeroxsolutions/strike/builderutil"
+)
+
+// DefaultFailureThreshold is the number of consecutive failed checks after which the service is
+// reported NOT_SERVING when no threshold is configured.
+const DefaultFailureThreshold = 3
+
+// Options holds the settings of the health prober.
+// This struct is populated through OptionsBuilder and consumed by Register.
+type Options struct {
+	Timeout          time.Duration // Timeout bounds each check.
+	FailureThreshold int           // FailureThreshold is the number of consecutive failures reporting NOT_SERVING.
+	SuccessThreshold int           // SuccessThreshold is the number of consecutive successes reporting SERVING again.
+}
+
+// OptionsBuilder provides a builder pattern for constructing Options.
+// This builder implements the builderutil.Lister interface to work with the functional options pattern.
+type OptionsBuilder struct {
+	Opts []func(*Options) error // Opts contains the list of option functions to be applied
+}
+
+// SetTimeout configures how long each check may take before counting as a failure.
+//
+// Parameters:
+//   - timeout: Maximum duration of a check, must be positive
+//
+// Returns:
+//   - *OptionsBuilder: The builder instance for method chaining
+func (b *OptionsBuilder) SetTimeout(timeout time.Duration) *OptionsBuilder {
+	b.Opts = append(b.Opts, func(o *Options) error {
+		if timeout <= 0 {
+			return errors.New("grpchealth: timeout must be positive")
+		}
+		o.Timeout = timeout
+		return nil
+	})
+	return b
+}
+
+// SetFailureThreshold configures how many consecutive checks must fail before a serving service
+// is reported NOT_SERVING, so that a single slow ping doesn't take the instance out of rotation.
+//
+// Parameters:
+//   - threshold: Number of consecutive failures, at least 1
+//
+// Returns:
+//   - *OptionsBuilder: The builder instance for method chaining
+func (b *OptionsBuilder) SetFailureThreshold(threshold int) *OptionsBuilder {
+	b.Opts = append(b.Opts, func(o *Options) error {
+		if threshold < 1 {
+			return errors.New("grpchealth: failure threshold must be at least 1")
+		}
+		o.FailureThreshold = threshold
+		return nil
+	})
+	return b
+}
+
+// SetSuccessThreshold configures how many consecutive checks must succeed before a service that
+// isn't serving is reported SERVING, including at startup.
+//
+// Parameters:
+//   - threshold: Number of consecutive successes, at least 1
+//
+// Returns:
+//   - *OptionsBuilder: The builder instance for method chaining
+func (b *OptionsBuilder) SetSuccessThreshold(threshold int) *OptionsBuilder {
+	b.Opts = append(b.Opts, func(o *Options) error {
+		if threshold < 1 {
+			return errors.New("grpchealth: success threshold must be at least 1")
+		}
+		o.SuccessThreshold = threshold
+		return nil
+	})
+	return b
+}
+
+// List returns the slice of option functions accumulated by the builder.
+// This method implements the builderutil.Lister interface.
+//
+// Returns:
+//   - []func(*Options) error: A slice of option functions that can be applied to configure Options
+func (b *OptionsBuilder) List() []func(*Options) error {
+	return b.Opts
+}
+
+// NewOptions creates and returns a new instance of OptionsBuilder.
+//
+// Returns:
+//   - *OptionsBuilder: A new instance of OptionsBuilder ready to be configured
+//
+// Example:
+//
+//	opts := grpchealth.NewOptions().SetFailureThreshold(5)
+func NewOptions() *OptionsBuilder {
+	return &OptionsBuilder{}
+}
+
+// defaultOptions returns the builder holding the prober defaults.
+func defaultOptions() builderutil.Lister[Options] {
+	return NewOptions().
+		SetTimeout(health.DefaultTimeout).
+		SetFailureThreshold(DefaultFailureThreshold).
+		SetSuccessThreshold(1)
+}
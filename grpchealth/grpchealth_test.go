@@ -0,0 +1,144 @@
+package grpchealth_test
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/zeroxsolutions/banshee/grpchealth"
+	bansheemock "github.com/zeroxsolutions/banshee/mock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// scriptedConnectivity feeds IsConnected results to the prober one check at a time. asked
+// receives a value whenever the prober starts a check, which also tells the test that the
+// previous result has been applied, since the prober checks sequentially.
+type scriptedConnectivity struct {
+	asked   chan struct{}
+	results chan bool
+}
+
+func (s *scriptedConnectivity) isConnected(ctx context.Context) bool {
+	select {
+	case s.asked <- struct{}{}:
+	case <-ctx.Done():
+		return false
+	}
+	select {
+	case result := <-s.results:
+		return result
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// TestRegister verifies the SERVING and NOT_SERVING transitions and the failure threshold through
+// a gRPC health client.
+func TestRegister(t *testing.T) {
+	script := &scriptedConnectivity{asked: make(chan struct{}), results: make(chan bool)}
+	mockCache := bansheemock.NewMockCache(t).(*bansheemock.MockCache)
+	mockCache.On("IsConnected", mock.Anything).Return(script.isConnected)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	listener := bufconn.Listen(1 << 20)
+	s := grpc.NewServer()
+	_, err := grpchealth.Register(ctx, s, mockCache, "orders", time.Millisecond,
+		grpchealth.NewOptions().SetTimeout(time.Minute).SetFailureThreshold(3))
+	if err != nil {
+		t.Fatal(err)
+	}
+	go func() {
+		_ = s.Serve(listener)
+	}()
+	defer s.Stop()
+
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	client := healthpb.NewHealthClient(conn)
+
+	status := func() healthpb.HealthCheckResponse_ServingStatus {
+		response, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{Service: "orders"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return response.Status
+	}
+	expect := func(step string, want healthpb.HealthCheckResponse_ServingStatus) {
+		t.Helper()
+		if got := status(); got != want {
+			t.Fatalf("%s: got %v, want %v", step, got, want)
+		}
+	}
+
+	<-script.asked
+	expect("before the first check", healthpb.HealthCheckResponse_NOT_SERVING)
+	script.results <- true
+
+	<-script.asked
+	expect("after a success", healthpb.HealthCheckResponse_SERVING)
+	script.results <- false
+
+	<-script.asked
+	expect("after 1 failure", healthpb.HealthCheckResponse_SERVING)
+	script.results <- false
+
+	<-script.asked
+	expect("after 2 failures", healthpb.HealthCheckResponse_SERVING)
+	script.results <- true
+
+	<-script.asked
+	expect("after a success resetting the failures", healthpb.HealthCheckResponse_SERVING)
+	for i := 0; i < 3; i++ {
+		script.results <- false
+		<-script.asked
+	}
+	expect("after 3 failures", healthpb.HealthCheckResponse_NOT_SERVING)
+	script.results <- true
+
+	<-script.asked
+	expect("after recovering", healthpb.HealthCheckResponse_SERVING)
+
+	cancel()
+	deadline := time.Now().Add(5 * time.Second)
+	for status() != healthpb.HealthCheckResponse_NOT_SERVING {
+		if time.Now().After(deadline) {
+			t.Fatal("the status should be NOT_SERVING once the context is cancelled")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// TestRegister_InvalidOptions verifies option validation.
+func TestRegister_InvalidOptions(t *testing.T) {
+	mockCache := bansheemock.NewMockCache(t)
+	_, err := grpchealth.Register(context.Background(), grpc.NewServer(), mockCache, "", time.Second,
+		grpchealth.NewOptions().SetFailureThreshold(0))
+	if err == nil {
+		t.Fatal("expected an error for a zero threshold")
+	}
+}
+
+// TestRegister_InvalidInterval verifies that a non-positive interval is rejected instead of
+// crashing the prober.
+func TestRegister_InvalidInterval(t *testing.T) {
+	mockCache := bansheemock.NewMockCache(t)
+	for _, interval := range []time.Duration{0, -time.Second} {
+		if _, err := grpchealth.Register(context.Background(), grpc.NewServer(), mockCache, "", interval); err == nil {
+			t.Fatal("expected an error for interval", interval)
+		}
+	}
+}
@@ -0,0 +1,131 @@
+// Package health exposes the connectivity of a cache as an HTTP readiness endpoint.
+//
+// Responses carry a JSON body such as:
+//
+//	{"status":"ok","latency_ms":0.42}
+//	{"status":"unavailable","error":"timeout"}
+//
+// where error is "disconnected" when the cache reported it isn't connected, and "timeout" when it
+// didn't answer within the configured timeout.
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/zeroxsolutions/barbatos/cache"
+	"github.com/zeroxsolutions/strike/builderutil"
+)
+
+const (
+	// StatusOK is the status reported when the cache is connected.
+	StatusOK = "ok"
+
+	// StatusUnavailable is the status reported when the cache is disconnected or too slow.
+	StatusUnavailable = "unavailable"
+
+	// ErrorDisconnected is the error class of a cache reporting it isn't connected.
+	ErrorDisconnected = "disconnected"
+
+	// ErrorTimeout is the error class of a cache that didn't answer within the timeout.
+	ErrorTimeout = "timeout"
+)
+
+// Response is the JSON body written by the handler.
+type Response struct {
+	Status    string  `json:"status"`               // Status is StatusOK or StatusUnavailable.
+	LatencyMs float64 `json:"latency_ms,omitempty"` // LatencyMs is the duration of a successful check in milliseconds.
+	Error     string  `json:"error,omitempty"`      // Error is the error class of a failed check.
+}
+
+// Handler returns an http.Handler reporting whether c is connected.
+//
+// Behavior:
+//   - Each request runs c.IsConnected with a context bounded by the timeout
+//   - A connected cache yields 200 with the check latency
+//   - A disconnected cache, or one not answering in time, yields 503 with the error class
+//   - With SetNonCritical(true), failures still yield 200 and only the body reports them
+//   - The response never waits longer than the timeout, even if the cache ignores the context;
+//     a hung check is left to finish in the background
+//
+// Parameters:
+//   - c: Cache to check
+//   - opts: Optional Options builders created with NewOptions
+//
+// Returns:
+//   - http.Handler: The health handler
+//   - error: An error if building the options fails
+//
+// Example:
+//
+//	ready, err := health.Handler(redisCache, health.NewOptions().SetTimeout(time.Second))
+//	http.Handle("/readyz", ready)
+func Handler(c cache.Cache, opts ...builderutil.Lister[Options]) (http.Handler, error) {
+	options, err := builderutil.Build(append([]builderutil.Lister[Options]{defaultOptions()}, opts...)...)
+	if err != nil {
+		return nil, err
+	}
+	return &handler{cache: c, options: options}, nil
+}
+
+// handler implements the endpoint returned by Handler.
+type handler struct {
+	cache   cache.Cache
+	options *Options
+}
+
+// ServeHTTP runs the check and writes the response.
+func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	response := Check(r.Context(), h.cache, h.options.Timeout)
+	code := http.StatusOK
+	if response.Status != StatusOK && !h.options.NonCritical {
+		code = http.StatusServiceUnavailable
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+// Check runs c.IsConnected bounded by timeout and reports the result. The check runs in its own
+// goroutine, so Check returns within timeout even if the cache ignores the context; a hung check
+// is left to finish in the background.
+//
+// Parameters:
+//   - ctx: Context for request cancellation
+//   - c: Cache to check
+//   - timeout: Maximum duration of the check
+//
+// Returns:
+//   - Response: StatusOK with the latency, or StatusUnavailable with the error class
+//
+// Example:
+//
+//	if health.Check(ctx, redisCache, time.Second).Status != health.StatusOK {
+//	    log.Println("cache unavailable")
+//	}
+func Check(ctx context.Context, c cache.Cache, timeout time.Duration) Response {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	start := time.Now()
+	connected := make(chan bool, 1)
+	go func() {
+		connected <- c.IsConnected(ctx)
+	}()
+
+	select {
+	case ok := <-connected:
+		if !ok {
+			if ctx.Err() != nil {
+				return Response{Status: StatusUnavailable, Error: ErrorTimeout}
+			}
+			return Response{Status: StatusUnavailable, Error: ErrorDisconnected}
+		}
+		return Response{Status: StatusOK, LatencyMs: float64(time.Since(start).Microseconds()) / 1000}
+	case <-ctx.Done():
+		return Response{Status: StatusUnavailable, Error: ErrorTimeout}
+	}
+}
@@ -0,0 +1,85 @@
+package health
+
+import (
+	"errors"
+	"time"
+
+	"github.com/zeroxsolutions/strike/builderutil"
+)
+
+// DefaultTimeout bounds the health check when no timeout is configured.
+const DefaultTimeout = 2 * time.Second
+
+// Options holds the settings of the health handler.
+// This struct is populated through OptionsBuilder and consumed by Handler.
+type Options struct {
+	Timeout     time.Duration // Timeout bounds each health check, and so each response.
+	NonCritical bool          // NonCritical makes the handler respond 200 even when the cache is down.
+}
+
+// OptionsBuilder provides a builder pattern for constructing Options.
+// This builder implements the builderutil.Lister interface to work with the functional options pattern.
+type OptionsBuilder struct {
+	Opts []func(*Options) error // Opts contains the list of option functions to be applied
+}
+
+// SetTimeout configures how long the handler waits for the cache. A check taking longer is
+// reported as a timeout, and the response is sent without waiting further.
+//
+// Parameters:
+//   - timeout: Maximum duration of a check, must be positive
+//
+// Returns:
+//   - *OptionsBuilder: The builder instance for method chaining
+func (b *OptionsBuilder) SetTimeout(timeout time.Duration) *OptionsBuilder {
+	b.Opts = append(b.Opts, func(o *Options) error {
+		if timeout <= 0 {
+			return errors.New("health: timeout must be positive")
+		}
+		o.Timeout = timeout
+		return nil
+	})
+	return b
+}
+
+// SetNonCritical marks the cache as non-critical: the handler always responds 200 and only reports
+// the cache status in the body, so an instance whose cache is down still receives traffic.
+//
+// Parameters:
+//   - nonCritical: true to never fail the check because of the cache
+//
+// Returns:
+//   - *OptionsBuilder: The builder instance for method chaining
+func (b *OptionsBuilder) SetNonCritical(nonCritical bool) *OptionsBuilder {
+	b.Opts = append(b.Opts, func(o *Options) error {
+		o.NonCritical = nonCritical
+		return nil
+	})
+	return b
+}
+
+// List returns the slice of option functions accumulated by the builder.
+// This method implements the builderutil.Lister interface.
+//
+// Returns:
+//   - []func(*Options) error: A slice of option functions that can be applied to configure Options
+func (b *OptionsBuilder) List() []func(*Options) error {
+	return b.Opts
+}
+
+// NewOptions creates and returns a new instance of OptionsBuilder.
+//
+// Returns:
+//   - *OptionsBuilder: A new instance of OptionsBuilder ready to be configured
+//
+// Example:
+//
+//	opts := health.NewOptions().SetTimeout(500 * time.Millisecond).SetNonCritical(true)
+func NewOptions() *OptionsBuilder {
+	return &OptionsBuilder{}
+}
+
+// defaultOptions returns the builder holding the handler defaults.
+func defaultOptions() builderutil.Lister[Options] {
+	return NewOptions().SetTimeout(DefaultTimeout)
+}
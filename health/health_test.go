@@ -0,0 +1,94 @@
+package health_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/zeroxsolutions/banshee/health"
+	"github.com/zeroxsolutions/banshee/memory"
+	"github.com/zeroxsolutions/barbatos/cache"
+)
+
+// scriptedCache answers IsConnected with a scripted function.
+type scriptedCache struct {
+	cache.Cache
+	isConnected func(ctx context.Context) bool
+}
+
+func (c scriptedCache) IsConnected(ctx context.Context) bool {
+	return c.isConnected(ctx)
+}
+
+// serve runs one request against a handler built for c and decodes the response.
+func serve(t *testing.T, c cache.Cache, opts *health.OptionsBuilder) (int, health.Response, time.Duration) {
+	handler, err := health.Handler(c, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	recorder := httptest.NewRecorder()
+	start := time.Now()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	elapsed := time.Since(start)
+
+	var response health.Response
+	if err := json.NewDecoder(recorder.Body).Decode(&response); err != nil {
+		t.Fatal(err)
+	}
+	if contentType := recorder.Header().Get("Content-Type"); contentType != "application/json" {
+		t.Fatal("unexpected content type:", contentType)
+	}
+	return recorder.Code, response, elapsed
+}
+
+// TestHandler verifies the status codes and bodies for healthy, failing and hanging caches.
+func TestHandler(t *testing.T) {
+	hang := make(chan struct{})
+	defer close(hang)
+
+	failing := scriptedCache{isConnected: func(ctx context.Context) bool { return false }}
+	hanging := scriptedCache{isConnected: func(ctx context.Context) bool {
+		<-hang
+		return true
+	}}
+
+	cases := []struct {
+		name      string
+		cache     cache.Cache
+		opts      *health.OptionsBuilder
+		code      int
+		status    string
+		errorKind string
+	}{
+		{"Healthy", memory.New(), health.NewOptions(), http.StatusOK, health.StatusOK, ""},
+		{"Disconnected", failing, health.NewOptions(), http.StatusServiceUnavailable, health.StatusUnavailable, health.ErrorDisconnected},
+		{"Hanging", hanging, health.NewOptions().SetTimeout(50 * time.Millisecond), http.StatusServiceUnavailable, health.StatusUnavailable, health.ErrorTimeout},
+		{"NonCritical", failing, health.NewOptions().SetNonCritical(true), http.StatusOK, health.StatusUnavailable, health.ErrorDisconnected},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			code, response, elapsed := serve(t, c.cache, c.opts)
+			if code != c.code {
+				t.Fatal("unexpected status code:", code)
+			}
+			if response.Status != c.status || response.Error != c.errorKind {
+				t.Fatal("unexpected response:", response)
+			}
+			if elapsed > time.Second {
+				t.Fatal("the handler held the request for", elapsed)
+			}
+		})
+	}
+}
+
+// TestHandler_InvalidTimeout verifies option validation.
+func TestHandler_InvalidTimeout(t *testing.T) {
+	if _, err := health.Handler(memory.New(), health.NewOptions().SetTimeout(0)); err == nil {
+		t.Fatal("expected an error for a zero timeout")
+	}
+}
@@ -0,0 +1,163 @@
+// Package fakecache provides a minimal map-backed cache.Cache for the tests of the helper packages.
+// Expirations are honored lazily on access and only the '*' wildcard is supported in patterns.
+package fakecache
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/zeroxsolutions/banshee"
+	"github.com/zeroxsolutions/banshee/internal/valueutil"
+	"github.com/zeroxsolutions/barbatos/cache"
+)
+
+// Cache is a map-backed cache.Cache that is safe for concurrent use.
+type Cache struct {
+	mu        sync.Mutex
+	values    map[string]string
+	deadlines map[string]time.Time
+	expires   int
+}
+
+var _ cache.Cache = (*Cache)(nil)
+var _ banshee.Expirer = (*Cache)(nil)
+
+// New creates an empty Cache.
+func New() *Cache {
+	return &Cache{values: map[string]string{}, deadlines: map[string]time.Time{}}
+}
+
+// IsConnected always reports true.
+func (c *Cache) IsConnected(ctx context.Context) bool {
+	return true
+}
+
+// Keys returns the keys matching pattern, where '*' matches any run of characters.
+func (c *Cache) Keys(ctx context.Context, pattern string) ([]string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	keys := []string{}
+	for key := range c.values {
+		if c.live(key) && match(pattern, key) {
+			keys = append(keys, key)
+		}
+	}
+	return keys, nil
+}
+
+// Get returns the value stored under key or cache.ErrCacheNil.
+func (c *Cache) Get(ctx context.Context, key string) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	value, ok := c.values[key]
+	if !ok || !c.live(key) {
+		return "", cache.ErrCacheNil
+	}
+	return value, nil
+}
+
+// Set stores value under key.
+func (c *Cache) Set(ctx context.Context, key string, value interface{}) error {
+	return c.SetWithExpiration(ctx, key, value, 0)
+}
+
+// SetWithExpiration stores value under key, expiring it after expiration unless it is 0.
+func (c *Cache) SetWithExpiration(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
+	raw, err := valueutil.Stringify(value)
+	if err != nil {
+		return err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.values[key] = raw
+	delete(c.deadlines, key)
+	if expiration > 0 {
+		c.deadlines[key] = time.Now().Add(expiration)
+	}
+	return nil
+}
+
+// Expire sets the expiration of key and reports whether the key exists.
+func (c *Cache) Expire(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	return c.ExpireAt(ctx, key, time.Now().Add(ttl))
+}
+
+// ExpireAt sets the deadline of key and reports whether the key exists. Expire and ExpireAt both
+// count as Expire calls.
+func (c *Cache) ExpireAt(ctx context.Context, key string, at time.Time) (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.expires++
+	if !at.After(time.Now()) {
+		return false, errors.New("fakecache: expiration must be in the future")
+	}
+	if _, ok := c.values[key]; !ok || !c.live(key) {
+		return false, nil
+	}
+	c.deadlines[key] = at
+	return true, nil
+}
+
+// ExpireCalls returns how many times Expire has been called.
+func (c *Cache) ExpireCalls() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.expires
+}
+
+// Del deletes keys.
+func (c *Cache) Del(ctx context.Context, keys ...string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, key := range keys {
+		delete(c.values, key)
+		delete(c.deadlines, key)
+	}
+	return nil
+}
+
+// DelWithPattern deletes the keys matching pattern.
+func (c *Cache) DelWithPattern(ctx context.Context, pattern string) error {
+	keys, _ := c.Keys(ctx, pattern)
+	return c.Del(ctx, keys...)
+}
+
+// Close does nothing.
+func (c *Cache) Close() error {
+	return nil
+}
+
+// live deletes key if it has expired and reports whether it is still present.
+// The caller must hold c.mu.
+func (c *Cache) live(key string) bool {
+	deadline, ok := c.deadlines[key]
+	if !ok || time.Now().Before(deadline) {
+		return true
+	}
+	delete(c.values, key)
+	delete(c.deadlines, key)
+	return false
+}
+
+// match reports whether key matches a pattern in which '*' matches any run of characters.
+func match(pattern, key string) bool {
+	parts := strings.Split(pattern, "*")
+	if len(parts) == 1 {
+		return pattern == key
+	}
+	if !strings.HasPrefix(key, parts[0]) {
+		return false
+	}
+	key = key[len(parts[0]):]
+	for _, part := range parts[1 : len(parts)-1] {
+		idx := strings.Index(key, part)
+		if idx < 0 {
+			return false
+		}
+		key = key[idx+len(part):]
+	}
+	return strings.HasSuffix(key, parts[len(parts)-1])
+}
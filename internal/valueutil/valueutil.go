@@ -0,0 +1,123 @@
+// Package valueutil holds helpers shared by the banshee helper packages for handling the
+// interface{} values accepted by cache.Cache.
+package valueutil
+
+import (
+	"encoding"
+	"fmt"
+	"net"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// Stringify converts a value to the string a cache backend would store for it, mirroring how the
+// go-redis client encodes command arguments, so that every cache stores the same string for a
+// value as Redis does:
+//   - strings and []byte are stored as-is, and nil as ""
+//   - integers, and pointers to them, are formatted in base 10; bools as "1" or "0"
+//   - floats are formatted without exponent, e.g. 1e21 as "1000000000000000000000"
+//   - time.Time is formatted as RFC 3339 with nanoseconds, and time.Duration as nanoseconds
+//   - encoding.BinaryMarshaler implementations are marshaled, and net.IP stored as raw bytes
+//
+// Other types fail with an error, as they do with the Redis client.
+func Stringify(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case *string:
+		return *v, nil
+	case []byte:
+		return string(v), nil
+	case int:
+		return strconv.FormatInt(int64(v), 10), nil
+	case *int:
+		return strconv.FormatInt(int64(*v), 10), nil
+	case int8:
+		return strconv.FormatInt(int64(v), 10), nil
+	case *int8:
+		return strconv.FormatInt(int64(*v), 10), nil
+	case int16:
+		return strconv.FormatInt(int64(v), 10), nil
+	case *int16:
+		return strconv.FormatInt(int64(*v), 10), nil
+	case int32:
+		return strconv.FormatInt(int64(v), 10), nil
+	case *int32:
+		return strconv.FormatInt(int64(*v), 10), nil
+	case int64:
+		return strconv.FormatInt(v, 10), nil
+	case *int64:
+		return strconv.FormatInt(*v, 10), nil
+	case uint:
+		return strconv.FormatUint(uint64(v), 10), nil
+	case *uint:
+		return strconv.FormatUint(uint64(*v), 10), nil
+	case uint8:
+		return strconv.FormatUint(uint64(v), 10), nil
+	case *uint8:
+		return strconv.FormatUint(uint64(*v), 10), nil
+	case uint16:
+		return strconv.FormatUint(uint64(v), 10), nil
+	case *uint16:
+		return strconv.FormatUint(uint64(*v), 10), nil
+	case uint32:
+		return strconv.FormatUint(uint64(v), 10), nil
+	case *uint32:
+		return strconv.FormatUint(uint64(*v), 10), nil
+	case uint64:
+		return strconv.FormatUint(v, 10), nil
+	case *uint64:
+		return strconv.FormatUint(*v, 10), nil
+	case float32:
+		return strconv.FormatFloat(float64(v), 'f', -1, 64), nil
+	case *float32:
+		return strconv.FormatFloat(float64(*v), 'f', -1, 64), nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case *float64:
+		return strconv.FormatFloat(*v, 'f', -1, 64), nil
+	case bool:
+		return formatBool(v), nil
+	case *bool:
+		return formatBool(*v), nil
+	case time.Time:
+		return v.Format(time.RFC3339Nano), nil
+	case time.Duration:
+		return strconv.FormatInt(v.Nanoseconds(), 10), nil
+	case encoding.BinaryMarshaler:
+		b, err := v.MarshalBinary()
+		if err != nil {
+			return "", err
+		}
+		return string(b), nil
+	case net.IP:
+		return string(v), nil
+	default:
+		return "", fmt.Errorf("can't marshal %T (implement encoding.BinaryMarshaler)", v)
+	}
+}
+
+// formatBool formats a bool the way Redis clients send it, as an integer.
+func formatBool(v bool) string {
+	if v {
+		return "1"
+	}
+	return "0"
+}
+
+// IsNil reports whether value is nil or a typed nil: a nil pointer, slice, map, channel, function,
+// or interface wrapped in value.
+func IsNil(value interface{}) bool {
+	if value == nil {
+		return true
+	}
+	switch v := reflect.ValueOf(value); v.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Map, reflect.Chan, reflect.Func, reflect.Interface:
+		return v.IsNil()
+	default:
+		return false
+	}
+}
@@ -0,0 +1,104 @@
+package banshee
+
+import (
+	"context"
+	"time"
+
+	"github.com/zeroxsolutions/barbatos/cache"
+)
+
+// KeyTransformCache is a cache.Cache decorator rewriting every key with a pure function before it
+// reaches the underlying cache, so the keys used at call sites and the keys stored can differ.
+type KeyTransformCache struct {
+	cache     cache.Cache
+	transform func(key string) string
+	reverse   func(key string) string
+}
+
+var _ cache.Cache = (*KeyTransformCache)(nil)
+
+// NewKeyTransformCache wraps inner so that every key goes through transform, for rewrites beyond
+// a fixed prefix such as lowercasing, adding an environment segment, or hashing long keys.
+//
+// Behavior:
+//   - Get, Set, SetWithExpiration and Del transform each key
+//   - Keys and DelWithPattern transform the pattern too, so transform must keep glob syntax
+//     meaningful: lowercasing or prepending a segment does, hashing doesn't
+//   - Keys maps every stored key back through reverse; with a nil reverse the stored keys are
+//     returned as they are
+//
+// transform must be deterministic: the same call-site key has to map to the same stored key on
+// every call and in every process, or written values can't be read back. reverse should be its
+// inverse; a transform that isn't invertible, such as lowercasing or hashing, can't map Keys
+// results back to the call-site keys they were written with.
+//
+// Parameters:
+//   - inner: Underlying cache
+//   - transform: Function mapping call-site keys to stored keys
+//   - reverse: Function mapping stored keys back to call-site keys, or nil
+//
+// Returns:
+//   - *KeyTransformCache: The key-transforming cache
+//
+// Example:
+//
+//	envCache := banshee.NewKeyTransformCache(redisCache,
+//	    func(key string) string { return "prod:" + key },
+//	    func(key string) string { return strings.TrimPrefix(key, "prod:") })
+func NewKeyTransformCache(inner cache.Cache, transform func(key string) string, reverse func(key string) string) *KeyTransformCache {
+	return &KeyTransformCache{cache: inner, transform: transform, reverse: reverse}
+}
+
+// IsConnected reports the connection status of the underlying cache.
+func (k *KeyTransformCache) IsConnected(ctx context.Context) bool {
+	return k.cache.IsConnected(ctx)
+}
+
+// Keys returns the keys matching the transformed pattern, mapped back through reverse.
+func (k *KeyTransformCache) Keys(ctx context.Context, pattern string) ([]string, error) {
+	keys, err := k.cache.Keys(ctx, k.transform(pattern))
+	if err != nil {
+		return nil, err
+	}
+	if k.reverse == nil {
+		return keys, nil
+	}
+	for i, key := range keys {
+		keys[i] = k.reverse(key)
+	}
+	return keys, nil
+}
+
+// Get retrieves the value stored under the transformed key.
+func (k *KeyTransformCache) Get(ctx context.Context, key string) (string, error) {
+	return k.cache.Get(ctx, k.transform(key))
+}
+
+// Set stores value under the transformed key without expiration.
+func (k *KeyTransformCache) Set(ctx context.Context, key string, value interface{}) error {
+	return k.cache.Set(ctx, k.transform(key), value)
+}
+
+// SetWithExpiration stores value under the transformed key with the given expiration.
+func (k *KeyTransformCache) SetWithExpiration(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
+	return k.cache.SetWithExpiration(ctx, k.transform(key), value, expiration)
+}
+
+// Del deletes the transformed keys.
+func (k *KeyTransformCache) Del(ctx context.Context, keys ...string) error {
+	transformed := make([]string, len(keys))
+	for i, key := range keys {
+		transformed[i] = k.transform(key)
+	}
+	return k.cache.Del(ctx, transformed...)
+}
+
+// DelWithPattern deletes the keys matching the transformed pattern.
+func (k *KeyTransformCache) DelWithPattern(ctx context.Context, pattern string) error {
+	return k.cache.DelWithPattern(ctx, k.transform(pattern))
+}
+
+// Close closes the underlying cache.
+func (k *KeyTransformCache) Close() error {
+	return k.cache.Close()
+}
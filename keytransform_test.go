@@ -0,0 +1,83 @@
+package banshee_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/zeroxsolutions/banshee"
+	"github.com/zeroxsolutions/banshee/memory"
+	"github.com/zeroxsolutions/barbatos/cache"
+)
+
+// TestKeyTransformCache_Lowercase verifies a lowercasing transform end to end: keys differing
+// only in case address one stored entry.
+func TestKeyTransformCache_Lowercase(t *testing.T) {
+	ctx := context.Background()
+	backend := memory.New()
+	lower := banshee.NewKeyTransformCache(backend, strings.ToLower, nil)
+
+	if err := lower.Set(ctx, "User:Alice", "1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := lower.SetWithExpiration(ctx, "USER:Bob", "2", 0); err != nil {
+		t.Fatal(err)
+	}
+
+	if value, err := backend.Get(ctx, "user:alice"); err != nil || value != "1" {
+		t.Fatalf("stored key = %q, %v", value, err)
+	}
+	if value, err := lower.Get(ctx, "user:ALICE"); err != nil || value != "1" {
+		t.Fatalf("Get = %q, %v", value, err)
+	}
+
+	keys, err := lower.Keys(ctx, "USER:*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sort.Strings(keys)
+	if !reflect.DeepEqual(keys, []string{"user:alice", "user:bob"}) {
+		t.Fatal("unexpected keys:", keys)
+	}
+
+	if err := lower.Del(ctx, "User:ALICE"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := backend.Get(ctx, "user:alice"); !errors.Is(err, cache.ErrCacheNil) {
+		t.Fatal("expected cache.ErrCacheNil, got", err)
+	}
+
+	if err := lower.DelWithPattern(ctx, "User:*"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := backend.Get(ctx, "user:bob"); !errors.Is(err, cache.ErrCacheNil) {
+		t.Fatal("expected cache.ErrCacheNil, got", err)
+	}
+}
+
+// TestKeyTransformCache_Reverse verifies that Keys maps stored keys back through reverse.
+func TestKeyTransformCache_Reverse(t *testing.T) {
+	ctx := context.Background()
+	backend := memory.New()
+	env := banshee.NewKeyTransformCache(backend,
+		func(key string) string { return "prod:" + key },
+		func(key string) string { return strings.TrimPrefix(key, "prod:") })
+
+	if err := backend.Set(ctx, "staging:order:1", "x"); err != nil {
+		t.Fatal(err)
+	}
+	if err := env.Set(ctx, "order:1", "y"); err != nil {
+		t.Fatal(err)
+	}
+
+	keys, err := env.Keys(ctx, "order:*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(keys, []string{"order:1"}) {
+		t.Fatal("unexpected keys:", keys)
+	}
+}
@@ -0,0 +1,16 @@
+package memory_test
+
+import (
+	"testing"
+
+	"github.com/zeroxsolutions/banshee/cachetest"
+	"github.com/zeroxsolutions/banshee/memory"
+	"github.com/zeroxsolutions/barbatos/cache"
+)
+
+// TestConformance runs the shared cache.Cache contract against the in-memory cache.
+func TestConformance(t *testing.T) {
+	cachetest.RunConformance(t, func() cache.Cache {
+		return memory.New()
+	})
+}
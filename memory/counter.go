@@ -0,0 +1,73 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"math"
+	"strconv"
+	"time"
+)
+
+// IncrByCeil increments the counter stored under key by delta unless the result would exceed
+// ceil. A missing key counts as 0. It returns the value after the call (unchanged when the
+// increment was refused) and whether the increment was applied. The key's expiration is kept.
+func (c *Cache) IncrByCeil(ctx context.Context, key string, delta, ceil int64) (int64, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return 0, false, ErrClosed
+	}
+	it, ok := c.lookup(key, c.clock.Now())
+	if ok && it.zset != nil {
+		return 0, false, ErrWrongType
+	}
+	var current int64
+	if ok {
+		var err error
+		if current, err = strconv.ParseInt(it.value, 10, 64); err != nil {
+			return 0, false, ErrNotInteger
+		}
+	}
+	next := current + delta
+	if (delta > 0 && next < current) || (delta < 0 && next > current) {
+		return 0, false, ErrNotInteger
+	}
+	if next > ceil {
+		return current, false, nil
+	}
+	it.value = strconv.FormatInt(next, 10)
+	c.items[key] = it
+	return next, true, nil
+}
+
+// IncrFirstSeen increments the counter stored under key by 1, giving it an expiration of window
+// when the increment creates it. It returns the new count and whether it is 1.
+func (c *Cache) IncrFirstSeen(ctx context.Context, key string, window time.Duration) (int64, bool, error) {
+	if window <= 0 {
+		return 0, false, errors.New("memory: first-seen window must be positive")
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return 0, false, ErrClosed
+	}
+	now := c.clock.Now()
+	it, ok := c.lookup(key, now)
+	if ok && it.zset != nil {
+		return 0, false, ErrWrongType
+	}
+	var current int64
+	if ok {
+		var err error
+		if current, err = strconv.ParseInt(it.value, 10, 64); err != nil || current == math.MaxInt64 {
+			return 0, false, ErrNotInteger
+		}
+	}
+	count := current + 1
+	it.value = strconv.FormatInt(count, 10)
+	if count == 1 {
+		it.expiresAt = now.Add(window)
+	}
+	c.items[key] = it
+	return count, count == 1, nil
+}
@@ -0,0 +1,84 @@
+package memory_test
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/zeroxsolutions/banshee/memory"
+)
+
+// TestCache_IncrByCeil verifies increments up to the ceiling, TTL preservation, and errors on
+// values that aren't integers.
+func TestCache_IncrByCeil(t *testing.T) {
+	ctx := context.Background()
+	clock := &manualClock{now: time.Now()}
+	c := memory.NewWithClock(clock)
+
+	if v, applied, err := c.IncrByCeil(ctx, "seats", 2, 3); err != nil || !applied || v != 2 {
+		t.Fatal(v, applied, err)
+	}
+	if v, applied, err := c.IncrByCeil(ctx, "seats", 2, 3); err != nil || applied || v != 2 {
+		t.Fatal(v, applied, err)
+	}
+	if v, applied, err := c.IncrByCeil(ctx, "seats", 1, 3); err != nil || !applied || v != 3 {
+		t.Fatal(v, applied, err)
+	}
+
+	if err := c.SetWithExpiration(ctx, "ttl", 5, time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	if v, _, err := c.IncrByCeil(ctx, "ttl", -1, math.MaxInt64); err != nil || v != 4 {
+		t.Fatal(v, err)
+	}
+	clock.now = clock.now.Add(time.Minute)
+	if _, err := c.Get(ctx, "ttl"); err == nil {
+		t.Fatal("increment should keep the expiration")
+	}
+
+	if err := c.Set(ctx, "text", "abc"); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := c.IncrByCeil(ctx, "text", 1, math.MaxInt64); !errors.Is(err, memory.ErrNotInteger) {
+		t.Fatal(err)
+	}
+
+	if err := c.Set(ctx, "max", int64(math.MaxInt64)); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := c.IncrByCeil(ctx, "max", 1, math.MaxInt64); !errors.Is(err, memory.ErrNotInteger) {
+		t.Fatal(err)
+	}
+}
+
+// TestCache_IncrFirstSeen verifies first-occurrence detection and that the window starts at the
+// first occurrence.
+func TestCache_IncrFirstSeen(t *testing.T) {
+	ctx := context.Background()
+	clock := &manualClock{now: time.Now()}
+	c := memory.NewWithClock(clock)
+
+	if count, first, err := c.IncrFirstSeen(ctx, "fp", time.Hour); err != nil || count != 1 || !first {
+		t.Fatal(count, first, err)
+	}
+	clock.now = clock.now.Add(30 * time.Minute)
+	if count, first, err := c.IncrFirstSeen(ctx, "fp", time.Hour); err != nil || count != 2 || first {
+		t.Fatal(count, first, err)
+	}
+	clock.now = clock.now.Add(30 * time.Minute)
+	if count, first, err := c.IncrFirstSeen(ctx, "fp", time.Hour); err != nil || count != 1 || !first {
+		t.Fatal("the window should not be extended by later occurrences:", count, first, err)
+	}
+
+	if err := c.Set(ctx, "text", "abc"); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := c.IncrFirstSeen(ctx, "text", time.Hour); !errors.Is(err, memory.ErrNotInteger) {
+		t.Fatal(err)
+	}
+	if _, _, err := c.IncrFirstSeen(ctx, "fp", 0); err == nil {
+		t.Fatal("expected an error for a zero window")
+	}
+}
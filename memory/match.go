@@ -0,0 +1,103 @@
+package memory
+
+// Match reports whether key matches pattern using the glob syntax of the Redis KEYS and SCAN
+// commands:
+//   - '*' matches any sequence of characters, including none
+//   - '?' matches exactly one character
+//   - '[abc]' matches one of the listed characters, '[a-z]' a range, and '[^a]' negates the set
+//   - '\' escapes the following character
+//
+// Matching works on bytes, like Redis does.
+//
+// Parameters:
+//   - pattern: Glob pattern
+//   - key: Key to test
+//
+// Returns:
+//   - bool: true if key matches pattern
+func Match(pattern, key string) bool {
+	for len(pattern) > 0 {
+		switch pattern[0] {
+		case '*':
+			for len(pattern) > 1 && pattern[1] == '*' {
+				pattern = pattern[1:]
+			}
+			if len(pattern) == 1 {
+				return true
+			}
+			for i := 0; i <= len(key); i++ {
+				if Match(pattern[1:], key[i:]) {
+					return true
+				}
+			}
+			return false
+		case '?':
+			if len(key) == 0 {
+				return false
+			}
+			key = key[1:]
+			pattern = pattern[1:]
+		case '[':
+			if len(key) == 0 {
+				return false
+			}
+			matched, rest := matchClass(pattern[1:], key[0])
+			if !matched {
+				return false
+			}
+			key = key[1:]
+			pattern = rest
+		case '\\':
+			if len(pattern) >= 2 {
+				pattern = pattern[1:]
+			}
+			fallthrough
+		default:
+			if len(key) == 0 || key[0] != pattern[0] {
+				return false
+			}
+			key = key[1:]
+			pattern = pattern[1:]
+		}
+	}
+	return len(key) == 0
+}
+
+// matchClass matches c against the character class at the start of pattern (just after '[')
+// and returns whether it matched and the pattern following the closing ']'. An unterminated
+// class extends to the end of the pattern, as in Redis.
+func matchClass(pattern string, c byte) (bool, string) {
+	negate := false
+	if len(pattern) > 0 && pattern[0] == '^' {
+		negate = true
+		pattern = pattern[1:]
+	}
+	matched := false
+	for len(pattern) > 0 && pattern[0] != ']' {
+		switch {
+		case pattern[0] == '\\' && len(pattern) >= 2:
+			if pattern[1] == c {
+				matched = true
+			}
+			pattern = pattern[2:]
+		case len(pattern) >= 3 && pattern[1] == '-':
+			lo, hi := pattern[0], pattern[2]
+			if lo > hi {
+				lo, hi = hi, lo
+			}
+			if c >= lo && c <= hi {
+				matched = true
+			}
+			pattern = pattern[3:]
+		default:
+			if pattern[0] == c {
+				matched = true
+			}
+			pattern = pattern[1:]
+		}
+	}
+	if len(pattern) > 0 {
+		pattern = pattern[1:]
+	}
+	return matched != negate, pattern
+}
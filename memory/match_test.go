@@ -0,0 +1,42 @@
+package memory_test
+
+import (
+	"testing"
+
+	"github.com/zeroxsolutions/banshee/memory"
+)
+
+// TestMatch checks the Redis glob syntax supported by Match.
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		pattern string
+		key     string
+		want    bool
+	}{
+		{"*", "", true},
+		{"*", "anything", true},
+		{"user:*", "user:42", true},
+		{"user:*", "order:42", false},
+		{"*:42", "user:42", true},
+		{"a*b*c", "axxbyyc", true},
+		{"a*b*c", "axxbyy", false},
+		{"h?llo", "hello", true},
+		{"h?llo", "hllo", false},
+		{"h[ae]llo", "hallo", true},
+		{"h[ae]llo", "hillo", false},
+		{"h[^e]llo", "hallo", true},
+		{"h[^e]llo", "hello", false},
+		{"h[a-c]llo", "hbllo", true},
+		{"h[a-c]llo", "hdllo", false},
+		{`h\*llo`, "h*llo", true},
+		{`h\*llo`, "hello", false},
+		{"exact", "exact", true},
+		{"exact", "exactly", false},
+	}
+
+	for _, tt := range tests {
+		if got := memory.Match(tt.pattern, tt.key); got != tt.want {
+			t.Errorf("Match(%q, %q) = %v, want %v", tt.pattern, tt.key, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,305 @@
+// Package memory provides an in-process cache.Cache backed by a Go map.
+//
+// It is intended for tests, local development, and small single-process deployments: values
+// live in the memory of the current process, are lost on restart, and are not shared between
+// instances. Expired keys are removed lazily when they are accessed. Patterns follow the Redis
+// glob syntax (*, ?, [abc], [a-z], [^a], and \ escapes), so code written against RedisCache
+// behaves the same way.
+package memory
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/zeroxsolutions/banshee"
+	"github.com/zeroxsolutions/banshee/internal/valueutil"
+	"github.com/zeroxsolutions/barbatos/cache"
+)
+
+// ErrClosed is returned by every operation on a Cache after Close.
+var ErrClosed = errors.New("memory: cache closed")
+
+// ErrWrongType is returned when an operation is applied to a key holding another kind of value,
+// such as Get on a sorted set, mirroring the Redis WRONGTYPE error.
+var ErrWrongType = errors.New("memory: operation against a key holding the wrong kind of value")
+
+// ErrInvalidExpiration is returned by Expire and ExpireAt for a TTL that isn't positive or a time
+// that isn't in the future, mirroring RedisCache.
+var ErrInvalidExpiration = errors.New("memory: expiration must be in the future")
+
+// ErrNotInteger is returned by counter operations on a value that isn't an integer, or when the
+// result would overflow an int64, mirroring the Redis INCRBY error.
+var ErrNotInteger = errors.New("memory: value is not an integer or out of range")
+
+// item is a stored value with its optional expiration time.
+type item struct {
+	value     string
+	zset      map[string]float64 // zset holds the members of a sorted set; nil for string values.
+	expiresAt time.Time          // expiresAt is zero for values without expiration.
+}
+
+// expired reports whether the item has expired at now.
+func (i item) expired(now time.Time) bool {
+	return !i.expiresAt.IsZero() && !now.Before(i.expiresAt)
+}
+
+// Cache is a map-backed cache.Cache that is safe for concurrent use.
+type Cache struct {
+	clock  banshee.Clock
+	mu     sync.Mutex
+	items  map[string]item
+	closed bool
+}
+
+var _ cache.Cache = (*Cache)(nil)
+var _ banshee.CounterCache = (*Cache)(nil)
+var _ banshee.Expirer = (*Cache)(nil)
+var _ banshee.SortedSetCache = (*Cache)(nil)
+var _ banshee.SetNXCache = (*Cache)(nil)
+var _ banshee.SetXXCache = (*Cache)(nil)
+
+// New creates an empty in-memory Cache.
+//
+// Returns:
+//   - *Cache: A ready-to-use cache
+//
+// Example:
+//
+//	c := memory.New()
+//	err := c.SetWithExpiration(ctx, "greeting", "hello", time.Minute)
+func New() *Cache {
+	return NewWithClock(banshee.SystemClock)
+}
+
+// NewWithClock creates an empty in-memory Cache measuring expirations with clock, so tests can
+// expire keys by advancing a fake clock instead of sleeping.
+//
+// Parameters:
+//   - clock: Clock telling the current time
+//
+// Returns:
+//   - *Cache: A ready-to-use cache
+//
+// Example:
+//
+//	clock := mock.NewMockClock(time.Now())
+//	c := memory.NewWithClock(clock)
+//	_ = c.SetWithExpiration(ctx, "greeting", "hello", time.Minute)
+//	clock.Advance(time.Minute) // "greeting" is now expired
+func NewWithClock(clock banshee.Clock) *Cache {
+	return &Cache{clock: clock, items: map[string]item{}}
+}
+
+// IsConnected reports true until the cache is closed.
+func (c *Cache) IsConnected(ctx context.Context) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return !c.closed
+}
+
+// Keys returns the keys matching pattern, in no particular order.
+func (c *Cache) Keys(ctx context.Context, pattern string) ([]string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return nil, ErrClosed
+	}
+	now := c.clock.Now()
+	keys := []string{}
+	for key, it := range c.items {
+		if it.expired(now) {
+			delete(c.items, key)
+			continue
+		}
+		if Match(pattern, key) {
+			keys = append(keys, key)
+		}
+	}
+	return keys, nil
+}
+
+// Get returns the value stored under key, or cache.ErrCacheNil if it is missing or expired.
+func (c *Cache) Get(ctx context.Context, key string) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return "", ErrClosed
+	}
+	it, ok := c.lookup(key, c.clock.Now())
+	if !ok {
+		return "", cache.ErrCacheNil
+	}
+	if it.zset != nil {
+		return "", ErrWrongType
+	}
+	return it.value, nil
+}
+
+// Set stores value under key without expiration.
+func (c *Cache) Set(ctx context.Context, key string, value interface{}) error {
+	return c.SetWithExpiration(ctx, key, value, 0)
+}
+
+// SetWithExpiration stores value under key. A positive expiration makes the key expire after
+// that duration; 0 stores it without expiration. Values are converted to strings the same way
+// RedisCache does.
+func (c *Cache) SetWithExpiration(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
+	raw, err := valueutil.Stringify(value)
+	if err != nil {
+		return err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return ErrClosed
+	}
+	it := item{value: raw}
+	if expiration > 0 {
+		it.expiresAt = c.clock.Now().Add(expiration)
+	}
+	c.items[key] = it
+	return nil
+}
+
+// SetNX stores value under key only if key doesn't exist, and reports whether it was stored.
+func (c *Cache) SetNX(ctx context.Context, key string, value interface{}, ttl time.Duration) (bool, error) {
+	stored, err := c.SetNXMany(ctx, []string{key}, value, ttl)
+	if err != nil {
+		return false, err
+	}
+	return stored[0], nil
+}
+
+// SetNXMany runs SetNX for every key and reports per key whether the value was stored.
+// The whole batch is applied atomically.
+func (c *Cache) SetNXMany(ctx context.Context, keys []string, value interface{}, ttl time.Duration) ([]bool, error) {
+	raw, err := valueutil.Stringify(value)
+	if err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return nil, ErrClosed
+	}
+	now := c.clock.Now()
+	stored := make([]bool, len(keys))
+	for i, key := range keys {
+		if _, ok := c.lookup(key, now); ok {
+			continue
+		}
+		it := item{value: raw}
+		if ttl > 0 {
+			it.expiresAt = now.Add(ttl)
+		}
+		c.items[key] = it
+		stored[i] = true
+	}
+	return stored, nil
+}
+
+// SetXX stores value under key only if key exists, and reports whether it was stored.
+func (c *Cache) SetXX(ctx context.Context, key string, value interface{}, ttl time.Duration) (bool, error) {
+	raw, err := valueutil.Stringify(value)
+	if err != nil {
+		return false, err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return false, ErrClosed
+	}
+	now := c.clock.Now()
+	if _, ok := c.lookup(key, now); !ok {
+		return false, nil
+	}
+	it := item{value: raw}
+	if ttl > 0 {
+		it.expiresAt = now.Add(ttl)
+	}
+	c.items[key] = it
+	return true, nil
+}
+
+// Expire sets the time-to-live of key and reports whether the key exists. A ttl that isn't
+// positive fails with ErrInvalidExpiration.
+func (c *Cache) Expire(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	if ttl <= 0 {
+		return false, ErrInvalidExpiration
+	}
+	return c.ExpireAt(ctx, key, c.clock.Now().Add(ttl))
+}
+
+// ExpireAt makes key expire at the given time and reports whether the key exists. A time that
+// isn't in the future fails with ErrInvalidExpiration.
+func (c *Cache) ExpireAt(ctx context.Context, key string, at time.Time) (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return false, ErrClosed
+	}
+	now := c.clock.Now()
+	if !at.After(now) {
+		return false, ErrInvalidExpiration
+	}
+	it, ok := c.lookup(key, now)
+	if !ok {
+		return false, nil
+	}
+	it.expiresAt = at
+	c.items[key] = it
+	return true, nil
+}
+
+// Del deletes keys; missing keys are ignored.
+func (c *Cache) Del(ctx context.Context, keys ...string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return ErrClosed
+	}
+	for _, key := range keys {
+		delete(c.items, key)
+	}
+	return nil
+}
+
+// DelWithPattern deletes the keys matching pattern.
+func (c *Cache) DelWithPattern(ctx context.Context, pattern string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return ErrClosed
+	}
+	for key := range c.items {
+		if Match(pattern, key) {
+			delete(c.items, key)
+		}
+	}
+	return nil
+}
+
+// Close discards every value; later operations return ErrClosed.
+func (c *Cache) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.closed = true
+	c.items = nil
+	return nil
+}
+
+// lookup returns the live item stored under key, deleting it if it has expired.
+// The caller must hold c.mu.
+func (c *Cache) lookup(key string, now time.Time) (item, bool) {
+	it, ok := c.items[key]
+	if !ok {
+		return item{}, false
+	}
+	if it.expired(now) {
+		delete(c.items, key)
+		return item{}, false
+	}
+	return it, true
+}
@@ -0,0 +1,210 @@
+package memory_test
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/zeroxsolutions/banshee/memory"
+	"github.com/zeroxsolutions/barbatos/cache"
+)
+
+// manualClock is a banshee.Clock that only moves when the test changes now. The mock module
+// offers the same as mock.MockClock, which this module's tests can't import.
+type manualClock struct {
+	now time.Time
+}
+
+func (c *manualClock) Now() time.Time {
+	return c.now
+}
+
+// TestCache exercises the cache.Cache operations of the in-memory cache.
+func TestCache(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("SetGetDel", func(t *testing.T) {
+		c := memory.New()
+
+		if err := c.Set(ctx, "key", 42); err != nil {
+			t.Fatal(err)
+		}
+
+		v, err := c.Get(ctx, "key")
+		if err != nil || v != "42" {
+			t.Fatal(v, err)
+		}
+
+		if err := c.Del(ctx, "key", "missing"); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := c.Get(ctx, "key"); err != cache.ErrCacheNil {
+			t.Log(err)
+			t.FailNow()
+		}
+	})
+
+	t.Run("Expiration", func(t *testing.T) {
+		clock := &manualClock{now: time.Now()}
+		c := memory.NewWithClock(clock)
+
+		if err := c.SetWithExpiration(ctx, "short", "value", 50*time.Millisecond); err != nil {
+			t.Fatal(err)
+		}
+		if err := c.SetWithExpiration(ctx, "extended", "value", 50*time.Millisecond); err != nil {
+			t.Fatal(err)
+		}
+
+		if found, err := c.Expire(ctx, "extended", time.Minute); err != nil || !found {
+			t.Fatal(found, err)
+		}
+
+		clock.now = clock.now.Add(50 * time.Millisecond)
+
+		if _, err := c.Get(ctx, "short"); err != cache.ErrCacheNil {
+			t.Log(err)
+			t.FailNow()
+		}
+
+		if _, err := c.Get(ctx, "extended"); err != nil {
+			t.Fatal(err)
+		}
+
+		if found, err := c.Expire(ctx, "short", time.Minute); err != nil || found {
+			t.Log(found, err)
+			t.FailNow()
+		}
+
+		if found, err := c.ExpireAt(ctx, "extended", clock.now.Add(time.Second)); err != nil || !found {
+			t.Fatal(found, err)
+		}
+
+		for _, err := range []error{
+			func() error { _, err := c.Expire(ctx, "extended", 0); return err }(),
+			func() error { _, err := c.ExpireAt(ctx, "extended", clock.now); return err }(),
+		} {
+			if err != memory.ErrInvalidExpiration {
+				t.Fatal(err)
+			}
+		}
+
+		clock.now = clock.now.Add(time.Second)
+
+		if _, err := c.Get(ctx, "extended"); err != cache.ErrCacheNil {
+			t.Fatal("the key should expire at the deadline set by ExpireAt:", err)
+		}
+	})
+
+	t.Run("Patterns", func(t *testing.T) {
+		c := memory.New()
+
+		for _, key := range []string{"user:1", "user:2", "order:1"} {
+			if err := c.Set(ctx, key, "value"); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		keys, err := c.Keys(ctx, "user:*")
+		if err != nil {
+			t.Fatal(err)
+		}
+		sort.Strings(keys)
+
+		if len(keys) != 2 || keys[0] != "user:1" || keys[1] != "user:2" {
+			t.Log("unexpected keys:", keys)
+			t.FailNow()
+		}
+
+		if err := c.DelWithPattern(ctx, "user:*"); err != nil {
+			t.Fatal(err)
+		}
+
+		keys, err = c.Keys(ctx, "*")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(keys) != 1 || keys[0] != "order:1" {
+			t.Log("unexpected keys:", keys)
+			t.FailNow()
+		}
+	})
+
+	t.Run("SetNX", func(t *testing.T) {
+		clock := &manualClock{now: time.Now()}
+		c := memory.NewWithClock(clock)
+
+		if stored, err := c.SetNX(ctx, "key", "first", time.Second); err != nil || !stored {
+			t.Fatal(stored, err)
+		}
+		if stored, err := c.SetNX(ctx, "key", "second", time.Second); err != nil || stored {
+			t.Fatal(stored, err)
+		}
+		if v, err := c.Get(ctx, "key"); err != nil || v != "first" {
+			t.Fatal(v, err)
+		}
+
+		clock.now = clock.now.Add(time.Second)
+
+		stored, err := c.SetNXMany(ctx, []string{"key", "other", "key"}, "third", 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(stored) != 3 || !stored[0] || !stored[1] || stored[2] {
+			t.Log("unexpected results:", stored)
+			t.FailNow()
+		}
+	})
+
+	t.Run("SetXX", func(t *testing.T) {
+		clock := &manualClock{now: time.Now()}
+		c := memory.NewWithClock(clock)
+
+		if stored, err := c.SetXX(ctx, "key", "first", time.Second); err != nil || stored {
+			t.Fatal(stored, err)
+		}
+		if _, err := c.Get(ctx, "key"); err != cache.ErrCacheNil {
+			t.Fatal("SetXX created a missing key:", err)
+		}
+
+		if err := c.SetWithExpiration(ctx, "key", "first", time.Second); err != nil {
+			t.Fatal(err)
+		}
+		if stored, err := c.SetXX(ctx, "key", "second", 2*time.Second); err != nil || !stored {
+			t.Fatal(stored, err)
+		}
+
+		clock.now = clock.now.Add(time.Second)
+		if v, err := c.Get(ctx, "key"); err != nil || v != "second" {
+			t.Fatal(v, err)
+		}
+
+		clock.now = clock.now.Add(time.Second)
+		if stored, err := c.SetXX(ctx, "key", "third", 0); err != nil || stored {
+			t.Fatal("SetXX revived an expired key:", stored, err)
+		}
+	})
+
+	t.Run("Close", func(t *testing.T) {
+		c := memory.New()
+
+		if !c.IsConnected(ctx) {
+			t.FailNow()
+		}
+
+		if err := c.Close(); err != nil {
+			t.Fatal(err)
+		}
+
+		if c.IsConnected(ctx) {
+			t.FailNow()
+		}
+
+		if _, err := c.Get(ctx, "key"); err != memory.ErrClosed {
+			t.Log(err)
+			t.FailNow()
+		}
+	})
+}
@@ -0,0 +1,216 @@
+package memory
+
+import (
+	"context"
+	"sort"
+
+	"github.com/zeroxsolutions/barbatos/cache"
+)
+
+// ZAdd adds member to the sorted set stored under key with score, updating the score of an
+// existing member. It returns ErrWrongType if key holds a string value.
+func (c *Cache) ZAdd(ctx context.Context, key string, score float64, member string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return ErrClosed
+	}
+	it, ok := c.lookup(key, c.clock.Now())
+	if ok && it.zset == nil {
+		return ErrWrongType
+	}
+	if !ok {
+		it = item{zset: map[string]float64{}}
+	}
+	it.zset[member] = score
+	c.items[key] = it
+	return nil
+}
+
+// ZRangeByScore returns the members whose score lies between min and max (inclusive), ordered by
+// score.
+func (c *Cache) ZRangeByScore(ctx context.Context, key string, min, max float64) ([]string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return nil, ErrClosed
+	}
+	it, ok := c.lookup(key, c.clock.Now())
+	if !ok {
+		return []string{}, nil
+	}
+	if it.zset == nil {
+		return nil, ErrWrongType
+	}
+	members := []string{}
+	for _, member := range sortedMembers(it.zset) {
+		if score := it.zset[member]; score >= min && score <= max {
+			members = append(members, member)
+		}
+	}
+	return members, nil
+}
+
+// ZRemRangeByScore removes the members whose score lies between min and max (inclusive).
+// Like Redis, it deletes the key once the sorted set is empty.
+func (c *Cache) ZRemRangeByScore(ctx context.Context, key string, min, max float64) (int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return 0, ErrClosed
+	}
+	it, ok := c.lookup(key, c.clock.Now())
+	if !ok {
+		return 0, nil
+	}
+	if it.zset == nil {
+		return 0, ErrWrongType
+	}
+	var removed int64
+	for member, score := range it.zset {
+		if score >= min && score <= max {
+			delete(it.zset, member)
+			removed++
+		}
+	}
+	if len(it.zset) == 0 {
+		delete(c.items, key)
+	}
+	return removed, nil
+}
+
+// ZRem removes members from the sorted set stored under key. Like Redis, it deletes the key once
+// the sorted set is empty.
+func (c *Cache) ZRem(ctx context.Context, key string, members ...string) (int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return 0, ErrClosed
+	}
+	it, ok := c.lookup(key, c.clock.Now())
+	if !ok {
+		return 0, nil
+	}
+	if it.zset == nil {
+		return 0, ErrWrongType
+	}
+	var removed int64
+	for _, member := range members {
+		if _, ok := it.zset[member]; ok {
+			delete(it.zset, member)
+			removed++
+		}
+	}
+	if len(it.zset) == 0 {
+		delete(c.items, key)
+	}
+	return removed, nil
+}
+
+// ZRange returns the members ranked start to stop (inclusive) in ascending score order. Negative
+// ranks count from the end, as in Redis.
+func (c *Cache) ZRange(ctx context.Context, key string, start, stop int64) ([]string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return nil, ErrClosed
+	}
+	it, ok := c.lookup(key, c.clock.Now())
+	if !ok {
+		return []string{}, nil
+	}
+	if it.zset == nil {
+		return nil, ErrWrongType
+	}
+	members := sortedMembers(it.zset)
+	n := int64(len(members))
+	if start < 0 {
+		start += n
+	}
+	if stop < 0 {
+		stop += n
+	}
+	if start < 0 {
+		start = 0
+	}
+	if stop >= n {
+		stop = n - 1
+	}
+	if start > stop {
+		return []string{}, nil
+	}
+	return members[start : stop+1], nil
+}
+
+// ZCard returns the number of members of the sorted set stored under key.
+func (c *Cache) ZCard(ctx context.Context, key string) (int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return 0, ErrClosed
+	}
+	it, ok := c.lookup(key, c.clock.Now())
+	if !ok {
+		return 0, nil
+	}
+	if it.zset == nil {
+		return 0, ErrWrongType
+	}
+	return int64(len(it.zset)), nil
+}
+
+// ZPopMin removes and returns the member with the lowest score, or cache.ErrCacheNil if the
+// sorted set is empty.
+func (c *Cache) ZPopMin(ctx context.Context, key string) (string, float64, error) {
+	return c.zPop(key, false)
+}
+
+// ZPopMax removes and returns the member with the highest score, or cache.ErrCacheNil if the
+// sorted set is empty.
+func (c *Cache) ZPopMax(ctx context.Context, key string) (string, float64, error) {
+	return c.zPop(key, true)
+}
+
+// zPop removes and returns the lowest or highest member of the sorted set stored under key.
+func (c *Cache) zPop(key string, highest bool) (string, float64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return "", 0, ErrClosed
+	}
+	it, ok := c.lookup(key, c.clock.Now())
+	if !ok {
+		return "", 0, cache.ErrCacheNil
+	}
+	if it.zset == nil {
+		return "", 0, ErrWrongType
+	}
+	members := sortedMembers(it.zset)
+	member := members[0]
+	if highest {
+		member = members[len(members)-1]
+	}
+	score := it.zset[member]
+	delete(it.zset, member)
+	if len(it.zset) == 0 {
+		delete(c.items, key)
+	}
+	return member, score, nil
+}
+
+// sortedMembers returns the members of zset ordered by score and then lexicographically, as Redis
+// orders members with equal scores.
+func sortedMembers(zset map[string]float64) []string {
+	members := make([]string, 0, len(zset))
+	for member := range zset {
+		members = append(members, member)
+	}
+	sort.Slice(members, func(i, j int) bool {
+		si, sj := zset[members[i]], zset[members[j]]
+		if si != sj {
+			return si < sj
+		}
+		return members[i] < members[j]
+	})
+	return members
+}
@@ -0,0 +1,100 @@
+package memory_test
+
+import (
+	"context"
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/zeroxsolutions/banshee/memory"
+	"github.com/zeroxsolutions/barbatos/cache"
+)
+
+// TestCache_SortedSet exercises the sorted set operations of the in-memory cache.
+func TestCache_SortedSet(t *testing.T) {
+	ctx := context.Background()
+	c := memory.New()
+
+	for member, score := range map[string]float64{"c": 3, "a": 1, "b": 2, "b2": 2} {
+		if err := c.ZAdd(ctx, "zset", score, member); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := c.ZAdd(ctx, "zset", 4, "a"); err != nil {
+		t.Fatal(err)
+	}
+
+	members, err := c.ZRangeByScore(ctx, "zset", 2, math.Inf(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(members, []string{"b", "b2", "c", "a"}) {
+		t.Fatal("unexpected members:", members)
+	}
+
+	if removed, err := c.ZRemRangeByScore(ctx, "zset", math.Inf(-1), 2); err != nil || removed != 2 {
+		t.Fatal(removed, err)
+	}
+	if removed, err := c.ZRem(ctx, "zset", "c", "missing"); err != nil || removed != 1 {
+		t.Fatal(removed, err)
+	}
+	if removed, err := c.ZRem(ctx, "zset", "a"); err != nil || removed != 1 {
+		t.Fatal(removed, err)
+	}
+
+	if keys, err := c.Keys(ctx, "zset"); err != nil || len(keys) != 0 {
+		t.Fatal("empty sorted set not deleted:", keys, err)
+	}
+
+	if err := c.Set(ctx, "string", "value"); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.ZAdd(ctx, "string", 1, "a"); !errors.Is(err, memory.ErrWrongType) {
+		t.Fatal("expected ErrWrongType, got", err)
+	}
+	if err := c.ZAdd(ctx, "zset", 1, "a"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Get(ctx, "zset"); !errors.Is(err, memory.ErrWrongType) {
+		t.Fatal("expected ErrWrongType, got", err)
+	}
+
+	if _, err := c.ZRem(ctx, "zset", "a"); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("RankAndPop", func(t *testing.T) {
+		for member, score := range map[string]float64{"low": 1, "mid": 5, "high": 9} {
+			if err := c.ZAdd(ctx, "ranked", score, member); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		if n, err := c.ZCard(ctx, "ranked"); err != nil || n != 3 {
+			t.Fatal(n, err)
+		}
+		if members, err := c.ZRange(ctx, "ranked", -1, -1); err != nil || !reflect.DeepEqual(members, []string{"high"}) {
+			t.Fatal(members, err)
+		}
+		if members, err := c.ZRange(ctx, "ranked", 0, 10); err != nil || !reflect.DeepEqual(members, []string{"low", "mid", "high"}) {
+			t.Fatal(members, err)
+		}
+
+		if member, score, err := c.ZPopMax(ctx, "ranked"); err != nil || member != "high" || score != 9 {
+			t.Fatal(member, score, err)
+		}
+		if member, score, err := c.ZPopMin(ctx, "ranked"); err != nil || member != "low" || score != 1 {
+			t.Fatal(member, score, err)
+		}
+		if member, _, err := c.ZPopMin(ctx, "ranked"); err != nil || member != "mid" {
+			t.Fatal(member, err)
+		}
+		if _, _, err := c.ZPopMin(ctx, "ranked"); !errors.Is(err, cache.ErrCacheNil) {
+			t.Fatal("expected cache.ErrCacheNil, got", err)
+		}
+		if n, err := c.ZCard(ctx, "ranked"); err != nil || n != 0 {
+			t.Fatal(n, err)
+		}
+	})
+}
@@ -4,6 +4,7 @@ go 1.18
 
 require (
 	github.com/stretchr/testify v1.9.0
+	github.com/zeroxsolutions/banshee v0.0.2
 	github.com/zeroxsolutions/barbatos v0.0.1
 )
 
@@ -11,5 +12,8 @@ require (
 	github.com/davecgh/go-spew v1.1.1 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
 	github.com/stretchr/objx v0.5.2 // indirect
+	github.com/zeroxsolutions/strike v0.0.1 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
+
+replace github.com/zeroxsolutions/banshee => ../
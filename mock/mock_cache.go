@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/mock"
+	"github.com/zeroxsolutions/banshee"
 	aliasCache "github.com/zeroxsolutions/barbatos/cache"
 )
 
@@ -30,6 +31,35 @@ type MockCache struct {
 	mock.Mock
 }
 
+var _ banshee.CounterCache = (*MockCache)(nil)
+var _ banshee.PublishingCache = (*MockCache)(nil)
+var _ banshee.Expirer = (*MockCache)(nil)
+var _ banshee.ConfigCache = (*MockCache)(nil)
+var _ banshee.GetExCache = (*MockCache)(nil)
+var _ banshee.HashCache = (*MockCache)(nil)
+var _ banshee.ListCache = (*MockCache)(nil)
+var _ banshee.SortedSetCache = (*MockCache)(nil)
+var _ banshee.PatternGetter = (*MockCache)(nil)
+var _ banshee.SetNXCache = (*MockCache)(nil)
+var _ banshee.RotateCache = (*MockCache)(nil)
+var _ banshee.ExpiryInspector = (*MockCache)(nil)
+var _ banshee.MultiGetter = (*MockCache)(nil)
+var _ banshee.MultiSetter = (*MockCache)(nil)
+var _ banshee.QuotaConsumer = (*MockCache)(nil)
+var _ banshee.ExistenceChecker = (*MockCache)(nil)
+var _ banshee.RateMeter = (*MockCache)(nil)
+var _ banshee.TTLReader = (*MockCache)(nil)
+var _ banshee.HeartbeatCache = (*MockCache)(nil)
+var _ banshee.PatternExpirer = (*MockCache)(nil)
+var _ banshee.ReliableQueue = (*MockCache)(nil)
+var _ banshee.GetDelCache = (*MockCache)(nil)
+var _ banshee.KeySampler = (*MockCache)(nil)
+var _ banshee.GetSetCache = (*MockCache)(nil)
+var _ banshee.CountingDeleter = (*MockCache)(nil)
+var _ banshee.KeyWalker = (*MockCache)(nil)
+var _ banshee.PatternDeleteGuard = (*MockCache)(nil)
+var _ banshee.SetXXCache = (*MockCache)(nil)
+
 // IsConnected mocks the cache connectivity check method.
 // This method simulates checking the connection status to the cache system
 // and allows tests to control whether the cache appears connected or not.
@@ -325,6 +355,1815 @@ func (m *MockCache) Close() error {
 	return r0
 }
 
+// IncrByCeil mocks the capped atomic increment method.
+// This method simulates incrementing a counter only while the result stays at or below a ceiling,
+// allowing tests to control both the resulting value and whether the increment was applied.
+//
+// The mock supports various return scenarios:
+//   - Return the new value and true to simulate an applied increment
+//   - Return the current value and false to simulate a refused increment at the ceiling
+//   - Return an error to simulate a non-integer value or connection failure
+//   - Use function-based returns for dynamic behavior
+//
+// Parameters:
+//   - ctx: Context for request lifecycle management and cancellation
+//   - key: Counter key to increment
+//   - delta: Amount to add
+//   - ceil: Maximum value the counter may reach
+//
+// Returns:
+//   - int64: Mocked counter value after the call
+//   - bool: Mocked flag reporting whether the increment was applied
+//   - error: Mocked error if the operation should fail
+//
+// Example:
+//
+//	mockCache.On("IncrByCeil", mock.Anything, "seats", int64(1), int64(180)).Return(int64(180), false, nil)
+//	seats, applied, err := mockCache.IncrByCeil(ctx, "seats", 1, 180) // returns 180, false, nil
+func (m *MockCache) IncrByCeil(ctx context.Context, key string, delta, ceil int64) (int64, bool, error) {
+	ret := m.Called(ctx, key, delta, ceil)
+	var r0 int64
+	var r1 bool
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64, int64) (int64, bool, error)); ok {
+		return rf(ctx, key, delta, ceil)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64, int64) int64); ok {
+		r0 = rf(ctx, key, delta, ceil)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if rf, ok := ret.Get(1).(func(context.Context, string, int64, int64) bool); ok {
+		r1 = rf(ctx, key, delta, ceil)
+	} else {
+		r1 = ret.Bool(1)
+	}
+	if rf, ok := ret.Get(2).(func(context.Context, string, int64, int64) error); ok {
+		r2 = rf(ctx, key, delta, ceil)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// SetAndPublish mocks the atomic write-and-notify method.
+// This method simulates storing a value and publishing its key on a Pub/Sub channel in one step,
+// allowing tests to verify which key, value, TTL, and channel the code under test used.
+//
+// The mock supports various return scenarios:
+//   - Return nil to simulate a successful write and publish
+//   - Return an error to simulate a failed transaction
+//   - Use function-based returns for dynamic behavior
+//
+// Parameters:
+//   - ctx: Context for request lifecycle management and cancellation
+//   - key: Cache key to store the value under
+//   - value: Value to be stored
+//   - ttl: Expiration of the stored value
+//   - channel: Channel notified with the key name
+//
+// Returns:
+//   - error: Mocked error if the operation should fail
+//
+// Example:
+//
+//	mockCache.On("SetAndPublish", mock.Anything, "config", mock.Anything, time.Duration(0), "invalidate").Return(nil)
+func (m *MockCache) SetAndPublish(ctx context.Context, key string, value interface{}, ttl time.Duration, channel string) error {
+	ret := m.Called(ctx, key, value, ttl, channel)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, interface{}, time.Duration, string) error); ok {
+		r0 = rf(ctx, key, value, ttl, channel)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Expire mocks the TTL update of an existing key.
+// This method simulates changing a key's expiration without rewriting its value,
+// allowing tests to verify which key and TTL the code under test refreshed.
+//
+// The mock supports various return scenarios:
+//   - Return true to simulate an existing key whose TTL was updated
+//   - Return false to simulate a missing key
+//   - Return an error to simulate a failed operation
+//   - Use function-based returns for dynamic behavior
+//
+// Parameters:
+//   - ctx: Context for request lifecycle management and cancellation
+//   - key: Cache key whose TTL is updated
+//   - ttl: New time-to-live
+//
+// Returns:
+//   - bool: Mocked existence of the key
+//   - error: Mocked error if the operation should fail
+//
+// Example:
+//
+//	mockCache.On("Expire", mock.Anything, "session:42", 30*time.Minute).Return(true, nil)
+func (m *MockCache) Expire(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	ret := m.Called(ctx, key, ttl)
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration) (bool, error)); ok {
+		return rf(ctx, key, ttl)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration) bool); ok {
+		r0 = rf(ctx, key, ttl)
+	} else {
+		r0 = ret.Bool(0)
+	}
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Duration) error); ok {
+		r1 = rf(ctx, key, ttl)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// ExpireAt mocks the absolute expiration update method.
+// This method simulates making an existing key expire at a given time without rewriting its value,
+// allowing tests to verify deadline refreshes and simulate missing keys.
+//
+// Parameters:
+//   - ctx: Context for request lifecycle management and cancellation
+//   - key: Cache key whose deadline is updated
+//   - at: Time at which the key expires
+//
+// Returns:
+//   - bool: Mocked existence of the key
+//   - error: Mocked error if the operation should fail
+//
+// Example:
+//
+//	mockCache.On("ExpireAt", mock.Anything, "session:42", mock.Anything).Return(true, nil)
+func (m *MockCache) ExpireAt(ctx context.Context, key string, at time.Time) (bool, error) {
+	ret := m.Called(ctx, key, at)
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) (bool, error)); ok {
+		return rf(ctx, key, at)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) bool); ok {
+		r0 = rf(ctx, key, at)
+	} else {
+		r0 = ret.Bool(0)
+	}
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time) error); ok {
+		r1 = rf(ctx, key, at)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// ConfigGet mocks the server configuration read method.
+// This method simulates reading runtime configuration parameters,
+// allowing tests to control what configuration admin tooling observes.
+//
+// The mock supports various return scenarios:
+//   - Return a map of parameters to simulate matching configuration
+//   - Return an error to simulate a forbidden or failed command
+//   - Use function-based returns for dynamic behavior
+//
+// Parameters:
+//   - ctx: Context for request lifecycle management and cancellation
+//   - parameter: Parameter name or glob pattern
+//
+// Returns:
+//   - map[string]string: Mocked parameters keyed by name
+//   - error: Mocked error if the operation should fail
+//
+// Example:
+//
+//	mockCache.On("ConfigGet", mock.Anything, "maxmemory").Return(map[string]string{"maxmemory": "0"}, nil)
+func (m *MockCache) ConfigGet(ctx context.Context, parameter string) (map[string]string, error) {
+	ret := m.Called(ctx, parameter)
+	var r0 map[string]string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (map[string]string, error)); ok {
+		return rf(ctx, parameter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) map[string]string); ok {
+		r0 = rf(ctx, parameter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]string)
+		}
+	}
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, parameter)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// ConfigSet mocks the server configuration write method.
+// This method simulates changing a runtime configuration parameter,
+// allowing tests to verify which parameters admin tooling changes.
+//
+// The mock supports various return scenarios:
+//   - Return nil to simulate an accepted change
+//   - Return an error to simulate a disabled, forbidden, or rejected change
+//   - Use function-based returns for dynamic behavior
+//
+// Parameters:
+//   - ctx: Context for request lifecycle management and cancellation
+//   - parameter: Parameter name
+//   - value: New value
+//
+// Returns:
+//   - error: Mocked error if the operation should fail
+//
+// Example:
+//
+//	mockCache.On("ConfigSet", mock.Anything, "maxmemory-policy", "allkeys-lru").Return(nil)
+func (m *MockCache) ConfigSet(ctx context.Context, parameter, value string) error {
+	ret := m.Called(ctx, parameter, value)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, parameter, value)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetEx mocks the read-and-expire method.
+// This method simulates retrieving a value while updating its TTL in one operation,
+// allowing tests to verify which key and TTL the code under test used.
+//
+// The mock supports various return scenarios:
+//   - Return a value to simulate a hit
+//   - Return an empty string and cache.ErrCacheNil to simulate a miss
+//   - Use function-based returns for dynamic behavior
+//
+// Parameters:
+//   - ctx: Context for request lifecycle management and cancellation
+//   - key: Cache key to retrieve the value for
+//   - ttl: New time-to-live, or 0 to keep the current one
+//
+// Returns:
+//   - string: Mocked value associated with the key
+//   - error: Mocked error if the operation should fail
+//
+// Example:
+//
+//	mockCache.On("GetEx", mock.Anything, "session:42", 30*time.Minute).Return("data", nil)
+func (m *MockCache) GetEx(ctx context.Context, key string, ttl time.Duration) (string, error) {
+	ret := m.Called(ctx, key, ttl)
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration) (string, error)); ok {
+		return rf(ctx, key, ttl)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration) string); ok {
+		r0 = rf(ctx, key, ttl)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Duration) error); ok {
+		r1 = rf(ctx, key, ttl)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// GetExPersist mocks the read-and-persist method.
+// This method simulates retrieving a value while removing its expiration in one operation.
+//
+// The mock supports various return scenarios:
+//   - Return a value to simulate a hit
+//   - Return an empty string and cache.ErrCacheNil to simulate a miss
+//   - Use function-based returns for dynamic behavior
+//
+// Parameters:
+//   - ctx: Context for request lifecycle management and cancellation
+//   - key: Cache key to retrieve the value for
+//
+// Returns:
+//   - string: Mocked value associated with the key
+//   - error: Mocked error if the operation should fail
+//
+// Example:
+//
+//	mockCache.On("GetExPersist", mock.Anything, "feature:flags").Return("{}", nil)
+func (m *MockCache) GetExPersist(ctx context.Context, key string) (string, error) {
+	ret := m.Called(ctx, key)
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (string, error)); ok {
+		return rf(ctx, key)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = rf(ctx, key)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// HGetAll mocks the hash read method.
+// This method simulates retrieving every field of a hash,
+// allowing tests to control the fields returned for specific keys.
+//
+// The mock supports various return scenarios:
+//   - Return a map of fields to simulate an existing hash
+//   - Return nil and cache.ErrCacheNil to simulate a missing key
+//   - Use function-based returns for dynamic behavior
+//
+// Parameters:
+//   - ctx: Context for request lifecycle management and cancellation
+//   - key: Cache key of the hash
+//
+// Returns:
+//   - map[string]string: Mocked fields of the hash
+//   - error: Mocked error if the operation should fail
+//
+// Example:
+//
+//	mockCache.On("HGetAll", mock.Anything, "user:1").Return(map[string]string{"name": "alice"}, nil)
+func (m *MockCache) HGetAll(ctx context.Context, key string) (map[string]string, error) {
+	ret := m.Called(ctx, key)
+	var r0 map[string]string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (map[string]string, error)); ok {
+		return rf(ctx, key)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) map[string]string); ok {
+		r0 = rf(ctx, key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]string)
+		}
+	}
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// HGetAllMany mocks the batched hash read method.
+// This method simulates retrieving the fields of several hashes in one call,
+// allowing tests to control which hashes exist.
+//
+// The mock supports various return scenarios:
+//   - Return a map of hashes keyed by key, omitting missing keys
+//   - Return an error to simulate a failed operation
+//   - Use function-based returns for dynamic behavior
+//
+// Parameters:
+//   - ctx: Context for request lifecycle management and cancellation
+//   - keys: Cache keys of the hashes
+//
+// Returns:
+//   - map[string]map[string]string: Mocked hashes keyed by key
+//   - error: Mocked error if the operation should fail
+//
+// Example:
+//
+//	mockCache.On("HGetAllMany", mock.Anything, []string{"user:1"}).Return(map[string]map[string]string{"user:1": {"name": "alice"}}, nil)
+func (m *MockCache) HGetAllMany(ctx context.Context, keys []string) (map[string]map[string]string, error) {
+	ret := m.Called(ctx, keys)
+	var r0 map[string]map[string]string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) (map[string]map[string]string, error)); ok {
+		return rf(ctx, keys)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string) map[string]map[string]string); ok {
+		r0 = rf(ctx, keys)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]map[string]string)
+		}
+	}
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, keys)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// LPopN mocks the multi-item list pop method.
+// This method simulates atomically removing up to count items from the head of a list,
+// allowing tests to control the batches handed to the code under test.
+//
+// The mock supports various return scenarios:
+//   - Return a slice of items to simulate a full or partial batch
+//   - Return nil and cache.ErrCacheNil to simulate an empty list
+//   - Use function-based returns for dynamic behavior
+//
+// Parameters:
+//   - ctx: Context for request lifecycle management and cancellation
+//   - key: Cache key of the list
+//   - count: Maximum number of items to pop
+//
+// Returns:
+//   - []string: Mocked items popped from the list
+//   - error: Mocked error if the operation should fail
+//
+// Example:
+//
+//	mockCache.On("LPopN", mock.Anything, "jobs", int64(10)).Return([]string{"job1", "job2"}, nil)
+func (m *MockCache) LPopN(ctx context.Context, key string, count int64) ([]string, error) {
+	ret := m.Called(ctx, key, count)
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) ([]string, error)); ok {
+		return rf(ctx, key, count)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) []string); ok {
+		r0 = rf(ctx, key, count)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+	if rf, ok := ret.Get(1).(func(context.Context, string, int64) error); ok {
+		r1 = rf(ctx, key, count)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// ZAdd mocks the sorted set insertion method.
+// This method simulates adding a member with a score to a sorted set,
+// allowing tests to verify which members and scores the code under test writes.
+//
+// The mock supports various return scenarios:
+//   - Return nil to simulate a successful insertion
+//   - Return an error to simulate a failure, e.g. a key holding another type
+//   - Use function-based returns for dynamic behavior
+//
+// Parameters:
+//   - ctx: Context for request lifecycle management and cancellation
+//   - key: Cache key of the sorted set
+//   - score: Score of the member
+//   - member: Member to add
+//
+// Returns:
+//   - error: Mocked error if the operation should fail
+//
+// Example:
+//
+//	mockCache.On("ZAdd", mock.Anything, "leaderboard", 10.0, "player:1").Return(nil)
+func (m *MockCache) ZAdd(ctx context.Context, key string, score float64, member string) error {
+	ret := m.Called(ctx, key, score, member)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, float64, string) error); ok {
+		r0 = rf(ctx, key, score, member)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ZRangeByScore mocks the sorted set range method.
+// This method simulates reading the members of a sorted set within a score range,
+// allowing tests to control which members the code under test observes.
+//
+// The mock supports various return scenarios:
+//   - Return a slice of members to simulate matching members
+//   - Return an empty slice to simulate a missing key or an empty range
+//   - Use function-based returns for dynamic behavior
+//
+// Parameters:
+//   - ctx: Context for request lifecycle management and cancellation
+//   - key: Cache key of the sorted set
+//   - min: Lowest score to return
+//   - max: Highest score to return
+//
+// Returns:
+//   - []string: Mocked members ordered by score
+//   - error: Mocked error if the operation should fail
+//
+// Example:
+//
+//	mockCache.On("ZRangeByScore", mock.Anything, "leaderboard", 0.0, math.Inf(1)).Return([]string{"player:1"}, nil)
+func (m *MockCache) ZRangeByScore(ctx context.Context, key string, min, max float64) ([]string, error) {
+	ret := m.Called(ctx, key, min, max)
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, float64, float64) ([]string, error)); ok {
+		return rf(ctx, key, min, max)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, float64, float64) []string); ok {
+		r0 = rf(ctx, key, min, max)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+	if rf, ok := ret.Get(1).(func(context.Context, string, float64, float64) error); ok {
+		r1 = rf(ctx, key, min, max)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// ZRemRangeByScore mocks the sorted set range removal method.
+// This method simulates removing the members of a sorted set within a score range,
+// allowing tests to verify pruning behavior.
+//
+// The mock supports various return scenarios:
+//   - Return the number of removed members to simulate a successful prune
+//   - Return an error to simulate a failure
+//   - Use function-based returns for dynamic behavior
+//
+// Parameters:
+//   - ctx: Context for request lifecycle management and cancellation
+//   - key: Cache key of the sorted set
+//   - min: Lowest score to remove
+//   - max: Highest score to remove
+//
+// Returns:
+//   - int64: Mocked number of removed members
+//   - error: Mocked error if the operation should fail
+//
+// Example:
+//
+//	mockCache.On("ZRemRangeByScore", mock.Anything, "sessions", math.Inf(-1), mock.Anything).Return(int64(3), nil)
+func (m *MockCache) ZRemRangeByScore(ctx context.Context, key string, min, max float64) (int64, error) {
+	ret := m.Called(ctx, key, min, max)
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, float64, float64) (int64, error)); ok {
+		return rf(ctx, key, min, max)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, float64, float64) int64); ok {
+		r0 = rf(ctx, key, min, max)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if rf, ok := ret.Get(1).(func(context.Context, string, float64, float64) error); ok {
+		r1 = rf(ctx, key, min, max)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// ZRem mocks the sorted set member removal method.
+// This method simulates removing specific members from a sorted set,
+// allowing tests to verify which members the code under test removes.
+//
+// The mock supports various return scenarios:
+//   - Return the number of removed members to simulate a successful removal
+//   - Return an error to simulate a failure
+//   - Use function-based returns for dynamic behavior
+//
+// Parameters:
+//   - ctx: Context for request lifecycle management and cancellation
+//   - key: Cache key of the sorted set
+//   - members: Members to remove
+//
+// Returns:
+//   - int64: Mocked number of removed members
+//   - error: Mocked error if the operation should fail
+//
+// Example:
+//
+//	mockCache.On("ZRem", mock.Anything, "leaderboard", "player:1").Return(int64(1), nil)
+func (m *MockCache) ZRem(ctx context.Context, key string, members ...string) (int64, error) {
+	_members := make([]interface{}, len(members))
+	for _idx := range members {
+		_members[_idx] = members[_idx]
+	}
+	var _args []interface{}
+	_args = append(_args, ctx, key)
+	_args = append(_args, _members...)
+	ret := m.Called(_args...)
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, ...string) (int64, error)); ok {
+		return rf(ctx, key, members...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, ...string) int64); ok {
+		r0 = rf(ctx, key, members...)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if rf, ok := ret.Get(1).(func(context.Context, string, ...string) error); ok {
+		r1 = rf(ctx, key, members...)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// GetByPattern mocks the pattern-based bulk read method.
+// This method simulates reading the values of every key matching a pattern,
+// allowing tests to control the key set the code under test loads.
+//
+// The mock supports various return scenarios:
+//   - Return a map of values to simulate matching keys
+//   - Return an empty map to simulate a pattern matching nothing
+//   - Use function-based returns for dynamic behavior
+//
+// Parameters:
+//   - ctx: Context for request lifecycle management and cancellation
+//   - pattern: Pattern of the keys to read
+//
+// Returns:
+//   - map[string]string: Mocked values keyed by key
+//   - error: Mocked error if the operation should fail
+//
+// Example:
+//
+//	mockCache.On("GetByPattern", mock.Anything, "feature:*").Return(map[string]string{"feature:a": "on"}, nil)
+func (m *MockCache) GetByPattern(ctx context.Context, pattern string) (map[string]string, error) {
+	ret := m.Called(ctx, pattern)
+	var r0 map[string]string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (map[string]string, error)); ok {
+		return rf(ctx, pattern)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) map[string]string); ok {
+		r0 = rf(ctx, pattern)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]string)
+		}
+	}
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, pattern)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// ZRange mocks the sorted set rank range method.
+// This method simulates reading the members of a sorted set by rank,
+// allowing tests to control which members the code under test observes.
+//
+// The mock supports various return scenarios:
+//   - Return a slice of members to simulate the ranked members
+//   - Return an empty slice to simulate a missing key or an empty range
+//   - Use function-based returns for dynamic behavior
+//
+// Parameters:
+//   - ctx: Context for request lifecycle management and cancellation
+//   - key: Cache key of the sorted set
+//   - start: Rank of the first member to return
+//   - stop: Rank of the last member to return
+//
+// Returns:
+//   - []string: Mocked members in ascending score order
+//   - error: Mocked error if the operation should fail
+//
+// Example:
+//
+//	mockCache.On("ZRange", mock.Anything, "tasks", int64(-1), int64(-1)).Return([]string{"task:1"}, nil)
+func (m *MockCache) ZRange(ctx context.Context, key string, start, stop int64) ([]string, error) {
+	ret := m.Called(ctx, key, start, stop)
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64, int64) ([]string, error)); ok {
+		return rf(ctx, key, start, stop)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64, int64) []string); ok {
+		r0 = rf(ctx, key, start, stop)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+	if rf, ok := ret.Get(1).(func(context.Context, string, int64, int64) error); ok {
+		r1 = rf(ctx, key, start, stop)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// ZCard mocks the sorted set cardinality method.
+// This method simulates counting the members of a sorted set,
+// allowing tests to control the size the code under test observes.
+//
+// The mock supports various return scenarios:
+//   - Return a count to simulate a populated sorted set
+//   - Return 0 to simulate a missing key
+//   - Use function-based returns for dynamic behavior
+//
+// Parameters:
+//   - ctx: Context for request lifecycle management and cancellation
+//   - key: Cache key of the sorted set
+//
+// Returns:
+//   - int64: Mocked number of members
+//   - error: Mocked error if the operation should fail
+//
+// Example:
+//
+//	mockCache.On("ZCard", mock.Anything, "tasks").Return(int64(3), nil)
+func (m *MockCache) ZCard(ctx context.Context, key string) (int64, error) {
+	ret := m.Called(ctx, key)
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (int64, error)); ok {
+		return rf(ctx, key)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) int64); ok {
+		r0 = rf(ctx, key)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// ZPopMin mocks the sorted set minimum pop method.
+// This method simulates atomically removing the member with the lowest score,
+// allowing tests to control the items handed to the code under test.
+//
+// The mock supports various return scenarios:
+//   - Return a member and score to simulate a successful pop
+//   - Return an empty member, 0 and cache.ErrCacheNil to simulate an empty sorted set
+//   - Use function-based returns for dynamic behavior
+//
+// Parameters:
+//   - ctx: Context for request lifecycle management and cancellation
+//   - key: Cache key of the sorted set
+//
+// Returns:
+//   - string: Mocked removed member
+//   - float64: Mocked score of the member
+//   - error: Mocked error if the operation should fail
+//
+// Example:
+//
+//	mockCache.On("ZPopMin", mock.Anything, "tasks").Return("task:1", 5.0, nil)
+func (m *MockCache) ZPopMin(ctx context.Context, key string) (string, float64, error) {
+	ret := m.Called(ctx, key)
+	var r0 string
+	var r1 float64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (string, float64, error)); ok {
+		return rf(ctx, key)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = rf(ctx, key)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	if rf, ok := ret.Get(1).(func(context.Context, string) float64); ok {
+		r1 = rf(ctx, key)
+	} else {
+		r1 = ret.Get(1).(float64)
+	}
+	if rf, ok := ret.Get(2).(func(context.Context, string) error); ok {
+		r2 = rf(ctx, key)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// ZPopMax mocks the sorted set maximum pop method.
+// This method simulates atomically removing the member with the highest score,
+// allowing tests to control the items handed to the code under test.
+//
+// The mock supports various return scenarios:
+//   - Return a member and score to simulate a successful pop
+//   - Return an empty member, 0 and cache.ErrCacheNil to simulate an empty sorted set
+//   - Use function-based returns for dynamic behavior
+//
+// Parameters:
+//   - ctx: Context for request lifecycle management and cancellation
+//   - key: Cache key of the sorted set
+//
+// Returns:
+//   - string: Mocked removed member
+//   - float64: Mocked score of the member
+//   - error: Mocked error if the operation should fail
+//
+// Example:
+//
+//	mockCache.On("ZPopMax", mock.Anything, "tasks").Return("task:1", 5.0, nil)
+func (m *MockCache) ZPopMax(ctx context.Context, key string) (string, float64, error) {
+	ret := m.Called(ctx, key)
+	var r0 string
+	var r1 float64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (string, float64, error)); ok {
+		return rf(ctx, key)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = rf(ctx, key)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	if rf, ok := ret.Get(1).(func(context.Context, string) float64); ok {
+		r1 = rf(ctx, key)
+	} else {
+		r1 = ret.Get(1).(float64)
+	}
+	if rf, ok := ret.Get(2).(func(context.Context, string) error); ok {
+		r2 = rf(ctx, key)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// SetNX mocks the conditional storage method.
+// This method simulates storing a value only if its key doesn't exist yet,
+// allowing tests to control whether the code under test sees a first or a repeated write.
+//
+// The mock supports various return scenarios:
+//   - Return true to simulate a stored value
+//   - Return false to simulate an existing key
+//   - Use function-based returns for dynamic behavior
+//
+// Parameters:
+//   - ctx: Context for request lifecycle management and cancellation
+//   - key: Cache key to store
+//   - value: Value to store
+//   - ttl: Time to live of the key
+//
+// Returns:
+//   - bool: Mocked result, true if the value was stored
+//   - error: Mocked error if the operation should fail
+//
+// Example:
+//
+//	mockCache.On("SetNX", mock.Anything, "lock:1", mock.Anything, time.Minute).Return(true, nil)
+func (m *MockCache) SetNX(ctx context.Context, key string, value interface{}, ttl time.Duration) (bool, error) {
+	ret := m.Called(ctx, key, value, ttl)
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, interface{}, time.Duration) (bool, error)); ok {
+		return rf(ctx, key, value, ttl)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, interface{}, time.Duration) bool); ok {
+		r0 = rf(ctx, key, value, ttl)
+	} else {
+		r0 = ret.Bool(0)
+	}
+	if rf, ok := ret.Get(1).(func(context.Context, string, interface{}, time.Duration) error); ok {
+		r1 = rf(ctx, key, value, ttl)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// SetNXMany mocks the batched conditional storage method.
+// This method simulates storing a value under several keys that don't exist yet,
+// allowing tests to control which keys the code under test sees as new.
+//
+// The mock supports various return scenarios:
+//   - Return a slice of per-key results to simulate a mix of new and existing keys
+//   - Return nil and an error to simulate a failure
+//   - Use function-based returns for dynamic behavior
+//
+// Parameters:
+//   - ctx: Context for request lifecycle management and cancellation
+//   - keys: Cache keys to store
+//   - value: Value stored under every key
+//   - ttl: Time to live of the keys
+//
+// Returns:
+//   - []bool: Mocked per-key results, true if the value was stored
+//   - error: Mocked error if the operation should fail
+//
+// Example:
+//
+//	mockCache.On("SetNXMany", mock.Anything, []string{"a", "b"}, mock.Anything, time.Hour).Return([]bool{true, false}, nil)
+func (m *MockCache) SetNXMany(ctx context.Context, keys []string, value interface{}, ttl time.Duration) ([]bool, error) {
+	ret := m.Called(ctx, keys, value, ttl)
+	var r0 []bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string, interface{}, time.Duration) ([]bool, error)); ok {
+		return rf(ctx, keys, value, ttl)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string, interface{}, time.Duration) []bool); ok {
+		r0 = rf(ctx, keys, value, ttl)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]bool)
+		}
+	}
+	if rf, ok := ret.Get(1).(func(context.Context, []string, interface{}, time.Duration) error); ok {
+		r1 = rf(ctx, keys, value, ttl)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// RotateKeepTTL mocks the TTL-preserving rotation method.
+// This method simulates replacing a value while keeping its expiration,
+// allowing tests to control the previous value returned to the code under test.
+//
+// The mock supports various return scenarios:
+//   - Return the previous value to simulate a successful rotation
+//   - Return cache.ErrCacheNil to simulate a missing key
+//   - Use function-based returns for dynamic behavior
+//
+// Parameters:
+//   - ctx: Context for request lifecycle management and cancellation
+//   - key: Cache key to rotate
+//   - newValue: Value replacing the current one
+//
+// Returns:
+//   - string: Mocked previous value
+//   - error: Mocked error if the operation should fail
+//
+// Example:
+//
+//	mockCache.On("RotateKeepTTL", mock.Anything, "apikey:1", "new").Return("old", nil)
+func (m *MockCache) RotateKeepTTL(ctx context.Context, key string, newValue interface{}) (string, error) {
+	ret := m.Called(ctx, key, newValue)
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, interface{}) (string, error)); ok {
+		return rf(ctx, key, newValue)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, interface{}) string); ok {
+		r0 = rf(ctx, key, newValue)
+	} else {
+		r0 = ret.String(0)
+	}
+	if rf, ok := ret.Get(1).(func(context.Context, string, interface{}) error); ok {
+		r1 = rf(ctx, key, newValue)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// KeysExpiringSoon mocks the expiring keys listing method.
+// This method simulates listing the keys closest to expiring,
+// allowing tests to control which keys and TTLs the code under test sees.
+//
+// The mock supports various return scenarios:
+//   - Return a slice of KeyTTL to simulate keys about to expire
+//   - Return an empty slice to simulate no expiring keys
+//   - Use function-based returns for dynamic behavior
+//
+// Parameters:
+//   - ctx: Context for request lifecycle management and cancellation
+//   - pattern: Glob-style pattern of the keys to inspect
+//   - within: Longest TTL included
+//   - limit: Maximum number of keys returned
+//
+// Returns:
+//   - []banshee.KeyTTL: Mocked keys and TTLs
+//   - error: Mocked error if the operation should fail
+//
+// Example:
+//
+//	mockCache.On("KeysExpiringSoon", mock.Anything, "session:*", time.Minute, 10).Return([]banshee.KeyTTL{{Key: "session:1", TTL: time.Second}}, nil)
+func (m *MockCache) KeysExpiringSoon(ctx context.Context, pattern string, within time.Duration, limit int) ([]banshee.KeyTTL, error) {
+	ret := m.Called(ctx, pattern, within, limit)
+	var r0 []banshee.KeyTTL
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration, int) ([]banshee.KeyTTL, error)); ok {
+		return rf(ctx, pattern, within, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration, int) []banshee.KeyTTL); ok {
+		r0 = rf(ctx, pattern, within, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]banshee.KeyTTL)
+		}
+	}
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Duration, int) error); ok {
+		r1 = rf(ctx, pattern, within, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// HSetMany mocks the batched hash write method.
+// This method simulates writing the fields of several hashes in one call,
+// allowing tests to verify which hashes and fields the code under test wrote.
+//
+// The mock supports various return scenarios:
+//   - Return nil to simulate a successful write
+//   - Return an error to simulate a failed operation
+//   - Use function-based returns for dynamic behavior
+//
+// Parameters:
+//   - ctx: Context for request lifecycle management and cancellation
+//   - entries: Fields to write, keyed by the cache key of their hash
+//
+// Returns:
+//   - error: Mocked error if the operation should fail
+//
+// Example:
+//
+//	mockCache.On("HSetMany", mock.Anything, mock.Anything).Return(nil)
+func (m *MockCache) HSetMany(ctx context.Context, entries map[string]map[string]interface{}) error {
+	ret := m.Called(ctx, entries)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, map[string]map[string]interface{}) error); ok {
+		r0 = rf(ctx, entries)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IncrFirstSeen mocks the first-seen counting method.
+// This method simulates counting an occurrence and reporting whether it is the first one within
+// a window, allowing tests to script repeated and first-time events.
+//
+// The mock supports various return scenarios:
+//   - Return a count of 1 and true to simulate a first occurrence
+//   - Return a higher count and false to simulate a repeated occurrence
+//   - Return an error to simulate a failed operation
+//   - Use function-based returns for dynamic behavior
+//
+// Parameters:
+//   - ctx: Context for request lifecycle management and cancellation
+//   - key: Cache key holding the counter
+//   - window: TTL given to the counter when it is created
+//
+// Returns:
+//   - int64: Mocked number of occurrences within the window
+//   - bool: Mocked first-occurrence flag
+//   - error: Mocked error if the operation should fail
+//
+// Example:
+//
+//	mockCache.On("IncrFirstSeen", mock.Anything, "fingerprint:abc", time.Hour).Return(int64(1), true, nil)
+func (m *MockCache) IncrFirstSeen(ctx context.Context, key string, window time.Duration) (int64, bool, error) {
+	ret := m.Called(ctx, key, window)
+	var r0 int64
+	var r1 bool
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration) (int64, bool, error)); ok {
+		return rf(ctx, key, window)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration) int64); ok {
+		r0 = rf(ctx, key, window)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Duration) bool); ok {
+		r1 = rf(ctx, key, window)
+	} else {
+		r1 = ret.Bool(1)
+	}
+	if rf, ok := ret.Get(2).(func(context.Context, string, time.Duration) error); ok {
+		r2 = rf(ctx, key, window)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// MGet mocks the multi-key read method.
+// This method simulates reading several keys in one round trip,
+// allowing tests to control which keys the code under test finds.
+//
+// The mock supports various return scenarios:
+//   - Return a map of values, omitting the keys that should appear missing
+//   - Return an empty map to simulate none of the keys existing
+//   - Use function-based returns for dynamic behavior
+//
+// Parameters:
+//   - ctx: Context for request lifecycle management and cancellation
+//   - keys: Keys to read
+//
+// Returns:
+//   - map[string]string: Mocked values keyed by key
+//   - error: Mocked error if the operation should fail
+//
+// Example:
+//
+//	mockCache.On("MGet", mock.Anything, "user:1", "user:2").Return(map[string]string{"user:1": "{}"}, nil)
+func (m *MockCache) MGet(ctx context.Context, keys ...string) (map[string]string, error) {
+	_keys := make([]interface{}, len(keys))
+	for _idx := range keys {
+		_keys[_idx] = keys[_idx]
+	}
+	var _args []interface{}
+	_args = append(_args, ctx)
+	_args = append(_args, _keys...)
+	ret := m.Called(_args...)
+	var r0 map[string]string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, ...string) (map[string]string, error)); ok {
+		return rf(ctx, keys...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, ...string) map[string]string); ok {
+		r0 = rf(ctx, keys...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]string)
+		}
+	}
+	if rf, ok := ret.Get(1).(func(context.Context, ...string) error); ok {
+		r1 = rf(ctx, keys...)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MSet mocks the multi-key write method.
+// This method simulates storing several values in one round trip,
+// allowing tests to verify bulk writes and simulate their failures.
+//
+// Parameters:
+//   - ctx: Context for request lifecycle management and cancellation
+//   - pairs: Values to store, keyed by key
+//
+// Returns:
+//   - error: Mocked error if the operation should fail
+//
+// Example:
+//
+//	mockCache.On("MSet", mock.Anything, map[string]interface{}{"user:1": "{}"}).Return(nil)
+func (m *MockCache) MSet(ctx context.Context, pairs map[string]interface{}) error {
+	ret := m.Called(ctx, pairs)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, map[string]interface{}) error); ok {
+		r0 = rf(ctx, pairs)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MSetWithExpiration mocks the multi-key write method with expiration.
+// This method simulates storing several values with a shared TTL in one round trip,
+// allowing tests to verify bulk writes and simulate partial failures.
+//
+// Parameters:
+//   - ctx: Context for request lifecycle management and cancellation
+//   - pairs: Values to store, keyed by key
+//   - expiration: Time-to-live of every key
+//
+// Returns:
+//   - error: Mocked error if the operation should fail
+//
+// Example:
+//
+//	mockCache.On("MSetWithExpiration", mock.Anything, mock.Anything, time.Minute).Return(nil)
+func (m *MockCache) MSetWithExpiration(ctx context.Context, pairs map[string]interface{}, expiration time.Duration) error {
+	ret := m.Called(ctx, pairs, expiration)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, map[string]interface{}, time.Duration) error); ok {
+		r0 = rf(ctx, pairs, expiration)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ConsumeQuota mocks the atomic quota check-and-consume method.
+// This method simulates consuming units of a per-key quota with a reset window,
+// allowing tests to script allowed and denied requests.
+//
+// The mock supports various return scenarios:
+//   - Return true with the remaining units to simulate an allowed request
+//   - Return false with a reset delay to simulate an exhausted quota
+//   - Return an error to simulate a failed operation
+//   - Use function-based returns for dynamic behavior
+//
+// Parameters:
+//   - ctx: Context for request lifecycle management and cancellation
+//   - key: Cache key holding the usage counter
+//   - limit: Units allowed per window
+//   - window: Length of the window
+//   - cost: Units to consume
+//
+// Returns:
+//   - bool: Mocked allowed flag
+//   - int64: Mocked remaining units
+//   - time.Duration: Mocked time until the window resets
+//   - error: Mocked error if the operation should fail
+//
+// Example:
+//
+//	mockCache.On("ConsumeQuota", mock.Anything, "quota:api:42", int64(100), time.Hour, int64(1)).Return(false, int64(0), time.Minute, nil)
+func (m *MockCache) ConsumeQuota(ctx context.Context, key string, limit int64, window time.Duration, cost int64) (bool, int64, time.Duration, error) {
+	ret := m.Called(ctx, key, limit, window, cost)
+	var r0 bool
+	var r1 int64
+	var r2 time.Duration
+	var r3 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64, time.Duration, int64) (bool, int64, time.Duration, error)); ok {
+		return rf(ctx, key, limit, window, cost)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64, time.Duration, int64) bool); ok {
+		r0 = rf(ctx, key, limit, window, cost)
+	} else {
+		r0 = ret.Bool(0)
+	}
+	if rf, ok := ret.Get(1).(func(context.Context, string, int64, time.Duration, int64) int64); ok {
+		r1 = rf(ctx, key, limit, window, cost)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+	if rf, ok := ret.Get(2).(func(context.Context, string, int64, time.Duration, int64) time.Duration); ok {
+		r2 = rf(ctx, key, limit, window, cost)
+	} else {
+		r2 = ret.Get(2).(time.Duration)
+	}
+	if rf, ok := ret.Get(3).(func(context.Context, string, int64, time.Duration, int64) error); ok {
+		r3 = rf(ctx, key, limit, window, cost)
+	} else {
+		r3 = ret.Error(3)
+	}
+	return r0, r1, r2, r3
+}
+
+// Exists mocks the key presence check method.
+// This method simulates counting the existing keys among those given,
+// allowing tests to control which keys the code under test sees as present.
+//
+// Parameters:
+//   - ctx: Context for request lifecycle management and cancellation
+//   - keys: Keys to check
+//
+// Returns:
+//   - int64: Mocked number of existing keys
+//   - error: Mocked error if the operation should fail
+//
+// Example:
+//
+//	mockCache.On("Exists", mock.Anything, "dedupe:abc").Return(int64(1), nil)
+func (m *MockCache) Exists(ctx context.Context, keys ...string) (int64, error) {
+	_keys := make([]interface{}, len(keys))
+	for _idx := range keys {
+		_keys[_idx] = keys[_idx]
+	}
+	var _args []interface{}
+	_args = append(_args, ctx)
+	_args = append(_args, _keys...)
+	ret := m.Called(_args...)
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, ...string) (int64, error)); ok {
+		return rf(ctx, keys...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, ...string) int64); ok {
+		r0 = rf(ctx, keys...)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if rf, ok := ret.Get(1).(func(context.Context, ...string) error); ok {
+		r1 = rf(ctx, keys...)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Rate mocks the sliding-window rate method.
+// This method simulates recording an event and reading the live event rate of a key,
+// allowing tests to script normal and anomalous rates.
+//
+// Parameters:
+//   - ctx: Context for request lifecycle management and cancellation
+//   - key: Cache key holding the events
+//   - window: Length of the sliding window
+//
+// Returns:
+//   - float64: Mocked events per second
+//   - error: Mocked error if the operation should fail
+//
+// Example:
+//
+//	mockCache.On("Rate", mock.Anything, "rate:login:42", 10*time.Second).Return(12.5, nil)
+func (m *MockCache) Rate(ctx context.Context, key string, window time.Duration) (float64, error) {
+	ret := m.Called(ctx, key, window)
+	var r0 float64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration) (float64, error)); ok {
+		return rf(ctx, key, window)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration) float64); ok {
+		r0 = rf(ctx, key, window)
+	} else {
+		r0 = ret.Get(0).(float64)
+	}
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Duration) error); ok {
+		r1 = rf(ctx, key, window)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// TTL mocks the remaining time-to-live lookup method.
+// This method simulates reading how long a key has left before it expires,
+// allowing tests to script expiring, persistent and missing keys.
+//
+// The mock supports various return scenarios:
+//   - Return a duration to simulate an expiring key
+//   - Return cache.ErrCacheNil to simulate a missing key
+//   - Return the implementation's no-expiry sentinel, such as redis.ErrNoExpiry, for persistent keys
+//   - Use function-based returns for dynamic behavior
+//
+// Parameters:
+//   - ctx: Context for request lifecycle management and cancellation
+//   - key: Cache key to inspect
+//
+// Returns:
+//   - time.Duration: Mocked remaining time-to-live
+//   - error: Mocked error if the operation should fail
+//
+// Example:
+//
+//	mockCache.On("TTL", mock.Anything, "session:42").Return(250*time.Millisecond, nil)
+func (m *MockCache) TTL(ctx context.Context, key string) (time.Duration, error) {
+	ret := m.Called(ctx, key)
+	var r0 time.Duration
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (time.Duration, error)); ok {
+		return rf(ctx, key)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) time.Duration); ok {
+		r0 = rf(ctx, key)
+	} else {
+		r0 = ret.Get(0).(time.Duration)
+	}
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Heartbeat mocks the atomic heartbeat method.
+// This method simulates recording a heartbeat and reading the previous one,
+// allowing tests to script first, regular and late heartbeats.
+//
+// The mock supports various return scenarios:
+//   - Return a time to simulate a previous heartbeat
+//   - Return cache.ErrCacheNil to simulate the first heartbeat
+//   - Use function-based returns for dynamic behavior
+//
+// Parameters:
+//   - ctx: Context for request lifecycle management and cancellation
+//   - key: Cache key holding the heartbeat
+//   - ttl: Time after which the lease lapses
+//
+// Returns:
+//   - time.Time: Mocked time of the previous heartbeat
+//   - error: Mocked error if the operation should fail
+//
+// Example:
+//
+//	mockCache.On("Heartbeat", mock.Anything, "lease:worker-7", 30*time.Second).Return(time.Time{}, cache.ErrCacheNil)
+func (m *MockCache) Heartbeat(ctx context.Context, key string, ttl time.Duration) (time.Time, error) {
+	ret := m.Called(ctx, key, ttl)
+	var r0 time.Time
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration) (time.Time, error)); ok {
+		return rf(ctx, key, ttl)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration) time.Time); ok {
+		r0 = rf(ctx, key, ttl)
+	} else {
+		r0 = ret.Get(0).(time.Time)
+	}
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Duration) error); ok {
+		r1 = rf(ctx, key, ttl)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// ExpirePattern mocks the pattern-based expiration method.
+// This method simulates setting the TTL of every key matching a pattern,
+// allowing tests to verify bulk TTL refreshes and simulate their failures.
+//
+// Parameters:
+//   - ctx: Context for request lifecycle management and cancellation
+//   - pattern: Pattern of the keys to update
+//   - ttl: New time-to-live
+//
+// Returns:
+//   - int64: Mocked number of keys updated
+//   - error: Mocked error if the operation should fail
+//
+// Example:
+//
+//	mockCache.On("ExpirePattern", mock.Anything, "tenant:42:*", time.Hour).Return(int64(12), nil)
+func (m *MockCache) ExpirePattern(ctx context.Context, pattern string, ttl time.Duration) (int64, error) {
+	ret := m.Called(ctx, pattern, ttl)
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration) (int64, error)); ok {
+		return rf(ctx, pattern, ttl)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration) int64); ok {
+		r0 = rf(ctx, pattern, ttl)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Duration) error); ok {
+		r1 = rf(ctx, pattern, ttl)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Enqueue mocks the reliable queue append method.
+// This method simulates appending jobs to a queue,
+// allowing tests to verify what producers enqueue.
+//
+// Parameters:
+//   - ctx: Context for request lifecycle management and cancellation
+//   - queue: Name of the queue
+//   - jobs: Jobs to append
+//
+// Returns:
+//   - error: Mocked error if the operation should fail
+//
+// Example:
+//
+//	mockCache.On("Enqueue", mock.Anything, "jobs:emails", []string{"job-1"}).Return(nil)
+func (m *MockCache) Enqueue(ctx context.Context, queue string, jobs ...string) error {
+	ret := m.Called(ctx, queue, jobs)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, ...string) error); ok {
+		r0 = rf(ctx, queue, jobs...)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// Reserve mocks the reliable queue reserve method.
+// This method simulates reserving the next job of a queue,
+// allowing tests to script jobs, empty queues and failures for consumers.
+//
+// Parameters:
+//   - ctx: Context for request lifecycle management and cancellation
+//   - queue: Name of the queue
+//   - visibility: How long the job stays reserved
+//
+// Returns:
+//   - string: Mocked job
+//   - string: Mocked acknowledgement token
+//   - error: Mocked error if the operation should fail
+//
+// Example:
+//
+//	mockCache.On("Reserve", mock.Anything, "jobs:emails", time.Minute).Return("job-1", "token", nil)
+func (m *MockCache) Reserve(ctx context.Context, queue string, visibility time.Duration) (string, string, error) {
+	ret := m.Called(ctx, queue, visibility)
+	var r0, r1 string
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration) (string, string, error)); ok {
+		return rf(ctx, queue, visibility)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration) string); ok {
+		r0 = rf(ctx, queue, visibility)
+	} else {
+		r0 = ret.String(0)
+	}
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Duration) string); ok {
+		r1 = rf(ctx, queue, visibility)
+	} else {
+		r1 = ret.String(1)
+	}
+	if rf, ok := ret.Get(2).(func(context.Context, string, time.Duration) error); ok {
+		r2 = rf(ctx, queue, visibility)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// Ack mocks the reliable queue acknowledgement method.
+// This method simulates acknowledging a reserved job,
+// allowing tests to verify that consumers acknowledge processed jobs.
+//
+// Parameters:
+//   - ctx: Context for request lifecycle management and cancellation
+//   - queue: Name of the queue
+//   - ackToken: Token returned by Reserve
+//
+// Returns:
+//   - error: Mocked error if the operation should fail
+//
+// Example:
+//
+//	mockCache.On("Ack", mock.Anything, "jobs:emails", "token").Return(nil)
+func (m *MockCache) Ack(ctx context.Context, queue, ackToken string) error {
+	ret := m.Called(ctx, queue, ackToken)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, queue, ackToken)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// GetDel mocks the atomic read-and-delete method.
+// This method simulates reading a value and deleting its key in one step,
+// allowing tests to script redeemed and unknown one-time tokens.
+//
+// Parameters:
+//   - ctx: Context for request lifecycle management and cancellation
+//   - key: Cache key to read and delete
+//
+// Returns:
+//   - string: Mocked value
+//   - error: Mocked error if the operation should fail
+//
+// Example:
+//
+//	mockCache.On("GetDel", mock.Anything, "reset-token:abc").Return("42", nil)
+func (m *MockCache) GetDel(ctx context.Context, key string) (string, error) {
+	ret := m.Called(ctx, key)
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (string, error)); ok {
+		return rf(ctx, key)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = rf(ctx, key)
+	} else {
+		r0 = ret.String(0)
+	}
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// SampleKeys mocks the random key sampling method.
+// This method simulates drawing a random sample of keys,
+// allowing tests to feed audit jobs a known sample.
+//
+// Parameters:
+//   - ctx: Context for request lifecycle management and cancellation
+//   - n: Maximum number of keys to return
+//
+// Returns:
+//   - []string: Mocked sample
+//   - error: Mocked error if the operation should fail
+//
+// Example:
+//
+//	mockCache.On("SampleKeys", mock.Anything, 100).Return([]string{"user:1", "session:9"}, nil)
+func (m *MockCache) SampleKeys(ctx context.Context, n int) ([]string, error) {
+	ret := m.Called(ctx, n)
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]string, error)); ok {
+		return rf(ctx, n)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []string); ok {
+		r0 = rf(ctx, n)
+	} else if ret.Get(0) != nil {
+		r0 = ret.Get(0).([]string)
+	}
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, n)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// GetSet mocks the value swap method.
+// This method simulates storing a value and returning the one it replaced,
+// allowing tests to script previous values and missing keys.
+//
+// Parameters:
+//   - ctx: Context for request lifecycle management and cancellation
+//   - key: Cache key to swap
+//   - value: Value replacing the current one
+//
+// Returns:
+//   - string: Mocked previous value
+//   - error: Mocked error if the operation should fail
+//
+// Example:
+//
+//	mockCache.On("GetSet", mock.Anything, "flag:beta", "on").Return("off", nil)
+func (m *MockCache) GetSet(ctx context.Context, key string, value interface{}) (string, error) {
+	ret := m.Called(ctx, key, value)
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, interface{}) (string, error)); ok {
+		return rf(ctx, key, value)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, interface{}) string); ok {
+		r0 = rf(ctx, key, value)
+	} else {
+		r0 = ret.String(0)
+	}
+	if rf, ok := ret.Get(1).(func(context.Context, string, interface{}) error); ok {
+		r1 = rf(ctx, key, value)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// GetSetKeepTTL mocks the TTL-preserving value swap method.
+// This method simulates storing a value, keeping the key's TTL, and returning the one it replaced,
+// allowing tests to script previous values and missing keys.
+//
+// Parameters:
+//   - ctx: Context for request lifecycle management and cancellation
+//   - key: Cache key to swap
+//   - value: Value replacing the current one
+//
+// Returns:
+//   - string: Mocked previous value
+//   - error: Mocked error if the operation should fail
+//
+// Example:
+//
+//	mockCache.On("GetSetKeepTTL", mock.Anything, "flag:beta", "on").Return("off", nil)
+func (m *MockCache) GetSetKeepTTL(ctx context.Context, key string, value interface{}) (string, error) {
+	ret := m.Called(ctx, key, value)
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, interface{}) (string, error)); ok {
+		return rf(ctx, key, value)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, interface{}) string); ok {
+		r0 = rf(ctx, key, value)
+	} else {
+		r0 = ret.String(0)
+	}
+	if rf, ok := ret.Get(1).(func(context.Context, string, interface{}) error); ok {
+		r1 = rf(ctx, key, value)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// DelCount mocks the counting delete method.
+// This method simulates deleting keys and reporting how many of them existed,
+// allowing tests to script deletes that race with other clients.
+//
+// Parameters:
+//   - ctx: Context for request lifecycle management and cancellation
+//   - keys: Keys to delete
+//
+// Returns:
+//   - int64: Mocked number of keys deleted
+//   - error: Mocked error if the operation should fail
+//
+// Example:
+//
+//	mockCache.On("DelCount", mock.Anything, "job:1", "job:2").Return(int64(1), nil)
+func (m *MockCache) DelCount(ctx context.Context, keys ...string) (int64, error) {
+	_keys := make([]interface{}, len(keys))
+	for _idx := range keys {
+		_keys[_idx] = keys[_idx]
+	}
+	var _args []interface{}
+	_args = append(_args, ctx)
+	_args = append(_args, _keys...)
+	ret := m.Called(_args...)
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, ...string) (int64, error)); ok {
+		return rf(ctx, keys...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, ...string) int64); ok {
+		r0 = rf(ctx, keys...)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if rf, ok := ret.Get(1).(func(context.Context, ...string) error); ok {
+		r1 = rf(ctx, keys...)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// WalkKeys mocks the batched key listing method.
+// This method simulates handing the keys matching a pattern to fn in batches;
+// return a function with the same signature to call fn with scripted batches.
+//
+// Parameters:
+//   - ctx: Context for request lifecycle management and cancellation
+//   - pattern: Pattern of the keys to list
+//   - fn: Function receiving each batch
+//
+// Returns:
+//   - error: Mocked error if the operation should fail
+//
+// Example:
+//
+//	mockCache.On("WalkKeys", mock.Anything, "job:*", mock.Anything).Return(
+//	    func(ctx context.Context, pattern string, fn func(keys []string) error) error {
+//	        return fn([]string{"job:1", "job:2"})
+//	    })
+func (m *MockCache) WalkKeys(ctx context.Context, pattern string, fn func(keys []string) error) error {
+	ret := m.Called(ctx, pattern, fn)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, func(keys []string) error) error); ok {
+		r0 = rf(ctx, pattern, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// CheckPatternDelete mocks the pattern delete guard.
+// This method simulates a cache that allows or refuses pattern-based deletes,
+// allowing tests to verify that helpers honour the guard.
+//
+// Returns:
+//   - error: Mocked error if pattern-based deletes should be refused
+//
+// Example:
+//
+//	mockCache.On("CheckPatternDelete").Return(redis.ErrOperationDisabled)
+func (m *MockCache) CheckPatternDelete() error {
+	ret := m.Called()
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// SetXX mocks the conditional overwrite method.
+// This method simulates storing a value under a key only if it still exists,
+// allowing tests to script updates racing with deletes.
+//
+// Parameters:
+//   - ctx: Context for request lifecycle management and cancellation
+//   - key: Cache key to store
+//   - value: Value to store
+//   - ttl: Time to live of the key
+//
+// Returns:
+//   - bool: Mocked result, true if the value was stored
+//   - error: Mocked error if the operation should fail
+//
+// Example:
+//
+//	mockCache.On("SetXX", mock.Anything, "session:1", mock.Anything, time.Minute).Return(false, nil)
+func (m *MockCache) SetXX(ctx context.Context, key string, value interface{}, ttl time.Duration) (bool, error) {
+	ret := m.Called(ctx, key, value, ttl)
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, interface{}, time.Duration) (bool, error)); ok {
+		return rf(ctx, key, value, ttl)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, interface{}, time.Duration) bool); ok {
+		r0 = rf(ctx, key, value, ttl)
+	} else {
+		r0 = ret.Bool(0)
+	}
+	if rf, ok := ret.Get(1).(func(context.Context, string, interface{}, time.Duration) error); ok {
+		r1 = rf(ctx, key, value, ttl)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
 // NewMockCache creates and configures a new MockCache instance for testing.
 // This constructor sets up the mock with proper test integration and automatic
 // expectation verification when the test completes.
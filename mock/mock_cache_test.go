@@ -7,7 +7,10 @@ import (
 	"testing"
 	"time"
 
+	testifymock "github.com/stretchr/testify/mock"
+	"github.com/zeroxsolutions/banshee"
 	"github.com/zeroxsolutions/banshee/mock"
+	"github.com/zeroxsolutions/barbatos/cache"
 )
 
 // TestMockCache_IsConnected_IsTrue tests the MockCache's IsConnected method when the mock returns true.
@@ -358,3 +361,1897 @@ func TestMockCache_Close_NilErr(t *testing.T) {
 
 	mockCache.AssertExpectations(t)
 }
+
+// TestMockCache_IncrByCeil_Err tests the IncrByCeil method when an error is returned.
+func TestMockCache_IncrByCeil_Err(t *testing.T) {
+	mockCache := mock.NewMockCache(t).(*mock.MockCache)
+
+	ctx := context.Background()
+
+	key := "seats"
+	delta := int64(1)
+	ceil := int64(10)
+
+	r2 := errors.New("error test")
+
+	mockCache.On("IncrByCeil", ctx, key, delta, ceil).Return(int64(0), false, r2)
+
+	value, applied, err := mockCache.IncrByCeil(ctx, key, delta, ceil)
+
+	if !errors.Is(err, r2) {
+		t.FailNow()
+	}
+
+	if value != 0 || applied {
+		t.FailNow()
+	}
+
+	mockCache.AssertExpectations(t)
+}
+
+// TestMockCache_IncrByCeil_NilErr tests the IncrByCeil method when the increment is refused at the ceiling.
+func TestMockCache_IncrByCeil_NilErr(t *testing.T) {
+	mockCache := mock.NewMockCache(t).(*mock.MockCache)
+
+	ctx := context.Background()
+
+	key := "seats"
+	delta := int64(1)
+	ceil := int64(10)
+
+	mockCache.On("IncrByCeil", ctx, key, delta, ceil).Return(ceil, false, nil)
+
+	value, applied, err := mockCache.IncrByCeil(ctx, key, delta, ceil)
+
+	if err != nil {
+		t.FailNow()
+	}
+
+	if value != ceil || applied {
+		t.FailNow()
+	}
+
+	mockCache.AssertExpectations(t)
+}
+
+// TestMockCache_SetAndPublish_Err tests the SetAndPublish method when an error is returned.
+func TestMockCache_SetAndPublish_Err(t *testing.T) {
+	mockCache := mock.NewMockCache(t).(*mock.MockCache)
+
+	ctx := context.Background()
+
+	key := "key"
+	value := "value"
+	ttl := 10 * time.Second
+	channel := "channel"
+
+	r0 := errors.New("error test")
+
+	mockCache.On("SetAndPublish", ctx, key, value, ttl, channel).Return(r0)
+
+	if err := mockCache.SetAndPublish(ctx, key, value, ttl, channel); !errors.Is(err, r0) {
+		t.FailNow()
+	}
+
+	mockCache.AssertExpectations(t)
+}
+
+// TestMockCache_SetAndPublish_NilErr tests the SetAndPublish method when no error is returned.
+func TestMockCache_SetAndPublish_NilErr(t *testing.T) {
+	mockCache := mock.NewMockCache(t).(*mock.MockCache)
+
+	ctx := context.Background()
+
+	key := "key"
+	value := "value"
+	ttl := 10 * time.Second
+	channel := "channel"
+
+	mockCache.On("SetAndPublish", ctx, key, value, ttl, channel).Return(nil)
+
+	if err := mockCache.SetAndPublish(ctx, key, value, ttl, channel); err != nil {
+		t.FailNow()
+	}
+
+	mockCache.AssertExpectations(t)
+}
+
+// TestMockCache_Expire_Err tests the Expire method when an error is returned.
+func TestMockCache_Expire_Err(t *testing.T) {
+	mockCache := mock.NewMockCache(t).(*mock.MockCache)
+
+	ctx := context.Background()
+
+	key := "key"
+	ttl := 10 * time.Second
+
+	r1 := errors.New("error test")
+
+	mockCache.On("Expire", ctx, key, ttl).Return(false, r1)
+
+	found, err := mockCache.Expire(ctx, key, ttl)
+
+	if !errors.Is(err, r1) {
+		t.FailNow()
+	}
+
+	if found {
+		t.FailNow()
+	}
+
+	mockCache.AssertExpectations(t)
+}
+
+// TestMockCache_Expire_NilErr tests the Expire method when no error is returned and the key exists.
+func TestMockCache_Expire_NilErr(t *testing.T) {
+	mockCache := mock.NewMockCache(t).(*mock.MockCache)
+
+	ctx := context.Background()
+
+	key := "key"
+	ttl := 10 * time.Second
+
+	mockCache.On("Expire", ctx, key, ttl).Return(true, nil)
+
+	found, err := mockCache.Expire(ctx, key, ttl)
+
+	if err != nil {
+		t.FailNow()
+	}
+
+	if !found {
+		t.FailNow()
+	}
+
+	mockCache.AssertExpectations(t)
+}
+
+// TestMockCache_ConfigGet_Err tests the ConfigGet method when an error is returned.
+func TestMockCache_ConfigGet_Err(t *testing.T) {
+	mockCache := mock.NewMockCache(t).(*mock.MockCache)
+
+	ctx := context.Background()
+
+	parameter := "maxmemory"
+
+	r1 := errors.New("error test")
+
+	mockCache.On("ConfigGet", ctx, parameter).Return(nil, r1)
+
+	config, err := mockCache.ConfigGet(ctx, parameter)
+
+	if !errors.Is(err, r1) {
+		t.FailNow()
+	}
+
+	if config != nil {
+		t.FailNow()
+	}
+
+	mockCache.AssertExpectations(t)
+}
+
+// TestMockCache_ConfigGet_NilErr tests the ConfigGet method when no error is returned and parameters are retrieved.
+func TestMockCache_ConfigGet_NilErr(t *testing.T) {
+	mockCache := mock.NewMockCache(t).(*mock.MockCache)
+
+	ctx := context.Background()
+
+	parameter := "maxmemory"
+	expected := map[string]string{"maxmemory": "0"}
+
+	mockCache.On("ConfigGet", ctx, parameter).Return(expected, nil)
+
+	config, err := mockCache.ConfigGet(ctx, parameter)
+
+	if err != nil {
+		t.FailNow()
+	}
+
+	if config["maxmemory"] != "0" {
+		t.FailNow()
+	}
+
+	mockCache.AssertExpectations(t)
+}
+
+// TestMockCache_ConfigSet_Err tests the ConfigSet method when an error is returned.
+func TestMockCache_ConfigSet_Err(t *testing.T) {
+	mockCache := mock.NewMockCache(t).(*mock.MockCache)
+
+	ctx := context.Background()
+
+	parameter := "maxmemory-policy"
+	value := "allkeys-lru"
+
+	r0 := errors.New("error test")
+
+	mockCache.On("ConfigSet", ctx, parameter, value).Return(r0)
+
+	if err := mockCache.ConfigSet(ctx, parameter, value); !errors.Is(err, r0) {
+		t.FailNow()
+	}
+
+	mockCache.AssertExpectations(t)
+}
+
+// TestMockCache_ConfigSet_NilErr tests the ConfigSet method when no error is returned.
+func TestMockCache_ConfigSet_NilErr(t *testing.T) {
+	mockCache := mock.NewMockCache(t).(*mock.MockCache)
+
+	ctx := context.Background()
+
+	parameter := "maxmemory-policy"
+	value := "allkeys-lru"
+
+	mockCache.On("ConfigSet", ctx, parameter, value).Return(nil)
+
+	if err := mockCache.ConfigSet(ctx, parameter, value); err != nil {
+		t.FailNow()
+	}
+
+	mockCache.AssertExpectations(t)
+}
+
+// TestMockCache_GetEx_Err tests the GetEx method when an error is returned.
+func TestMockCache_GetEx_Err(t *testing.T) {
+	mockCache := mock.NewMockCache(t).(*mock.MockCache)
+
+	ctx := context.Background()
+
+	key := "key"
+	ttl := 10 * time.Second
+
+	r1 := errors.New("error test")
+
+	mockCache.On("GetEx", ctx, key, ttl).Return("", r1)
+
+	v, err := mockCache.GetEx(ctx, key, ttl)
+
+	if !errors.Is(err, r1) {
+		t.FailNow()
+	}
+
+	if v != "" {
+		t.FailNow()
+	}
+
+	mockCache.AssertExpectations(t)
+}
+
+// TestMockCache_GetEx_NilErr tests the GetEx method when no error is returned and a value is successfully retrieved.
+func TestMockCache_GetEx_NilErr(t *testing.T) {
+	mockCache := mock.NewMockCache(t).(*mock.MockCache)
+
+	ctx := context.Background()
+
+	key := "key"
+	value := "value"
+	ttl := 10 * time.Second
+
+	mockCache.On("GetEx", ctx, key, ttl).Return(value, nil)
+
+	v, err := mockCache.GetEx(ctx, key, ttl)
+
+	if err != nil {
+		t.FailNow()
+	}
+
+	if v != value {
+		t.FailNow()
+	}
+
+	mockCache.AssertExpectations(t)
+}
+
+// TestMockCache_GetExPersist_Err tests the GetExPersist method when an error is returned.
+func TestMockCache_GetExPersist_Err(t *testing.T) {
+	mockCache := mock.NewMockCache(t).(*mock.MockCache)
+
+	ctx := context.Background()
+
+	key := "key"
+
+	r1 := errors.New("error test")
+
+	mockCache.On("GetExPersist", ctx, key).Return("", r1)
+
+	v, err := mockCache.GetExPersist(ctx, key)
+
+	if !errors.Is(err, r1) {
+		t.FailNow()
+	}
+
+	if v != "" {
+		t.FailNow()
+	}
+
+	mockCache.AssertExpectations(t)
+}
+
+// TestMockCache_GetExPersist_NilErr tests the GetExPersist method when no error is returned and a value is successfully retrieved.
+func TestMockCache_GetExPersist_NilErr(t *testing.T) {
+	mockCache := mock.NewMockCache(t).(*mock.MockCache)
+
+	ctx := context.Background()
+
+	key := "key"
+	value := "value"
+
+	mockCache.On("GetExPersist", ctx, key).Return(value, nil)
+
+	v, err := mockCache.GetExPersist(ctx, key)
+
+	if err != nil {
+		t.FailNow()
+	}
+
+	if v != value {
+		t.FailNow()
+	}
+
+	mockCache.AssertExpectations(t)
+}
+
+// TestMockCache_HGetAll_Err tests the HGetAll method when an error is returned.
+func TestMockCache_HGetAll_Err(t *testing.T) {
+	mockCache := mock.NewMockCache(t).(*mock.MockCache)
+
+	ctx := context.Background()
+
+	key := "key"
+
+	r1 := errors.New("error test")
+
+	mockCache.On("HGetAll", ctx, key).Return(nil, r1)
+
+	fields, err := mockCache.HGetAll(ctx, key)
+
+	if !errors.Is(err, r1) {
+		t.FailNow()
+	}
+
+	if fields != nil {
+		t.FailNow()
+	}
+
+	mockCache.AssertExpectations(t)
+}
+
+// TestMockCache_HGetAll_NilErr tests the HGetAll method when no error is returned and fields are retrieved.
+func TestMockCache_HGetAll_NilErr(t *testing.T) {
+	mockCache := mock.NewMockCache(t).(*mock.MockCache)
+
+	ctx := context.Background()
+
+	key := "key"
+	expected := map[string]string{"field": "value"}
+
+	mockCache.On("HGetAll", ctx, key).Return(expected, nil)
+
+	fields, err := mockCache.HGetAll(ctx, key)
+
+	if err != nil {
+		t.FailNow()
+	}
+
+	if fields["field"] != "value" {
+		t.FailNow()
+	}
+
+	mockCache.AssertExpectations(t)
+}
+
+// TestMockCache_HGetAllMany_Err tests the HGetAllMany method when an error is returned.
+func TestMockCache_HGetAllMany_Err(t *testing.T) {
+	mockCache := mock.NewMockCache(t).(*mock.MockCache)
+
+	ctx := context.Background()
+
+	keys := []string{"key1", "key2"}
+
+	r1 := errors.New("error test")
+
+	mockCache.On("HGetAllMany", ctx, keys).Return(nil, r1)
+
+	hashes, err := mockCache.HGetAllMany(ctx, keys)
+
+	if !errors.Is(err, r1) {
+		t.FailNow()
+	}
+
+	if hashes != nil {
+		t.FailNow()
+	}
+
+	mockCache.AssertExpectations(t)
+}
+
+// TestMockCache_HGetAllMany_NilErr tests the HGetAllMany method when no error is returned and hashes are retrieved.
+func TestMockCache_HGetAllMany_NilErr(t *testing.T) {
+	mockCache := mock.NewMockCache(t).(*mock.MockCache)
+
+	ctx := context.Background()
+
+	keys := []string{"key1", "key2"}
+	expected := map[string]map[string]string{"key1": {"field": "value"}}
+
+	mockCache.On("HGetAllMany", ctx, keys).Return(expected, nil)
+
+	hashes, err := mockCache.HGetAllMany(ctx, keys)
+
+	if err != nil {
+		t.FailNow()
+	}
+
+	if len(hashes) != 1 || hashes["key1"]["field"] != "value" {
+		t.FailNow()
+	}
+
+	mockCache.AssertExpectations(t)
+}
+
+// TestMockCache_LPopN_Err tests the LPopN method when an error is returned.
+func TestMockCache_LPopN_Err(t *testing.T) {
+	mockCache := mock.NewMockCache(t).(*mock.MockCache)
+
+	ctx := context.Background()
+
+	key := "key"
+	count := int64(10)
+
+	r1 := errors.New("error test")
+
+	mockCache.On("LPopN", ctx, key, count).Return(nil, r1)
+
+	items, err := mockCache.LPopN(ctx, key, count)
+
+	if !errors.Is(err, r1) {
+		t.FailNow()
+	}
+
+	if items != nil {
+		t.FailNow()
+	}
+
+	mockCache.AssertExpectations(t)
+}
+
+// TestMockCache_LPopN_NilErr tests the LPopN method when no error is returned and a partial batch is popped.
+func TestMockCache_LPopN_NilErr(t *testing.T) {
+	mockCache := mock.NewMockCache(t).(*mock.MockCache)
+
+	ctx := context.Background()
+
+	key := "key"
+	count := int64(10)
+	expected := []string{"item1", "item2"}
+
+	mockCache.On("LPopN", ctx, key, count).Return(expected, nil)
+
+	items, err := mockCache.LPopN(ctx, key, count)
+
+	if err != nil {
+		t.FailNow()
+	}
+
+	if len(items) != 2 || items[0] != "item1" {
+		t.FailNow()
+	}
+
+	mockCache.AssertExpectations(t)
+}
+
+// TestMockCache_ZAdd_Err tests the ZAdd method when an error is returned.
+func TestMockCache_ZAdd_Err(t *testing.T) {
+	mockCache := mock.NewMockCache(t).(*mock.MockCache)
+
+	ctx := context.Background()
+
+	key := "key"
+	score := 1.5
+	member := "member"
+
+	r0 := errors.New("error test")
+
+	mockCache.On("ZAdd", ctx, key, score, member).Return(r0)
+
+	err := mockCache.ZAdd(ctx, key, score, member)
+
+	if !errors.Is(err, r0) {
+		t.FailNow()
+	}
+
+	mockCache.AssertExpectations(t)
+}
+
+// TestMockCache_ZAdd_NilErr tests the ZAdd method when no error is returned.
+func TestMockCache_ZAdd_NilErr(t *testing.T) {
+	mockCache := mock.NewMockCache(t).(*mock.MockCache)
+
+	ctx := context.Background()
+
+	key := "key"
+	score := 1.5
+	member := "member"
+
+	mockCache.On("ZAdd", ctx, key, score, member).Return(nil)
+
+	err := mockCache.ZAdd(ctx, key, score, member)
+
+	if err != nil {
+		t.FailNow()
+	}
+
+	mockCache.AssertExpectations(t)
+}
+
+// TestMockCache_ZRangeByScore_Err tests the ZRangeByScore method when an error is returned.
+func TestMockCache_ZRangeByScore_Err(t *testing.T) {
+	mockCache := mock.NewMockCache(t).(*mock.MockCache)
+
+	ctx := context.Background()
+
+	key := "key"
+
+	r1 := errors.New("error test")
+
+	mockCache.On("ZRangeByScore", ctx, key, 0.0, 10.0).Return(nil, r1)
+
+	members, err := mockCache.ZRangeByScore(ctx, key, 0, 10)
+
+	if !errors.Is(err, r1) {
+		t.FailNow()
+	}
+
+	if members != nil {
+		t.FailNow()
+	}
+
+	mockCache.AssertExpectations(t)
+}
+
+// TestMockCache_ZRangeByScore_NilErr tests the ZRangeByScore method when no error is returned and members are retrieved.
+func TestMockCache_ZRangeByScore_NilErr(t *testing.T) {
+	mockCache := mock.NewMockCache(t).(*mock.MockCache)
+
+	ctx := context.Background()
+
+	key := "key"
+
+	mockCache.On("ZRangeByScore", ctx, key, 0.0, 10.0).Return([]string{"a", "b"}, nil)
+
+	members, err := mockCache.ZRangeByScore(ctx, key, 0, 10)
+
+	if err != nil {
+		t.FailNow()
+	}
+
+	if len(members) != 2 || members[0] != "a" {
+		t.FailNow()
+	}
+
+	mockCache.AssertExpectations(t)
+}
+
+// TestMockCache_ZRemRangeByScore_Err tests the ZRemRangeByScore method when an error is returned.
+func TestMockCache_ZRemRangeByScore_Err(t *testing.T) {
+	mockCache := mock.NewMockCache(t).(*mock.MockCache)
+
+	ctx := context.Background()
+
+	key := "key"
+
+	r1 := errors.New("error test")
+
+	mockCache.On("ZRemRangeByScore", ctx, key, 0.0, 10.0).Return(int64(0), r1)
+
+	removed, err := mockCache.ZRemRangeByScore(ctx, key, 0, 10)
+
+	if !errors.Is(err, r1) {
+		t.FailNow()
+	}
+
+	if removed != 0 {
+		t.FailNow()
+	}
+
+	mockCache.AssertExpectations(t)
+}
+
+// TestMockCache_ZRemRangeByScore_NilErr tests the ZRemRangeByScore method when no error is returned.
+func TestMockCache_ZRemRangeByScore_NilErr(t *testing.T) {
+	mockCache := mock.NewMockCache(t).(*mock.MockCache)
+
+	ctx := context.Background()
+
+	key := "key"
+
+	mockCache.On("ZRemRangeByScore", ctx, key, 0.0, 10.0).Return(int64(3), nil)
+
+	removed, err := mockCache.ZRemRangeByScore(ctx, key, 0, 10)
+
+	if err != nil {
+		t.FailNow()
+	}
+
+	if removed != 3 {
+		t.FailNow()
+	}
+
+	mockCache.AssertExpectations(t)
+}
+
+// TestMockCache_ZRem_Err tests the ZRem method when an error is returned.
+func TestMockCache_ZRem_Err(t *testing.T) {
+	mockCache := mock.NewMockCache(t).(*mock.MockCache)
+
+	ctx := context.Background()
+
+	key := "key"
+
+	r1 := errors.New("error test")
+
+	mockCache.On("ZRem", ctx, key, "a", "b").Return(int64(0), r1)
+
+	removed, err := mockCache.ZRem(ctx, key, "a", "b")
+
+	if !errors.Is(err, r1) {
+		t.FailNow()
+	}
+
+	if removed != 0 {
+		t.FailNow()
+	}
+
+	mockCache.AssertExpectations(t)
+}
+
+// TestMockCache_ZRem_NilErr tests the ZRem method when no error is returned.
+func TestMockCache_ZRem_NilErr(t *testing.T) {
+	mockCache := mock.NewMockCache(t).(*mock.MockCache)
+
+	ctx := context.Background()
+
+	key := "key"
+
+	mockCache.On("ZRem", ctx, key, "a", "b").Return(int64(2), nil)
+
+	removed, err := mockCache.ZRem(ctx, key, "a", "b")
+
+	if err != nil {
+		t.FailNow()
+	}
+
+	if removed != 2 {
+		t.FailNow()
+	}
+
+	mockCache.AssertExpectations(t)
+}
+
+// TestMockCache_GetByPattern_Err tests the GetByPattern method when an error is returned.
+func TestMockCache_GetByPattern_Err(t *testing.T) {
+	mockCache := mock.NewMockCache(t).(*mock.MockCache)
+
+	ctx := context.Background()
+
+	pattern := "key-*"
+
+	r1 := errors.New("error test")
+
+	mockCache.On("GetByPattern", ctx, pattern).Return(nil, r1)
+
+	values, err := mockCache.GetByPattern(ctx, pattern)
+
+	if !errors.Is(err, r1) {
+		t.FailNow()
+	}
+
+	if values != nil {
+		t.FailNow()
+	}
+
+	mockCache.AssertExpectations(t)
+}
+
+// TestMockCache_GetByPattern_NilErr tests the GetByPattern method when no error is returned and values are retrieved.
+func TestMockCache_GetByPattern_NilErr(t *testing.T) {
+	mockCache := mock.NewMockCache(t).(*mock.MockCache)
+
+	ctx := context.Background()
+
+	pattern := "key-*"
+	expected := map[string]string{"key-1": "value-1"}
+
+	mockCache.On("GetByPattern", ctx, pattern).Return(expected, nil)
+
+	values, err := mockCache.GetByPattern(ctx, pattern)
+
+	if err != nil {
+		t.FailNow()
+	}
+
+	if values["key-1"] != "value-1" {
+		t.FailNow()
+	}
+
+	mockCache.AssertExpectations(t)
+}
+
+// TestMockCache_ZRange_Err tests the ZRange method when an error is returned.
+func TestMockCache_ZRange_Err(t *testing.T) {
+	mockCache := mock.NewMockCache(t).(*mock.MockCache)
+
+	ctx := context.Background()
+
+	key := "key"
+
+	r1 := errors.New("error test")
+
+	mockCache.On("ZRange", ctx, key, int64(0), int64(-1)).Return(nil, r1)
+
+	_, err := mockCache.ZRange(ctx, key, 0, -1)
+
+	if !errors.Is(err, r1) {
+		t.FailNow()
+	}
+
+	mockCache.AssertExpectations(t)
+}
+
+// TestMockCache_ZRange_NilErr tests the ZRange method when no error is returned.
+func TestMockCache_ZRange_NilErr(t *testing.T) {
+	mockCache := mock.NewMockCache(t).(*mock.MockCache)
+
+	ctx := context.Background()
+
+	key := "key"
+
+	mockCache.On("ZRange", ctx, key, int64(0), int64(-1)).Return([]string{"a", "b"}, nil)
+
+	values, err := mockCache.ZRange(ctx, key, 0, -1)
+
+	if err != nil {
+		t.FailNow()
+	}
+
+	if len(values) != 2 || values[0] != "a" {
+		t.FailNow()
+	}
+
+	mockCache.AssertExpectations(t)
+}
+
+// TestMockCache_ZCard_Err tests the ZCard method when an error is returned.
+func TestMockCache_ZCard_Err(t *testing.T) {
+	mockCache := mock.NewMockCache(t).(*mock.MockCache)
+
+	ctx := context.Background()
+
+	key := "key"
+
+	r1 := errors.New("error test")
+
+	mockCache.On("ZCard", ctx, key).Return(int64(0), r1)
+
+	_, err := mockCache.ZCard(ctx, key)
+
+	if !errors.Is(err, r1) {
+		t.FailNow()
+	}
+
+	mockCache.AssertExpectations(t)
+}
+
+// TestMockCache_ZCard_NilErr tests the ZCard method when no error is returned.
+func TestMockCache_ZCard_NilErr(t *testing.T) {
+	mockCache := mock.NewMockCache(t).(*mock.MockCache)
+
+	ctx := context.Background()
+
+	key := "key"
+
+	mockCache.On("ZCard", ctx, key).Return(int64(3), nil)
+
+	values, err := mockCache.ZCard(ctx, key)
+
+	if err != nil {
+		t.FailNow()
+	}
+
+	if values != 3 {
+		t.FailNow()
+	}
+
+	mockCache.AssertExpectations(t)
+}
+
+// TestMockCache_ZPopMin_Err tests the ZPopMin method when the sorted set is empty.
+func TestMockCache_ZPopMin_Err(t *testing.T) {
+	mockCache := mock.NewMockCache(t).(*mock.MockCache)
+
+	ctx := context.Background()
+
+	key := "key"
+
+	mockCache.On("ZPopMin", ctx, key).Return("", 0.0, cache.ErrCacheNil)
+
+	member, _, err := mockCache.ZPopMin(ctx, key)
+
+	if !errors.Is(err, cache.ErrCacheNil) {
+		t.FailNow()
+	}
+
+	if member != "" {
+		t.FailNow()
+	}
+
+	mockCache.AssertExpectations(t)
+}
+
+// TestMockCache_ZPopMin_NilErr tests the ZPopMin method when no error is returned and a member is popped.
+func TestMockCache_ZPopMin_NilErr(t *testing.T) {
+	mockCache := mock.NewMockCache(t).(*mock.MockCache)
+
+	ctx := context.Background()
+
+	key := "key"
+
+	mockCache.On("ZPopMin", ctx, key).Return("member", 2.5, nil)
+
+	member, score, err := mockCache.ZPopMin(ctx, key)
+
+	if err != nil {
+		t.FailNow()
+	}
+
+	if member != "member" || score != 2.5 {
+		t.FailNow()
+	}
+
+	mockCache.AssertExpectations(t)
+}
+
+// TestMockCache_ZPopMax_Err tests the ZPopMax method when the sorted set is empty.
+func TestMockCache_ZPopMax_Err(t *testing.T) {
+	mockCache := mock.NewMockCache(t).(*mock.MockCache)
+
+	ctx := context.Background()
+
+	key := "key"
+
+	mockCache.On("ZPopMax", ctx, key).Return("", 0.0, cache.ErrCacheNil)
+
+	member, _, err := mockCache.ZPopMax(ctx, key)
+
+	if !errors.Is(err, cache.ErrCacheNil) {
+		t.FailNow()
+	}
+
+	if member != "" {
+		t.FailNow()
+	}
+
+	mockCache.AssertExpectations(t)
+}
+
+// TestMockCache_ZPopMax_NilErr tests the ZPopMax method when no error is returned and a member is popped.
+func TestMockCache_ZPopMax_NilErr(t *testing.T) {
+	mockCache := mock.NewMockCache(t).(*mock.MockCache)
+
+	ctx := context.Background()
+
+	key := "key"
+
+	mockCache.On("ZPopMax", ctx, key).Return("member", 2.5, nil)
+
+	member, score, err := mockCache.ZPopMax(ctx, key)
+
+	if err != nil {
+		t.FailNow()
+	}
+
+	if member != "member" || score != 2.5 {
+		t.FailNow()
+	}
+
+	mockCache.AssertExpectations(t)
+}
+
+// TestMockCache_SetNX_Err tests the SetNX method when an error is returned.
+func TestMockCache_SetNX_Err(t *testing.T) {
+	mockCache := mock.NewMockCache(t).(*mock.MockCache)
+
+	ctx := context.Background()
+
+	key := "key"
+	value := "value"
+	ttl := time.Minute
+
+	r1 := errors.New("error test")
+
+	mockCache.On("SetNX", ctx, key, value, ttl).Return(false, r1)
+
+	stored, err := mockCache.SetNX(ctx, key, value, ttl)
+
+	if !errors.Is(err, r1) {
+		t.FailNow()
+	}
+
+	if stored {
+		t.FailNow()
+	}
+
+	mockCache.AssertExpectations(t)
+}
+
+// TestMockCache_SetNX_NilErr tests the SetNX method when no error is returned and the value is stored.
+func TestMockCache_SetNX_NilErr(t *testing.T) {
+	mockCache := mock.NewMockCache(t).(*mock.MockCache)
+
+	ctx := context.Background()
+
+	key := "key"
+	value := "value"
+	ttl := time.Minute
+
+	mockCache.On("SetNX", ctx, key, value, ttl).Return(true, nil)
+
+	stored, err := mockCache.SetNX(ctx, key, value, ttl)
+
+	if err != nil {
+		t.FailNow()
+	}
+
+	if !stored {
+		t.FailNow()
+	}
+
+	mockCache.AssertExpectations(t)
+}
+
+// TestMockCache_SetNXMany_Err tests the SetNXMany method when an error is returned.
+func TestMockCache_SetNXMany_Err(t *testing.T) {
+	mockCache := mock.NewMockCache(t).(*mock.MockCache)
+
+	ctx := context.Background()
+
+	keys := []string{"key1", "key2"}
+	value := "value"
+	ttl := time.Minute
+
+	r1 := errors.New("error test")
+
+	mockCache.On("SetNXMany", ctx, keys, value, ttl).Return(nil, r1)
+
+	stored, err := mockCache.SetNXMany(ctx, keys, value, ttl)
+
+	if !errors.Is(err, r1) {
+		t.FailNow()
+	}
+
+	if stored != nil {
+		t.FailNow()
+	}
+
+	mockCache.AssertExpectations(t)
+}
+
+// TestMockCache_SetNXMany_NilErr tests the SetNXMany method when no error is returned and per-key results are returned.
+func TestMockCache_SetNXMany_NilErr(t *testing.T) {
+	mockCache := mock.NewMockCache(t).(*mock.MockCache)
+
+	ctx := context.Background()
+
+	keys := []string{"key1", "key2"}
+	value := "value"
+	ttl := time.Minute
+
+	mockCache.On("SetNXMany", ctx, keys, value, ttl).Return([]bool{true, false}, nil)
+
+	stored, err := mockCache.SetNXMany(ctx, keys, value, ttl)
+
+	if err != nil {
+		t.FailNow()
+	}
+
+	if !reflect.DeepEqual(stored, []bool{true, false}) {
+		t.FailNow()
+	}
+
+	mockCache.AssertExpectations(t)
+}
+
+// TestMockCache_RotateKeepTTL_Err tests the RotateKeepTTL method when an error is returned.
+func TestMockCache_RotateKeepTTL_Err(t *testing.T) {
+	mockCache := mock.NewMockCache(t).(*mock.MockCache)
+
+	ctx := context.Background()
+
+	key := "key"
+	newValue := "new"
+
+	mockCache.On("RotateKeepTTL", ctx, key, newValue).Return("", cache.ErrCacheNil)
+
+	old, err := mockCache.RotateKeepTTL(ctx, key, newValue)
+
+	if !errors.Is(err, cache.ErrCacheNil) {
+		t.FailNow()
+	}
+
+	if old != "" {
+		t.FailNow()
+	}
+
+	mockCache.AssertExpectations(t)
+}
+
+// TestMockCache_RotateKeepTTL_NilErr tests the RotateKeepTTL method when no error is returned and the previous value is returned.
+func TestMockCache_RotateKeepTTL_NilErr(t *testing.T) {
+	mockCache := mock.NewMockCache(t).(*mock.MockCache)
+
+	ctx := context.Background()
+
+	key := "key"
+	newValue := "new"
+
+	mockCache.On("RotateKeepTTL", ctx, key, newValue).Return("old", nil)
+
+	old, err := mockCache.RotateKeepTTL(ctx, key, newValue)
+
+	if err != nil {
+		t.FailNow()
+	}
+
+	if old != "old" {
+		t.FailNow()
+	}
+
+	mockCache.AssertExpectations(t)
+}
+
+// TestMockCache_KeysExpiringSoon_Err tests the KeysExpiringSoon method when an error is returned.
+func TestMockCache_KeysExpiringSoon_Err(t *testing.T) {
+	mockCache := mock.NewMockCache(t).(*mock.MockCache)
+
+	ctx := context.Background()
+
+	pattern := "session:*"
+	within := time.Minute
+	limit := 10
+
+	r1 := errors.New("error test")
+
+	mockCache.On("KeysExpiringSoon", ctx, pattern, within, limit).Return(nil, r1)
+
+	soon, err := mockCache.KeysExpiringSoon(ctx, pattern, within, limit)
+
+	if !errors.Is(err, r1) {
+		t.FailNow()
+	}
+
+	if soon != nil {
+		t.FailNow()
+	}
+
+	mockCache.AssertExpectations(t)
+}
+
+// TestMockCache_KeysExpiringSoon_NilErr tests the KeysExpiringSoon method when no error is returned and keys are returned.
+func TestMockCache_KeysExpiringSoon_NilErr(t *testing.T) {
+	mockCache := mock.NewMockCache(t).(*mock.MockCache)
+
+	ctx := context.Background()
+
+	pattern := "session:*"
+	within := time.Minute
+	limit := 10
+
+	r0 := []banshee.KeyTTL{{Key: "session:1", TTL: time.Second}}
+
+	mockCache.On("KeysExpiringSoon", ctx, pattern, within, limit).Return(r0, nil)
+
+	soon, err := mockCache.KeysExpiringSoon(ctx, pattern, within, limit)
+
+	if err != nil {
+		t.FailNow()
+	}
+
+	if !reflect.DeepEqual(soon, r0) {
+		t.FailNow()
+	}
+
+	mockCache.AssertExpectations(t)
+}
+
+// TestMockCache_HSetMany_Err tests the HSetMany method when an error is returned.
+func TestMockCache_HSetMany_Err(t *testing.T) {
+	mockCache := mock.NewMockCache(t).(*mock.MockCache)
+
+	ctx := context.Background()
+
+	entries := map[string]map[string]interface{}{"key1": {"field": "value"}}
+
+	r0 := errors.New("error test")
+
+	mockCache.On("HSetMany", ctx, entries).Return(r0)
+
+	err := mockCache.HSetMany(ctx, entries)
+
+	if !errors.Is(err, r0) {
+		t.FailNow()
+	}
+
+	mockCache.AssertExpectations(t)
+}
+
+// TestMockCache_HSetMany_NilErr tests the HSetMany method when no error is returned.
+func TestMockCache_HSetMany_NilErr(t *testing.T) {
+	mockCache := mock.NewMockCache(t).(*mock.MockCache)
+
+	ctx := context.Background()
+
+	entries := map[string]map[string]interface{}{"key1": {"field": "value"}, "key2": {"a": 1, "b": 2}}
+
+	mockCache.On("HSetMany", ctx, entries).Return(nil)
+
+	err := mockCache.HSetMany(ctx, entries)
+
+	if err != nil {
+		t.FailNow()
+	}
+
+	mockCache.AssertExpectations(t)
+}
+
+// TestMockCache_IncrFirstSeen_Err tests the IncrFirstSeen method when an error is returned.
+func TestMockCache_IncrFirstSeen_Err(t *testing.T) {
+	mockCache := mock.NewMockCache(t).(*mock.MockCache)
+
+	ctx := context.Background()
+
+	key := "fingerprint"
+	window := time.Hour
+
+	r2 := errors.New("error test")
+
+	mockCache.On("IncrFirstSeen", ctx, key, window).Return(int64(0), false, r2)
+
+	count, firstSeen, err := mockCache.IncrFirstSeen(ctx, key, window)
+
+	if !errors.Is(err, r2) {
+		t.FailNow()
+	}
+
+	if count != 0 || firstSeen {
+		t.FailNow()
+	}
+
+	mockCache.AssertExpectations(t)
+}
+
+// TestMockCache_IncrFirstSeen_NilErr tests the IncrFirstSeen method when no error is returned.
+func TestMockCache_IncrFirstSeen_NilErr(t *testing.T) {
+	mockCache := mock.NewMockCache(t).(*mock.MockCache)
+
+	ctx := context.Background()
+
+	key := "fingerprint"
+	window := time.Hour
+
+	mockCache.On("IncrFirstSeen", ctx, key, window).Return(int64(1), true, nil)
+
+	count, firstSeen, err := mockCache.IncrFirstSeen(ctx, key, window)
+
+	if err != nil {
+		t.FailNow()
+	}
+
+	if count != 1 || !firstSeen {
+		t.FailNow()
+	}
+
+	mockCache.AssertExpectations(t)
+}
+
+// TestMockCache_MGet_Err tests the MGet method when an error is returned.
+func TestMockCache_MGet_Err(t *testing.T) {
+	mockCache := mock.NewMockCache(t).(*mock.MockCache)
+
+	ctx := context.Background()
+
+	r1 := errors.New("error test")
+
+	mockCache.On("MGet", ctx, "key-1", "key-2").Return(nil, r1)
+
+	values, err := mockCache.MGet(ctx, "key-1", "key-2")
+
+	if !errors.Is(err, r1) {
+		t.FailNow()
+	}
+
+	if values != nil {
+		t.FailNow()
+	}
+
+	mockCache.AssertExpectations(t)
+}
+
+// TestMockCache_MGet_NilErr tests the MGet method when no error is returned and values are retrieved.
+func TestMockCache_MGet_NilErr(t *testing.T) {
+	mockCache := mock.NewMockCache(t).(*mock.MockCache)
+
+	ctx := context.Background()
+
+	expected := map[string]string{"key-1": "value-1"}
+
+	mockCache.On("MGet", ctx, "key-1", "key-2").Return(expected, nil)
+
+	values, err := mockCache.MGet(ctx, "key-1", "key-2")
+
+	if err != nil {
+		t.FailNow()
+	}
+
+	if values["key-1"] != "value-1" {
+		t.FailNow()
+	}
+
+	if _, ok := values["key-2"]; ok {
+		t.FailNow()
+	}
+
+	mockCache.AssertExpectations(t)
+}
+
+// TestMockCache_MSet_Err tests the MSet method when an error is returned.
+func TestMockCache_MSet_Err(t *testing.T) {
+	mockCache := mock.NewMockCache(t).(*mock.MockCache)
+
+	ctx := context.Background()
+
+	pairs := map[string]interface{}{"key-1": "value-1"}
+
+	r0 := errors.New("error test")
+
+	mockCache.On("MSet", ctx, pairs).Return(r0)
+
+	if err := mockCache.MSet(ctx, pairs); !errors.Is(err, r0) {
+		t.FailNow()
+	}
+
+	mockCache.AssertExpectations(t)
+}
+
+// TestMockCache_MSet_NilErr tests the MSet method when no error is returned.
+func TestMockCache_MSet_NilErr(t *testing.T) {
+	mockCache := mock.NewMockCache(t).(*mock.MockCache)
+
+	ctx := context.Background()
+
+	pairs := map[string]interface{}{"key-1": "value-1"}
+
+	mockCache.On("MSet", ctx, pairs).Return(nil)
+
+	if err := mockCache.MSet(ctx, pairs); err != nil {
+		t.FailNow()
+	}
+
+	mockCache.AssertExpectations(t)
+}
+
+// TestMockCache_MSetWithExpiration_Err tests the MSetWithExpiration method when an error is returned.
+func TestMockCache_MSetWithExpiration_Err(t *testing.T) {
+	mockCache := mock.NewMockCache(t).(*mock.MockCache)
+
+	ctx := context.Background()
+
+	pairs := map[string]interface{}{"key-1": "value-1"}
+
+	r0 := errors.New("error test")
+
+	mockCache.On("MSetWithExpiration", ctx, pairs, time.Minute).Return(r0)
+
+	if err := mockCache.MSetWithExpiration(ctx, pairs, time.Minute); !errors.Is(err, r0) {
+		t.FailNow()
+	}
+
+	mockCache.AssertExpectations(t)
+}
+
+// TestMockCache_MSetWithExpiration_NilErr tests the MSetWithExpiration method when no error is returned.
+func TestMockCache_MSetWithExpiration_NilErr(t *testing.T) {
+	mockCache := mock.NewMockCache(t).(*mock.MockCache)
+
+	ctx := context.Background()
+
+	pairs := map[string]interface{}{"key-1": "value-1"}
+
+	mockCache.On("MSetWithExpiration", ctx, pairs, time.Minute).Return(nil)
+
+	if err := mockCache.MSetWithExpiration(ctx, pairs, time.Minute); err != nil {
+		t.FailNow()
+	}
+
+	mockCache.AssertExpectations(t)
+}
+
+// TestMockCache_ConsumeQuota_Err tests the ConsumeQuota method when an error is returned.
+func TestMockCache_ConsumeQuota_Err(t *testing.T) {
+	mockCache := mock.NewMockCache(t).(*mock.MockCache)
+
+	ctx := context.Background()
+
+	key := "quota"
+	window := time.Hour
+
+	r3 := errors.New("error test")
+
+	mockCache.On("ConsumeQuota", ctx, key, int64(10), window, int64(1)).Return(false, int64(0), time.Duration(0), r3)
+
+	allowed, _, _, err := mockCache.ConsumeQuota(ctx, key, 10, window, 1)
+
+	if !errors.Is(err, r3) {
+		t.FailNow()
+	}
+
+	if allowed {
+		t.FailNow()
+	}
+
+	mockCache.AssertExpectations(t)
+}
+
+// TestMockCache_ConsumeQuota_NilErr tests the ConsumeQuota method when no error is returned.
+func TestMockCache_ConsumeQuota_NilErr(t *testing.T) {
+	mockCache := mock.NewMockCache(t).(*mock.MockCache)
+
+	ctx := context.Background()
+
+	key := "quota"
+	window := time.Hour
+
+	mockCache.On("ConsumeQuota", ctx, key, int64(10), window, int64(1)).Return(true, int64(9), window, nil)
+
+	allowed, remaining, resetAfter, err := mockCache.ConsumeQuota(ctx, key, 10, window, 1)
+
+	if err != nil {
+		t.FailNow()
+	}
+
+	if !allowed || remaining != 9 || resetAfter != window {
+		t.FailNow()
+	}
+
+	mockCache.AssertExpectations(t)
+}
+
+// TestMockCache_Exists_Err tests the Exists method when an error is returned.
+func TestMockCache_Exists_Err(t *testing.T) {
+	mockCache := mock.NewMockCache(t).(*mock.MockCache)
+
+	ctx := context.Background()
+
+	r1 := errors.New("error test")
+
+	mockCache.On("Exists", ctx, "key-1", "key-2").Return(int64(0), r1)
+
+	if _, err := mockCache.Exists(ctx, "key-1", "key-2"); !errors.Is(err, r1) {
+		t.FailNow()
+	}
+
+	mockCache.AssertExpectations(t)
+}
+
+// TestMockCache_Exists_NilErr tests the Exists method when no error is returned.
+func TestMockCache_Exists_NilErr(t *testing.T) {
+	mockCache := mock.NewMockCache(t).(*mock.MockCache)
+
+	ctx := context.Background()
+
+	mockCache.On("Exists", ctx, "key-1", "key-2").Return(int64(1), nil)
+
+	n, err := mockCache.Exists(ctx, "key-1", "key-2")
+
+	if err != nil || n != 1 {
+		t.FailNow()
+	}
+
+	mockCache.AssertExpectations(t)
+}
+
+// TestMockCache_Rate_Err tests the Rate method when an error is returned.
+func TestMockCache_Rate_Err(t *testing.T) {
+	mockCache := mock.NewMockCache(t).(*mock.MockCache)
+
+	ctx := context.Background()
+
+	r1 := errors.New("error test")
+
+	mockCache.On("Rate", ctx, "key", time.Second).Return(float64(0), r1)
+
+	if _, err := mockCache.Rate(ctx, "key", time.Second); !errors.Is(err, r1) {
+		t.FailNow()
+	}
+
+	mockCache.AssertExpectations(t)
+}
+
+// TestMockCache_Rate_NilErr tests the Rate method when no error is returned.
+func TestMockCache_Rate_NilErr(t *testing.T) {
+	mockCache := mock.NewMockCache(t).(*mock.MockCache)
+
+	ctx := context.Background()
+
+	mockCache.On("Rate", ctx, "key", time.Second).Return(12.5, nil)
+
+	rate, err := mockCache.Rate(ctx, "key", time.Second)
+
+	if err != nil || rate != 12.5 {
+		t.FailNow()
+	}
+
+	mockCache.AssertExpectations(t)
+}
+
+// TestMockCache_TTL_Err tests the TTL method when an error is returned.
+func TestMockCache_TTL_Err(t *testing.T) {
+	mockCache := mock.NewMockCache(t).(*mock.MockCache)
+
+	ctx := context.Background()
+
+	key := "key"
+
+	mockCache.On("TTL", ctx, key).Return(time.Duration(0), cache.ErrCacheNil)
+
+	if _, err := mockCache.TTL(ctx, key); !errors.Is(err, cache.ErrCacheNil) {
+		t.FailNow()
+	}
+
+	mockCache.AssertExpectations(t)
+}
+
+// TestMockCache_TTL_NilErr tests the TTL method when no error is returned.
+func TestMockCache_TTL_NilErr(t *testing.T) {
+	mockCache := mock.NewMockCache(t).(*mock.MockCache)
+
+	ctx := context.Background()
+
+	key := "key"
+
+	mockCache.On("TTL", ctx, key).Return(250*time.Millisecond, nil)
+
+	ttl, err := mockCache.TTL(ctx, key)
+
+	if err != nil || ttl != 250*time.Millisecond {
+		t.FailNow()
+	}
+
+	mockCache.AssertExpectations(t)
+}
+
+// TestMockCache_ExpireAt_Err tests the ExpireAt method when an error is returned.
+func TestMockCache_ExpireAt_Err(t *testing.T) {
+	mockCache := mock.NewMockCache(t).(*mock.MockCache)
+
+	ctx := context.Background()
+
+	key := "key"
+	at := time.Now().Add(time.Hour)
+
+	r1 := errors.New("error test")
+
+	mockCache.On("ExpireAt", ctx, key, at).Return(false, r1)
+
+	if _, err := mockCache.ExpireAt(ctx, key, at); !errors.Is(err, r1) {
+		t.FailNow()
+	}
+
+	mockCache.AssertExpectations(t)
+}
+
+// TestMockCache_ExpireAt_NilErr tests the ExpireAt method when no error is returned.
+func TestMockCache_ExpireAt_NilErr(t *testing.T) {
+	mockCache := mock.NewMockCache(t).(*mock.MockCache)
+
+	ctx := context.Background()
+
+	key := "key"
+	at := time.Now().Add(time.Hour)
+
+	mockCache.On("ExpireAt", ctx, key, at).Return(true, nil)
+
+	found, err := mockCache.ExpireAt(ctx, key, at)
+
+	if err != nil || !found {
+		t.FailNow()
+	}
+
+	mockCache.AssertExpectations(t)
+}
+
+// TestMockCache_Heartbeat_Err tests the Heartbeat method when an error is returned.
+func TestMockCache_Heartbeat_Err(t *testing.T) {
+	mockCache := mock.NewMockCache(t).(*mock.MockCache)
+
+	ctx := context.Background()
+
+	key := "lease"
+
+	mockCache.On("Heartbeat", ctx, key, time.Minute).Return(time.Time{}, cache.ErrCacheNil)
+
+	if _, err := mockCache.Heartbeat(ctx, key, time.Minute); !errors.Is(err, cache.ErrCacheNil) {
+		t.FailNow()
+	}
+
+	mockCache.AssertExpectations(t)
+}
+
+// TestMockCache_Heartbeat_NilErr tests the Heartbeat method when no error is returned.
+func TestMockCache_Heartbeat_NilErr(t *testing.T) {
+	mockCache := mock.NewMockCache(t).(*mock.MockCache)
+
+	ctx := context.Background()
+
+	key := "lease"
+	previous := time.UnixMilli(1700000000000)
+
+	mockCache.On("Heartbeat", ctx, key, time.Minute).Return(previous, nil)
+
+	got, err := mockCache.Heartbeat(ctx, key, time.Minute)
+
+	if err != nil || !got.Equal(previous) {
+		t.FailNow()
+	}
+
+	mockCache.AssertExpectations(t)
+}
+
+// TestMockCache_ExpirePattern_Err tests the ExpirePattern method when an error is returned.
+func TestMockCache_ExpirePattern_Err(t *testing.T) {
+	mockCache := mock.NewMockCache(t).(*mock.MockCache)
+
+	ctx := context.Background()
+
+	pattern := "key-*"
+
+	r1 := errors.New("error test")
+
+	mockCache.On("ExpirePattern", ctx, pattern, time.Hour).Return(int64(0), r1)
+
+	if _, err := mockCache.ExpirePattern(ctx, pattern, time.Hour); !errors.Is(err, r1) {
+		t.FailNow()
+	}
+
+	mockCache.AssertExpectations(t)
+}
+
+// TestMockCache_ExpirePattern_NilErr tests the ExpirePattern method when no error is returned.
+func TestMockCache_ExpirePattern_NilErr(t *testing.T) {
+	mockCache := mock.NewMockCache(t).(*mock.MockCache)
+
+	ctx := context.Background()
+
+	pattern := "key-*"
+
+	mockCache.On("ExpirePattern", ctx, pattern, time.Hour).Return(int64(3), nil)
+
+	updated, err := mockCache.ExpirePattern(ctx, pattern, time.Hour)
+
+	if err != nil || updated != 3 {
+		t.FailNow()
+	}
+
+	mockCache.AssertExpectations(t)
+}
+
+// TestMockCache_ReliableQueue_Err tests the reliable queue methods when an error is returned.
+func TestMockCache_ReliableQueue_Err(t *testing.T) {
+	mockCache := mock.NewMockCache(t).(*mock.MockCache)
+
+	ctx := context.Background()
+
+	queue := "queue"
+
+	r1 := errors.New("error test")
+
+	mockCache.On("Enqueue", ctx, queue, []string{"job"}).Return(r1)
+	mockCache.On("Reserve", ctx, queue, time.Minute).Return("", "", r1)
+	mockCache.On("Ack", ctx, queue, "token").Return(r1)
+
+	if err := mockCache.Enqueue(ctx, queue, "job"); !errors.Is(err, r1) {
+		t.FailNow()
+	}
+
+	if _, _, err := mockCache.Reserve(ctx, queue, time.Minute); !errors.Is(err, r1) {
+		t.FailNow()
+	}
+
+	if err := mockCache.Ack(ctx, queue, "token"); !errors.Is(err, r1) {
+		t.FailNow()
+	}
+
+	mockCache.AssertExpectations(t)
+}
+
+// TestMockCache_ReliableQueue_NilErr tests the reliable queue methods when no error is returned.
+func TestMockCache_ReliableQueue_NilErr(t *testing.T) {
+	mockCache := mock.NewMockCache(t).(*mock.MockCache)
+
+	ctx := context.Background()
+
+	queue := "queue"
+
+	mockCache.On("Enqueue", ctx, queue, []string{"job"}).Return(nil)
+	mockCache.On("Reserve", ctx, queue, time.Minute).Return("job", "token", nil)
+	mockCache.On("Ack", ctx, queue, "token").Return(nil)
+
+	if err := mockCache.Enqueue(ctx, queue, "job"); err != nil {
+		t.FailNow()
+	}
+
+	job, token, err := mockCache.Reserve(ctx, queue, time.Minute)
+
+	if err != nil || job != "job" || token != "token" {
+		t.FailNow()
+	}
+
+	if err := mockCache.Ack(ctx, queue, "token"); err != nil {
+		t.FailNow()
+	}
+
+	mockCache.AssertExpectations(t)
+}
+
+// TestMockCache_GetDel_Err tests the GetDel method when an error is returned.
+func TestMockCache_GetDel_Err(t *testing.T) {
+	mockCache := mock.NewMockCache(t).(*mock.MockCache)
+
+	ctx := context.Background()
+
+	key := "key"
+
+	r1 := errors.New("error test")
+
+	mockCache.On("GetDel", ctx, key).Return("", r1)
+
+	if _, err := mockCache.GetDel(ctx, key); !errors.Is(err, r1) {
+		t.FailNow()
+	}
+
+	mockCache.AssertExpectations(t)
+}
+
+// TestMockCache_GetDel_NilErr tests the GetDel method when no error is returned.
+func TestMockCache_GetDel_NilErr(t *testing.T) {
+	mockCache := mock.NewMockCache(t).(*mock.MockCache)
+
+	ctx := context.Background()
+
+	key := "key"
+
+	mockCache.On("GetDel", ctx, key).Return("value", nil)
+
+	value, err := mockCache.GetDel(ctx, key)
+
+	if err != nil || value != "value" {
+		t.FailNow()
+	}
+
+	mockCache.AssertExpectations(t)
+}
+
+// TestMockCache_SampleKeys_Err tests the SampleKeys method when an error is returned.
+func TestMockCache_SampleKeys_Err(t *testing.T) {
+	mockCache := mock.NewMockCache(t).(*mock.MockCache)
+
+	ctx := context.Background()
+
+	r1 := errors.New("error test")
+
+	mockCache.On("SampleKeys", ctx, 10).Return(nil, r1)
+
+	if _, err := mockCache.SampleKeys(ctx, 10); !errors.Is(err, r1) {
+		t.FailNow()
+	}
+
+	mockCache.AssertExpectations(t)
+}
+
+// TestMockCache_SampleKeys_NilErr tests the SampleKeys method when no error is returned.
+func TestMockCache_SampleKeys_NilErr(t *testing.T) {
+	mockCache := mock.NewMockCache(t).(*mock.MockCache)
+
+	ctx := context.Background()
+
+	mockCache.On("SampleKeys", ctx, 10).Return([]string{"key-1", "key-2"}, nil)
+
+	keys, err := mockCache.SampleKeys(ctx, 10)
+
+	if err != nil || len(keys) != 2 {
+		t.FailNow()
+	}
+
+	mockCache.AssertExpectations(t)
+}
+
+// TestMockCache_GetSet_Err tests the GetSet methods when an error is returned.
+func TestMockCache_GetSet_Err(t *testing.T) {
+	mockCache := mock.NewMockCache(t).(*mock.MockCache)
+
+	ctx := context.Background()
+
+	key := "key"
+
+	r1 := errors.New("error test")
+
+	mockCache.On("GetSet", ctx, key, "on").Return("", r1)
+	mockCache.On("GetSetKeepTTL", ctx, key, "on").Return("", r1)
+
+	if _, err := mockCache.GetSet(ctx, key, "on"); !errors.Is(err, r1) {
+		t.FailNow()
+	}
+
+	if _, err := mockCache.GetSetKeepTTL(ctx, key, "on"); !errors.Is(err, r1) {
+		t.FailNow()
+	}
+
+	mockCache.AssertExpectations(t)
+}
+
+// TestMockCache_GetSet_NilErr tests the GetSet methods when no error is returned.
+func TestMockCache_GetSet_NilErr(t *testing.T) {
+	mockCache := mock.NewMockCache(t).(*mock.MockCache)
+
+	ctx := context.Background()
+
+	key := "key"
+
+	mockCache.On("GetSet", ctx, key, "on").Return("off", nil)
+	mockCache.On("GetSetKeepTTL", ctx, key, "off").Return("on", nil)
+
+	if old, err := mockCache.GetSet(ctx, key, "on"); err != nil || old != "off" {
+		t.FailNow()
+	}
+
+	if old, err := mockCache.GetSetKeepTTL(ctx, key, "off"); err != nil || old != "on" {
+		t.FailNow()
+	}
+
+	mockCache.AssertExpectations(t)
+}
+
+// TestMockCache_DelCount_Err tests the DelCount method when an error is returned.
+func TestMockCache_DelCount_Err(t *testing.T) {
+	mockCache := mock.NewMockCache(t).(*mock.MockCache)
+
+	ctx := context.Background()
+
+	r1 := errors.New("error test")
+
+	mockCache.On("DelCount", ctx, "key-1", "key-2").Return(int64(0), r1)
+
+	if _, err := mockCache.DelCount(ctx, "key-1", "key-2"); !errors.Is(err, r1) {
+		t.FailNow()
+	}
+
+	mockCache.AssertExpectations(t)
+}
+
+// TestMockCache_DelCount_NilErr tests the DelCount method when no error is returned.
+func TestMockCache_DelCount_NilErr(t *testing.T) {
+	mockCache := mock.NewMockCache(t).(*mock.MockCache)
+
+	ctx := context.Background()
+
+	mockCache.On("DelCount", ctx, "key-1", "key-2").Return(int64(1), nil)
+
+	deleted, err := mockCache.DelCount(ctx, "key-1", "key-2")
+	if err != nil || deleted != 1 {
+		t.FailNow()
+	}
+
+	mockCache.AssertExpectations(t)
+}
+
+// TestMockCache_WalkKeys_Err tests the WalkKeys method when an error is returned.
+func TestMockCache_WalkKeys_Err(t *testing.T) {
+	mockCache := mock.NewMockCache(t).(*mock.MockCache)
+
+	ctx := context.Background()
+
+	r1 := errors.New("error test")
+
+	mockCache.On("WalkKeys", ctx, "job:*", testifymock.Anything).Return(r1)
+
+	if err := mockCache.WalkKeys(ctx, "job:*", func(keys []string) error { return nil }); !errors.Is(err, r1) {
+		t.FailNow()
+	}
+
+	mockCache.AssertExpectations(t)
+}
+
+// TestMockCache_WalkKeys_NilErr tests the WalkKeys method when scripted batches are returned.
+func TestMockCache_WalkKeys_NilErr(t *testing.T) {
+	mockCache := mock.NewMockCache(t).(*mock.MockCache)
+
+	ctx := context.Background()
+
+	mockCache.On("WalkKeys", ctx, "job:*", testifymock.Anything).Return(
+		func(ctx context.Context, pattern string, fn func(keys []string) error) error {
+			return fn([]string{"job:1", "job:2"})
+		})
+
+	var walked []string
+	err := mockCache.WalkKeys(ctx, "job:*", func(keys []string) error {
+		walked = append(walked, keys...)
+		return nil
+	})
+	if err != nil || len(walked) != 2 {
+		t.FailNow()
+	}
+
+	mockCache.AssertExpectations(t)
+}
+
+// TestMockCache_CheckPatternDelete_Err tests the CheckPatternDelete method when an error is returned.
+func TestMockCache_CheckPatternDelete_Err(t *testing.T) {
+	mockCache := mock.NewMockCache(t).(*mock.MockCache)
+
+	r0 := errors.New("error test")
+
+	mockCache.On("CheckPatternDelete").Return(r0)
+
+	if err := mockCache.CheckPatternDelete(); !errors.Is(err, r0) {
+		t.FailNow()
+	}
+
+	mockCache.AssertExpectations(t)
+}
+
+// TestMockCache_CheckPatternDelete_NilErr tests the CheckPatternDelete method when no error is returned.
+func TestMockCache_CheckPatternDelete_NilErr(t *testing.T) {
+	mockCache := mock.NewMockCache(t).(*mock.MockCache)
+
+	mockCache.On("CheckPatternDelete").Return(nil)
+
+	if err := mockCache.CheckPatternDelete(); err != nil {
+		t.FailNow()
+	}
+
+	mockCache.AssertExpectations(t)
+}
+
+// TestMockCache_SetXX_Err tests the SetXX method when an error is returned.
+func TestMockCache_SetXX_Err(t *testing.T) {
+	mockCache := mock.NewMockCache(t).(*mock.MockCache)
+
+	ctx := context.Background()
+
+	r1 := errors.New("error test")
+
+	mockCache.On("SetXX", ctx, "key-1", "value-1", time.Minute).Return(false, r1)
+
+	if _, err := mockCache.SetXX(ctx, "key-1", "value-1", time.Minute); !errors.Is(err, r1) {
+		t.FailNow()
+	}
+
+	mockCache.AssertExpectations(t)
+}
+
+// TestMockCache_SetXX_NilErr tests the SetXX method when no error is returned.
+func TestMockCache_SetXX_NilErr(t *testing.T) {
+	mockCache := mock.NewMockCache(t).(*mock.MockCache)
+
+	ctx := context.Background()
+
+	mockCache.On("SetXX", ctx, "key-1", "value-1", time.Minute).Return(true, nil)
+
+	stored, err := mockCache.SetXX(ctx, "key-1", "value-1", time.Minute)
+	if err != nil || !stored {
+		t.FailNow()
+	}
+
+	mockCache.AssertExpectations(t)
+}
@@ -0,0 +1,67 @@
+package mock
+
+import (
+	"sync"
+	"time"
+
+	"github.com/zeroxsolutions/banshee"
+)
+
+// MockClock is a banshee.Clock whose time only moves when the test says so.
+// Inject it into time-dependent components, such as memory.NewWithClock, to assert expiration
+// logic instantly and deterministically instead of sleeping.
+//
+// Unlike MockCache it records no expectations: it is a fake, not a testify mock.
+//
+// Example usage:
+//
+//	clock := mock.NewMockClock(time.Now())
+//	c := memory.NewWithClock(clock)
+//	_ = c.SetWithExpiration(ctx, "key", "value", time.Minute)
+//	clock.Advance(time.Minute)
+//	_, err := c.Get(ctx, "key") // cache.ErrCacheNil
+type MockClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+var _ banshee.Clock = (*MockClock)(nil)
+
+// NewMockClock creates a MockClock stopped at start.
+//
+// Parameters:
+//   - start: Initial time of the clock
+//
+// Returns:
+//   - *MockClock: A clock reporting start until advanced
+func NewMockClock(start time.Time) *MockClock {
+	return &MockClock{now: start}
+}
+
+// Now returns the current time of the clock. It is safe for concurrent use.
+func (c *MockClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// Advance moves the clock forward by d. A negative d moves it backward, which is useful to
+// simulate clock skew.
+//
+// Parameters:
+//   - d: Duration to add to the current time
+func (c *MockClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+// Set moves the clock to t.
+//
+// Parameters:
+//   - t: New current time
+func (c *MockClock) Set(t time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = t
+}
@@ -0,0 +1,59 @@
+package mock_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/zeroxsolutions/banshee/memory"
+	"github.com/zeroxsolutions/banshee/mock"
+	"github.com/zeroxsolutions/barbatos/cache"
+)
+
+// TestMockClock tests that the clock only moves when advanced or set.
+func TestMockClock(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := mock.NewMockClock(start)
+
+	if !clock.Now().Equal(start) {
+		t.FailNow()
+	}
+
+	clock.Advance(time.Hour)
+
+	if !clock.Now().Equal(start.Add(time.Hour)) {
+		t.FailNow()
+	}
+
+	clock.Set(start)
+
+	if !clock.Now().Equal(start) {
+		t.FailNow()
+	}
+}
+
+// TestMockClock_MemoryExpiration tests that advancing the clock expires keys of a memory cache
+// without sleeping.
+func TestMockClock_MemoryExpiration(t *testing.T) {
+	clock := mock.NewMockClock(time.Now())
+	c := memory.NewWithClock(clock)
+
+	ctx := context.Background()
+
+	if err := c.SetWithExpiration(ctx, "key", "value", 3*time.Second); err != nil {
+		t.Fatal(err)
+	}
+
+	clock.Advance(3*time.Second - time.Nanosecond)
+
+	if _, err := c.Get(ctx, "key"); err != nil {
+		t.Fatal(err)
+	}
+
+	clock.Advance(time.Nanosecond)
+
+	if _, err := c.Get(ctx, "key"); !errors.Is(err, cache.ErrCacheNil) {
+		t.Fatal("expected cache.ErrCacheNil, got", err)
+	}
+}
@@ -0,0 +1,151 @@
+// Package presence tracks which members of a group are currently online, based on periodic
+// heartbeats, with one key convention shared by every service.
+//
+// For a group, the tracker writes:
+//
+//	<prefix><group>:member:<id>  a key per member holding its last heartbeat (unix milliseconds),
+//	                             expiring after the heartbeat TTL
+//	<prefix><group>:members      a sorted set of member IDs scored by the time their presence
+//	                             expires (unix milliseconds)
+//
+// The sorted set lets PresentMembers list a group without scanning keys by pattern; members whose
+// heartbeat lapsed are pruned from it on read.
+package presence
+
+import (
+	"context"
+	"errors"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/zeroxsolutions/banshee"
+	"github.com/zeroxsolutions/barbatos/cache"
+	"github.com/zeroxsolutions/strike/builderutil"
+)
+
+// Store is a cache supporting the sorted sets the tracker lists groups with, such as
+// redis.RedisCache or memory.Cache.
+type Store interface {
+	cache.Cache
+	banshee.SortedSetCache
+}
+
+// Tracker records heartbeats and answers presence queries.
+type Tracker struct {
+	store   Store
+	options *Options
+}
+
+// New creates a Tracker storing presence in s.
+//
+// Parameters:
+//   - s: Cache storing the presence keys
+//   - opts: Optional Options builders created with NewOptions
+//
+// Returns:
+//   - *Tracker: The presence tracker
+//   - error: An error if building the options fails
+//
+// Example:
+//
+//	tracker, err := presence.New(redisCache)
+//	err = tracker.Heartbeat(ctx, "team:7", "user:42", 30*time.Second)
+func New(s Store, opts ...builderutil.Lister[Options]) (*Tracker, error) {
+	options, err := builderutil.Build(append([]builderutil.Lister[Options]{defaultOptions()}, opts...)...)
+	if err != nil {
+		return nil, err
+	}
+	return &Tracker{store: s, options: options}, nil
+}
+
+// Heartbeat marks id as present in group for ttl. Clients call it periodically with an interval
+// comfortably shorter than ttl, so a single delayed heartbeat doesn't flip the member offline.
+//
+// Parameters:
+//   - ctx: Context for timeout control and request cancellation
+//   - group: Group the member belongs to, e.g. "team:7"
+//   - id: Member identifier
+//   - ttl: Time after which the member is considered gone without a new heartbeat, must be positive
+//
+// Returns:
+//   - error: An error if ttl is not positive or the cache fails
+func (t *Tracker) Heartbeat(ctx context.Context, group, id string, ttl time.Duration) error {
+	if ttl <= 0 {
+		return errors.New("presence: heartbeat TTL must be positive")
+	}
+	now := t.options.Clock.Now()
+	if err := t.store.SetWithExpiration(ctx, t.memberKey(group, id), strconv.FormatInt(now.UnixMilli(), 10), ttl); err != nil {
+		return err
+	}
+	return t.store.ZAdd(ctx, t.membersKey(group), float64(now.Add(ttl).UnixMilli()), id)
+}
+
+// IsPresent reports whether id sent a heartbeat to group that hasn't expired yet.
+//
+// Parameters:
+//   - ctx: Context for timeout control and request cancellation
+//   - group: Group the member belongs to
+//   - id: Member identifier
+//
+// Returns:
+//   - bool: true if the member is present
+//   - error: An error from the cache
+func (t *Tracker) IsPresent(ctx context.Context, group, id string) (bool, error) {
+	if _, err := t.store.Get(ctx, t.memberKey(group, id)); err != nil {
+		if errors.Is(err, cache.ErrCacheNil) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// PresentMembers returns the members of group whose heartbeat hasn't expired, ordered by the
+// time their presence expires (least recently seen first for a uniform TTL). Expired members are
+// removed from the group's sorted set first, so it never grows beyond the members seen within
+// the longest TTL.
+//
+// Parameters:
+//   - ctx: Context for timeout control and request cancellation
+//   - group: Group to list
+//
+// Returns:
+//   - []string: IDs of the present members
+//   - error: An error from the cache
+func (t *Tracker) PresentMembers(ctx context.Context, group string) ([]string, error) {
+	key := t.membersKey(group)
+	now := float64(t.options.Clock.Now().UnixMilli())
+	if _, err := t.store.ZRemRangeByScore(ctx, key, math.Inf(-1), now); err != nil {
+		return nil, err
+	}
+	return t.store.ZRangeByScore(ctx, key, now, math.Inf(1))
+}
+
+// Leave removes id from group immediately, e.g. on logout, instead of waiting for its heartbeat
+// to expire.
+//
+// Parameters:
+//   - ctx: Context for timeout control and request cancellation
+//   - group: Group the member belongs to
+//   - id: Member identifier
+//
+// Returns:
+//   - error: An error from the cache
+func (t *Tracker) Leave(ctx context.Context, group, id string) error {
+	if err := t.store.Del(ctx, t.memberKey(group, id)); err != nil {
+		return err
+	}
+	_, err := t.store.ZRem(ctx, t.membersKey(group), id)
+	return err
+}
+
+// memberKey returns the key holding the last heartbeat of id in group.
+func (t *Tracker) memberKey(group, id string) string {
+	return t.options.Prefix + group + ":member:" + id
+}
+
+// membersKey returns the key of the sorted set listing the members of group.
+func (t *Tracker) membersKey(group string) string {
+	return t.options.Prefix + group + ":members"
+}
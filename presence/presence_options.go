@@ -0,0 +1,86 @@
+package presence
+
+import (
+	"errors"
+
+	"github.com/zeroxsolutions/banshee"
+	"github.com/zeroxsolutions/strike/builderutil"
+)
+
+// DefaultPrefix is the key prefix used when none is configured.
+const DefaultPrefix = "presence:"
+
+// Options holds the settings of a Tracker.
+// This struct is populated through OptionsBuilder and consumed by New.
+type Options struct {
+	Prefix string        // Prefix is prepended to every key written by the tracker.
+	Clock  banshee.Clock // Clock timestamps heartbeats and decides which members expired.
+}
+
+// OptionsBuilder provides a builder pattern for constructing Options.
+// This builder implements the builderutil.Lister interface to work with the functional options pattern.
+type OptionsBuilder struct {
+	Opts []func(*Options) error // Opts contains the list of option functions to be applied
+}
+
+// SetPrefix configures the prefix of every key written by the tracker.
+//
+// Parameters:
+//   - prefix: Key prefix, e.g. "chat:presence:"
+//
+// Returns:
+//   - *OptionsBuilder: The builder instance for method chaining
+func (b *OptionsBuilder) SetPrefix(prefix string) *OptionsBuilder {
+	b.Opts = append(b.Opts, func(o *Options) error {
+		o.Prefix = prefix
+		return nil
+	})
+	return b
+}
+
+// SetClock configures the clock timestamping heartbeats and pruning expired members. The
+// per-member keys still expire on the cache's own clock, so with a fake clock only
+// PresentMembers follows it; use a cache sharing the clock, such as memory.NewWithClock, to make
+// IsPresent follow it too.
+//
+// Parameters:
+//   - clock: Clock telling the current time, must not be nil
+//
+// Returns:
+//   - *OptionsBuilder: The builder instance for method chaining
+func (b *OptionsBuilder) SetClock(clock banshee.Clock) *OptionsBuilder {
+	b.Opts = append(b.Opts, func(o *Options) error {
+		if clock == nil {
+			return errors.New("presence: clock must not be nil")
+		}
+		o.Clock = clock
+		return nil
+	})
+	return b
+}
+
+// List returns the slice of option functions accumulated by the builder.
+// This method implements the builderutil.Lister interface.
+//
+// Returns:
+//   - []func(*Options) error: A slice of option functions that can be applied to configure Options
+func (b *OptionsBuilder) List() []func(*Options) error {
+	return b.Opts
+}
+
+// NewOptions creates and returns a new instance of OptionsBuilder.
+//
+// Returns:
+//   - *OptionsBuilder: A new instance of OptionsBuilder ready to be configured
+//
+// Example:
+//
+//	opts := presence.NewOptions().SetPrefix("chat:presence:")
+func NewOptions() *OptionsBuilder {
+	return &OptionsBuilder{}
+}
+
+// defaultOptions returns the builder holding the Tracker defaults.
+func defaultOptions() builderutil.Lister[Options] {
+	return NewOptions().SetPrefix(DefaultPrefix).SetClock(banshee.SystemClock)
+}
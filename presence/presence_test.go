@@ -0,0 +1,116 @@
+package presence_test
+
+import (
+	"context"
+	"math"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/zeroxsolutions/banshee/memory"
+	"github.com/zeroxsolutions/banshee/presence"
+)
+
+// TestTracker verifies that members appear after a heartbeat, expire once heartbeats stop, and
+// leave explicitly.
+func TestTracker(t *testing.T) {
+	tracker, err := presence.New(memory.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+
+	for _, id := range []string{"alice", "bob", "carol"} {
+		if err := tracker.Heartbeat(ctx, "team", id, 80*time.Millisecond); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	present, err := tracker.IsPresent(ctx, "team", "alice")
+	if err != nil || !present {
+		t.Fatal("alice not present:", err)
+	}
+	if present, err := tracker.IsPresent(ctx, "other", "alice"); err != nil || present {
+		t.Fatal("alice present in another group:", err)
+	}
+
+	if err := tracker.Leave(ctx, "team", "carol"); err != nil {
+		t.Fatal(err)
+	}
+
+	members, err := tracker.PresentMembers(ctx, "team")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sort.Strings(members)
+	if !reflect.DeepEqual(members, []string{"alice", "bob"}) {
+		t.Fatal("unexpected members:", members)
+	}
+
+	// Only bob keeps sending heartbeats.
+	time.Sleep(50 * time.Millisecond)
+	if err := tracker.Heartbeat(ctx, "team", "bob", 80*time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(50 * time.Millisecond)
+
+	if present, err := tracker.IsPresent(ctx, "team", "alice"); err != nil || present {
+		t.Fatal("alice still present:", err)
+	}
+	members, err = tracker.PresentMembers(ctx, "team")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(members, []string{"bob"}) {
+		t.Fatal("unexpected members:", members)
+	}
+
+	if err := tracker.Heartbeat(ctx, "team", "bob", 0); err == nil {
+		t.Fatal("expected an error for a zero TTL")
+	}
+}
+
+// TestTracker_Prune verifies that listing removes expired members from the sorted set.
+func TestTracker_Prune(t *testing.T) {
+	store := memory.New()
+	tracker, err := presence.New(store, presence.NewOptions().SetPrefix("p:"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+
+	if err := tracker.Heartbeat(ctx, "room", "short", 20*time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+	if err := tracker.Heartbeat(ctx, "room", "long", time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(40 * time.Millisecond)
+
+	stored, err := store.ZRangeByScore(ctx, "p:room:members", math.Inf(-1), math.Inf(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(stored) != 2 {
+		t.Fatal("expected both members before listing:", stored)
+	}
+
+	members, err := tracker.PresentMembers(ctx, "room")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(members, []string{"long"}) {
+		t.Fatal("unexpected members:", members)
+	}
+
+	stored, err = store.ZRangeByScore(ctx, "p:room:members", math.Inf(-1), math.Inf(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(stored, []string{"long"}) {
+		t.Fatal("expired member not pruned:", stored)
+	}
+}
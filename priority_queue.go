@@ -0,0 +1,114 @@
+package banshee
+
+import (
+	"context"
+
+	"github.com/zeroxsolutions/barbatos/cache"
+	"github.com/zeroxsolutions/strike/builderutil"
+)
+
+// PriorityQueue is a queue of unique string items ordered by priority, stored in a sorted set so
+// that it can be shared by workers in several processes.
+type PriorityQueue struct {
+	cache   SortedSetCache
+	key     string
+	options *PriorityQueueOptions
+}
+
+// NewPriorityQueue creates a PriorityQueue stored in the sorted set under key.
+//
+// Behavior:
+//   - Pop removes the highest-priority item, or the lowest with SetLowestFirst(true)
+//   - Pops are atomic on the server: concurrent workers never receive the same item
+//   - Items with equal priorities are popped in lexicographic order (reversed when highest first)
+//   - Items are unique: pushing an item already queued only updates its priority
+//
+// Parameters:
+//   - c: Cache storing the sorted set, such as redis.RedisCache or memory.Cache
+//   - key: Key of the sorted set
+//   - opts: Optional PriorityQueueOptions builders created with NewPriorityQueueOptions
+//
+// Returns:
+//   - *PriorityQueue: The priority queue
+//   - error: An error if building the options fails
+//
+// Example:
+//
+//	tasks, err := banshee.NewPriorityQueue(redisCache.(banshee.SortedSetCache), "tasks")
+//	err = tasks.Push(ctx, "task:42", 10)
+//	next, err := tasks.Pop(ctx)
+func NewPriorityQueue(c SortedSetCache, key string, opts ...builderutil.Lister[PriorityQueueOptions]) (*PriorityQueue, error) {
+	options, err := builderutil.Build(opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &PriorityQueue{cache: c, key: key, options: options}, nil
+}
+
+// Push adds item with priority, or updates the priority of an item already queued.
+//
+// Parameters:
+//   - ctx: Context for timeout control and request cancellation
+//   - item: Item to queue
+//   - priority: Priority of the item
+//
+// Returns:
+//   - error: An error from the cache
+func (q *PriorityQueue) Push(ctx context.Context, item string, priority float64) error {
+	return q.cache.ZAdd(ctx, q.key, priority, item)
+}
+
+// Pop removes and returns the next item.
+//
+// Parameters:
+//   - ctx: Context for timeout control and request cancellation
+//
+// Returns:
+//   - string: The next item
+//   - error: cache.ErrCacheNil if the queue is empty, or an error from the cache
+func (q *PriorityQueue) Pop(ctx context.Context) (string, error) {
+	var item string
+	var err error
+	if q.options.LowestFirst {
+		item, _, err = q.cache.ZPopMin(ctx, q.key)
+	} else {
+		item, _, err = q.cache.ZPopMax(ctx, q.key)
+	}
+	return item, err
+}
+
+// Peek returns the next item without removing it. Another worker may pop it before the caller
+// acts on it.
+//
+// Parameters:
+//   - ctx: Context for timeout control and request cancellation
+//
+// Returns:
+//   - string: The next item
+//   - error: cache.ErrCacheNil if the queue is empty, or an error from the cache
+func (q *PriorityQueue) Peek(ctx context.Context) (string, error) {
+	rank := int64(-1)
+	if q.options.LowestFirst {
+		rank = 0
+	}
+	items, err := q.cache.ZRange(ctx, q.key, rank, rank)
+	if err != nil {
+		return "", err
+	}
+	if len(items) == 0 {
+		return "", cache.ErrCacheNil
+	}
+	return items[0], nil
+}
+
+// Len returns the number of queued items.
+//
+// Parameters:
+//   - ctx: Context for timeout control and request cancellation
+//
+// Returns:
+//   - int64: Number of queued items
+//   - error: An error from the cache
+func (q *PriorityQueue) Len(ctx context.Context) (int64, error) {
+	return q.cache.ZCard(ctx, q.key)
+}
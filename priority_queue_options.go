@@ -0,0 +1,51 @@
+package banshee
+
+// PriorityQueueOptions holds the settings of a PriorityQueue.
+// This struct is populated through PriorityQueueOptionsBuilder and consumed by NewPriorityQueue.
+type PriorityQueueOptions struct {
+	LowestFirst bool // LowestFirst makes Pop and Peek return the item with the lowest priority first.
+}
+
+// PriorityQueueOptionsBuilder provides a builder pattern for constructing PriorityQueueOptions.
+// This builder implements the builderutil.Lister interface to work with the functional options pattern.
+type PriorityQueueOptionsBuilder struct {
+	Opts []func(*PriorityQueueOptions) error // Opts contains the list of option functions to be applied
+}
+
+// SetLowestFirst configures the direction of the queue. By default Pop returns the item with the
+// highest priority; with lowest first it returns the lowest, which suits priorities expressed as
+// deadlines or ranks where 1 comes before 2.
+//
+// Parameters:
+//   - lowestFirst: true to pop the lowest priority first
+//
+// Returns:
+//   - *PriorityQueueOptionsBuilder: The builder instance for method chaining
+func (b *PriorityQueueOptionsBuilder) SetLowestFirst(lowestFirst bool) *PriorityQueueOptionsBuilder {
+	b.Opts = append(b.Opts, func(o *PriorityQueueOptions) error {
+		o.LowestFirst = lowestFirst
+		return nil
+	})
+	return b
+}
+
+// List returns the slice of option functions accumulated by the builder.
+// This method implements the builderutil.Lister interface.
+//
+// Returns:
+//   - []func(*PriorityQueueOptions) error: A slice of option functions that can be applied to configure PriorityQueueOptions
+func (b *PriorityQueueOptionsBuilder) List() []func(*PriorityQueueOptions) error {
+	return b.Opts
+}
+
+// NewPriorityQueueOptions creates and returns a new instance of PriorityQueueOptionsBuilder.
+//
+// Returns:
+//   - *PriorityQueueOptionsBuilder: A new instance of PriorityQueueOptionsBuilder ready to be configured
+//
+// Example:
+//
+//	opts := banshee.NewPriorityQueueOptions().SetLowestFirst(true)
+func NewPriorityQueueOptions() *PriorityQueueOptionsBuilder {
+	return &PriorityQueueOptionsBuilder{}
+}
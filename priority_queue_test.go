@@ -0,0 +1,139 @@
+package banshee_test
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/zeroxsolutions/banshee"
+	"github.com/zeroxsolutions/banshee/memory"
+	"github.com/zeroxsolutions/barbatos/cache"
+)
+
+// TestPriorityQueue verifies ordering in both directions, Peek, Len and the empty queue.
+func TestPriorityQueue(t *testing.T) {
+	ctx := context.Background()
+
+	for _, lowestFirst := range []bool{false, true} {
+		t.Run("LowestFirst="+strconv.FormatBool(lowestFirst), func(t *testing.T) {
+			queue, err := banshee.NewPriorityQueue(memory.New(), "tasks",
+				banshee.NewPriorityQueueOptions().SetLowestFirst(lowestFirst))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if _, err := queue.Pop(ctx); !errors.Is(err, cache.ErrCacheNil) {
+				t.Fatal("expected cache.ErrCacheNil, got", err)
+			}
+			if _, err := queue.Peek(ctx); !errors.Is(err, cache.ErrCacheNil) {
+				t.Fatal("expected cache.ErrCacheNil, got", err)
+			}
+
+			for item, priority := range map[string]float64{"low": 1, "high": 10, "mid": 5} {
+				if err := queue.Push(ctx, item, priority); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if n, err := queue.Len(ctx); err != nil || n != 3 {
+				t.Fatal(n, err)
+			}
+
+			want := []string{"high", "mid", "low"}
+			if lowestFirst {
+				want = []string{"low", "mid", "high"}
+			}
+			if item, err := queue.Peek(ctx); err != nil || item != want[0] {
+				t.Fatal(item, err)
+			}
+			for _, expected := range want {
+				if item, err := queue.Pop(ctx); err != nil || item != expected {
+					t.Fatalf("Pop = %q, %v; want %q", item, err, expected)
+				}
+			}
+			if n, err := queue.Len(ctx); err != nil || n != 0 {
+				t.Fatal(n, err)
+			}
+		})
+	}
+}
+
+// TestPriorityQueue_Concurrent pushes from several goroutines, pops from several more, and checks
+// that every item is popped once and that each popper sees non-increasing priorities.
+func TestPriorityQueue_Concurrent(t *testing.T) {
+	ctx := context.Background()
+	queue, err := banshee.NewPriorityQueue(memory.New(), "tasks")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const pushers, perPusher = 4, 100
+	var wg sync.WaitGroup
+	for p := 0; p < pushers; p++ {
+		wg.Add(1)
+		go func(p int) {
+			defer wg.Done()
+			for i := 0; i < perPusher; i++ {
+				priority := (i*7 + p*13) % 50
+				if err := queue.Push(ctx, strconv.Itoa(p)+":"+strconv.Itoa(i)+":"+strconv.Itoa(priority), float64(priority)); err != nil {
+					t.Error(err)
+				}
+			}
+		}(p)
+	}
+	wg.Wait()
+
+	var mu sync.Mutex
+	popped := map[string]int{}
+	for c := 0; c < 4; c++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			last := -1
+			for {
+				item, err := queue.Pop(ctx)
+				if errors.Is(err, cache.ErrCacheNil) {
+					return
+				}
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				priority := itemPriority(t, item)
+				if last >= 0 && priority > last {
+					t.Errorf("popped priority %d after %d", priority, last)
+				}
+				last = priority
+				mu.Lock()
+				popped[item]++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if len(popped) != pushers*perPusher {
+		t.Fatalf("expected %d items, got %d", pushers*perPusher, len(popped))
+	}
+	for item, n := range popped {
+		if n != 1 {
+			t.Fatalf("item %s popped %d times", item, n)
+		}
+	}
+}
+
+// itemPriority extracts the priority encoded as the last segment of a test item.
+func itemPriority(t *testing.T, item string) int {
+	for i := len(item) - 1; i >= 0; i-- {
+		if item[i] == ':' {
+			priority, err := strconv.Atoi(item[i+1:])
+			if err != nil {
+				t.Fatal(err)
+			}
+			return priority
+		}
+	}
+	t.Fatal("malformed item:", item)
+	return 0
+}
@@ -0,0 +1,482 @@
+// Package quota limits how many keys, and how many bytes, each key prefix may hold in a shared
+// cache, so one tenant's runaway job can't starve the others.
+//
+// Usage is tracked in two counters per limited prefix, stored in the same cache:
+//
+//	<counter prefix><prefix>keys   number of keys under the prefix
+//	<counter prefix><prefix>bytes  approximate size of those keys: len(key) + len(value) each
+//
+// The counters are maintained on writes and deletes made through the wrapper. Keys that expire,
+// or are written by other clients, make them drift; Reconcile recounts them from the keyspace.
+package quota
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/zeroxsolutions/banshee"
+	"github.com/zeroxsolutions/banshee/internal/valueutil"
+	"github.com/zeroxsolutions/barbatos/cache"
+	"github.com/zeroxsolutions/strike/builderutil"
+)
+
+// reconcileBatchSize is the number of keys Reconcile reads per batch.
+const reconcileBatchSize = 100
+
+const (
+	// ResourceKeys names the key count limit in an ExceededError.
+	ResourceKeys = "keys"
+
+	// ResourceBytes names the byte size limit in an ExceededError.
+	ResourceBytes = "bytes"
+)
+
+// ErrUnsupported is returned by New when the cache doesn't implement banshee.CounterCache, which
+// the usage counters are updated with.
+var ErrUnsupported = errors.New("quota: cache must implement banshee.CounterCache")
+
+// ErrUnknownPrefix is returned by Usage for prefixes without a configured limit.
+var ErrUnknownPrefix = errors.New("quota: no limit configured for prefix")
+
+// ErrQuotaExceeded is matched (via errors.Is) by the *ExceededError returned for writes that
+// would take a prefix over its limit.
+var ErrQuotaExceeded = errors.New("quota: quota exceeded")
+
+// ExceededError reports a write rejected because it would exceed the limit of its prefix.
+type ExceededError struct {
+	Prefix   string // Prefix is the limited prefix the key belongs to.
+	Resource string // Resource is the exceeded limit, ResourceKeys or ResourceBytes.
+	Limit    int64  // Limit is the configured maximum of the resource.
+}
+
+// Error implements the error interface.
+func (e *ExceededError) Error() string {
+	return fmt.Sprintf("quota: %s quota of %q exceeded: limit is %d", e.Resource, e.Prefix, e.Limit)
+}
+
+// Is reports whether target is ErrQuotaExceeded, so errors.Is matches any exceeded quota.
+func (e *ExceededError) Is(target error) bool {
+	return target == ErrQuotaExceeded
+}
+
+// Usage is the tracked usage of one prefix.
+type Usage struct {
+	Keys  int64 // Keys is the number of keys under the prefix.
+	Bytes int64 // Bytes is the approximate size of the keys and values under the prefix.
+}
+
+// store is the set of capabilities the quota cache relies on.
+type store interface {
+	cache.Cache
+	banshee.CounterCache
+}
+
+// Cache is a cache.Cache decorator rejecting writes that would take a key prefix over its limit.
+type Cache struct {
+	cache   store
+	options *Options
+}
+
+var _ cache.Cache = (*Cache)(nil)
+
+// New wraps c so that writes under the prefixes configured with SetLimit respect their limits.
+//
+// Behavior:
+//   - A write creating a key, or growing one, first reserves its share of the quota with
+//     IncrByCeil; if a limit would be exceeded, nothing is written and an *ExceededError is
+//     returned
+//   - Overwriting a key with a value of the same size or smaller always succeeds
+//   - Keys outside every limited prefix are passed through unchecked
+//   - Del releases the quota of the keys it deletes; DelWithPattern is followed by Reconcile, as
+//     the keys it deletes aren't known in advance
+//   - Limits are approximate under concurrency: racing writes or deletes of the same key can
+//     count it twice, until the next Reconcile
+//
+// Parameters:
+//   - c: Cache implementing banshee.CounterCache, such as redis.RedisCache or memory.Cache
+//   - opts: Optional Options builders created with NewOptions
+//
+// Returns:
+//   - *Cache: The quota-enforcing cache
+//   - error: ErrUnsupported if c lacks CounterCache, or an error if building the options fails
+//
+// Example:
+//
+//	shared, err := quota.New(redisCache, quota.NewOptions().
+//	    SetLimit("team-a:", quota.Limit{MaxKeys: 1000000, MaxBytes: 1 << 30}).
+//	    SetLimit("team-b:", quota.Limit{MaxKeys: 200000}))
+//	go shared.RunReconcile(ctx, 10*time.Minute)
+func New(c cache.Cache, opts ...builderutil.Lister[Options]) (*Cache, error) {
+	s, ok := c.(store)
+	if !ok {
+		return nil, ErrUnsupported
+	}
+	options, err := builderutil.Build(append([]builderutil.Lister[Options]{defaultOptions()}, opts...)...)
+	if err != nil {
+		return nil, err
+	}
+	return &Cache{cache: s, options: options}, nil
+}
+
+// IsConnected reports the connection status of the underlying cache.
+func (q *Cache) IsConnected(ctx context.Context) bool {
+	return q.cache.IsConnected(ctx)
+}
+
+// Keys returns the keys matching pattern in the underlying cache.
+func (q *Cache) Keys(ctx context.Context, pattern string) ([]string, error) {
+	return q.cache.Keys(ctx, pattern)
+}
+
+// Get retrieves the value stored under key from the underlying cache.
+func (q *Cache) Get(ctx context.Context, key string) (string, error) {
+	return q.cache.Get(ctx, key)
+}
+
+// Set stores value under key without expiration, within the quota of its prefix.
+func (q *Cache) Set(ctx context.Context, key string, value interface{}) error {
+	return q.SetWithExpiration(ctx, key, value, 0)
+}
+
+// SetWithExpiration stores value under key with the given expiration, within the quota of its
+// prefix. It returns an *ExceededError if the write would exceed the quota.
+func (q *Cache) SetWithExpiration(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
+	prefix, ok := q.prefixOf(key)
+	if !ok {
+		return q.cache.SetWithExpiration(ctx, key, value, expiration)
+	}
+	s, err := valueutil.Stringify(value)
+	if err != nil {
+		return err
+	}
+	var keys, bytes int64 = 1, int64(len(key) + len(s))
+	old, err := q.cache.Get(ctx, key)
+	switch {
+	case err == nil:
+		keys, bytes = 0, bytes-int64(len(key)+len(old))
+	case !errors.Is(err, cache.ErrCacheNil):
+		return err
+	}
+	if err := q.reserve(ctx, prefix, keys, bytes); err != nil {
+		return err
+	}
+	if err := q.cache.SetWithExpiration(ctx, key, value, expiration); err != nil {
+		_ = q.release(ctx, prefix, keys, bytes)
+		return err
+	}
+	return nil
+}
+
+// Del deletes keys and releases the quota they used.
+func (q *Cache) Del(ctx context.Context, keys ...string) error {
+	type usage struct{ keys, bytes int64 }
+	freed := map[string]*usage{}
+	for _, key := range keys {
+		prefix, ok := q.prefixOf(key)
+		if !ok {
+			continue
+		}
+		value, err := q.cache.Get(ctx, key)
+		if errors.Is(err, cache.ErrCacheNil) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if freed[prefix] == nil {
+			freed[prefix] = &usage{}
+		}
+		freed[prefix].keys++
+		freed[prefix].bytes += int64(len(key) + len(value))
+	}
+	if err := q.cache.Del(ctx, keys...); err != nil {
+		return err
+	}
+	for prefix, u := range freed {
+		if err := q.release(ctx, prefix, u.keys, u.bytes); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DelWithPattern deletes the keys matching pattern from the underlying cache and then reconciles
+// every limited prefix.
+func (q *Cache) DelWithPattern(ctx context.Context, pattern string) error {
+	if err := q.cache.DelWithPattern(ctx, pattern); err != nil {
+		return err
+	}
+	return q.Reconcile(ctx)
+}
+
+// Close closes the underlying cache.
+func (q *Cache) Close() error {
+	return q.cache.Close()
+}
+
+// Usage returns the tracked usage of prefix.
+//
+// Parameters:
+//   - ctx: Context for timeout control and request cancellation
+//   - prefix: Prefix configured with SetLimit
+//
+// Returns:
+//   - Usage: The number of keys and bytes under the prefix
+//   - error: ErrUnknownPrefix, or an error from the cache
+//
+// Example:
+//
+//	usage, err := shared.Usage(ctx, "team-a:")
+func (q *Cache) Usage(ctx context.Context, prefix string) (Usage, error) {
+	if _, ok := q.options.Limits[prefix]; !ok {
+		return Usage{}, ErrUnknownPrefix
+	}
+	keys, err := q.counter(ctx, q.counterKey(prefix, ResourceKeys))
+	if err != nil {
+		return Usage{}, err
+	}
+	bytes, err := q.counter(ctx, q.counterKey(prefix, ResourceBytes))
+	if err != nil {
+		return Usage{}, err
+	}
+	return Usage{Keys: keys, Bytes: bytes}, nil
+}
+
+// Reconcile recounts the usage of every limited prefix from the keys actually stored, correcting
+// the drift left by expired keys and by writes that bypassed the wrapper. Run it periodically,
+// with RunReconcile, rather than on a hot path. Writes made while it runs may be miscounted until
+// the next run.
+//
+// Keys are listed one batch at a time when the cache implements banshee.KeyWalker, as the Redis
+// cache does with SCAN, and all at once with Keys otherwise. Their values are read in batches of
+// reconcileBatchSize keys, with one MGet per batch when the cache implements banshee.MultiGetter
+// and one Get per key otherwise. Keys holding another type than a string, such as a Redis hash,
+// are skipped.
+//
+// Parameters:
+//   - ctx: Context for timeout control and request cancellation
+//
+// Returns:
+//   - error: An error from the cache or the context
+func (q *Cache) Reconcile(ctx context.Context) error {
+	for prefix := range q.options.Limits {
+		usage, err := q.recount(ctx, prefix)
+		if err != nil {
+			return err
+		}
+		if err := q.cache.Set(ctx, q.counterKey(prefix, ResourceKeys), usage.Keys); err != nil {
+			return err
+		}
+		if err := q.cache.Set(ctx, q.counterKey(prefix, ResourceBytes), usage.Bytes); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// recount measures the keys stored under prefix, as described by Reconcile.
+func (q *Cache) recount(ctx context.Context, prefix string) (Usage, error) {
+	var usage Usage
+	// SCAN may report a key more than once; counting it twice would reject legitimate writes.
+	seen := map[string]struct{}{}
+	count := func(keys []string) error {
+		var owned []string
+		for _, key := range keys {
+			if owner, ok := q.prefixOf(key); !ok || owner != prefix {
+				continue
+			}
+			if _, ok := seen[key]; ok {
+				continue
+			}
+			seen[key] = struct{}{}
+			owned = append(owned, key)
+		}
+		for start := 0; start < len(owned); start += reconcileBatchSize {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			end := start + reconcileBatchSize
+			if end > len(owned) {
+				end = len(owned)
+			}
+			values, err := q.readBatch(ctx, owned[start:end])
+			if err != nil {
+				return err
+			}
+			for key, value := range values {
+				usage.Keys++
+				usage.Bytes += int64(len(key) + len(value))
+			}
+		}
+		return nil
+	}
+
+	pattern := escape(prefix) + "*"
+	if walker, ok := q.cache.(banshee.KeyWalker); ok {
+		err := walker.WalkKeys(ctx, pattern, count)
+		return usage, err
+	}
+	keys, err := q.cache.Keys(ctx, pattern)
+	if err != nil {
+		return Usage{}, err
+	}
+	err = count(keys)
+	return usage, err
+}
+
+// readBatch returns the string values of the keys that exist, keyed by key.
+func (q *Cache) readBatch(ctx context.Context, keys []string) (map[string]string, error) {
+	if getter, ok := q.cache.(banshee.MultiGetter); ok {
+		return getter.MGet(ctx, keys...)
+	}
+	values := make(map[string]string, len(keys))
+	for _, key := range keys {
+		value, err := q.cache.Get(ctx, key)
+		if errors.Is(err, cache.ErrCacheNil) || isWrongType(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		values[key] = value
+	}
+	return values, nil
+}
+
+// isWrongType reports whether err is the Redis error for a key holding another type than a string.
+func isWrongType(err error) bool {
+	return err != nil && strings.HasPrefix(err.Error(), "WRONGTYPE")
+}
+
+// RunReconcile calls Reconcile every interval until ctx is done, typically in its own goroutine.
+//
+// Parameters:
+//   - ctx: Context whose cancellation stops the job
+//   - interval: Time between reconciliations, must be positive
+//
+// Returns:
+//   - error: An error if interval isn't positive, the first cache error, or the context error
+//     once ctx is done
+//
+// Example:
+//
+//	go func() {
+//	    if err := shared.RunReconcile(ctx, 10*time.Minute); !errors.Is(err, context.Canceled) {
+//	        log.Println("quota reconciliation stopped:", err)
+//	    }
+//	}()
+func (q *Cache) RunReconcile(ctx context.Context, interval time.Duration) error {
+	if interval <= 0 {
+		return errors.New("quota: reconcile interval must be positive")
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			if err := q.Reconcile(ctx); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// reserve adds keys and bytes to the usage of prefix, or returns an *ExceededError and changes
+// nothing if either limit would be exceeded.
+func (q *Cache) reserve(ctx context.Context, prefix string, keys, bytes int64) error {
+	limit := q.options.Limits[prefix]
+	if keys != 0 {
+		_, applied, err := q.cache.IncrByCeil(ctx, q.counterKey(prefix, ResourceKeys), keys, ceil(keys, limit.MaxKeys))
+		if err != nil {
+			return err
+		}
+		if !applied {
+			return &ExceededError{Prefix: prefix, Resource: ResourceKeys, Limit: limit.MaxKeys}
+		}
+	}
+	if bytes != 0 {
+		_, applied, err := q.cache.IncrByCeil(ctx, q.counterKey(prefix, ResourceBytes), bytes, ceil(bytes, limit.MaxBytes))
+		if err == nil && !applied {
+			err = &ExceededError{Prefix: prefix, Resource: ResourceBytes, Limit: limit.MaxBytes}
+		}
+		if err != nil {
+			_ = q.release(ctx, prefix, keys, 0)
+			return err
+		}
+	}
+	return nil
+}
+
+// release subtracts keys and bytes from the usage of prefix.
+func (q *Cache) release(ctx context.Context, prefix string, keys, bytes int64) error {
+	if keys != 0 {
+		if _, _, err := q.cache.IncrByCeil(ctx, q.counterKey(prefix, ResourceKeys), -keys, math.MaxInt64); err != nil {
+			return err
+		}
+	}
+	if bytes != 0 {
+		if _, _, err := q.cache.IncrByCeil(ctx, q.counterKey(prefix, ResourceBytes), -bytes, math.MaxInt64); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// counter reads the counter at key, 0 if missing.
+func (q *Cache) counter(ctx context.Context, key string) (int64, error) {
+	value, err := q.cache.Get(ctx, key)
+	if errors.Is(err, cache.ErrCacheNil) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseInt(value, 10, 64)
+}
+
+// prefixOf returns the longest limited prefix of key. Counter keys belong to no prefix.
+func (q *Cache) prefixOf(key string) (string, bool) {
+	if strings.HasPrefix(key, q.options.CounterPrefix) {
+		return "", false
+	}
+	best, found := "", false
+	for prefix := range q.options.Limits {
+		if strings.HasPrefix(key, prefix) && len(prefix) > len(best) {
+			best, found = prefix, true
+		}
+	}
+	return best, found
+}
+
+// counterKey returns the key of the resource counter of prefix.
+func (q *Cache) counterKey(prefix, resource string) string {
+	return q.options.CounterPrefix + prefix + resource
+}
+
+// ceil returns the ceiling for a counter change of delta under limit. Decreases and unlimited
+// resources are never refused, even when a limit was lowered below the current usage.
+func ceil(delta, limit int64) int64 {
+	if delta < 0 || limit == 0 {
+		return math.MaxInt64
+	}
+	return limit
+}
+
+// escape quotes the glob metacharacters of prefix so Keys matches it literally.
+func escape(prefix string) string {
+	var b strings.Builder
+	for _, r := range prefix {
+		if strings.ContainsRune(`*?[]\`, r) {
+			b.WriteByte('\\')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
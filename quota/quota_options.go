@@ -0,0 +1,100 @@
+package quota
+
+import (
+	"errors"
+
+	"github.com/zeroxsolutions/strike/builderutil"
+)
+
+// DefaultCounterPrefix is the prefix of the usage counter keys when none is configured.
+const DefaultCounterPrefix = "quota:"
+
+// Limit caps the usage of one key prefix. A zero field means no limit on that resource.
+type Limit struct {
+	MaxKeys  int64 // MaxKeys is the maximum number of keys under the prefix.
+	MaxBytes int64 // MaxBytes is the maximum approximate size of the keys and values under the prefix.
+}
+
+// Options holds the settings of a quota Cache.
+// This struct is populated through OptionsBuilder and consumed by New.
+type Options struct {
+	Limits        map[string]Limit // Limits holds the limit of each key prefix.
+	CounterPrefix string           // CounterPrefix is prepended to the usage counter keys.
+}
+
+// OptionsBuilder provides a builder pattern for constructing Options.
+// This builder implements the builderutil.Lister interface to work with the functional options pattern.
+type OptionsBuilder struct {
+	Opts []func(*Options) error // Opts contains the list of option functions to be applied
+}
+
+// SetLimit configures the limit of the keys starting with prefix. When prefixes are nested, such
+// as "team-a:" and "team-a:batch:", a key counts against the longest prefix it matches only.
+//
+// Parameters:
+//   - prefix: Key prefix, e.g. "team-a:", must not be empty
+//   - limit: Limit of the prefix, with non-negative fields
+//
+// Returns:
+//   - *OptionsBuilder: The builder instance for method chaining
+func (b *OptionsBuilder) SetLimit(prefix string, limit Limit) *OptionsBuilder {
+	b.Opts = append(b.Opts, func(o *Options) error {
+		if prefix == "" {
+			return errors.New("quota: prefix must not be empty")
+		}
+		if limit.MaxKeys < 0 || limit.MaxBytes < 0 {
+			return errors.New("quota: limits must not be negative")
+		}
+		if o.Limits == nil {
+			o.Limits = map[string]Limit{}
+		}
+		o.Limits[prefix] = limit
+		return nil
+	})
+	return b
+}
+
+// SetCounterPrefix configures the prefix of the keys holding usage counters. Keys under it are
+// never counted against a quota.
+//
+// Parameters:
+//   - prefix: Counter key prefix, must not be empty
+//
+// Returns:
+//   - *OptionsBuilder: The builder instance for method chaining
+func (b *OptionsBuilder) SetCounterPrefix(prefix string) *OptionsBuilder {
+	b.Opts = append(b.Opts, func(o *Options) error {
+		if prefix == "" {
+			return errors.New("quota: counter prefix must not be empty")
+		}
+		o.CounterPrefix = prefix
+		return nil
+	})
+	return b
+}
+
+// List returns the slice of option functions accumulated by the builder.
+// This method implements the builderutil.Lister interface.
+//
+// Returns:
+//   - []func(*Options) error: A slice of option functions that can be applied to configure Options
+func (b *OptionsBuilder) List() []func(*Options) error {
+	return b.Opts
+}
+
+// NewOptions creates and returns a new instance of OptionsBuilder.
+//
+// Returns:
+//   - *OptionsBuilder: A new instance of OptionsBuilder ready to be configured
+//
+// Example:
+//
+//	opts := quota.NewOptions().SetLimit("team-a:", quota.Limit{MaxKeys: 1000000})
+func NewOptions() *OptionsBuilder {
+	return &OptionsBuilder{}
+}
+
+// defaultOptions returns the builder holding the quota defaults.
+func defaultOptions() builderutil.Lister[Options] {
+	return NewOptions().SetCounterPrefix(DefaultCounterPrefix)
+}
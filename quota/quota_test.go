@@ -0,0 +1,277 @@
+package quota_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/zeroxsolutions/banshee/memory"
+	"github.com/zeroxsolutions/banshee/quota"
+	"github.com/zeroxsolutions/barbatos/cache"
+)
+
+// manualClock is a banshee.Clock that only moves when the test changes now.
+type manualClock struct {
+	now time.Time
+}
+
+func (c *manualClock) Now() time.Time {
+	return c.now
+}
+
+// plainCache hides the CounterCache method of the in-memory cache.
+type plainCache struct {
+	cache.Cache
+}
+
+// expectUsage fails the test unless prefix has the given usage.
+func expectUsage(t *testing.T, q *quota.Cache, prefix string, expected quota.Usage) {
+	t.Helper()
+	usage, err := q.Usage(context.Background(), prefix)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if usage != expected {
+		t.Fatalf("unexpected usage of %q: %+v, expected %+v", prefix, usage, expected)
+	}
+}
+
+// TestCache_MaxKeys verifies that writes are rejected once a prefix is full and resume after
+// deletes, and that other keys aren't limited.
+func TestCache_MaxKeys(t *testing.T) {
+	ctx := context.Background()
+	q, err := quota.New(memory.New(), quota.NewOptions().SetLimit("a:", quota.Limit{MaxKeys: 3}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, key := range []string{"a:1", "a:2", "a:3"} {
+		if err := q.Set(ctx, key, "v"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	expectUsage(t, q, "a:", quota.Usage{Keys: 3, Bytes: 12})
+
+	err = q.Set(ctx, "a:4", "v")
+	var exceeded *quota.ExceededError
+	if !errors.Is(err, quota.ErrQuotaExceeded) || !errors.As(err, &exceeded) {
+		t.Fatal(err)
+	}
+	if exceeded.Prefix != "a:" || exceeded.Resource != quota.ResourceKeys || exceeded.Limit != 3 {
+		t.Fatalf("unexpected error: %+v", exceeded)
+	}
+	if _, err := q.Get(ctx, "a:4"); !errors.Is(err, cache.ErrCacheNil) {
+		t.Fatal("the rejected write should not be stored:", err)
+	}
+
+	// Overwriting an existing key doesn't add a key.
+	if err := q.Set(ctx, "a:1", "value"); err != nil {
+		t.Fatal(err)
+	}
+	expectUsage(t, q, "a:", quota.Usage{Keys: 3, Bytes: 16})
+
+	// Unlimited keys are passed through.
+	if err := q.Set(ctx, "b:1", "v"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := q.Del(ctx, "a:1", "a:missing", "b:1"); err != nil {
+		t.Fatal(err)
+	}
+	expectUsage(t, q, "a:", quota.Usage{Keys: 2, Bytes: 8})
+
+	if err := q.Set(ctx, "a:4", "v"); err != nil {
+		t.Fatal("writes should resume after a delete:", err)
+	}
+	expectUsage(t, q, "a:", quota.Usage{Keys: 3, Bytes: 12})
+
+	if err := q.DelWithPattern(ctx, "a:*"); err != nil {
+		t.Fatal(err)
+	}
+	expectUsage(t, q, "a:", quota.Usage{})
+}
+
+// TestCache_MaxBytes verifies the byte limit and that a rejected write reserves no key.
+func TestCache_MaxBytes(t *testing.T) {
+	ctx := context.Background()
+	q, err := quota.New(memory.New(), quota.NewOptions().
+		SetLimit("a:", quota.Limit{MaxBytes: 20}).
+		SetLimit("a:big:", quota.Limit{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := q.Set(ctx, "a:1", strings.Repeat("x", 10)); err != nil {
+		t.Fatal(err)
+	}
+	if err := q.Set(ctx, "a:2", strings.Repeat("x", 10)); !errors.Is(err, quota.ErrQuotaExceeded) {
+		t.Fatal(err)
+	}
+	expectUsage(t, q, "a:", quota.Usage{Keys: 1, Bytes: 13})
+
+	// Shrinking a value frees bytes.
+	if err := q.Set(ctx, "a:1", "x"); err != nil {
+		t.Fatal(err)
+	}
+	if err := q.Set(ctx, "a:2", strings.Repeat("x", 10)); err != nil {
+		t.Fatal(err)
+	}
+	expectUsage(t, q, "a:", quota.Usage{Keys: 2, Bytes: 17})
+
+	// Keys under a longer prefix count against it only.
+	if err := q.Set(ctx, "a:big:1", strings.Repeat("x", 100)); err != nil {
+		t.Fatal(err)
+	}
+	expectUsage(t, q, "a:", quota.Usage{Keys: 2, Bytes: 17})
+	expectUsage(t, q, "a:big:", quota.Usage{Keys: 1, Bytes: 107})
+}
+
+// TestCache_Reconcile verifies that Reconcile corrects skewed counters and keys that expired.
+func TestCache_Reconcile(t *testing.T) {
+	ctx := context.Background()
+	clock := &manualClock{now: time.Now()}
+	c := memory.NewWithClock(clock)
+	q, err := quota.New(c, quota.NewOptions().SetLimit("a:", quota.Limit{MaxKeys: 3}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := q.Set(ctx, "a:1", "v"); err != nil {
+		t.Fatal(err)
+	}
+	if err := q.SetWithExpiration(ctx, "a:2", "v", time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Set(ctx, quota.DefaultCounterPrefix+"a:keys", 3); err != nil {
+		t.Fatal(err)
+	}
+	if err := q.Set(ctx, "a:3", "v"); !errors.Is(err, quota.ErrQuotaExceeded) {
+		t.Fatal("the skewed counter should reject the write:", err)
+	}
+
+	clock.now = clock.now.Add(time.Minute)
+	if err := q.Reconcile(ctx); err != nil {
+		t.Fatal(err)
+	}
+	expectUsage(t, q, "a:", quota.Usage{Keys: 1, Bytes: 4})
+
+	if err := q.Set(ctx, "a:3", "v"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// batchingCache adds batched key listing and MGet to the in-memory cache, omitting sorted sets
+// from MGet as Redis omits non-string keys, and counts the calls.
+type batchingCache struct {
+	*memory.Cache
+	walks, mgets int
+}
+
+func (c *batchingCache) WalkKeys(ctx context.Context, pattern string, fn func(keys []string) error) error {
+	c.walks++
+	keys, err := c.Keys(ctx, pattern)
+	if err != nil {
+		return err
+	}
+	// Report every key twice, as SCAN may.
+	return fn(append(keys, keys...))
+}
+
+func (c *batchingCache) MGet(ctx context.Context, keys ...string) (map[string]string, error) {
+	c.mgets++
+	values := map[string]string{}
+	for _, key := range keys {
+		if value, err := c.Get(ctx, key); err == nil {
+			values[key] = value
+		}
+	}
+	return values, nil
+}
+
+// wrongTypeCache fails Get like Redis does for keys holding another type than a string.
+type wrongTypeCache struct {
+	*memory.Cache
+	wrongType string
+}
+
+func (c *wrongTypeCache) Get(ctx context.Context, key string) (string, error) {
+	if key == c.wrongType {
+		return "", errors.New("WRONGTYPE Operation against a key holding the wrong kind of value")
+	}
+	return c.Cache.Get(ctx, key)
+}
+
+// TestCache_ReconcileBatched verifies that Reconcile walks keys in batches, reads them with one
+// MGet per batch, counts keys reported twice once, and skips keys that aren't strings.
+func TestCache_ReconcileBatched(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("MGet", func(t *testing.T) {
+		c := &batchingCache{Cache: memory.New()}
+		q, err := quota.New(c, quota.NewOptions().SetLimit("a:", quota.Limit{}))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i := 0; i < 150; i++ {
+			if err := c.Set(ctx, "a:"+strings.Repeat("k", i+1), "v"); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := c.ZAdd(ctx, "a:zset", 1, "member"); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := q.Reconcile(ctx); err != nil {
+			t.Fatal(err)
+		}
+		// 150 keys of 3 to 152 bytes, each holding a 1-byte value.
+		expectUsage(t, q, "a:", quota.Usage{Keys: 150, Bytes: 150*2 + 150*151/2 + 150})
+		if c.walks != 1 || c.mgets != 2 {
+			t.Fatal("expected 1 walk and 2 MGet calls, got", c.walks, c.mgets)
+		}
+	})
+
+	t.Run("Get", func(t *testing.T) {
+		c := &wrongTypeCache{Cache: memory.New(), wrongType: "a:hash"}
+		q, err := quota.New(c, quota.NewOptions().SetLimit("a:", quota.Limit{}))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, key := range []string{"a:1", "a:hash"} {
+			if err := c.Set(ctx, key, "v"); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		if err := q.Reconcile(ctx); err != nil {
+			t.Fatal(err)
+		}
+		expectUsage(t, q, "a:", quota.Usage{Keys: 1, Bytes: 4})
+	})
+}
+
+// TestNew_Errors verifies capability, option, prefix and reconcile interval checks.
+func TestNew_Errors(t *testing.T) {
+	if _, err := quota.New(plainCache{memory.New()}); !errors.Is(err, quota.ErrUnsupported) {
+		t.Fatal(err)
+	}
+	if _, err := quota.New(memory.New(), quota.NewOptions().SetLimit("", quota.Limit{MaxKeys: 1})); err == nil {
+		t.Fatal("expected an error for an empty prefix")
+	}
+	if _, err := quota.New(memory.New(), quota.NewOptions().SetLimit("a:", quota.Limit{MaxKeys: -1})); err == nil {
+		t.Fatal("expected an error for a negative limit")
+	}
+
+	q, err := quota.New(memory.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := q.Usage(context.Background(), "a:"); !errors.Is(err, quota.ErrUnknownPrefix) {
+		t.Fatal(err)
+	}
+	if err := q.RunReconcile(context.Background(), 0); err == nil {
+		t.Fatal("expected an error for a non-positive reconcile interval")
+	}
+}
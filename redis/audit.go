@@ -0,0 +1,96 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Audit operation names recorded in the "op" field of audit entries.
+const (
+	AuditOpDel            = "del"
+	AuditOpDelWithPattern = "del_with_pattern"
+	AuditOpExtendTTL      = "extend_ttl_pattern"
+	AuditOpExpirePattern  = "expire_pattern"
+	AuditOpReapStaleLocks = "reap_stale_locks"
+	AuditOpPurge          = "purge"
+)
+
+// AuditErrorFunc receives the errors raised while writing audit entries, together with the
+// operation that was being audited.
+type AuditErrorFunc func(ctx context.Context, op string, err error)
+
+// auditActorKey is the unexported context key under which WithAuditActor stores its value.
+type auditActorKey struct{}
+
+// WithAuditActor returns a copy of ctx identifying actor as the caller of the destructive
+// operations issued with it. The actor is recorded in the "actor" field of audit entries
+// written by caches built with SetAuditStream; it is ignored otherwise.
+//
+// Parameters:
+//   - ctx: Parent context to derive from
+//   - actor: Identity of the caller, e.g. a user ID or service name
+//
+// Returns:
+//   - context.Context: A derived context carrying the actor
+//
+// Example:
+//
+//	ctx := redis.WithAuditActor(r.Context(), "admin:alice")
+//	err := cache.DelWithPattern(ctx, "tenant:42:*")
+func WithAuditActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, auditActorKey{}, actor)
+}
+
+// auditActorFromContext extracts the actor stored by WithAuditActor, or "" if none is present.
+func auditActorFromContext(ctx context.Context) string {
+	actor, _ := ctx.Value(auditActorKey{}).(string)
+	return actor
+}
+
+// audit appends an entry describing a completed destructive operation to the audit stream, if
+// auditing is enabled. Exactly one of keys and pattern is set. Failures never propagate to the
+// audited operation; they are reported to the audit error callback instead.
+func (r *RedisCache) audit(ctx context.Context, op string, keys []string, pattern string, count int64) {
+	if r.options.AuditStream == "" {
+		return
+	}
+	values := map[string]interface{}{
+		"ts":    time.Now().UnixMilli(),
+		"op":    op,
+		"count": count,
+		"actor": auditActorFromContext(ctx),
+	}
+	if keys != nil {
+		encoded, err := json.Marshal(keys)
+		if err != nil {
+			r.auditFailed(ctx, op, err)
+			return
+		}
+		values["keys"] = string(encoded)
+	} else {
+		values["pattern"] = pattern
+	}
+	err := r.client.XAdd(ctx, &redis.XAddArgs{
+		Stream: r.options.AuditStream,
+		MaxLen: r.options.AuditMaxLen,
+		Values: values,
+	}).Err()
+	if err != nil {
+		r.auditFailed(ctx, op, err)
+	}
+}
+
+// auditFailed passes err to the audit error callback, recovering from panics raised by it so a
+// faulty callback can't fail the audited operation.
+func (r *RedisCache) auditFailed(ctx context.Context, op string, err error) {
+	if r.options.AuditErrorCallback == nil {
+		return
+	}
+	defer func() {
+		_ = recover()
+	}()
+	r.options.AuditErrorCallback(ctx, op, err)
+}
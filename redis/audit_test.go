@@ -0,0 +1,146 @@
+package redis_test
+
+import (
+	"context"
+	"encoding/json"
+	"sync/atomic"
+	"testing"
+
+	"github.com/zeroxsolutions/banshee/redis"
+	"github.com/zeroxsolutions/barbatos/cache"
+	"github.com/zeroxsolutions/strike/ssutil"
+)
+
+// TestRedisCache_Audit performs destructive operations and verifies the recorded stream entries
+// and the stream cap.
+func TestRedisCache_Audit(t *testing.T) {
+	stream := "audit:" + ssutil.MakeString(10)
+
+	redisCache := initRedisCache(t, redis.NewRedisCacheOptions().SetAuditStream(stream, 3))
+
+	defer func(redisCache cache.Cache) {
+		if err := redisCache.Close(); err != nil {
+			t.Log("Close Redis cache connection err", err)
+		}
+	}(redisCache)
+
+	client := initRedisClient(t)
+	defer client.Close()
+
+	ctx := redis.WithAuditActor(context.Background(), "admin:alice")
+	prefix := ssutil.MakeString(10)
+
+	defer func() {
+		if err := client.Del(ctx, stream).Err(); err != nil {
+			t.Error(err)
+		}
+	}()
+
+	for _, key := range []string{prefix + ":1", prefix + ":2", prefix + ":3"} {
+		if err := redisCache.Set(ctx, key, "value"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := redisCache.Del(ctx, prefix+":1", prefix+":missing"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := redisCache.DelWithPattern(ctx, prefix+":*"); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := client.XRange(ctx, stream, "-", "+").Result()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(entries) != 2 {
+		t.Fatal("expected 2 audit entries, got", len(entries))
+	}
+
+	del := entries[0].Values
+	var keys []string
+	if err := json.Unmarshal([]byte(del["keys"].(string)), &keys); err != nil {
+		t.Fatal(err)
+	}
+
+	if del["op"] != redis.AuditOpDel || del["count"] != "1" || del["actor"] != "admin:alice" || len(keys) != 2 || del["ts"] == "" {
+		t.Log("unexpected Del entry:", del)
+		t.FailNow()
+	}
+
+	delPattern := entries[1].Values
+	if delPattern["op"] != redis.AuditOpDelWithPattern || delPattern["pattern"] != prefix+":*" || delPattern["count"] != "2" {
+		t.Log("unexpected DelWithPattern entry:", delPattern)
+		t.FailNow()
+	}
+
+	for range make([]int, 5) {
+		if err := redisCache.Del(ctx, prefix+":missing"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	length, err := client.XLen(ctx, stream).Result()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if length != 3 {
+		t.Log("expected the stream to be capped at 3 entries, got", length)
+		t.FailNow()
+	}
+}
+
+// TestRedisCache_AuditError verifies that a failed audit write invokes the error callback without
+// failing the audited operation.
+func TestRedisCache_AuditError(t *testing.T) {
+	stream := "audit:" + ssutil.MakeString(10)
+
+	var failures int64
+	redisCache := initRedisCache(t, redis.NewRedisCacheOptions().
+		SetAuditStream(stream, 10).
+		SetAuditErrorCallback(func(ctx context.Context, op string, err error) {
+			atomic.AddInt64(&failures, 1)
+			panic("callback panics must not escape")
+		}))
+
+	defer func(redisCache cache.Cache) {
+		if err := redisCache.Close(); err != nil {
+			t.Log("Close Redis cache connection err", err)
+		}
+	}(redisCache)
+
+	ctx := context.Background()
+
+	// A plain string under the stream name makes XADD fail with WRONGTYPE.
+	if err := redisCache.Set(ctx, stream, "not a stream"); err != nil {
+		t.Fatal(err)
+	}
+
+	key := ssutil.MakeString(10)
+	if err := redisCache.Set(ctx, key, "value"); err != nil {
+		t.Fatal(err)
+	}
+
+	defer func() {
+		if err := redisCache.Del(ctx, stream); err != nil {
+			t.Error(err)
+		}
+	}()
+
+	if err := redisCache.Del(ctx, key); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := redisCache.Get(ctx, key); err != cache.ErrCacheNil {
+		t.Log("key not deleted:", err)
+		t.FailNow()
+	}
+
+	if atomic.LoadInt64(&failures) != 1 {
+		t.Log("expected one audit failure, got", failures)
+		t.FailNow()
+	}
+}
@@ -0,0 +1,219 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"hash/fnv"
+	"math"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// bloomDefaultErrorRate and bloomDefaultCapacity mirror the RedisBloom defaults used when
+	// BFAdd creates a filter that was never reserved.
+	bloomDefaultErrorRate = 0.01
+	bloomDefaultCapacity  = 100
+
+	// bloomMaxBits is the largest bitmap a Redis string can hold (512 MB).
+	bloomMaxBits = 1 << 32
+
+	// bloomMetaSuffix names the hash holding the size parameters of a fallback filter.
+	bloomMetaSuffix = ":bf:meta"
+)
+
+// bloomReserveScript creates the parameter hash of a fallback filter, refusing to overwrite an
+// existing one with the same error RedisBloom returns for BF.RESERVE on an existing key.
+//
+// KEYS[1] = parameter hash, ARGV[1] = bit count, ARGV[2] = hash function count
+var bloomReserveScript = redis.NewScript(`
+if redis.call('EXISTS', KEYS[1]) == 1 then
+	return redis.error_reply('ERR item exists')
+end
+redis.call('HSET', KEYS[1], 'bits', ARGV[1], 'hashes', ARGV[2])
+return 'OK'
+`)
+
+// bloomAddScript sets the k bits derived from the item's two base hashes and reports whether
+// any of them was previously unset, i.e. whether the item was newly added. Filters that were
+// never reserved are created with the default parameters, matching BF.ADD.
+//
+// KEYS[1] = bitmap, KEYS[2] = parameter hash
+// ARGV[1] = h1, ARGV[2] = h2, ARGV[3] = default bit count, ARGV[4] = default hash function count
+var bloomAddScript = redis.NewScript(`
+local bits = tonumber(redis.call('HGET', KEYS[2], 'bits'))
+local hashes = tonumber(redis.call('HGET', KEYS[2], 'hashes'))
+if not bits or not hashes then
+	bits = tonumber(ARGV[3])
+	hashes = tonumber(ARGV[4])
+	redis.call('HSET', KEYS[2], 'bits', bits, 'hashes', hashes)
+end
+local h1 = tonumber(ARGV[1])
+local h2 = tonumber(ARGV[2])
+local added = 0
+for i = 0, hashes - 1 do
+	if redis.call('SETBIT', KEYS[1], (h1 + i * h2) % bits, 1) == 0 then
+		added = 1
+	end
+end
+return added
+`)
+
+// bloomExistsScript checks the k bits derived from the item's two base hashes. A filter that
+// does not exist contains nothing.
+//
+// KEYS[1] = bitmap, KEYS[2] = parameter hash
+// ARGV[1] = h1, ARGV[2] = h2
+var bloomExistsScript = redis.NewScript(`
+local bits = tonumber(redis.call('HGET', KEYS[2], 'bits'))
+local hashes = tonumber(redis.call('HGET', KEYS[2], 'hashes'))
+if not bits or not hashes then
+	return 0
+end
+local h1 = tonumber(ARGV[1])
+local h2 = tonumber(ARGV[2])
+for i = 0, hashes - 1 do
+	if redis.call('GETBIT', KEYS[1], (h1 + i * h2) % bits) == 0 then
+		return 0
+	end
+end
+return 1
+`)
+
+// BFReserve creates an empty bloom filter sized for capacity items at the given false-positive rate.
+// With RedisBloom available this issues BF.RESERVE; when the cache was built with
+// SetBloomFallback(true), the filter is a plain bitmap plus a small parameter hash stored under
+// key + ":bf:meta", sized with the standard formulas:
+//
+//	bits   = ceil(-capacity * ln(errorRate) / ln(2)^2)
+//	hashes = round(bits / capacity * ln(2))
+//
+// Reserving a key that already holds a filter fails with "ERR item exists" on both paths.
+// The fallback bitmap is limited to 2^32 bits (512 MB), which covers roughly 450 million items
+// at a 1% error rate; larger fallback filters fail with ErrBloomFilterTooLarge. RedisBloom has no
+// such limit.
+//
+// Parameters:
+//   - ctx: Context for timeout control and request cancellation
+//   - key: Redis key of the filter
+//   - errorRate: Desired false-positive probability, strictly between 0 and 1
+//   - capacity: Number of items the filter is expected to hold
+//
+// Returns:
+//   - error: Validation error, ErrBloomFilterTooLarge, "ERR item exists", or Redis error
+//
+// Example:
+//
+//	err := cache.BFReserve(ctx, "signup:emails", 0.001, 500_000_000)
+func (r *RedisCache) BFReserve(ctx context.Context, key string, errorRate float64, capacity int64) error {
+	if !r.options.BloomFallback {
+		if err := validateBloomArgs(errorRate, capacity); err != nil {
+			return err
+		}
+		return r.client.BFReserve(ctx, key, errorRate, capacity).Err()
+	}
+	bits, hashes, err := bloomParameters(errorRate, capacity)
+	if err != nil {
+		return err
+	}
+	return bloomReserveScript.Run(ctx, r.client, []string{key + bloomMetaSuffix}, bits, hashes).Err()
+}
+
+// BFAdd adds item to the bloom filter stored at key, creating the filter with the RedisBloom
+// defaults (1% error rate, capacity 100) if it was never reserved.
+//
+// The boolean result is true when the item was not yet present. Because bloom filters are
+// probabilistic, false may also be returned for a new item that collides with existing ones.
+//
+// Parameters:
+//   - ctx: Context for timeout control and request cancellation
+//   - key: Redis key of the filter
+//   - item: Item to add
+//
+// Returns:
+//   - bool: true if the item was newly added
+//   - error: Redis connection error or command execution error
+//
+// Example:
+//
+//	added, err := cache.BFAdd(ctx, "signup:emails", "john@example.com")
+func (r *RedisCache) BFAdd(ctx context.Context, key, item string) (bool, error) {
+	if !r.options.BloomFallback {
+		return r.client.BFAdd(ctx, key, item).Result()
+	}
+	bits, hashes, err := bloomParameters(bloomDefaultErrorRate, bloomDefaultCapacity)
+	if err != nil {
+		return false, err
+	}
+	h1, h2 := bloomHashes(item)
+	added, err := bloomAddScript.Run(ctx, r.client, []string{key, key + bloomMetaSuffix}, h1, h2, bits, hashes).Int64()
+	if err != nil {
+		return false, err
+	}
+	return added == 1, nil
+}
+
+// BFExists reports whether item may have been added to the bloom filter stored at key.
+// A false result is definitive; a true result is wrong with roughly the error rate the filter
+// was reserved with. Missing filters report false.
+//
+// Parameters:
+//   - ctx: Context for timeout control and request cancellation
+//   - key: Redis key of the filter
+//   - item: Item to look up
+//
+// Returns:
+//   - bool: false if the item was definitely never added, true if it probably was
+//   - error: Redis connection error or command execution error
+//
+// Example:
+//
+//	seen, err := cache.BFExists(ctx, "signup:emails", "john@example.com")
+//	if err == nil && !seen {
+//	    // Definitely a new address
+//	}
+func (r *RedisCache) BFExists(ctx context.Context, key, item string) (bool, error) {
+	if !r.options.BloomFallback {
+		return r.client.BFExists(ctx, key, item).Result()
+	}
+	h1, h2 := bloomHashes(item)
+	exists, err := bloomExistsScript.Run(ctx, r.client, []string{key, key + bloomMetaSuffix}, h1, h2).Int64()
+	if err != nil {
+		return false, err
+	}
+	return exists == 1, nil
+}
+
+// validateBloomArgs checks the error rate and capacity a filter is reserved with.
+func validateBloomArgs(errorRate float64, capacity int64) error {
+	if errorRate <= 0 || errorRate >= 1 {
+		return errors.New("redis: bloom filter error rate must be between 0 and 1")
+	}
+	if capacity <= 0 {
+		return errors.New("redis: bloom filter capacity must be greater than 0")
+	}
+	return nil
+}
+
+// bloomParameters validates the requested filter shape and derives the bitmap size and number
+// of hash functions for the fallback implementation.
+func bloomParameters(errorRate float64, capacity int64) (int64, int64, error) {
+	if err := validateBloomArgs(errorRate, capacity); err != nil {
+		return 0, 0, err
+	}
+	bits := math.Ceil(-float64(capacity) * math.Log(errorRate) / (math.Ln2 * math.Ln2))
+	if bits > bloomMaxBits {
+		return 0, 0, ErrBloomFilterTooLarge
+	}
+	hashes := math.Max(1, math.Round(bits/float64(capacity)*math.Ln2))
+	return int64(bits), int64(hashes), nil
+}
+
+// bloomHashes derives the two base hashes used for double hashing (h1 + i*h2) from a single
+// 64-bit FNV-1a digest. h2 is forced odd so successive positions never collapse onto h1.
+func bloomHashes(item string) (uint32, uint32) {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(item))
+	sum := h.Sum64()
+	return uint32(sum), uint32(sum>>32) | 1
+}
@@ -0,0 +1,162 @@
+package redis_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strconv"
+	"testing"
+
+	"github.com/zeroxsolutions/banshee/redis"
+	"github.com/zeroxsolutions/barbatos/cache"
+	"github.com/zeroxsolutions/strike/ssutil"
+)
+
+// TestRedisCache_Bloom exercises the bloom filter methods on both the bitmap fallback and,
+// when REDIS_BLOOM is set, the RedisBloom module.
+func TestRedisCache_Bloom(t *testing.T) {
+
+	// Test the pure-Redis bitmap implementation.
+	t.Run("Fallback", func(t *testing.T) {
+		redisCache := initRedisCache(t, redis.NewRedisCacheOptions().SetBloomFallback(true))
+
+		defer func(redisCache cache.Cache) {
+			if err := redisCache.Close(); err != nil {
+				t.Log("Close Redis cache connection err", err)
+			}
+		}(redisCache)
+
+		testBloomFilter(t, redisCache.(*redis.RedisCache))
+	})
+
+	// Test the RedisBloom module commands.
+	t.Run("Native", func(t *testing.T) {
+		if os.Getenv("REDIS_BLOOM") == "" {
+			t.Skip("REDIS_BLOOM not set; skipping RedisBloom test")
+		}
+
+		redisCache := initRedisCache(t)
+
+		defer func(redisCache cache.Cache) {
+			if err := redisCache.Close(); err != nil {
+				t.Log("Close Redis cache connection err", err)
+			}
+		}(redisCache)
+
+		testBloomFilter(t, redisCache.(*redis.RedisCache))
+	})
+}
+
+// TestRedisCache_BloomReserveLarge reserves the filter of the BFReserve doc example, which needs
+// about 7.2e9 bits: RedisBloom accepts it, while the bitmap fallback rejects it as too large.
+func TestRedisCache_BloomReserveLarge(t *testing.T) {
+	const (
+		capacity  = 500_000_000
+		errorRate = 0.001
+	)
+
+	t.Run("Fallback", func(t *testing.T) {
+		redisCache := initRedisCache(t, redis.NewRedisCacheOptions().SetBloomFallback(true))
+
+		defer func(redisCache cache.Cache) {
+			if err := redisCache.Close(); err != nil {
+				t.Log("Close Redis cache connection err", err)
+			}
+		}(redisCache)
+
+		key := "bloom" + ssutil.MakeString(10)
+		err := redisCache.(*redis.RedisCache).BFReserve(context.Background(), key, errorRate, capacity)
+		if !errors.Is(err, redis.ErrBloomFilterTooLarge) {
+			t.Fatal(err)
+		}
+	})
+
+	// The native path must leave sizing to RedisBloom; without the module the command itself
+	// fails, but never with the fallback's size limit.
+	t.Run("Native", func(t *testing.T) {
+		redisCache := initRedisCache(t)
+
+		defer func(redisCache cache.Cache) {
+			if err := redisCache.Close(); err != nil {
+				t.Log("Close Redis cache connection err", err)
+			}
+		}(redisCache)
+
+		ctx := context.Background()
+		key := "bloom" + ssutil.MakeString(10)
+		err := redisCache.(*redis.RedisCache).BFReserve(ctx, key, errorRate, capacity)
+		if errors.Is(err, redis.ErrBloomFilterTooLarge) {
+			t.Fatal("the native path should not apply the fallback's size limit")
+		}
+		if os.Getenv("REDIS_BLOOM") == "" {
+			return
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := redisCache.Del(ctx, key); err != nil {
+			t.Error(err)
+		}
+	})
+}
+
+// testBloomFilter reserves a filter, fills it to capacity, and checks there are no false
+// negatives and that the false-positive rate stays near the requested error rate.
+func testBloomFilter(t *testing.T, redisCache *redis.RedisCache) {
+	ctx := context.Background()
+	key := "bloom" + ssutil.MakeString(10)
+
+	const (
+		capacity  = 1000
+		errorRate = 0.01
+	)
+
+	if err := redisCache.BFReserve(ctx, key, errorRate, capacity); err != nil {
+		t.Fatal(err)
+	}
+
+	defer func() {
+		if err := redisCache.DelWithPattern(ctx, key+"*"); err != nil {
+			t.Error(err)
+		}
+	}()
+
+	if err := redisCache.BFReserve(ctx, key, errorRate, capacity); err == nil {
+		t.Log("reserving an existing filter should fail")
+		t.FailNow()
+	}
+
+	for i := 0; i < capacity; i++ {
+		if _, err := redisCache.BFAdd(ctx, key, "member-"+strconv.Itoa(i)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for i := 0; i < capacity; i++ {
+		exists, err := redisCache.BFExists(ctx, key, "member-"+strconv.Itoa(i))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !exists {
+			t.Log("false negative for member", i)
+			t.FailNow()
+		}
+	}
+
+	const probes = 10000
+	falsePositives := 0
+	for i := 0; i < probes; i++ {
+		exists, err := redisCache.BFExists(ctx, key, "stranger-"+strconv.Itoa(i))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if exists {
+			falsePositives++
+		}
+	}
+
+	if rate := float64(falsePositives) / probes; rate > 3*errorRate {
+		t.Log("false positive rate too high:", rate)
+		t.FailNow()
+	}
+}
@@ -0,0 +1,86 @@
+package redis
+
+import (
+	"context"
+	"strings"
+
+	"github.com/zeroxsolutions/banshee"
+)
+
+var _ banshee.ConfigCache = (*RedisCache)(nil)
+
+// ConfigGet reads runtime configuration parameters with CONFIG GET.
+// parameter may be a glob pattern such as "maxmemory*", in which case every matching parameter
+// is returned.
+//
+// The connecting user needs the @admin and @dangerous ACL categories (or an explicit +config|get
+// rule); managed Redis offerings often restrict CONFIG entirely.
+//
+// Parameters:
+//   - ctx: Context for timeout control and request cancellation
+//   - parameter: Parameter name or glob pattern
+//
+// Returns:
+//   - map[string]string: Matching parameters keyed by name; empty if none match
+//   - error: *PermissionError (matching ErrPermissionDenied) if CONFIG is forbidden, renamed, or
+//     disabled, or a Redis error
+//
+// Example:
+//
+//	config, err := cache.ConfigGet(ctx, "maxmemory-policy")
+//	policy := config["maxmemory-policy"]
+func (r *RedisCache) ConfigGet(ctx context.Context, parameter string) (map[string]string, error) {
+	config, err := r.client.ConfigGet(ctx, parameter).Result()
+	if err != nil {
+		return nil, permissionError("CONFIG", err)
+	}
+	return config, nil
+}
+
+// ConfigSet changes a runtime configuration parameter with CONFIG SET. The change is not
+// persisted to the server's configuration file and is lost on restart.
+//
+// ConfigSet is disabled by default and must be enabled at construction:
+//   - SetConfigAllowlist permits changing only the listed parameters; others fail with
+//     ErrConfigNotAllowed
+//   - SetConfigSetEnabled(true) without an allowlist permits changing any parameter
+//   - Without either, ConfigSet fails with ErrOperationDisabled
+//
+// The connecting user needs the @admin and @dangerous ACL categories (or an explicit
+// +config|set rule).
+//
+// Parameters:
+//   - ctx: Context for timeout control and request cancellation
+//   - parameter: Parameter name
+//   - value: New value in the server's textual format, e.g. "256mb"
+//
+// Returns:
+//   - error: ErrOperationDisabled or ErrConfigNotAllowed if the change is not permitted by the
+//     options, *PermissionError (matching ErrPermissionDenied) if the server forbids CONFIG, or an
+//     error if Redis rejects the change
+//
+// Example:
+//
+//	admin, _ := redis.NewRedisCache(config, redis.NewRedisCacheOptions().SetConfigAllowlist("maxmemory-policy"))
+//	err := admin.(*redis.RedisCache).ConfigSet(ctx, "maxmemory-policy", "allkeys-lru")
+func (r *RedisCache) ConfigSet(ctx context.Context, parameter, value string) error {
+	if len(r.options.ConfigAllowlist) > 0 {
+		if !r.configAllowed(parameter) {
+			return ErrConfigNotAllowed
+		}
+	} else if !r.options.ConfigSetEnabled {
+		return ErrOperationDisabled
+	}
+	return permissionError("CONFIG", r.client.ConfigSet(ctx, parameter, value).Err())
+}
+
+// configAllowed reports whether parameter is in the allowlist. Redis configuration parameter
+// names are case-insensitive.
+func (r *RedisCache) configAllowed(parameter string) bool {
+	for _, allowed := range r.options.ConfigAllowlist {
+		if strings.EqualFold(allowed, parameter) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,79 @@
+package redis_test
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/zeroxsolutions/banshee/redis"
+	"github.com/zeroxsolutions/barbatos/cache"
+)
+
+// TestRedisCache_Config reads a known parameter and writes it back unchanged. CONFIG is often
+// restricted, so the test only runs when REDIS_CONFIG is set.
+func TestRedisCache_Config(t *testing.T) {
+	if os.Getenv("REDIS_CONFIG") == "" {
+		t.Skip("REDIS_CONFIG not set; skipping CONFIG GET/SET test")
+	}
+
+	redisCache := initRedisCache(t, redis.NewRedisCacheOptions().SetConfigAllowlist("MAXMEMORY-POLICY"))
+
+	defer func(redisCache cache.Cache) {
+		if err := redisCache.Close(); err != nil {
+			t.Log("Close Redis cache connection err", err)
+		}
+	}(redisCache)
+
+	admin := redisCache.(*redis.RedisCache)
+	ctx := context.Background()
+
+	config, err := admin.ConfigGet(ctx, "maxmemory-policy")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	policy, ok := config["maxmemory-policy"]
+	if !ok || policy == "" {
+		t.Log("maxmemory-policy not returned:", config)
+		t.FailNow()
+	}
+
+	if err := admin.ConfigSet(ctx, "maxmemory-policy", policy); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestRedisCache_ConfigSetDisabled verifies that ConfigSet is rejected unless enabled.
+func TestRedisCache_ConfigSetDisabled(t *testing.T) {
+	redisCache := initRedisCache(t)
+
+	defer func(redisCache cache.Cache) {
+		if err := redisCache.Close(); err != nil {
+			t.Log("Close Redis cache connection err", err)
+		}
+	}(redisCache)
+
+	if err := redisCache.(*redis.RedisCache).ConfigSet(context.Background(), "maxmemory-policy", "noeviction"); err != redis.ErrOperationDisabled {
+		t.Log(err)
+		t.FailNow()
+	}
+}
+
+// TestRedisCache_ConfigAllowlist verifies that parameters missing from the allowlist are rejected
+// without reaching the server, even when ConfigSet is enabled.
+func TestRedisCache_ConfigAllowlist(t *testing.T) {
+	redisCache := initRedisCache(t, redis.NewRedisCacheOptions().
+		SetConfigSetEnabled(true).
+		SetConfigAllowlist("notify-keyspace-events"))
+
+	defer func(redisCache cache.Cache) {
+		if err := redisCache.Close(); err != nil {
+			t.Log("Close Redis cache connection err", err)
+		}
+	}(redisCache)
+
+	if err := redisCache.(*redis.RedisCache).ConfigSet(context.Background(), "maxmemory", "1"); err != redis.ErrConfigNotAllowed {
+		t.Log(err)
+		t.FailNow()
+	}
+}
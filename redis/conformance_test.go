@@ -0,0 +1,15 @@
+package redis_test
+
+import (
+	"testing"
+
+	"github.com/zeroxsolutions/banshee/cachetest"
+	"github.com/zeroxsolutions/barbatos/cache"
+)
+
+// TestConformance runs the shared cache.Cache contract against RedisCache.
+func TestConformance(t *testing.T) {
+	cachetest.RunConformance(t, func() cache.Cache {
+		return initRedisCache(t)
+	})
+}
@@ -0,0 +1,99 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/zeroxsolutions/banshee"
+)
+
+var _ banshee.QuotaConsumer = (*RedisCache)(nil)
+
+// consumeQuotaScript consumes cost units of a quota unless the usage would exceed the limit, and
+// starts the window when the usage counter is created. A counter found without a TTL is given one,
+// so a quota can never stop resetting. A cost of 0 only reads the counter, so it neither creates
+// it nor starts a window.
+//
+// KEYS[1] = usage counter key, ARGV[1] = limit, ARGV[2] = window in milliseconds, ARGV[3] = cost
+// Returns {allowed, remaining, reset after in milliseconds} where allowed is 1 when consumed.
+var consumeQuotaScript = redis.NewScript(`
+local limit = tonumber(ARGV[1])
+local cost = tonumber(ARGV[3])
+local used = redis.call('GET', KEYS[1])
+if used then
+	used = tonumber(used)
+	if not used then
+		return redis.error_reply('ERR value is not an integer or out of range')
+	end
+else
+	used = 0
+end
+if used + cost > limit then
+	local ttl = redis.call('PTTL', KEYS[1])
+	if ttl < 0 then
+		ttl = 0
+	end
+	return {0, math.max(limit - used, 0), ttl}
+end
+if cost == 0 then
+	local ttl = redis.call('PTTL', KEYS[1])
+	if ttl < 0 then
+		ttl = 0
+	end
+	return {1, limit - used, ttl}
+end
+used = redis.call('INCRBY', KEYS[1], cost)
+local ttl = redis.call('PTTL', KEYS[1])
+if ttl < 0 then
+	redis.call('PEXPIRE', KEYS[1], ARGV[2])
+	ttl = tonumber(ARGV[2])
+end
+return {1, limit - used, ttl}
+`)
+
+// ConsumeQuota atomically consumes cost units of the quota stored at key, which allows limit
+// units per window, e.g. the API calls of a billing plan. The check, the INCRBY and the EXPIRE of
+// a newly created counter run in a single Lua script, so concurrent callers can never consume
+// more than limit units within a window.
+//
+// Behavior:
+//   - The window starts with the first consumption and is not extended by later ones; once it
+//     ends the counter expires and the full limit is available again
+//   - A request that would exceed the limit consumes nothing, so a smaller cost may still fit
+//   - A cost of 0 checks the quota without consuming it
+//   - Non-integer values return the Redis "not an integer" error
+//
+// Parameters:
+//   - ctx: Context for timeout control and request cancellation
+//   - key: Redis key holding the usage counter
+//   - limit: Units allowed per window, must not be negative
+//   - window: Length of the window, must be positive
+//   - cost: Units to consume, must not be negative
+//
+// Returns:
+//   - allowed: true if the units were consumed
+//   - remaining: Units left in the current window after the call
+//   - resetAfter: Time until the window resets; 0 if nothing was consumed yet
+//   - err: An error for invalid arguments, or a Redis error
+//
+// Example:
+//
+//	allowed, remaining, resetAfter, err := cache.ConsumeQuota(ctx, "quota:api:"+accountID, 10000, 30*24*time.Hour, 1)
+//	if err == nil && !allowed {
+//	    w.Header().Set("Retry-After", strconv.Itoa(int(resetAfter.Seconds())))
+//	}
+func (r *RedisCache) ConsumeQuota(ctx context.Context, key string, limit int64, window time.Duration, cost int64) (bool, int64, time.Duration, error) {
+	if limit < 0 || cost < 0 {
+		return false, 0, 0, errors.New("redis: quota limit and cost must not be negative")
+	}
+	if window <= 0 {
+		return false, 0, 0, errors.New("redis: quota window must be positive")
+	}
+	result, err := consumeQuotaScript.Run(ctx, r.client, []string{key}, limit, millis(window), cost).Int64Slice()
+	if err != nil {
+		return false, 0, 0, err
+	}
+	return result[0] == 1, result[1], time.Duration(result[2]) * time.Millisecond, nil
+}
@@ -0,0 +1,77 @@
+package redis_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/zeroxsolutions/banshee/redis"
+	"github.com/zeroxsolutions/barbatos/cache"
+	"github.com/zeroxsolutions/strike/ssutil"
+)
+
+// TestRedisCache_ConsumeQuota verifies that ConsumeQuota allows variable costs up to the limit,
+// denies without consuming, and resets once the window ends.
+func TestRedisCache_ConsumeQuota(t *testing.T) {
+	redisCache := initRedisCache(t)
+
+	defer func(redisCache cache.Cache) {
+		if err := redisCache.Close(); err != nil {
+			t.Log("Close Redis cache connection err", err)
+		}
+	}(redisCache)
+
+	consumer := redisCache.(*redis.RedisCache)
+
+	ctx := context.Background()
+	key := ssutil.MakeString(10)
+	window := 300 * time.Millisecond
+
+	defer func() {
+		if err := consumer.Del(ctx, key); err != nil {
+			t.Error(err)
+		}
+	}()
+
+	allowed, remaining, resetAfter, err := consumer.ConsumeQuota(ctx, key, 10, window, 0)
+	if err != nil || !allowed || remaining != 10 || resetAfter != 0 {
+		t.Fatal("a cost of 0 should check the quota:", allowed, remaining, resetAfter, err)
+	}
+	if exists, err := consumer.Exists(ctx, key); err != nil || exists != 0 {
+		t.Fatal("a cost of 0 should not create the counter:", exists, err)
+	}
+
+	allowed, remaining, resetAfter, err = consumer.ConsumeQuota(ctx, key, 10, window, 6)
+	if err != nil || !allowed || remaining != 4 || resetAfter <= 0 || resetAfter > window {
+		t.Fatal(allowed, remaining, resetAfter, err)
+	}
+
+	allowed, remaining, resetAfter, err = consumer.ConsumeQuota(ctx, key, 10, window, 5)
+	if err != nil || allowed || remaining != 4 || resetAfter <= 0 || resetAfter > window {
+		t.Fatal("a cost over the remaining quota should be denied:", allowed, remaining, resetAfter, err)
+	}
+
+	allowed, remaining, _, err = consumer.ConsumeQuota(ctx, key, 10, window, 4)
+	if err != nil || !allowed || remaining != 0 {
+		t.Fatal("a denied request should not consume the quota:", allowed, remaining, err)
+	}
+
+	allowed, remaining, _, err = consumer.ConsumeQuota(ctx, key, 10, window, 1)
+	if err != nil || allowed || remaining != 0 {
+		t.Fatal(allowed, remaining, err)
+	}
+
+	time.Sleep(window + 200*time.Millisecond)
+
+	allowed, remaining, _, err = consumer.ConsumeQuota(ctx, key, 10, window, 1)
+	if err != nil || !allowed || remaining != 9 {
+		t.Fatal("the quota should reset after the window:", allowed, remaining, err)
+	}
+
+	if _, _, _, err := consumer.ConsumeQuota(ctx, key, 10, 0, 1); err == nil {
+		t.Fatal("a non-positive window should be rejected")
+	}
+	if _, _, _, err := consumer.ConsumeQuota(ctx, key, 10, window, -1); err == nil {
+		t.Fatal("a negative cost should be rejected")
+	}
+}
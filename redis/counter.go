@@ -0,0 +1,240 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/zeroxsolutions/banshee"
+)
+
+var _ banshee.CounterCache = (*RedisCache)(nil)
+
+// incrByCeilScript increments a counter only if the result does not exceed the ceiling.
+// A missing key counts as 0; non-integer values fail like INCRBY does. The key's TTL is
+// preserved because INCRBY never touches it.
+//
+// KEYS[1] = counter key, ARGV[1] = delta, ARGV[2] = ceiling
+// Returns {value, applied} where applied is 1 when the increment happened.
+var incrByCeilScript = redis.NewScript(`
+local current = redis.call('GET', KEYS[1])
+if current then
+	current = tonumber(current)
+	if not current then
+		return redis.error_reply('ERR value is not an integer or out of range')
+	end
+else
+	current = 0
+end
+if current + tonumber(ARGV[1]) > tonumber(ARGV[2]) then
+	return {current, 0}
+end
+return {redis.call('INCRBY', KEYS[1], ARGV[1]), 1}
+`)
+
+// incrFirstSeenScript increments a counter and sets its TTL when the increment created it.
+//
+// KEYS[1] = counter key, ARGV[1] = window in milliseconds
+// Returns the counter value after the increment.
+var incrFirstSeenScript = redis.NewScript(`
+local count = redis.call('INCR', KEYS[1])
+if count == 1 then
+	redis.call('PEXPIRE', KEYS[1], ARGV[1])
+end
+return count
+`)
+
+// incrWithExpirationScript increments a counter by a delta and sets its TTL when the increment
+// created it, i.e. when the new value equals the delta.
+//
+// KEYS[1] = counter key, ARGV[1] = delta, ARGV[2] = expiration in milliseconds
+// Returns the counter value after the increment.
+var incrWithExpirationScript = redis.NewScript(`
+local count = redis.call('INCRBY', KEYS[1], ARGV[1])
+if count == tonumber(ARGV[1]) then
+	redis.call('PEXPIRE', KEYS[1], ARGV[2])
+end
+return count
+`)
+
+// IncrByCeil atomically increments the counter at key by delta unless the result would exceed ceil.
+// The check and the increment run in a single Lua script, so concurrent callers can never push
+// the counter past the ceiling, which makes it suitable for bounded resources such as "seats
+// remaining" that are handed back concurrently.
+//
+// Behavior:
+//   - A missing key is treated as 0 and created by a successful increment
+//   - When the increment would exceed ceil, the counter is left untouched
+//   - The key's TTL, if any, is preserved
+//   - Non-integer values return the Redis "not an integer" error
+//
+// Parameters:
+//   - ctx: Context for timeout control and request cancellation
+//   - key: Redis key holding the counter
+//   - delta: Amount to add
+//   - ceil: Maximum value the counter may reach
+//
+// Returns:
+//   - int64: The counter value after the call (the current value when refused)
+//   - bool: true if the increment was applied
+//   - error: Redis connection error or command execution error
+//
+// Example:
+//
+//	// A booking was cancelled; give the seat back without exceeding capacity.
+//	seats, applied, err := cache.IncrByCeil(ctx, "seats:flight:42", 1, 180)
+func (r *RedisCache) IncrByCeil(ctx context.Context, key string, delta, ceil int64) (int64, bool, error) {
+	result, err := incrByCeilScript.Run(ctx, r.client, []string{key}, delta, ceil).Int64Slice()
+	if err != nil {
+		return 0, false, err
+	}
+	return result[0], result[1] == 1, nil
+}
+
+// IncrFirstSeen atomically counts an occurrence at key and reports whether it is the first one
+// within window, e.g. the first time a payment fingerprint is seen in the last hour. INCR and the
+// EXPIRE of a newly created key run in a single Lua script, so exactly one of many concurrent
+// callers sees firstSeen, and the counter can't be left without a TTL.
+//
+// Behavior:
+//   - The window starts at the first occurrence: later occurrences don't extend it
+//   - Once the key expires, the next occurrence is first seen again
+//   - A key that already exists without a TTL is counted but never given one
+//   - Non-integer values return the Redis "not an integer" error
+//
+// Parameters:
+//   - ctx: Context for timeout control and request cancellation
+//   - key: Redis key holding the counter
+//   - window: TTL given to the counter when it is created, must be positive
+//
+// Returns:
+//   - int64: The number of occurrences within the window, including this one
+//   - bool: true if this is the first occurrence within the window
+//   - error: An error if window is not positive, or a Redis error
+//
+// Example:
+//
+//	count, firstSeen, err := cache.IncrFirstSeen(ctx, "fingerprint:"+hash, time.Hour)
+func (r *RedisCache) IncrFirstSeen(ctx context.Context, key string, window time.Duration) (int64, bool, error) {
+	if window <= 0 {
+		return 0, false, errors.New("redis: first-seen window must be positive")
+	}
+	count, err := incrFirstSeenScript.Run(ctx, r.client, []string{key}, millis(window)).Int64()
+	if err != nil {
+		return 0, false, err
+	}
+	return count, count == 1, nil
+}
+
+// Incr atomically increments the integer stored at key by one and returns the new value.
+// A missing key is treated as 0, and the key's TTL, if any, is preserved.
+//
+// Calling it on a value that isn't an integer, or whose result would overflow an int64, returns
+// the Redis error unchanged ("ERR value is not an integer or out of range"), so callers can
+// detect type mismatches; the value is left untouched.
+//
+// Parameters:
+//   - ctx: Context for timeout control and request cancellation
+//   - key: Redis key holding the counter
+//
+// Returns:
+//   - int64: The counter value after the increment
+//   - error: Redis connection error or command execution error
+//
+// Example:
+//
+//	views, err := cache.Incr(ctx, "views:article:42")
+func (r *RedisCache) Incr(ctx context.Context, key string) (int64, error) {
+	return r.client.Incr(ctx, key).Result()
+}
+
+// IncrBy atomically adds delta, which may be negative, to the integer stored at key and returns
+// the new value. It behaves like Incr otherwise.
+//
+// Parameters:
+//   - ctx: Context for timeout control and request cancellation
+//   - key: Redis key holding the counter
+//   - delta: Amount to add
+//
+// Returns:
+//   - int64: The counter value after the increment
+//   - error: Redis connection error or command execution error
+//
+// Example:
+//
+//	bytes, err := cache.IncrBy(ctx, "traffic:user:123", int64(len(payload)))
+func (r *RedisCache) IncrBy(ctx context.Context, key string, delta int64) (int64, error) {
+	return r.client.IncrBy(ctx, key, delta).Result()
+}
+
+// Decr atomically decrements the integer stored at key by one and returns the new value. It
+// behaves like Incr otherwise.
+//
+// Parameters:
+//   - ctx: Context for timeout control and request cancellation
+//   - key: Redis key holding the counter
+//
+// Returns:
+//   - int64: The counter value after the decrement
+//   - error: Redis connection error or command execution error
+//
+// Example:
+//
+//	active, err := cache.Decr(ctx, "connections:active")
+func (r *RedisCache) Decr(ctx context.Context, key string) (int64, error) {
+	return r.client.Decr(ctx, key).Result()
+}
+
+// DecrBy atomically subtracts delta from the integer stored at key and returns the new value. It
+// behaves like Incr otherwise.
+//
+// Parameters:
+//   - ctx: Context for timeout control and request cancellation
+//   - key: Redis key holding the counter
+//   - delta: Amount to subtract
+//
+// Returns:
+//   - int64: The counter value after the decrement
+//   - error: Redis connection error or command execution error
+//
+// Example:
+//
+//	stock, err := cache.DecrBy(ctx, "stock:sku:42", int64(quantity))
+func (r *RedisCache) DecrBy(ctx context.Context, key string, delta int64) (int64, error) {
+	return r.client.DecrBy(ctx, key, delta).Result()
+}
+
+// IncrWithExpiration atomically adds delta to the counter at key and sets its TTL to expiration
+// when the increment created it, the building block of fixed-window rate limiting. INCRBY and the
+// PEXPIRE run in a single Lua script, so the TTL can't be lost to a crash or a race between two
+// callers both seeing an existing key.
+//
+// Behavior:
+//   - The key is considered created when the new value equals delta; only then is the TTL set,
+//     so later increments don't extend the window
+//   - A key that already exists without a TTL is incremented but never given one
+//   - Non-integer values return the Redis "not an integer" error
+//
+// Parameters:
+//   - ctx: Context for timeout control and request cancellation
+//   - key: Redis key holding the counter
+//   - delta: Amount to add
+//   - expiration: TTL given to the counter when it is created, must be positive
+//
+// Returns:
+//   - int64: The counter value after the increment
+//   - error: An error if expiration is not positive, or a Redis error
+//
+// Example:
+//
+//	hits, err := cache.IncrWithExpiration(ctx, "rate:"+clientIP, 1, time.Minute)
+//	if err == nil && hits > 100 {
+//	    // Reject: more than 100 requests this minute
+//	}
+func (r *RedisCache) IncrWithExpiration(ctx context.Context, key string, delta int64, expiration time.Duration) (int64, error) {
+	if expiration <= 0 {
+		return 0, errors.New("redis: counter expiration must be positive")
+	}
+	return incrWithExpirationScript.Run(ctx, r.client, []string{key}, delta, millis(expiration)).Int64()
+}
@@ -0,0 +1,286 @@
+package redis_test
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/zeroxsolutions/banshee/redis"
+	"github.com/zeroxsolutions/barbatos/cache"
+	"github.com/zeroxsolutions/strike/ssutil"
+)
+
+// TestRedisCache_IncrByCeil verifies that concurrent capped increments never push the counter
+// past the ceiling and that exactly the available headroom is consumed.
+func TestRedisCache_IncrByCeil(t *testing.T) {
+	redisCache := initRedisCache(t)
+
+	defer func(redisCache cache.Cache) {
+		if err := redisCache.Close(); err != nil {
+			t.Log("Close Redis cache connection err", err)
+		}
+	}(redisCache)
+
+	counters := redisCache.(*redis.RedisCache)
+
+	ctx := context.Background()
+	key := ssutil.MakeString(10)
+
+	const (
+		start = 95
+		ceil  = 100
+	)
+
+	if err := counters.Set(ctx, key, start); err != nil {
+		t.Fatal(err)
+	}
+
+	defer func() {
+		if err := counters.Del(ctx, key); err != nil {
+			t.Error(err)
+		}
+	}()
+
+	var applied int64
+	var wg sync.WaitGroup
+	for range make([]int, 50) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			value, ok, err := counters.IncrByCeil(ctx, key, 1, ceil)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			if value > ceil {
+				t.Error("counter exceeded ceiling:", value)
+			}
+			if ok {
+				atomic.AddInt64(&applied, 1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if applied != ceil-start {
+		t.Log("unexpected number of applied increments:", applied)
+		t.FailNow()
+	}
+
+	value, err := counters.Get(ctx, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if value != "100" {
+		t.Log("unexpected final value:", value)
+		t.FailNow()
+	}
+
+	missing := ssutil.MakeString(10)
+
+	value2, ok, err := counters.IncrByCeil(ctx, missing, 5, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if ok || value2 != 0 {
+		t.FailNow()
+	}
+}
+
+// TestRedisCache_IncrFirstSeen verifies that exactly one of many concurrent callers sees the
+// first occurrence, that the counter gets the window as TTL, and that it starts over once expired.
+func TestRedisCache_IncrFirstSeen(t *testing.T) {
+	redisCache := initRedisCache(t)
+
+	defer func(redisCache cache.Cache) {
+		if err := redisCache.Close(); err != nil {
+			t.Log("Close Redis cache connection err", err)
+		}
+	}(redisCache)
+
+	client := initRedisClient(t)
+	defer client.Close()
+
+	counters := redisCache.(*redis.RedisCache)
+
+	ctx := context.Background()
+	key := ssutil.MakeString(10)
+
+	defer func() {
+		if err := counters.Del(ctx, key); err != nil {
+			t.Error(err)
+		}
+	}()
+
+	const callers = 50
+
+	var first int64
+	seen := make([]bool, callers+1)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for range make([]int, callers) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			count, firstSeen, err := counters.IncrFirstSeen(ctx, key, time.Minute)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			if firstSeen != (count == 1) {
+				t.Error("firstSeen disagrees with count:", count, firstSeen)
+			}
+			if firstSeen {
+				atomic.AddInt64(&first, 1)
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			if count < 1 || count > callers || seen[count] {
+				t.Error("unexpected count:", count)
+				return
+			}
+			seen[count] = true
+		}()
+	}
+	wg.Wait()
+
+	if first != 1 {
+		t.Log("unexpected number of first occurrences:", first)
+		t.FailNow()
+	}
+
+	ttl, err := client.PTTL(ctx, key).Result()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ttl <= 0 || ttl > time.Minute {
+		t.Log("unexpected TTL:", ttl)
+		t.FailNow()
+	}
+
+	if err := counters.Del(ctx, key); err != nil {
+		t.Fatal(err)
+	}
+	if count, firstSeen, err := counters.IncrFirstSeen(ctx, key, time.Minute); err != nil || count != 1 || !firstSeen {
+		t.Fatal(count, firstSeen, err)
+	}
+
+	if _, _, err := counters.IncrFirstSeen(ctx, key, 0); err == nil {
+		t.Fatal("expected an error for a zero window")
+	}
+}
+
+// TestRedisCache_IncrDecr verifies that the counter methods return the value after the operation
+// and report non-integer values as errors.
+func TestRedisCache_IncrDecr(t *testing.T) {
+	redisCache := initRedisCache(t)
+
+	defer func(redisCache cache.Cache) {
+		if err := redisCache.Close(); err != nil {
+			t.Log("Close Redis cache connection err", err)
+		}
+	}(redisCache)
+
+	counter := redisCache.(*redis.RedisCache)
+
+	ctx := context.Background()
+	key := ssutil.MakeString(10)
+	text := ssutil.MakeString(10)
+
+	defer func() {
+		if err := counter.Del(ctx, key, text); err != nil {
+			t.Error(err)
+		}
+	}()
+
+	steps := []struct {
+		name     string
+		apply    func() (int64, error)
+		expected int64
+	}{
+		{"Incr", func() (int64, error) { return counter.Incr(ctx, key) }, 1},
+		{"IncrBy", func() (int64, error) { return counter.IncrBy(ctx, key, 10) }, 11},
+		{"Decr", func() (int64, error) { return counter.Decr(ctx, key) }, 10},
+		{"DecrBy", func() (int64, error) { return counter.DecrBy(ctx, key, 15) }, -5},
+	}
+	for _, step := range steps {
+		if value, err := step.apply(); err != nil || value != step.expected {
+			t.Fatal(step.name, value, err)
+		}
+	}
+
+	if err := counter.Set(ctx, text, "not a number"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := counter.Incr(ctx, text); err == nil {
+		t.Fatal("incrementing a non-integer value should fail")
+	}
+	if value, err := counter.Get(ctx, text); err != nil || value != "not a number" {
+		t.Fatal(value, err)
+	}
+}
+
+// TestRedisCache_IncrWithExpiration verifies that concurrent increments set the TTL once, when the
+// counter is created, and that later increments don't extend it.
+func TestRedisCache_IncrWithExpiration(t *testing.T) {
+	redisCache := initRedisCache(t)
+
+	defer func(redisCache cache.Cache) {
+		if err := redisCache.Close(); err != nil {
+			t.Log("Close Redis cache connection err", err)
+		}
+	}(redisCache)
+
+	client := initRedisClient(t)
+	defer client.Close()
+
+	counter := redisCache.(*redis.RedisCache)
+
+	ctx := context.Background()
+	key := ssutil.MakeString(10)
+
+	defer func() {
+		if err := counter.Del(ctx, key); err != nil {
+			t.Error(err)
+		}
+	}()
+
+	const callers = 50
+	var wg sync.WaitGroup
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := counter.IncrWithExpiration(ctx, key, 2, time.Minute); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if value, err := counter.Get(ctx, key); err != nil || value != "100" {
+		t.Fatal(value, err)
+	}
+
+	if ttl, err := client.PTTL(ctx, key).Result(); err != nil || ttl <= 0 || ttl > time.Minute {
+		t.Fatal("the counter should have the TTL set at creation:", ttl, err)
+	}
+
+	if err := client.PExpire(ctx, key, time.Hour).Err(); err != nil {
+		t.Fatal(err)
+	}
+	if value, err := counter.IncrWithExpiration(ctx, key, 1, time.Minute); err != nil || value != 101 {
+		t.Fatal(value, err)
+	}
+	if ttl, err := client.PTTL(ctx, key).Result(); err != nil || ttl <= time.Minute {
+		t.Fatal("an increment of an existing counter should keep its TTL:", ttl, err)
+	}
+
+	if _, err := counter.IncrWithExpiration(ctx, key, 1, 0); err == nil {
+		t.Fatal("a non-positive expiration should be rejected")
+	}
+}
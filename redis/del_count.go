@@ -0,0 +1,43 @@
+package redis
+
+import (
+	"context"
+
+	"github.com/zeroxsolutions/banshee"
+)
+
+var _ banshee.CountingDeleter = (*RedisCache)(nil)
+
+// DelCount deletes keys as Del does and returns how many of them existed, so callers such as
+// bulk.DelWhere can report the keys actually removed rather than the keys they asked to remove.
+//
+// Caches built with SetSoftDelete rename the keys to tombstones instead and count the keys
+// renamed.
+//
+// Parameters:
+//   - ctx: Context for timeout control and request cancellation
+//   - keys: Redis keys to delete
+//
+// Returns:
+//   - int64: Number of keys deleted, or soft-deleted
+//   - error: Redis connection error or command execution error
+//
+// Example:
+//
+//	deleted, err := cache.DelCount(ctx, "session:abc", "session:def")
+func (r *RedisCache) DelCount(ctx context.Context, keys ...string) (int64, error) {
+	var (
+		count int64
+		err   error
+	)
+	if r.options.SoftDeleteRetention > 0 {
+		count, err = r.softDel(ctx, keys)
+	} else {
+		count, err = r.client.Del(ctx, keys...).Result()
+	}
+	if err != nil {
+		return 0, err
+	}
+	r.audit(ctx, AuditOpDel, keys, "", count)
+	return count, nil
+}
@@ -0,0 +1,146 @@
+package redis
+
+import (
+	"context"
+
+	"github.com/zeroxsolutions/banshee"
+)
+
+var _ banshee.PatternDeleteGuard = (*RedisCache)(nil)
+
+// DefaultDelBatchSize is the number of keys DelWithPattern deletes per call when none is
+// configured with SetDelBatchSize.
+const DefaultDelBatchSize = 500
+
+// DelWithPatternCount deletes all keys matching pattern, as DelWithPattern does, and returns the
+// number of keys deleted.
+//
+// Keys are walked with SCAN and removed with one UNLINK per batch of SetDelBatchSize keys, so the
+// server reclaims their memory in the background and keeps serving other clients between batches.
+// Caches built with SetLegacyCommands(true), for servers older than Redis 4 without UNLINK, collect
+// every matching key first and remove them all with a single DEL.
+//
+// Behavior:
+//   - The context is checked between batches: once it is done, the keys deleted so far stay
+//     deleted and their count is returned together with the context error
+//   - Keys written while the walk is in progress may or may not be deleted
+//   - Caches built with SetSoftDelete rename the matching keys to tombstones instead, skipping
+//     existing tombstones
+//   - Caches built with SetDelWithPatternDisabled(true) return ErrOperationDisabled
+//
+// Parameters:
+//   - ctx: Context for timeout control and request cancellation
+//   - pattern: Glob-style pattern to match keys for deletion
+//
+// Returns:
+//   - int64: Number of keys deleted, or soft-deleted
+//   - error: An error if the context is done or Redis fails
+//
+// Example:
+//
+//	deleted, err := cache.DelWithPatternCount(ctx, "temp:*")
+//	log.Printf("cleanup removed %d keys", deleted)
+func (r *RedisCache) DelWithPatternCount(ctx context.Context, pattern string) (int64, error) {
+	if err := r.CheckPatternDelete(); err != nil {
+		return 0, err
+	}
+	var (
+		count int64
+		err   error
+	)
+	if r.options.LegacyCommands {
+		count, err = r.delWithPatternLegacy(ctx, pattern)
+	} else {
+		count, err = r.delWithPatternBatched(ctx, pattern)
+	}
+	if err == nil || count > 0 {
+		r.audit(ctx, AuditOpDelWithPattern, nil, pattern, count)
+	}
+	return count, err
+}
+
+// CheckPatternDelete returns ErrOperationDisabled on caches built with
+// SetDelWithPatternDisabled(true), and nil otherwise. Client-side helpers that delete keys by
+// pattern, such as bulk.DelWhere, call it so that they honour the same guard as DelWithPattern.
+//
+// Returns:
+//   - error: ErrOperationDisabled if pattern-based deletes are switched off
+//
+// Example:
+//
+//	if err := cache.CheckPatternDelete(); err != nil {
+//	    return err
+//	}
+func (r *RedisCache) CheckPatternDelete() error {
+	if r.options.DelWithPatternDisabled {
+		return ErrOperationDisabled
+	}
+	return nil
+}
+
+// delWithPatternBatched deletes the keys matching pattern one SCAN batch at a time.
+func (r *RedisCache) delWithPatternBatched(ctx context.Context, pattern string) (int64, error) {
+	var (
+		count  int64
+		cursor uint64
+		batch  []string
+	)
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		n, err := r.delBatch(ctx, batch)
+		count += n
+		batch = batch[:0]
+		return err
+	}
+	for {
+		if err := ctx.Err(); err != nil {
+			return count, err
+		}
+		keys, next, err := r.client.Scan(ctx, cursor, pattern, r.options.ScanCount).Result()
+		if err != nil {
+			return count, err
+		}
+		for _, key := range r.liveKeys(keys) {
+			batch = append(batch, key)
+			if int64(len(batch)) < r.options.DelBatchSize {
+				continue
+			}
+			if err := flush(); err != nil {
+				return count, err
+			}
+			if err := ctx.Err(); err != nil {
+				return count, err
+			}
+		}
+		if next == 0 {
+			return count, flush()
+		}
+		cursor = next
+	}
+}
+
+// delWithPatternLegacy deletes the keys matching pattern with a single DEL, as servers without
+// UNLINK require.
+func (r *RedisCache) delWithPatternLegacy(ctx context.Context, pattern string) (int64, error) {
+	keys, err := r.Keys(ctx, pattern)
+	if err != nil {
+		return 0, err
+	}
+	if len(keys) == 0 {
+		return 0, nil
+	}
+	if r.options.SoftDeleteRetention > 0 {
+		return r.softDel(ctx, keys)
+	}
+	return r.client.Del(ctx, keys...).Result()
+}
+
+// delBatch removes one batch of keys, renaming them to tombstones on soft-deleting caches.
+func (r *RedisCache) delBatch(ctx context.Context, keys []string) (int64, error) {
+	if r.options.SoftDeleteRetention > 0 {
+		return r.softDel(ctx, keys)
+	}
+	return r.client.Unlink(ctx, keys...).Result()
+}
@@ -0,0 +1,134 @@
+package redis_test
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/zeroxsolutions/banshee/bulk"
+	"github.com/zeroxsolutions/banshee/redis"
+	"github.com/zeroxsolutions/barbatos/cache"
+	"github.com/zeroxsolutions/strike/ssutil"
+)
+
+// TestRedisCache_DelWithPatternCount verifies that pattern deletes remove every matching key in
+// batches, report how many were deleted, honour a cancelled context, and keep the single-DEL
+// behaviour with legacy commands.
+func TestRedisCache_DelWithPatternCount(t *testing.T) {
+	for name, opts := range map[string]*redis.RedisCacheOptionsBuilder{
+		"Batched": redis.NewRedisCacheOptions().SetDelBatchSize(100),
+		"Legacy":  redis.NewRedisCacheOptions().SetLegacyCommands(true),
+	} {
+		t.Run(name, func(t *testing.T) {
+			redisCache := initRedisCache(t, opts)
+
+			defer func(redisCache cache.Cache) {
+				if err := redisCache.Close(); err != nil {
+					t.Log("Close Redis cache connection err", err)
+				}
+			}(redisCache)
+
+			client := initRedisClient(t)
+			defer client.Close()
+
+			deleter := redisCache.(*redis.RedisCache)
+
+			ctx := context.Background()
+			prefix := ssutil.MakeString(10)
+			other := ssutil.MakeString(10)
+
+			const total = 1050
+			pipe := client.Pipeline()
+			for i := 0; i < total; i++ {
+				pipe.Set(ctx, prefix+":"+strconv.Itoa(i), "value", 0)
+			}
+			pipe.Set(ctx, other, "value", 0)
+			if _, err := pipe.Exec(ctx); err != nil {
+				t.Fatal(err)
+			}
+
+			defer func() {
+				if err := deleter.Del(ctx, other); err != nil {
+					t.Error(err)
+				}
+			}()
+
+			cancelled, cancel := context.WithCancel(ctx)
+			cancel()
+			if n, err := deleter.DelWithPatternCount(cancelled, prefix+":*"); !errors.Is(err, context.Canceled) || n != 0 {
+				t.Fatal("a cancelled delete should not remove keys:", n, err)
+			}
+
+			n, err := deleter.DelWithPatternCount(ctx, prefix+":*")
+			if err != nil || n != total {
+				t.Fatal(n, err)
+			}
+
+			if remaining, err := client.Keys(ctx, prefix+":*").Result(); err != nil || len(remaining) != 0 {
+				t.Fatal(len(remaining), err)
+			}
+			if found, err := client.Exists(ctx, other).Result(); err != nil || found != 1 {
+				t.Fatal("a key outside the pattern was deleted:", found, err)
+			}
+
+			if n, err := deleter.DelWithPatternCount(ctx, prefix+":*"); err != nil || n != 0 {
+				t.Fatal(n, err)
+			}
+		})
+	}
+}
+
+// TestRedisCache_DelWhere verifies that bulk.DelWhere walks, reads and deletes through the Redis
+// capabilities, counts the keys actually deleted, and honours SetDelWithPatternDisabled.
+func TestRedisCache_DelWhere(t *testing.T) {
+	redisCache := initRedisCache(t, redis.NewRedisCacheOptions().SetScanCount(50))
+
+	defer func(redisCache cache.Cache) {
+		if err := redisCache.Close(); err != nil {
+			t.Log("Close Redis cache connection err", err)
+		}
+	}(redisCache)
+
+	ctx := context.Background()
+	prefix := ssutil.MakeString(10)
+
+	const total = 300
+	for i := 0; i < total; i++ {
+		status := "pending"
+		if i%3 == 0 {
+			status = "done"
+		}
+		if err := redisCache.Set(ctx, prefix+":"+strconv.Itoa(i), status); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	defer func() {
+		if err := redisCache.DelWithPattern(ctx, prefix+":*"); err != nil {
+			t.Error(err)
+		}
+	}()
+
+	deleted, err := bulk.DelWhere(ctx, redisCache, prefix+":*", func(key, value string) bool {
+		return value == "done"
+	})
+	if err != nil || deleted != total/3 {
+		t.Fatal(deleted, err)
+	}
+
+	if keys, err := redisCache.Keys(ctx, prefix+":*"); err != nil || len(keys) != total-total/3 {
+		t.Fatal(len(keys), err)
+	}
+
+	disabled := initRedisCache(t, redis.NewRedisCacheOptions().SetDelWithPatternDisabled(true))
+	defer disabled.Close()
+
+	if _, err := bulk.DelWhere(ctx, disabled, prefix+":*", func(key, value string) bool { return true }); !errors.Is(err, redis.ErrOperationDisabled) {
+		t.Fatal(err)
+	}
+
+	if keys, err := redisCache.Keys(ctx, prefix+":*"); err != nil || len(keys) != total-total/3 {
+		t.Fatal("a guarded DelWhere deleted keys:", len(keys), err)
+	}
+}
@@ -0,0 +1,249 @@
+// Package delay schedules payloads for delivery at a given time on top of Redis sorted sets, with
+// at-least-once delivery and no queue system beyond Redis.
+//
+// Each queue uses four keys sharing a hash tag, so they live in one slot on Redis Cluster:
+//
+//	<prefix>{<queue>}:scheduled   sorted set of job IDs scored by due time (unix milliseconds)
+//	<prefix>{<queue>}:processing  sorted set of leased job IDs scored by lease expiry
+//	<prefix>{<queue>}:payloads    hash of job ID to payload
+//	<prefix>{<queue>}:leases      hash of job ID to the token of its current lease
+//
+// PollDue hands each due job to exactly one poller under a lease. A job that isn't acknowledged
+// before its lease expires is moved back to the scheduled set by the next poll and delivered
+// again, so handlers must be idempotent.
+package delay
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+	"github.com/zeroxsolutions/banshee/redis"
+	"github.com/zeroxsolutions/strike/builderutil"
+)
+
+// scheduleScript stores the payload and schedules the job.
+//
+// KEYS[1] = scheduled, KEYS[2] = payloads, ARGV[1] = job ID, ARGV[2] = due time, ARGV[3] = payload
+var scheduleScript = goredis.NewScript(`
+redis.call('HSET', KEYS[2], ARGV[1], ARGV[3])
+redis.call('ZADD', KEYS[1], ARGV[2], ARGV[1])
+return 1
+`)
+
+// pollScript requeues the jobs whose lease expired, then leases up to ARGV[2] due jobs.
+//
+// KEYS[1] = scheduled, KEYS[2] = processing, KEYS[3] = payloads, KEYS[4] = leases
+// ARGV[1] = now, ARGV[2] = limit, ARGV[3] = lease expiry, ARGV[4] = lease token
+// Returns a flat list of job ID, due time and payload triples.
+var pollScript = goredis.NewScript(`
+local expired = redis.call('ZRANGEBYSCORE', KEYS[2], '-inf', ARGV[1], 'WITHSCORES')
+for i = 1, #expired, 2 do
+	redis.call('ZREM', KEYS[2], expired[i])
+	redis.call('HDEL', KEYS[4], expired[i])
+	redis.call('ZADD', KEYS[1], expired[i + 1], expired[i])
+end
+local due = redis.call('ZRANGEBYSCORE', KEYS[1], '-inf', ARGV[1], 'WITHSCORES', 'LIMIT', 0, ARGV[2])
+local jobs = {}
+for i = 1, #due, 2 do
+	redis.call('ZREM', KEYS[1], due[i])
+	local payload = redis.call('HGET', KEYS[3], due[i])
+	if payload then
+		redis.call('ZADD', KEYS[2], ARGV[3], due[i])
+		redis.call('HSET', KEYS[4], due[i], ARGV[4])
+		table.insert(jobs, due[i])
+		table.insert(jobs, due[i + 1])
+		table.insert(jobs, payload)
+	end
+end
+return jobs
+`)
+
+// ackScript completes a job while the caller still holds its lease.
+//
+// KEYS[1] = processing, KEYS[2] = payloads, KEYS[3] = leases, ARGV[1] = job ID, ARGV[2] = lease token
+var ackScript = goredis.NewScript(`
+if redis.call('HGET', KEYS[3], ARGV[1]) ~= ARGV[2] then
+	return 0
+end
+redis.call('ZREM', KEYS[1], ARGV[1])
+redis.call('HDEL', KEYS[2], ARGV[1])
+redis.call('HDEL', KEYS[3], ARGV[1])
+return 1
+`)
+
+// Job is a due job handed to a poller.
+type Job struct {
+	Queue   string    // Queue is the queue the job was scheduled on.
+	ID      string    // ID is the identifier returned by Schedule.
+	Payload string    // Payload is the scheduled payload.
+	Due     time.Time // Due is the time the job was scheduled for, or its previous lease expiry once redelivered.
+
+	token string
+}
+
+// Scheduler schedules and delivers delayed jobs.
+type Scheduler struct {
+	client  goredis.Scripter
+	options *Options
+}
+
+// New creates a Scheduler running its scripts on client, typically a *redis.Client from
+// github.com/redis/go-redis/v9.
+//
+// Parameters:
+//   - client: Redis client
+//   - opts: Optional Options builders created with NewOptions
+//
+// Returns:
+//   - *Scheduler: The scheduler
+//   - error: An error if building the options fails
+//
+// Example:
+//
+//	scheduler, err := delay.New(goredis.NewClient(&goredis.Options{Addr: "localhost:6379"}))
+//	id, err := scheduler.Schedule(ctx, "emails", payload, time.Now().Add(time.Hour))
+func New(client goredis.Scripter, opts ...builderutil.Lister[Options]) (*Scheduler, error) {
+	options, err := builderutil.Build(append([]builderutil.Lister[Options]{defaultOptions()}, opts...)...)
+	if err != nil {
+		return nil, err
+	}
+	return &Scheduler{client: client, options: options}, nil
+}
+
+// Schedule stores payload for delivery on queue at the given time. Times in the past make the
+// job due immediately.
+//
+// Parameters:
+//   - ctx: Context for timeout control and request cancellation
+//   - queue: Name of the queue
+//   - payload: Payload delivered to the poller
+//   - at: Time from which the job is due
+//
+// Returns:
+//   - string: The job ID
+//   - error: A Redis error
+func (s *Scheduler) Schedule(ctx context.Context, queue string, payload string, at time.Time) (string, error) {
+	id, err := newID()
+	if err != nil {
+		return "", err
+	}
+	keys := s.keys(queue)
+	err = scheduleScript.Run(ctx, s.client, []string{keys.scheduled, keys.payloads}, id, at.UnixMilli(), payload).Err()
+	if err != nil {
+		return "", err
+	}
+	return id, nil
+}
+
+// PollDue atomically leases up to limit jobs of queue that are due, in due order. Each job is
+// handed to exactly one poller per lease: concurrent pollers never receive the same job while its
+// lease runs. Jobs not acknowledged with Ack before leaseTTL elapses are requeued by a later poll.
+//
+// Parameters:
+//   - ctx: Context for timeout control and request cancellation
+//   - queue: Name of the queue
+//   - limit: Maximum number of jobs to lease, must be positive
+//   - leaseTTL: Time the poller has to process and acknowledge the jobs, must be positive
+//
+// Returns:
+//   - []Job: The leased jobs; empty when none is due
+//   - error: An error if limit or leaseTTL is not positive, or a Redis error
+//
+// Example:
+//
+//	jobs, err := scheduler.PollDue(ctx, "emails", 10, time.Minute)
+//	for _, job := range jobs {
+//	    if err := send(job.Payload); err == nil {
+//	        _ = scheduler.Ack(ctx, job)
+//	    }
+//	}
+func (s *Scheduler) PollDue(ctx context.Context, queue string, limit int64, leaseTTL time.Duration) ([]Job, error) {
+	if limit <= 0 {
+		return nil, errors.New("delay: poll limit must be positive")
+	}
+	if leaseTTL <= 0 {
+		return nil, errors.New("delay: lease TTL must be positive")
+	}
+	token, err := newID()
+	if err != nil {
+		return nil, err
+	}
+	keys := s.keys(queue)
+	now := time.Now()
+	result, err := pollScript.Run(
+		ctx, s.client, []string{keys.scheduled, keys.processing, keys.payloads, keys.leases},
+		now.UnixMilli(), limit, now.Add(leaseTTL).UnixMilli(), token,
+	).StringSlice()
+	if err != nil {
+		return nil, err
+	}
+	jobs := make([]Job, 0, len(result)/3)
+	for i := 0; i+2 < len(result); i += 3 {
+		due, err := strconv.ParseFloat(result[i+1], 64)
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, Job{
+			Queue:   queue,
+			ID:      result[i],
+			Payload: result[i+2],
+			Due:     time.UnixMilli(int64(due)),
+			token:   token,
+		})
+	}
+	return jobs, nil
+}
+
+// Ack completes job, removing it from its queue for good.
+//
+// Parameters:
+//   - ctx: Context for timeout control and request cancellation
+//   - job: Job returned by PollDue
+//
+// Returns:
+//   - error: redis.ErrLeaseExpired if the lease expired before the acknowledgement, in which case
+//     the job is or will be delivered again, or a Redis error
+func (s *Scheduler) Ack(ctx context.Context, job Job) error {
+	keys := s.keys(job.Queue)
+	acked, err := ackScript.Run(ctx, s.client, []string{keys.processing, keys.payloads, keys.leases}, job.ID, job.token).Int()
+	if err != nil {
+		return err
+	}
+	if acked == 0 {
+		return redis.ErrLeaseExpired
+	}
+	return nil
+}
+
+// queueKeys holds the keys of one queue.
+type queueKeys struct {
+	scheduled  string
+	processing string
+	payloads   string
+	leases     string
+}
+
+// keys returns the keys of queue.
+func (s *Scheduler) keys(queue string) queueKeys {
+	base := s.options.Prefix + "{" + queue + "}:"
+	return queueKeys{
+		scheduled:  base + "scheduled",
+		processing: base + "processing",
+		payloads:   base + "payloads",
+		leases:     base + "leases",
+	}
+}
+
+// newID returns a random 128-bit identifier in hex.
+func newID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
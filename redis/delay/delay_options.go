@@ -0,0 +1,59 @@
+package delay
+
+import "github.com/zeroxsolutions/strike/builderutil"
+
+// DefaultPrefix is the key prefix used when none is configured.
+const DefaultPrefix = "delay:"
+
+// Options holds the settings of a Scheduler.
+// This struct is populated through OptionsBuilder and consumed by New.
+type Options struct {
+	Prefix string // Prefix is prepended to the keys of every queue.
+}
+
+// OptionsBuilder provides a builder pattern for constructing Options.
+// This builder implements the builderutil.Lister interface to work with the functional options pattern.
+type OptionsBuilder struct {
+	Opts []func(*Options) error // Opts contains the list of option functions to be applied
+}
+
+// SetPrefix configures the prefix of the keys of every queue.
+//
+// Parameters:
+//   - prefix: Key prefix, e.g. "billing:delay:"
+//
+// Returns:
+//   - *OptionsBuilder: The builder instance for method chaining
+func (b *OptionsBuilder) SetPrefix(prefix string) *OptionsBuilder {
+	b.Opts = append(b.Opts, func(o *Options) error {
+		o.Prefix = prefix
+		return nil
+	})
+	return b
+}
+
+// List returns the slice of option functions accumulated by the builder.
+// This method implements the builderutil.Lister interface.
+//
+// Returns:
+//   - []func(*Options) error: A slice of option functions that can be applied to configure Options
+func (b *OptionsBuilder) List() []func(*Options) error {
+	return b.Opts
+}
+
+// NewOptions creates and returns a new instance of OptionsBuilder.
+//
+// Returns:
+//   - *OptionsBuilder: A new instance of OptionsBuilder ready to be configured
+//
+// Example:
+//
+//	opts := delay.NewOptions().SetPrefix("billing:delay:")
+func NewOptions() *OptionsBuilder {
+	return &OptionsBuilder{}
+}
+
+// defaultOptions returns the builder holding the Scheduler defaults.
+func defaultOptions() builderutil.Lister[Options] {
+	return NewOptions().SetPrefix(DefaultPrefix)
+}
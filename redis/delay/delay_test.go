@@ -0,0 +1,195 @@
+package delay_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+	"github.com/zeroxsolutions/banshee/redis"
+	"github.com/zeroxsolutions/banshee/redis/delay"
+	"github.com/zeroxsolutions/strike/ssutil"
+)
+
+// initScheduler creates a Scheduler on the test Redis server and a unique queue name whose keys
+// are deleted when the test ends.
+func initScheduler(t *testing.T) (*delay.Scheduler, string) {
+	db := 0
+	if dbRaw := os.Getenv("REDIS_DB"); dbRaw != "" {
+		dbConverted, err := strconv.Atoi(dbRaw)
+		if err != nil {
+			t.Fatal(err)
+		}
+		db = dbConverted
+	}
+	client := goredis.NewClient(&goredis.Options{
+		Addr:     os.Getenv("REDIS_ADDRESS"),
+		Password: os.Getenv("REDIS_PASSWORD"),
+		DB:       db,
+	})
+
+	queue := ssutil.MakeString(10)
+	t.Cleanup(func() {
+		keys, err := client.Keys(context.Background(), delay.DefaultPrefix+"{"+queue+"}:*").Result()
+		if err == nil && len(keys) > 0 {
+			_ = client.Del(context.Background(), keys...).Err()
+		}
+		_ = client.Close()
+	})
+
+	scheduler, err := delay.New(client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return scheduler, queue
+}
+
+// TestScheduler_PollDue verifies that only due jobs are delivered, in due order, and that
+// acknowledged jobs are not delivered again.
+func TestScheduler_PollDue(t *testing.T) {
+	scheduler, queue := initScheduler(t)
+	ctx := context.Background()
+	now := time.Now()
+
+	late, err := scheduler.Schedule(ctx, queue, "late", now.Add(-time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+	early, err := scheduler.Schedule(ctx, queue, "early", now.Add(-time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := scheduler.Schedule(ctx, queue, "future", now.Add(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+
+	jobs, err := scheduler.PollDue(ctx, queue, 10, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(jobs) != 2 || jobs[0].ID != early || jobs[1].ID != late {
+		t.Fatalf("unexpected jobs: %+v", jobs)
+	}
+	if jobs[0].Payload != "early" || jobs[0].Queue != queue {
+		t.Fatalf("unexpected job: %+v", jobs[0])
+	}
+	if d := jobs[0].Due.Sub(now.Add(-time.Hour)); d < -time.Millisecond || d > time.Millisecond {
+		t.Fatal("unexpected due time:", jobs[0].Due)
+	}
+
+	for _, job := range jobs {
+		if err := scheduler.Ack(ctx, job); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	jobs, err = scheduler.PollDue(ctx, queue, 10, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(jobs) != 0 {
+		t.Fatalf("unexpected jobs: %+v", jobs)
+	}
+
+	if _, err := scheduler.PollDue(ctx, queue, 0, time.Minute); err == nil {
+		t.Fatal("expected an error for a zero limit")
+	}
+}
+
+// TestScheduler_ConcurrentPollers verifies that two concurrent pollers never receive the same job.
+func TestScheduler_ConcurrentPollers(t *testing.T) {
+	scheduler, queue := initScheduler(t)
+	ctx := context.Background()
+
+	const total = 50
+	for i := 0; i < total; i++ {
+		if _, err := scheduler.Schedule(ctx, queue, strconv.Itoa(i), time.Now().Add(-time.Second)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var mu sync.Mutex
+	deliveries := map[string]int{}
+	var wg sync.WaitGroup
+	for p := 0; p < 2; p++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				jobs, err := scheduler.PollDue(ctx, queue, 3, time.Minute)
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				if len(jobs) == 0 {
+					return
+				}
+				mu.Lock()
+				for _, job := range jobs {
+					deliveries[job.ID]++
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if len(deliveries) != total {
+		t.Fatalf("expected %d jobs, got %d", total, len(deliveries))
+	}
+	for id, n := range deliveries {
+		if n != 1 {
+			t.Fatalf("job %s delivered %d times", id, n)
+		}
+	}
+}
+
+// TestScheduler_LeaseExpiry verifies that an unacknowledged job is delivered again once its
+// lease expires, and that the stale lease can no longer acknowledge it.
+func TestScheduler_LeaseExpiry(t *testing.T) {
+	scheduler, queue := initScheduler(t)
+	ctx := context.Background()
+
+	id, err := scheduler.Schedule(ctx, queue, "payload", time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	first, err := scheduler.PollDue(ctx, queue, 1, 50*time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(first) != 1 || first[0].ID != id {
+		t.Fatalf("unexpected jobs: %+v", first)
+	}
+
+	if jobs, err := scheduler.PollDue(ctx, queue, 1, time.Minute); err != nil || len(jobs) != 0 {
+		t.Fatalf("job delivered while leased: %+v, %v", jobs, err)
+	}
+
+	time.Sleep(80 * time.Millisecond)
+
+	second, err := scheduler.PollDue(ctx, queue, 1, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(second) != 1 || second[0].ID != id || second[0].Payload != "payload" {
+		t.Fatalf("job not requeued: %+v", second)
+	}
+
+	if err := scheduler.Ack(ctx, first[0]); !errors.Is(err, redis.ErrLeaseExpired) {
+		t.Fatal("expected redis.ErrLeaseExpired, got", err)
+	}
+	if err := scheduler.Ack(ctx, second[0]); err != nil {
+		t.Fatal(err)
+	}
+
+	time.Sleep(80 * time.Millisecond)
+	if jobs, err := scheduler.PollDue(ctx, queue, 1, time.Minute); err != nil || len(jobs) != 0 {
+		t.Fatalf("acknowledged job delivered again: %+v, %v", jobs, err)
+	}
+}
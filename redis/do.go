@@ -0,0 +1,53 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/zeroxsolutions/barbatos/cache"
+)
+
+// Do runs an arbitrary command, for commands this package doesn't wrap, such as GEOADD. Unlike
+// using a separate raw client, the command goes through the cache's own connection pool and
+// hooks, such as the server time callback, and its errors are translated like those of the
+// wrapped methods.
+//
+// Reply types:
+//   - Simple and bulk strings are returned as string
+//   - Integers are returned as int64
+//   - Arrays are returned as []interface{} holding the same types, with nil for null elements
+//   - RESP3 doubles, booleans and maps are returned as float64, bool and
+//     map[interface{}]interface{}
+//
+// Error translation:
+//   - A null reply returns cache.ErrCacheNil
+//   - A command refused by ACLs or disabled on the server returns a *PermissionError
+//   - Other server errors, e.g. WRONGTYPE, are returned as-is
+//
+// Parameters:
+//   - ctx: Context for timeout control and request cancellation
+//   - args: Command name followed by its arguments
+//
+// Returns:
+//   - interface{}: The reply, typed as described above
+//   - error: ErrNoCommand, cache.ErrCacheNil, a *PermissionError, or a Redis error
+//
+// Example:
+//
+//	added, err := cache.Do(ctx, "GEOADD", "stores", 13.361389, 38.115556, "palermo")
+func (r *RedisCache) Do(ctx context.Context, args ...interface{}) (interface{}, error) {
+	if len(args) == 0 {
+		return nil, ErrNoCommand
+	}
+	reply, err := r.client.Do(ctx, args...).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, cache.ErrCacheNil
+		}
+		return nil, permissionError(strings.ToUpper(fmt.Sprint(args[0])), err)
+	}
+	return reply, nil
+}
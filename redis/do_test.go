@@ -0,0 +1,73 @@
+package redis_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/zeroxsolutions/banshee/redis"
+	"github.com/zeroxsolutions/barbatos/cache"
+	"github.com/zeroxsolutions/strike/ssutil"
+)
+
+// TestRedisCache_Do verifies custom commands, the reply types, and the error translation.
+func TestRedisCache_Do(t *testing.T) {
+	redisCache := initRedisCache(t)
+
+	defer func(redisCache cache.Cache) {
+		if err := redisCache.Close(); err != nil {
+			t.Log("Close Redis cache connection err", err)
+		}
+	}(redisCache)
+
+	do := redisCache.(*redis.RedisCache)
+
+	ctx := context.Background()
+	key := ssutil.MakeString(10)
+	missing := ssutil.MakeString(10)
+
+	defer func() {
+		if err := do.Del(ctx, key); err != nil {
+			t.Error(err)
+		}
+	}()
+
+	t.Run("Custom", func(t *testing.T) {
+		added, err := do.Do(ctx, "GEOADD", key, 13.361389, 38.115556, "palermo", 15.087269, 37.502669, "catania")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if added != int64(2) {
+			t.Fatalf("unexpected reply %v (%T)", added, added)
+		}
+
+		members, err := do.Do(ctx, "GEORADIUS", key, 15, 37, 200, "km", "ASC")
+		if err != nil {
+			t.Fatal(err)
+		}
+		list, ok := members.([]interface{})
+		if !ok || len(list) != 2 || list[0] != "catania" || list[1] != "palermo" {
+			t.Fatalf("unexpected reply %v (%T)", members, members)
+		}
+	})
+
+	t.Run("Nil", func(t *testing.T) {
+		if _, err := do.Do(ctx, "GET", missing); !errors.Is(err, cache.ErrCacheNil) {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("UnknownCommand", func(t *testing.T) {
+		_, err := do.Do(ctx, "notacommand", key)
+		var permissionErr *redis.PermissionError
+		if !errors.As(err, &permissionErr) || permissionErr.Command != "NOTACOMMAND" {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("NoCommand", func(t *testing.T) {
+		if _, err := do.Do(ctx); !errors.Is(err, redis.ErrNoCommand) {
+			t.Fatal(err)
+		}
+	})
+}
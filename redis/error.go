@@ -0,0 +1,210 @@
+package redis
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/zeroxsolutions/banshee"
+)
+
+// ErrLeasePending is returned by the lease methods when the value is missing and another
+// caller currently holds the lease to recompute it. Callers should wait and retry the read.
+var ErrLeasePending = errors.New("redis: lease pending")
+
+// ErrLeaseExpired is returned when fulfilling or abandoning a lease that is no longer held,
+// typically because its TTL elapsed and another caller acquired a new lease in the meantime.
+var ErrLeaseExpired = errors.New("redis: lease expired")
+
+// ErrReservationExpired is returned by Ack when the job is no longer reserved under the token,
+// because its visibility timeout elapsed and it was requeued, or it was already acknowledged.
+var ErrReservationExpired = errors.New("redis: reservation expired")
+
+// ErrBloomFilterTooLarge is returned by BFReserve when a filter built with SetBloomFallback(true)
+// would need a bitmap larger than Redis allows.
+var ErrBloomFilterTooLarge = errors.New("redis: bloom filter exceeds the maximum bitmap size")
+
+// ErrLockNotAcquired is returned by Locker.Acquire and MultiLock when a lock is held by someone
+// else.
+var ErrLockNotAcquired = errors.New("redis: lock not acquired")
+
+// ErrLockExpired is returned when releasing locks of which some were no longer held, typically
+// because their TTL elapsed before the release.
+var ErrLockExpired = errors.New("redis: lock expired")
+
+// ErrNilValue is returned by Set and SetWithExpiration for nil values, unless the cache was built
+// with SetNilValueDeletes(true), and by the other writes rejecting them. It is banshee.ErrNilValue,
+// so errors.Is matches it whichever layer rejected the value.
+var ErrNilValue = banshee.ErrNilValue
+
+// ErrNoExpiry is returned by TTL for keys that exist but have no time-to-live.
+var ErrNoExpiry = errors.New("redis: key has no expiry")
+
+// ErrStreamTruncated is returned by GetStream when the value shrank or expired while it was
+// streamed, so that fewer bytes than it held at the start could be read.
+var ErrStreamTruncated = errors.New("redis: value truncated while streaming")
+
+// ErrInvalidExpiration is returned by Expire and ExpireAt for a TTL that isn't positive or a time
+// that isn't in the future, which Redis would otherwise treat as a request to delete the key.
+var ErrInvalidExpiration = errors.New("redis: expiration must be in the future")
+
+// ErrNoCommand is returned by Do when called without arguments.
+var ErrNoCommand = errors.New("redis: no command given")
+
+// ErrOperationDisabled is returned by operations that were switched off when the cache was
+// constructed, such as DelWithPattern, ExpirePattern or ReapStaleLocks on a cache built with
+// SetDelWithPatternDisabled(true), or ConfigSet on a cache built without SetConfigSetEnabled(true)
+// or SetConfigAllowlist.
+var ErrOperationDisabled = errors.New("redis: operation disabled")
+
+// ErrConfigNotAllowed is returned by ConfigSet for parameters missing from the allowlist
+// configured with SetConfigAllowlist.
+var ErrConfigNotAllowed = errors.New("redis: config parameter not allowed")
+
+// ErrVersionConflict is matched (via errors.Is) by the *VersionConflictError returned when a
+// versioned write is rejected because the stored version differs from the expected one.
+var ErrVersionConflict = errors.New("redis: version conflict")
+
+// VersionConflictError reports a rejected versioned write together with the version currently
+// stored, so callers can reload and retry without an extra round trip to learn it.
+type VersionConflictError struct {
+	Key     string // Key is the key whose write was rejected.
+	Current int64  // Current is the version stored at the time of the write (0 if absent).
+}
+
+// Error implements the error interface.
+func (e *VersionConflictError) Error() string {
+	return fmt.Sprintf("redis: version conflict on %q: current version is %d", e.Key, e.Current)
+}
+
+// Is reports whether target is ErrVersionConflict, so errors.Is matches any conflict.
+func (e *VersionConflictError) Is(target error) bool {
+	return target == ErrVersionConflict
+}
+
+// ErrPartialWrite is matched (via errors.Is) by the *PartialWriteError returned when some writes
+// of a batch failed while others were applied.
+var ErrPartialWrite = errors.New("redis: partial write")
+
+// PartialWriteError reports the keys of a batch write that failed, together with their errors.
+// The keys missing from Failed were written.
+type PartialWriteError struct {
+	Failed map[string]error // Failed maps each key that wasn't written to the error it got.
+}
+
+// Error implements the error interface.
+func (e *PartialWriteError) Error() string {
+	keys := make([]string, 0, len(e.Failed))
+	for key := range e.Failed {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return fmt.Sprintf("redis: partial write: %d keys failed: %s", len(keys), strings.Join(keys, ", "))
+}
+
+// Is reports whether target is ErrPartialWrite, so errors.Is matches any partial write.
+func (e *PartialWriteError) Is(target error) bool {
+	return target == ErrPartialWrite
+}
+
+// partialWriteError returns a *PartialWriteError listing the keys whose command failed, or nil if
+// every command succeeded. cmds[i] is the command written for keys[i].
+func partialWriteError(keys []string, cmds []redis.Cmder) error {
+	var failed map[string]error
+	for i, cmd := range cmds {
+		if err := cmd.Err(); err != nil {
+			if failed == nil {
+				failed = map[string]error{}
+			}
+			failed[keys[i]] = err
+		}
+	}
+	if failed == nil {
+		return nil
+	}
+	return &PartialWriteError{Failed: failed}
+}
+
+// ErrPermissionDenied is matched (via errors.Is) by the *PermissionError returned when the server
+// refuses an administrative command to the connecting user.
+var ErrPermissionDenied = errors.New("redis: permission denied")
+
+// PermissionError reports a command the server refused, either through an ACL rule (NOPERM) or
+// because the command was disabled or renamed, as managed Redis offerings often do.
+type PermissionError struct {
+	Command string // Command is the refused command, e.g. "INFO".
+	Err     error  // Err is the error returned by the server.
+}
+
+// Error implements the error interface.
+func (e *PermissionError) Error() string {
+	return fmt.Sprintf("redis: permission denied for %s: %v", e.Command, e.Err)
+}
+
+// Is reports whether target is ErrPermissionDenied, so errors.Is matches any refused command.
+func (e *PermissionError) Is(target error) bool {
+	return target == ErrPermissionDenied
+}
+
+// Unwrap returns the server error.
+func (e *PermissionError) Unwrap() error {
+	return e.Err
+}
+
+// ErrReadOnlyReplica is matched (via errors.Is) by the *ReadOnlyError returned when a write is
+// sent to a read-only replica, and is returned by NewRedisCache with SetRequirePrimary(true) when
+// the server is a replica.
+var ErrReadOnlyReplica = errors.New("redis: server is a read-only replica")
+
+// ReadOnlyError reports a write the server refused because it is a read-only replica. Retrying
+// can't succeed until the cache is pointed at the primary, so it isn't a transient error.
+type ReadOnlyError struct {
+	Err error // Err is the READONLY error returned by the server.
+}
+
+// Error implements the error interface.
+func (e *ReadOnlyError) Error() string {
+	return fmt.Sprintf("redis: write refused by read-only replica: %v", e.Err)
+}
+
+// Is reports whether target is ErrReadOnlyReplica, so errors.Is matches any refused write.
+func (e *ReadOnlyError) Is(target error) bool {
+	return target == ErrReadOnlyReplica
+}
+
+// Unwrap returns the server error.
+func (e *ReadOnlyError) Unwrap() error {
+	return e.Err
+}
+
+// readOnlyError wraps err in a *ReadOnlyError when it is a READONLY error, including one raised
+// by a script, and returns it unchanged otherwise.
+func readOnlyError(err error) error {
+	if err == nil {
+		return nil
+	}
+	var readOnly *ReadOnlyError
+	if errors.As(err, &readOnly) {
+		return err
+	}
+	message := err.Error()
+	if strings.HasPrefix(message, "READONLY") || strings.Contains(message, "-READONLY") {
+		return &ReadOnlyError{Err: err}
+	}
+	return err
+}
+
+// permissionError wraps err in a *PermissionError when it reports a refused command, and returns
+// it unchanged otherwise.
+func permissionError(command string, err error) error {
+	if err == nil {
+		return nil
+	}
+	message := err.Error()
+	if strings.HasPrefix(message, "NOPERM") || strings.HasPrefix(message, "ERR unknown command") {
+		return &PermissionError{Command: command, Err: err}
+	}
+	return err
+}
@@ -0,0 +1,96 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// TestPermissionErrorMapping verifies which server errors are classified as refused commands.
+func TestPermissionErrorMapping(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		denied bool
+	}{
+		{"ACL", errors.New("NOPERM User reporting has no permissions to run the 'config|set' command"), true},
+		{"Renamed", errors.New("ERR unknown command 'CONFIG', with args beginning with: 'GET' 'maxmemory'"), true},
+		{"Other", errors.New("ERR Invalid argument 'x' for CONFIG SET 'maxmemory'"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := permissionError("CONFIG", tt.err)
+
+			if errors.Is(err, ErrPermissionDenied) != tt.denied || !errors.Is(err, tt.err) {
+				t.Log(err)
+				t.FailNow()
+			}
+		})
+	}
+
+	if permissionError("CONFIG", nil) != nil {
+		t.FailNow()
+	}
+}
+
+// TestReadOnlyErrorMapping verifies which server errors are classified as writes refused by a
+// replica.
+func TestReadOnlyErrorMapping(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		readOnly bool
+	}{
+		{"Command", errors.New("READONLY You can't write against a read only replica."), true},
+		{"Script", errors.New("ERR Error running script (call to f_5c5e): @user_script:1: @user_script: 1: -READONLY You can't write against a read only replica."), true},
+		{"Other", errors.New("ERR value is not an integer or out of range"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := readOnlyError(tt.err)
+
+			if errors.Is(err, ErrReadOnlyReplica) != tt.readOnly || !errors.Is(err, tt.err) {
+				t.Log(err)
+				t.FailNow()
+			}
+
+			if again := readOnlyError(err); again != err {
+				t.Log("a classified error should be returned unchanged:", again)
+				t.FailNow()
+			}
+		})
+	}
+
+	if readOnlyError(nil) != nil {
+		t.FailNow()
+	}
+}
+
+// TestPartialWriteError verifies that only the keys whose command failed are reported.
+func TestPartialWriteError(t *testing.T) {
+	ctx := context.Background()
+	failure := errors.New("OOM command not allowed when used memory > 'maxmemory'")
+
+	ok := redis.NewStatusCmd(ctx, "set", "ok", "value")
+	failed := redis.NewStatusCmd(ctx, "set", "failed", "value")
+	failed.SetErr(failure)
+
+	err := partialWriteError([]string{"ok", "failed"}, []redis.Cmder{ok, failed})
+
+	var partial *PartialWriteError
+	if !errors.Is(err, ErrPartialWrite) || !errors.As(err, &partial) {
+		t.Fatal(err)
+	}
+
+	if len(partial.Failed) != 1 || partial.Failed["failed"] != failure {
+		t.Fatal(partial.Failed)
+	}
+
+	if partialWriteError([]string{"ok"}, []redis.Cmder{ok}) != nil {
+		t.FailNow()
+	}
+}
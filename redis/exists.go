@@ -0,0 +1,30 @@
+package redis
+
+import (
+	"context"
+
+	"github.com/zeroxsolutions/banshee"
+)
+
+var _ banshee.ExistenceChecker = (*RedisCache)(nil)
+
+// Exists reports how many of the given keys exist, without transferring their values.
+// It wraps EXISTS, so all keys are checked in one round trip and a key passed twice is counted twice.
+//
+// Parameters:
+//   - ctx: Context for timeout control and request cancellation
+//   - keys: Redis keys to check
+//
+// Returns:
+//   - int64: Number of the given keys that exist, 0 when none of them exist or no key is given
+//   - error: An error if the Redis operation fails; a missing key is never an error
+//
+// Example:
+//
+//	n, err := cache.Exists(ctx, "user:1", "user:2")
+func (r *RedisCache) Exists(ctx context.Context, keys ...string) (int64, error) {
+	if len(keys) == 0 {
+		return 0, nil
+	}
+	return r.client.Exists(ctx, keys...).Result()
+}
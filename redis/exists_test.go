@@ -0,0 +1,54 @@
+package redis_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/zeroxsolutions/banshee/redis"
+	"github.com/zeroxsolutions/barbatos/cache"
+	"github.com/zeroxsolutions/strike/ssutil"
+)
+
+// TestRedisCache_Exists verifies that Exists counts the present keys and reports 0, not an error,
+// when none exist.
+func TestRedisCache_Exists(t *testing.T) {
+	redisCache := initRedisCache(t)
+
+	defer func(redisCache cache.Cache) {
+		if err := redisCache.Close(); err != nil {
+			t.Log("Close Redis cache connection err", err)
+		}
+	}(redisCache)
+
+	exists := redisCache.(*redis.RedisCache)
+
+	ctx := context.Background()
+	key := ssutil.MakeString(10)
+	other := ssutil.MakeString(10)
+	missing := ssutil.MakeString(10)
+
+	defer func() {
+		if err := exists.Del(ctx, key, other); err != nil {
+			t.Error(err)
+		}
+	}()
+
+	if err := exists.Set(ctx, key, "value"); err != nil {
+		t.Fatal(err)
+	}
+	if err := exists.Set(ctx, other, "value"); err != nil {
+		t.Fatal(err)
+	}
+
+	if n, err := exists.Exists(ctx, key, other, missing); err != nil || n != 2 {
+		t.Fatal(n, err)
+	}
+
+	if n, err := exists.Exists(ctx, missing); err != nil || n != 0 {
+		t.Fatal(n, err)
+	}
+
+	if n, err := exists.Exists(ctx); err != nil || n != 0 {
+		t.Fatal(n, err)
+	}
+}
@@ -0,0 +1,79 @@
+package redis
+
+import (
+	"context"
+	"time"
+
+	"github.com/zeroxsolutions/banshee"
+)
+
+var _ banshee.Expirer = (*RedisCache)(nil)
+
+// Expire sets the time-to-live of an existing key without rewriting its value.
+// It uses PEXPIRE, so the TTL keeps millisecond precision.
+//
+// A ttl that isn't positive is rejected with ErrInvalidExpiration instead of being sent: Redis
+// would delete the key.
+//
+// Parameters:
+//   - ctx: Context for timeout control and request cancellation
+//   - key: Redis key to update
+//   - ttl: New time-to-live, must be positive
+//
+// Returns:
+//   - bool: true if the key exists and the TTL was set, false if the key doesn't exist
+//   - error: ErrInvalidExpiration, or an error if the Redis operation fails
+//
+// Example:
+//
+//	found, err := cache.Expire(ctx, "session:42", 30*time.Minute)
+func (r *RedisCache) Expire(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	if ttl <= 0 {
+		return false, ErrInvalidExpiration
+	}
+	return r.client.PExpire(ctx, key, ttl).Result()
+}
+
+// ExpireAt makes an existing key expire at the given time without rewriting its value.
+// It uses PEXPIREAT, so the deadline keeps millisecond precision.
+//
+// A time that isn't in the future is rejected with ErrInvalidExpiration instead of being sent:
+// Redis would delete the key.
+//
+// Parameters:
+//   - ctx: Context for timeout control and request cancellation
+//   - key: Redis key to update
+//   - at: Time at which the key expires, must be in the future
+//
+// Returns:
+//   - bool: true if the key exists and the deadline was set, false if the key doesn't exist
+//   - error: ErrInvalidExpiration, or an error if the Redis operation fails
+//
+// Example:
+//
+//	// Sessions end at midnight whatever their activity.
+//	found, err := cache.ExpireAt(ctx, "session:42", midnight)
+func (r *RedisCache) ExpireAt(ctx context.Context, key string, at time.Time) (bool, error) {
+	if !at.After(time.Now()) {
+		return false, ErrInvalidExpiration
+	}
+	return r.client.PExpireAt(ctx, key, at).Result()
+}
+
+// Persist removes the time-to-live of an existing key, so it no longer expires, without
+// rewriting its value.
+//
+// Parameters:
+//   - ctx: Context for timeout control and request cancellation
+//   - key: Redis key to update
+//
+// Returns:
+//   - bool: true if the key had a TTL that was removed, false if the key doesn't exist or has no TTL
+//   - error: An error if the Redis operation fails
+//
+// Example:
+//
+//	persisted, err := cache.Persist(ctx, "session:42")
+func (r *RedisCache) Persist(ctx context.Context, key string) (bool, error) {
+	return r.client.Persist(ctx, key).Result()
+}
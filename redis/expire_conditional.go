@@ -0,0 +1,157 @@
+package redis
+
+import (
+	"context"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/zeroxsolutions/barbatos/cache"
+)
+
+// Conditions accepted by the PEXPIRE command since Redis 7.0.
+const (
+	expireNX = "NX" // Only when the key has no TTL.
+	expireXX = "XX" // Only when the key already has a TTL.
+	expireGT = "GT" // Only when the new TTL is greater than the current one.
+	expireLT = "LT" // Only when the new TTL is less than the current one.
+)
+
+// conditionalExpireScript emulates PEXPIRE NX/XX/GT/LT for servers older than Redis 7.0.
+// Like Redis, a key without a TTL is treated as having an infinite TTL: GT never applies to it
+// and LT always does.
+//
+// KEYS[1] = key, ARGV[1] = TTL in milliseconds, ARGV[2] = condition
+// Returns -1 when the key doesn't exist, 1 when the TTL was applied, and 0 otherwise.
+var conditionalExpireScript = redis.NewScript(`
+local current = redis.call('PTTL', KEYS[1])
+if current == -2 then
+	return -1
+end
+local ttl = tonumber(ARGV[1])
+local condition = ARGV[2]
+local apply = false
+if condition == 'NX' then
+	apply = current == -1
+elseif condition == 'XX' then
+	apply = current ~= -1
+elseif condition == 'GT' then
+	apply = current ~= -1 and ttl > current
+elseif condition == 'LT' then
+	apply = current == -1 or ttl < current
+end
+if not apply then
+	return 0
+end
+redis.call('PEXPIRE', KEYS[1], ttl)
+return 1
+`)
+
+// ExpireNX sets the TTL of key only if the key currently has no TTL. It is meant for backfilling
+// expirations on keys that were written without one, without shortening TTLs set by other writers.
+//
+// Parameters:
+//   - ctx: Context for timeout control and request cancellation
+//   - key: Redis key to update
+//   - ttl: New time-to-live, must be positive
+//
+// Returns:
+//   - bool: true if the TTL was applied, false if the condition wasn't met
+//   - error: ErrInvalidExpiration, cache.ErrCacheNil if the key doesn't exist, or a Redis error
+//
+// Example:
+//
+//	applied, err := cache.ExpireNX(ctx, "session:42", 24*time.Hour)
+func (r *RedisCache) ExpireNX(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	return r.expireIf(ctx, key, ttl, expireNX)
+}
+
+// ExpireXX sets the TTL of key only if the key already has a TTL, leaving persistent keys untouched.
+//
+// Parameters:
+//   - ctx: Context for timeout control and request cancellation
+//   - key: Redis key to update
+//   - ttl: New time-to-live, must be positive
+//
+// Returns:
+//   - bool: true if the TTL was applied, false if the condition wasn't met
+//   - error: ErrInvalidExpiration, cache.ErrCacheNil if the key doesn't exist, or a Redis error
+//
+// Example:
+//
+//	applied, err := cache.ExpireXX(ctx, "session:42", time.Hour)
+func (r *RedisCache) ExpireXX(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	return r.expireIf(ctx, key, ttl, expireXX)
+}
+
+// ExpireGT sets the TTL of key only if it is longer than the current one, so concurrent callers
+// extending a deadline can never shorten it. A key without a TTL is considered to live forever and
+// is left untouched.
+//
+// Parameters:
+//   - ctx: Context for timeout control and request cancellation
+//   - key: Redis key to update
+//   - ttl: New time-to-live, must be positive
+//
+// Returns:
+//   - bool: true if the TTL was applied, false if the condition wasn't met
+//   - error: ErrInvalidExpiration, cache.ErrCacheNil if the key doesn't exist, or a Redis error
+//
+// Example:
+//
+//	applied, err := cache.ExpireGT(ctx, "session:42", 30*time.Minute)
+func (r *RedisCache) ExpireGT(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	return r.expireIf(ctx, key, ttl, expireGT)
+}
+
+// ExpireLT sets the TTL of key only if it is shorter than the current one. A key without a TTL is
+// considered to live forever, so it always receives the new TTL.
+//
+// Parameters:
+//   - ctx: Context for timeout control and request cancellation
+//   - key: Redis key to update
+//   - ttl: New time-to-live, must be positive
+//
+// Returns:
+//   - bool: true if the TTL was applied, false if the condition wasn't met
+//   - error: ErrInvalidExpiration, cache.ErrCacheNil if the key doesn't exist, or a Redis error
+//
+// Example:
+//
+//	applied, err := cache.ExpireLT(ctx, "session:42", time.Minute)
+func (r *RedisCache) ExpireLT(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	return r.expireIf(ctx, key, ttl, expireLT)
+}
+
+// expireIf applies ttl to key under the given PEXPIRE condition. Native PEXPIRE reports a missing
+// key and an unmet condition the same way, so a refused update is followed by an EXISTS check to
+// tell them apart; the Lua emulation reports both cases itself. A non-positive ttl would delete
+// the key rather than expire it, so it is rejected with ErrInvalidExpiration, and a ttl under a
+// millisecond is rounded up to one.
+func (r *RedisCache) expireIf(ctx context.Context, key string, ttl time.Duration, condition string) (bool, error) {
+	if ttl <= 0 {
+		return false, ErrInvalidExpiration
+	}
+	if r.options.LegacyCommands {
+		result, err := conditionalExpireScript.Run(ctx, r.client, []string{key}, millis(ttl), condition).Int64()
+		if err != nil {
+			return false, err
+		}
+		if result == -1 {
+			return false, cache.ErrCacheNil
+		}
+		return result == 1, nil
+	}
+
+	applied, err := r.client.Do(ctx, "PEXPIRE", key, millis(ttl), condition).Bool()
+	if err != nil || applied {
+		return applied, err
+	}
+	exists, err := r.client.Exists(ctx, key).Result()
+	if err != nil {
+		return false, err
+	}
+	if exists == 0 {
+		return false, cache.ErrCacheNil
+	}
+	return false, nil
+}
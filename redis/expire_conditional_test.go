@@ -0,0 +1,118 @@
+package redis_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/zeroxsolutions/banshee/redis"
+	"github.com/zeroxsolutions/barbatos/cache"
+	"github.com/zeroxsolutions/strike/builderutil"
+	"github.com/zeroxsolutions/strike/ssutil"
+)
+
+// TestRedisCache_ConditionalExpire checks the apply and skip cases of every condition against both
+// the native PEXPIRE options and the Lua emulation.
+func TestRedisCache_ConditionalExpire(t *testing.T) {
+	type expireFunc func(*redis.RedisCache, context.Context, string, time.Duration) (bool, error)
+
+	tests := []struct {
+		name    string
+		initial time.Duration // initial is the TTL set before the call; 0 means no TTL.
+		expire  expireFunc
+		ttl     time.Duration
+		applied bool
+	}{
+		{"NX/Persistent", 0, (*redis.RedisCache).ExpireNX, time.Minute, true},
+		{"NX/Volatile", time.Minute, (*redis.RedisCache).ExpireNX, time.Hour, false},
+		{"XX/Persistent", 0, (*redis.RedisCache).ExpireXX, time.Minute, false},
+		{"XX/Volatile", time.Minute, (*redis.RedisCache).ExpireXX, time.Hour, true},
+		{"GT/Longer", time.Minute, (*redis.RedisCache).ExpireGT, time.Hour, true},
+		{"GT/Shorter", time.Hour, (*redis.RedisCache).ExpireGT, time.Minute, false},
+		{"GT/Persistent", 0, (*redis.RedisCache).ExpireGT, time.Hour, false},
+		{"LT/Shorter", time.Hour, (*redis.RedisCache).ExpireLT, time.Minute, true},
+		{"LT/Longer", time.Minute, (*redis.RedisCache).ExpireLT, time.Hour, false},
+		{"LT/Persistent", 0, (*redis.RedisCache).ExpireLT, time.Minute, true},
+	}
+
+	paths := []struct {
+		name string
+		opts builderutil.Lister[redis.RedisCacheOptions]
+	}{
+		{"Native", redis.NewRedisCacheOptions()},
+		{"Legacy", redis.NewRedisCacheOptions().SetLegacyCommands(true)},
+	}
+
+	for _, path := range paths {
+		t.Run(path.name, func(t *testing.T) {
+			redisCache := initRedisCache(t, path.opts)
+
+			defer func(redisCache cache.Cache) {
+				if err := redisCache.Close(); err != nil {
+					t.Log("Close Redis cache connection err", err)
+				}
+			}(redisCache)
+
+			expirer := redisCache.(*redis.RedisCache)
+			ctx := context.Background()
+
+			for _, tt := range tests {
+				t.Run(tt.name, func(t *testing.T) {
+					key := ssutil.MakeString(10)
+
+					if err := expirer.SetWithExpiration(ctx, key, "value", tt.initial); err != nil {
+						t.Fatal(err)
+					}
+
+					defer func() {
+						if err := expirer.Del(ctx, key); err != nil {
+							t.Error(err)
+						}
+					}()
+
+					applied, err := tt.expire(expirer, ctx, key, tt.ttl)
+					if err != nil {
+						t.Fatal(err)
+					}
+
+					if applied != tt.applied {
+						t.Log("expected applied to be", tt.applied)
+						t.FailNow()
+					}
+				})
+			}
+
+			t.Run("Missing", func(t *testing.T) {
+				if _, err := expirer.ExpireNX(ctx, ssutil.MakeString(10), time.Minute); err != cache.ErrCacheNil {
+					t.Log(err)
+					t.FailNow()
+				}
+			})
+
+			t.Run("InvalidTTL", func(t *testing.T) {
+				key := ssutil.MakeString(10)
+				if err := expirer.SetWithExpiration(ctx, key, "value", time.Minute); err != nil {
+					t.Fatal(err)
+				}
+
+				defer func() {
+					if err := expirer.Del(ctx, key); err != nil {
+						t.Error(err)
+					}
+				}()
+
+				for _, ttl := range []time.Duration{0, -time.Second} {
+					if _, err := expirer.ExpireLT(ctx, key, ttl); err != redis.ErrInvalidExpiration {
+						t.Log(ttl, err)
+						t.FailNow()
+					}
+				}
+
+				if _, err := expirer.Get(ctx, key); err != nil {
+					t.Log("a rejected TTL deleted the key:", err)
+					t.FailNow()
+				}
+			})
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package redis
+
+import (
+	"context"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/zeroxsolutions/banshee"
+)
+
+var _ banshee.PatternExpirer = (*RedisCache)(nil)
+
+// ExpirePattern sets the TTL of every key matching pattern to ttl and returns how many keys were
+// updated, e.g. to extend all the cached keys of a tenant whose subscription renewed. Unlike
+// ExtendTTLPattern, which adds to each key's current TTL, every key ends up with the same TTL, and
+// keys without one are given it.
+//
+// Keys are walked with SCAN, sized by SetScanCount, and each batch is updated with one pipelined
+// PEXPIRE per key. The operation is not atomic across keys: each key is updated on its own, keys
+// created or deleted during the walk may or may not be updated, and a cancelled context stops the
+// walk between batches, returning the number of keys updated so far together with the context
+// error. For large sets, build the cache with SetExpirePatternPause to pause between batches.
+// Caches built with SetSoftDelete leave tombstones out, so their retention is kept.
+// Since a short TTL empties the keyspace as surely as a delete, caches built with
+// SetDelWithPatternDisabled(true) return ErrOperationDisabled.
+//
+// Parameters:
+//   - ctx: Context for timeout control and request cancellation
+//   - pattern: Glob-style pattern of the keys to update
+//   - ttl: New time-to-live of every matching key, must be positive
+//
+// Returns:
+//   - int64: Number of keys whose TTL was set
+//   - error: ErrInvalidExpiration, ErrOperationDisabled, the context error, or a Redis error
+//
+// Example:
+//
+//	updated, err := cache.ExpirePattern(ctx, "tenant:42:*", 30*24*time.Hour)
+func (r *RedisCache) ExpirePattern(ctx context.Context, pattern string, ttl time.Duration) (int64, error) {
+	if ttl <= 0 {
+		return 0, ErrInvalidExpiration
+	}
+	if err := r.CheckPatternDelete(); err != nil {
+		return 0, err
+	}
+	updated, err := r.expirePattern(ctx, pattern, ttl)
+	if err == nil || updated > 0 {
+		r.audit(ctx, AuditOpExpirePattern, nil, pattern, updated)
+	}
+	return updated, err
+}
+
+// expirePattern walks the keys matching pattern and sets their TTL, one SCAN batch at a time.
+func (r *RedisCache) expirePattern(ctx context.Context, pattern string, ttl time.Duration) (int64, error) {
+	var updated int64
+	var cursor uint64
+	for {
+		if err := ctx.Err(); err != nil {
+			return updated, err
+		}
+		keys, next, err := r.client.Scan(ctx, cursor, pattern, r.options.ScanCount).Result()
+		if err != nil {
+			return updated, err
+		}
+		keys = r.liveKeys(keys)
+		if len(keys) > 0 {
+			cmds := make([]*redis.BoolCmd, len(keys))
+			_, err := r.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+				for i, key := range keys {
+					cmds[i] = pipe.PExpire(ctx, key, ttl)
+				}
+				return nil
+			})
+			for _, cmd := range cmds {
+				if cmd.Val() {
+					updated++
+				}
+			}
+			if err != nil {
+				return updated, err
+			}
+		}
+		if next == 0 {
+			return updated, nil
+		}
+		cursor = next
+		if r.options.ExpirePatternPause > 0 {
+			timer := time.NewTimer(r.options.ExpirePatternPause)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return updated, ctx.Err()
+			case <-timer.C:
+			}
+		}
+	}
+}
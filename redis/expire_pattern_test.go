@@ -0,0 +1,77 @@
+package redis_test
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/zeroxsolutions/banshee/redis"
+	"github.com/zeroxsolutions/barbatos/cache"
+	"github.com/zeroxsolutions/strike/ssutil"
+)
+
+// TestRedisCache_ExpirePattern verifies that ExpirePattern sets the TTL of every matching key,
+// persistent or not, and leaves other keys alone.
+func TestRedisCache_ExpirePattern(t *testing.T) {
+	redisCache := initRedisCache(t, redis.NewRedisCacheOptions().SetScanCount(2).SetExpirePatternPause(time.Millisecond))
+
+	defer func(redisCache cache.Cache) {
+		if err := redisCache.Close(); err != nil {
+			t.Log("Close Redis cache connection err", err)
+		}
+	}(redisCache)
+
+	expirer := redisCache.(*redis.RedisCache)
+
+	ctx := context.Background()
+	prefix := ssutil.MakeString(10)
+	other := ssutil.MakeString(10)
+
+	defer func() {
+		if err := expirer.DelWithPattern(ctx, prefix+":*"); err != nil {
+			t.Error(err)
+		}
+		if err := expirer.Del(ctx, other); err != nil {
+			t.Error(err)
+		}
+	}()
+
+	const total = 5
+	for i := 0; i < total; i++ {
+		key := prefix + ":" + strconv.Itoa(i)
+		var err error
+		if i%2 == 0 {
+			err = expirer.Set(ctx, key, "value")
+		} else {
+			err = expirer.SetWithExpiration(ctx, key, "value", time.Minute)
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := expirer.Set(ctx, other, "value"); err != nil {
+		t.Fatal(err)
+	}
+
+	updated, err := expirer.ExpirePattern(ctx, prefix+":*", time.Hour)
+	if err != nil || updated != total {
+		t.Fatal(updated, err)
+	}
+
+	for i := 0; i < total; i++ {
+		ttl, err := expirer.TTL(ctx, prefix+":"+strconv.Itoa(i))
+		if err != nil || ttl <= 59*time.Minute || ttl > time.Hour {
+			t.Fatal("unexpected TTL:", i, ttl, err)
+		}
+	}
+
+	if _, err := expirer.TTL(ctx, other); !errors.Is(err, redis.ErrNoExpiry) {
+		t.Fatal("a key outside the pattern should keep no expiry:", err)
+	}
+
+	if _, err := expirer.ExpirePattern(ctx, prefix+":*", 0); !errors.Is(err, redis.ErrInvalidExpiration) {
+		t.Fatal(err)
+	}
+}
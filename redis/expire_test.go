@@ -0,0 +1,170 @@
+package redis_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/zeroxsolutions/banshee/redis"
+	"github.com/zeroxsolutions/barbatos/cache"
+	"github.com/zeroxsolutions/strike/ssutil"
+)
+
+// TestRedisCache_Expire verifies that Expire sets the TTL of an existing key and reports missing keys.
+func TestRedisCache_Expire(t *testing.T) {
+	redisCache := initRedisCache(t)
+
+	defer func(redisCache cache.Cache) {
+		if err := redisCache.Close(); err != nil {
+			t.Log("Close Redis cache connection err", err)
+		}
+	}(redisCache)
+
+	client := initRedisClient(t)
+	defer client.Close()
+
+	expirer := redisCache.(*redis.RedisCache)
+
+	ctx := context.Background()
+	key := ssutil.MakeString(10)
+
+	if err := expirer.Set(ctx, key, "value"); err != nil {
+		t.Fatal(err)
+	}
+
+	defer func() {
+		if err := expirer.Del(ctx, key); err != nil {
+			t.Error(err)
+		}
+	}()
+
+	found, err := expirer.Expire(ctx, key, time.Minute)
+	if err != nil || !found {
+		t.Fatal(found, err)
+	}
+
+	ttl, err := client.PTTL(ctx, key).Result()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if ttl <= 0 || ttl > time.Minute {
+		t.Log("unexpected TTL:", ttl)
+		t.FailNow()
+	}
+
+	found, err = expirer.Expire(ctx, ssutil.MakeString(10), time.Minute)
+	if err != nil || found {
+		t.Log(found, err)
+		t.FailNow()
+	}
+}
+
+// TestRedisCache_Persist verifies that Persist removes the TTL of an expiring key and reports keys
+// without one.
+func TestRedisCache_Persist(t *testing.T) {
+	redisCache := initRedisCache(t)
+
+	defer func(redisCache cache.Cache) {
+		if err := redisCache.Close(); err != nil {
+			t.Log("Close Redis cache connection err", err)
+		}
+	}(redisCache)
+
+	client := initRedisClient(t)
+	defer client.Close()
+
+	expirer := redisCache.(*redis.RedisCache)
+
+	ctx := context.Background()
+	key := ssutil.MakeString(10)
+
+	if err := expirer.SetWithExpiration(ctx, key, "value", time.Minute); err != nil {
+		t.Fatal(err)
+	}
+
+	defer func() {
+		if err := expirer.Del(ctx, key); err != nil {
+			t.Error(err)
+		}
+	}()
+
+	persisted, err := expirer.Persist(ctx, key)
+	if err != nil || !persisted {
+		t.Fatal(persisted, err)
+	}
+
+	if ttl, err := client.PTTL(ctx, key).Result(); err != nil || ttl != -1 {
+		t.Fatal("the key should no longer expire:", ttl, err)
+	}
+
+	if persisted, err := expirer.Persist(ctx, key); err != nil || persisted {
+		t.Fatal("a key without TTL should not be reported:", persisted, err)
+	}
+
+	if persisted, err := expirer.Persist(ctx, ssutil.MakeString(10)); err != nil || persisted {
+		t.Fatal("a missing key should not be reported:", persisted, err)
+	}
+}
+
+// TestRedisCache_ExpireAt verifies that ExpireAt sets the deadline without changing the value and
+// that expirations Redis would turn into deletes are rejected.
+func TestRedisCache_ExpireAt(t *testing.T) {
+	redisCache := initRedisCache(t)
+
+	defer func(redisCache cache.Cache) {
+		if err := redisCache.Close(); err != nil {
+			t.Log("Close Redis cache connection err", err)
+		}
+	}(redisCache)
+
+	client := initRedisClient(t)
+	defer client.Close()
+
+	expirer := redisCache.(*redis.RedisCache)
+
+	ctx := context.Background()
+	key := ssutil.MakeString(10)
+
+	if err := expirer.SetWithExpiration(ctx, key, "value", time.Minute); err != nil {
+		t.Fatal(err)
+	}
+
+	defer func() {
+		if err := expirer.Del(ctx, key); err != nil {
+			t.Error(err)
+		}
+	}()
+
+	found, err := expirer.ExpireAt(ctx, key, time.Now().Add(time.Hour))
+	if err != nil || !found {
+		t.Fatal(found, err)
+	}
+
+	if ttl, err := client.PTTL(ctx, key).Result(); err != nil || ttl <= 59*time.Minute || ttl > time.Hour {
+		t.Fatal("unexpected TTL:", ttl, err)
+	}
+
+	if value, err := expirer.Get(ctx, key); err != nil || value != "value" {
+		t.Fatal("the value should not change:", value, err)
+	}
+
+	if found, err := expirer.ExpireAt(ctx, ssutil.MakeString(10), time.Now().Add(time.Hour)); err != nil || found {
+		t.Fatal(found, err)
+	}
+
+	if _, err := expirer.Expire(ctx, key, 0); !errors.Is(err, redis.ErrInvalidExpiration) {
+		t.Fatal(err)
+	}
+	if _, err := expirer.Expire(ctx, key, -time.Second); !errors.Is(err, redis.ErrInvalidExpiration) {
+		t.Fatal(err)
+	}
+	if _, err := expirer.ExpireAt(ctx, key, time.Now().Add(-time.Second)); !errors.Is(err, redis.ErrInvalidExpiration) {
+		t.Fatal(err)
+	}
+
+	if value, err := expirer.Get(ctx, key); err != nil || value != "value" {
+		t.Fatal("a rejected expiration should not delete the key:", value, err)
+	}
+}
@@ -0,0 +1,88 @@
+package redis
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/zeroxsolutions/banshee"
+)
+
+// keysExpiringSoonBatchSize is the number of keys KeysExpiringSoon requests per SCAN call and
+// looks up per pipelined PTTL batch.
+const keysExpiringSoonBatchSize = 100
+
+var _ banshee.ExpiryInspector = (*RedisCache)(nil)
+
+// KeysExpiringSoon returns the keys matching pattern whose TTL is at most within, sorted from the
+// soonest to expire, and truncated to limit entries.
+//
+// The cost is O(matched keys): every key matching pattern is visited with SCAN and has its TTL
+// read with PTTL, pipelined per batch of 100, whatever limit is. Restrict pattern to the keys of
+// interest on large keyspaces. Like GetByPattern, the result is not a snapshot, and TTLs are those
+// observed when each batch was read.
+//
+// Behavior:
+//   - Keys without a TTL are skipped, as are keys deleted or expired during the walk
+//   - Keys with equal TTLs are ordered by key
+//   - Caches built with SetSoftDelete leave tombstones out
+//   - A limit of 0 or less returns every key expiring within the window
+//
+// Parameters:
+//   - ctx: Context for timeout control and request cancellation
+//   - pattern: Glob-style pattern of the keys to inspect
+//   - within: Longest TTL included
+//   - limit: Maximum number of keys returned
+//
+// Returns:
+//   - []banshee.KeyTTL: The keys and their TTLs, soonest first; empty when none qualify
+//   - error: A Redis error
+//
+// Example:
+//
+//	soon, err := cache.KeysExpiringSoon(ctx, "session:*", 5*time.Minute, 20)
+func (r *RedisCache) KeysExpiringSoon(ctx context.Context, pattern string, within time.Duration, limit int) ([]banshee.KeyTTL, error) {
+	expiring := []banshee.KeyTTL{}
+	var cursor uint64
+	for {
+		keys, next, err := r.client.Scan(ctx, cursor, pattern, keysExpiringSoonBatchSize).Result()
+		if err != nil {
+			return nil, err
+		}
+		keys = r.liveKeys(keys)
+		if len(keys) > 0 {
+			cmds := make([]*redis.DurationCmd, len(keys))
+			_, err := r.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+				for i, key := range keys {
+					cmds[i] = pipe.PTTL(ctx, key)
+				}
+				return nil
+			})
+			if err != nil {
+				return nil, err
+			}
+			for i, cmd := range cmds {
+				// PTTL reports -1 for persistent keys and -2 for missing ones.
+				if ttl := cmd.Val(); ttl >= 0 && ttl <= within {
+					expiring = append(expiring, banshee.KeyTTL{Key: keys[i], TTL: ttl})
+				}
+			}
+		}
+		if next == 0 {
+			break
+		}
+		cursor = next
+	}
+
+	sort.Slice(expiring, func(i, j int) bool {
+		if expiring[i].TTL != expiring[j].TTL {
+			return expiring[i].TTL < expiring[j].TTL
+		}
+		return expiring[i].Key < expiring[j].Key
+	})
+	if limit > 0 && len(expiring) > limit {
+		expiring = expiring[:limit]
+	}
+	return expiring, nil
+}
@@ -0,0 +1,86 @@
+package redis_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/zeroxsolutions/banshee/redis"
+	"github.com/zeroxsolutions/barbatos/cache"
+	"github.com/zeroxsolutions/strike/ssutil"
+)
+
+// TestRedisCache_KeysExpiringSoon verifies filtering by window, ordering by TTL, and the limit
+// over keys with varied TTLs.
+func TestRedisCache_KeysExpiringSoon(t *testing.T) {
+	redisCache := initRedisCache(t)
+
+	defer func(redisCache cache.Cache) {
+		if err := redisCache.Close(); err != nil {
+			t.Log("Close Redis cache connection err", err)
+		}
+	}(redisCache)
+
+	inspector := redisCache.(*redis.RedisCache)
+
+	ctx := context.Background()
+	prefix := ssutil.MakeString(10) + ":"
+
+	ttls := map[string]time.Duration{
+		"a": 3 * time.Minute,
+		"b": time.Minute,
+		"c": 2 * time.Minute,
+		"d": time.Hour,
+		"e": 0,
+	}
+	for name, ttl := range ttls {
+		if err := inspector.SetWithExpiration(ctx, prefix+name, "v", ttl); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	defer func() {
+		if err := inspector.DelWithPattern(ctx, prefix+"*"); err != nil {
+			t.Error(err)
+		}
+	}()
+
+	t.Run("Window", func(t *testing.T) {
+		soon, err := inspector.KeysExpiringSoon(ctx, prefix+"*", 5*time.Minute, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := []string{"b", "c", "a"}
+		if len(soon) != len(want) {
+			t.Fatal("unexpected keys:", soon)
+		}
+		for i, name := range want {
+			if soon[i].Key != prefix+name {
+				t.Fatal("unexpected order:", soon)
+			}
+			if soon[i].TTL <= ttls[name]-time.Second || soon[i].TTL > ttls[name] {
+				t.Fatal("unexpected TTL:", soon[i])
+			}
+		}
+	})
+
+	t.Run("Limit", func(t *testing.T) {
+		soon, err := inspector.KeysExpiringSoon(ctx, prefix+"*", 2*time.Hour, 2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(soon) != 2 || soon[0].Key != prefix+"b" || soon[1].Key != prefix+"c" {
+			t.Fatal("unexpected keys:", soon)
+		}
+	})
+
+	t.Run("None", func(t *testing.T) {
+		soon, err := inspector.KeysExpiringSoon(ctx, prefix+"*", time.Second, 10)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(soon) != 0 {
+			t.Fatal("unexpected keys:", soon)
+		}
+	})
+}
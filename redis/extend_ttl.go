@@ -0,0 +1,132 @@
+package redis
+
+import (
+	"context"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/zeroxsolutions/strike/builderutil"
+)
+
+// ExtendTTLPattern adds extendBy to the TTL of every key matching pattern and returns how many keys
+// were extended. It is meant for incident response, e.g. buying an hour for every "session:*" key
+// while a login backend is down.
+//
+// Keys are walked with SCAN rather than KEYS, so the server is never blocked by a large keyspace.
+// Each batch costs two pipelined round trips: one reading PTTL for every key and one writing the
+// new TTLs with PEXPIRE. The context is checked between batches, so a cancelled call stops early
+// and returns the number of keys extended so far together with the context error.
+//
+// Behavior:
+//   - Keys without a TTL are skipped unless SetIncludePersistent(true) is given
+//   - Keys deleted or expired during the walk are skipped
+//   - With SetMaxTTL, the resulting TTL is capped and keys already at the cap are skipped
+//   - A TTL changed by another client between the read and the write is overwritten
+//   - Caches built with SetSoftDelete leave tombstones out
+//   - Caches built with SetDelWithPatternDisabled(true) allow it: it only lengthens TTLs, so it
+//     stays available for incidents on guarded production caches
+//
+// Parameters:
+//   - ctx: Context for timeout control and request cancellation
+//   - pattern: Glob-style pattern of the keys to extend
+//   - extendBy: Duration added to each key's current TTL, must be positive
+//   - opts: Optional ExtendTTLOptions builders created with NewExtendTTLOptions
+//
+// Returns:
+//   - int64: Number of keys whose TTL was extended
+//   - error: ErrInvalidExpiration, an error if building the options fails, the context error, or
+//     a Redis error
+//
+// Example:
+//
+//	extended, err := cache.ExtendTTLPattern(ctx, "session:*", time.Hour,
+//	    redis.NewExtendTTLOptions().SetMaxTTL(24*time.Hour))
+func (r *RedisCache) ExtendTTLPattern(ctx context.Context, pattern string, extendBy time.Duration, opts ...builderutil.Lister[ExtendTTLOptions]) (int64, error) {
+	if extendBy <= 0 {
+		return 0, ErrInvalidExpiration
+	}
+	options, err := builderutil.Build(append([]builderutil.Lister[ExtendTTLOptions]{defaultExtendTTLOptions()}, opts...)...)
+	if err != nil {
+		return 0, err
+	}
+
+	var extended int64
+	var cursor uint64
+	for {
+		if err := ctx.Err(); err != nil {
+			return extended, err
+		}
+		var keys []string
+		keys, cursor, err = r.client.Scan(ctx, cursor, pattern, options.BatchSize).Result()
+		if err != nil {
+			return extended, err
+		}
+		keys = r.liveKeys(keys)
+		if len(keys) > 0 {
+			n, err := r.extendTTLBatch(ctx, keys, extendBy, options)
+			extended += n
+			if err != nil {
+				if extended > 0 {
+					r.audit(ctx, AuditOpExtendTTL, nil, pattern, extended)
+				}
+				return extended, err
+			}
+		}
+		if cursor == 0 {
+			r.audit(ctx, AuditOpExtendTTL, nil, pattern, extended)
+			return extended, nil
+		}
+	}
+}
+
+// extendTTLBatch extends the TTLs of one SCAN batch and returns how many were updated.
+func (r *RedisCache) extendTTLBatch(ctx context.Context, keys []string, extendBy time.Duration, options *ExtendTTLOptions) (int64, error) {
+	ttlCmds := make([]*redis.DurationCmd, len(keys))
+	_, err := r.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for i, key := range keys {
+			ttlCmds[i] = pipe.PTTL(ctx, key)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	var expireCmds []*redis.BoolCmd
+	_, err = r.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for i, key := range keys {
+			current := ttlCmds[i].Val()
+			var ttl time.Duration
+			switch {
+			case current == -2*time.Nanosecond:
+				continue
+			case current == -1*time.Nanosecond:
+				if !options.IncludePersistent {
+					continue
+				}
+				ttl = extendBy
+			default:
+				ttl = current + extendBy
+			}
+			if options.MaxTTL > 0 && ttl > options.MaxTTL {
+				if current >= options.MaxTTL {
+					continue
+				}
+				ttl = options.MaxTTL
+			}
+			expireCmds = append(expireCmds, pipe.PExpire(ctx, key, ttl))
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	var extended int64
+	for _, cmd := range expireCmds {
+		if cmd.Val() {
+			extended++
+		}
+	}
+	return extended, nil
+}
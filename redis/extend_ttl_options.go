@@ -0,0 +1,107 @@
+package redis
+
+import (
+	"errors"
+	"time"
+
+	"github.com/zeroxsolutions/strike/builderutil"
+)
+
+// DefaultExtendTTLBatchSize is the number of keys ExtendTTLPattern requests per SCAN call and
+// updates per pipeline when no batch size is configured.
+const DefaultExtendTTLBatchSize = 100
+
+// ExtendTTLOptions holds the settings of an ExtendTTLPattern call.
+// This struct is populated through ExtendTTLOptionsBuilder.
+type ExtendTTLOptions struct {
+	BatchSize         int64         // BatchSize is the SCAN COUNT hint and the pipeline size.
+	IncludePersistent bool          // IncludePersistent gives keys without a TTL a TTL of extendBy.
+	MaxTTL            time.Duration // MaxTTL caps the resulting TTL; 0 means no cap.
+}
+
+// ExtendTTLOptionsBuilder provides a builder pattern for constructing ExtendTTLOptions.
+// This builder implements the builderutil.Lister interface to work with the functional options pattern.
+type ExtendTTLOptionsBuilder struct {
+	Opts []func(*ExtendTTLOptions) error // Opts contains the list of option functions to be applied
+}
+
+// SetBatchSize configures how many keys are scanned and updated per round trip.
+// Larger batches finish sooner but hold the server for longer per pipeline.
+//
+// Parameters:
+//   - size: Number of keys per batch, must be positive
+//
+// Returns:
+//   - *ExtendTTLOptionsBuilder: The builder instance for method chaining
+func (b *ExtendTTLOptionsBuilder) SetBatchSize(size int64) *ExtendTTLOptionsBuilder {
+	b.Opts = append(b.Opts, func(o *ExtendTTLOptions) error {
+		if size <= 0 {
+			return errors.New("redis: batch size must be positive")
+		}
+		o.BatchSize = size
+		return nil
+	})
+	return b
+}
+
+// SetIncludePersistent configures whether keys without a TTL are updated too. Such keys never
+// expire, so "extending" them means giving them a TTL of extendBy; they are skipped by default.
+//
+// Parameters:
+//   - include: true to give persistent keys a TTL
+//
+// Returns:
+//   - *ExtendTTLOptionsBuilder: The builder instance for method chaining
+func (b *ExtendTTLOptionsBuilder) SetIncludePersistent(include bool) *ExtendTTLOptionsBuilder {
+	b.Opts = append(b.Opts, func(o *ExtendTTLOptions) error {
+		o.IncludePersistent = include
+		return nil
+	})
+	return b
+}
+
+// SetMaxTTL configures the upper bound of the extended TTLs. Keys whose extended TTL would exceed
+// the cap get the cap instead, and keys already at or above it are left untouched, so the cap
+// never shortens a TTL.
+//
+// Parameters:
+//   - maxTTL: Largest TTL a key may receive, or 0 for no cap
+//
+// Returns:
+//   - *ExtendTTLOptionsBuilder: The builder instance for method chaining
+func (b *ExtendTTLOptionsBuilder) SetMaxTTL(maxTTL time.Duration) *ExtendTTLOptionsBuilder {
+	b.Opts = append(b.Opts, func(o *ExtendTTLOptions) error {
+		if maxTTL < 0 {
+			return errors.New("redis: max TTL must not be negative")
+		}
+		o.MaxTTL = maxTTL
+		return nil
+	})
+	return b
+}
+
+// List returns the slice of option functions accumulated by the builder.
+// This method implements the builderutil.Lister interface.
+//
+// Returns:
+//   - []func(*ExtendTTLOptions) error: A slice of option functions that can be applied to configure ExtendTTLOptions
+func (b *ExtendTTLOptionsBuilder) List() []func(*ExtendTTLOptions) error {
+	return b.Opts
+}
+
+// NewExtendTTLOptions creates and returns a new instance of ExtendTTLOptionsBuilder.
+//
+// Returns:
+//   - *ExtendTTLOptionsBuilder: A new instance of ExtendTTLOptionsBuilder ready to be configured
+//
+// Example:
+//
+//	opts := redis.NewExtendTTLOptions().SetMaxTTL(24 * time.Hour).SetBatchSize(500)
+func NewExtendTTLOptions() *ExtendTTLOptionsBuilder {
+	return &ExtendTTLOptionsBuilder{}
+}
+
+// defaultExtendTTLOptions returns the builder holding the ExtendTTLPattern defaults.
+func defaultExtendTTLOptions() builderutil.Lister[ExtendTTLOptions] {
+	return NewExtendTTLOptions().SetBatchSize(DefaultExtendTTLBatchSize)
+}
@@ -0,0 +1,134 @@
+package redis_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/zeroxsolutions/banshee/redis"
+	"github.com/zeroxsolutions/barbatos/cache"
+	"github.com/zeroxsolutions/strike/ssutil"
+)
+
+// TestRedisCache_ExtendTTLPattern seeds keys with varied TTLs and verifies the extended TTLs and the
+// returned count, with and without persistent keys and a cap.
+func TestRedisCache_ExtendTTLPattern(t *testing.T) {
+	redisCache := initRedisCache(t)
+
+	defer func(redisCache cache.Cache) {
+		if err := redisCache.Close(); err != nil {
+			t.Log("Close Redis cache connection err", err)
+		}
+	}(redisCache)
+
+	client := initRedisClient(t)
+	defer client.Close()
+
+	extender := redisCache.(*redis.RedisCache)
+	ctx := context.Background()
+
+	seed := func(t *testing.T) string {
+		prefix := ssutil.MakeString(10)
+		initial := map[string]time.Duration{
+			"short":      time.Minute,
+			"long":       10 * time.Minute,
+			"persistent": 0,
+		}
+		for name, ttl := range initial {
+			if err := extender.SetWithExpiration(ctx, prefix+":"+name, "value", ttl); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := extender.SetWithExpiration(ctx, ssutil.MakeString(10), "value", time.Minute); err != nil {
+			t.Fatal(err)
+		}
+		return prefix
+	}
+
+	assertTTL := func(t *testing.T, key string, min, max time.Duration) {
+		ttl, err := client.PTTL(ctx, key).Result()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ttl < min || ttl > max {
+			t.Log("unexpected TTL for", key, ttl)
+			t.FailNow()
+		}
+	}
+
+	t.Run("Default", func(t *testing.T) {
+		prefix := seed(t)
+		defer func() {
+			if err := extender.DelWithPattern(ctx, prefix+":*"); err != nil {
+				t.Error(err)
+			}
+		}()
+
+		extended, err := extender.ExtendTTLPattern(ctx, prefix+":*", time.Hour, redis.NewExtendTTLOptions().SetBatchSize(1))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if extended != 2 {
+			t.Log("expected 2 extended keys, got", extended)
+			t.FailNow()
+		}
+
+		assertTTL(t, prefix+":short", 60*time.Minute, 61*time.Minute)
+		assertTTL(t, prefix+":long", 69*time.Minute, 70*time.Minute)
+		assertTTL(t, prefix+":persistent", -1, -1)
+	})
+
+	t.Run("PersistentAndCap", func(t *testing.T) {
+		prefix := seed(t)
+		defer func() {
+			if err := extender.DelWithPattern(ctx, prefix+":*"); err != nil {
+				t.Error(err)
+			}
+		}()
+
+		extended, err := extender.ExtendTTLPattern(ctx, prefix+":*", 5*time.Minute,
+			redis.NewExtendTTLOptions().SetIncludePersistent(true).SetMaxTTL(8*time.Minute))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if extended != 2 {
+			t.Log("expected 2 extended keys, got", extended)
+			t.FailNow()
+		}
+
+		assertTTL(t, prefix+":short", 5*time.Minute, 6*time.Minute)
+		assertTTL(t, prefix+":long", 9*time.Minute, 10*time.Minute)
+		assertTTL(t, prefix+":persistent", 4*time.Minute, 5*time.Minute)
+	})
+
+	t.Run("InvalidExtension", func(t *testing.T) {
+		prefix := seed(t)
+		defer func() {
+			if err := extender.DelWithPattern(ctx, prefix+":*"); err != nil {
+				t.Error(err)
+			}
+		}()
+
+		for _, extendBy := range []time.Duration{0, -time.Hour} {
+			if _, err := extender.ExtendTTLPattern(ctx, prefix+":*", extendBy,
+				redis.NewExtendTTLOptions().SetIncludePersistent(true)); err != redis.ErrInvalidExpiration {
+				t.Log(extendBy, err)
+				t.FailNow()
+			}
+		}
+
+		assertTTL(t, prefix+":short", 59*time.Second, time.Minute)
+	})
+
+	t.Run("Cancelled", func(t *testing.T) {
+		cancelled, cancel := context.WithCancel(ctx)
+		cancel()
+
+		if _, err := extender.ExtendTTLPattern(cancelled, "*", time.Hour); err != context.Canceled {
+			t.Log(err)
+			t.FailNow()
+		}
+	})
+}
@@ -0,0 +1,54 @@
+package redis
+
+import (
+	"context"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/zeroxsolutions/barbatos/cache"
+	"github.com/zeroxsolutions/strike/builderutil"
+)
+
+// NewRedisCacheFromClient wraps an existing go-redis client in a cache, so several cache views,
+// e.g. one per subsystem, each with its own options or key prefix through NewKeyTransformCache,
+// can share a single connection pool instead of opening one each.
+//
+// Behavior:
+//   - No connection is made: the client is used as is, and unreachable servers surface as errors
+//     of the first operations; only SetRequirePrimary(true) sends a ROLE command
+//   - The client is left unchanged, so writes refused by a replica return the raw READONLY
+//     error rather than a *ReadOnlyError
+//   - Close leaves the client open, since other views may still use it; build the view owning
+//     the client with SetCloseClient(true), or close the client yourself
+//   - Options configuring how connections are opened (SetNetwork, SetNoTouch,
+//     SetReconnectJitter, SetServerTimeCallback) have no effect: configure the client instead
+//
+// Parameters:
+//   - client: The go-redis client to wrap, shared with its other users
+//   - opts: Optional RedisCacheOptions builders applied in order
+//
+// Returns:
+//   - cache.Cache: A Redis cache using client
+//   - error: An error if the options are invalid
+//
+// Example:
+//
+//	client := goredis.NewClient(&goredis.Options{Addr: "localhost:6379"})
+//	defer client.Close()
+//
+//	shared, err := redis.NewRedisCacheFromClient(client)
+//	sessions := banshee.NewKeyTransformCache(shared,
+//	    func(key string) string { return "session:" + key },
+//	    func(key string) string { return strings.TrimPrefix(key, "session:") })
+//	catalog, err := redis.NewRedisCacheFromClient(client, redis.NewRedisCacheOptions().SetScanCount(100))
+func NewRedisCacheFromClient(client *redis.Client, opts ...builderutil.Lister[RedisCacheOptions]) (cache.Cache, error) {
+	options, err := builderutil.Build(append([]builderutil.Lister[RedisCacheOptions]{defaultRedisCacheOptions()}, opts...)...)
+	if err != nil {
+		return nil, err
+	}
+	if options.RequirePrimary {
+		if err := checkPrimary(context.Background(), client); err != nil {
+			return nil, err
+		}
+	}
+	return &RedisCache{client: client, options: options, ownsClient: options.CloseClient}, nil
+}
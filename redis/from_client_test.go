@@ -0,0 +1,70 @@
+package redis_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	goredis "github.com/redis/go-redis/v9"
+	"github.com/zeroxsolutions/banshee/redis"
+	"github.com/zeroxsolutions/strike/ssutil"
+)
+
+// TestNewRedisCacheFromClient verifies that views over one client share its connection pool, and
+// that Close only closes the client for the view owning it.
+func TestNewRedisCacheFromClient(t *testing.T) {
+	client := initRedisClient(t)
+	defer client.Close()
+
+	ctx := context.Background()
+	key := ssutil.MakeString(10)
+
+	first, err := redis.NewRedisCacheFromClient(client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := redis.NewRedisCacheFromClient(client, redis.NewRedisCacheOptions().SetScanCount(10))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer func() {
+		if err := client.Del(ctx, key).Err(); err != nil {
+			t.Error(err)
+		}
+	}()
+
+	if err := first.Set(ctx, key, "value"); err != nil {
+		t.Fatal(err)
+	}
+	if value, err := second.Get(ctx, key); err != nil || value != "value" {
+		t.Fatal(value, err)
+	}
+
+	if stats := client.PoolStats(); stats.TotalConns != 1 || stats.Hits == 0 {
+		t.Fatal("both views should reuse the connection of the shared pool:", stats)
+	}
+
+	if err := first.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if !second.IsConnected(ctx) {
+		t.Fatal("closing a view should leave the shared client open")
+	}
+
+	owned := initRedisClient(t)
+	owner, err := redis.NewRedisCacheFromClient(owned, redis.NewRedisCacheOptions().SetCloseClient(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := owner.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := owned.Ping(ctx).Err(); !errors.Is(err, goredis.ErrClosed) {
+		t.Fatal("closing the owning view should close the client:", err)
+	}
+
+	if _, err := redis.NewRedisCacheFromClient(client, redis.NewRedisCacheOptions().SetScanCount(0)); err == nil {
+		t.Fatal("invalid options should be rejected")
+	}
+}
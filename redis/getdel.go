@@ -0,0 +1,65 @@
+package redis
+
+import (
+	"context"
+	"errors"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/zeroxsolutions/banshee"
+	"github.com/zeroxsolutions/barbatos/cache"
+)
+
+var _ banshee.GetDelCache = (*RedisCache)(nil)
+
+// getDelScript emulates GETDEL for servers older than Redis 6.2.
+//
+// KEYS[1] = key
+// Returns the value, or nil when the key doesn't exist.
+var getDelScript = redis.NewScript(`
+local value = redis.call('GET', KEYS[1])
+if not value then
+	return false
+end
+redis.call('DEL', KEYS[1])
+return value
+`)
+
+// GetDel retrieves the value stored under key and deletes the key in the same atomic step using
+// GETDEL (Redis 6.2+, or a Lua emulation with SetLegacyCommands(true)). Among concurrent callers
+// exactly one receives the value, which makes it suitable for redeeming one-time tokens where a
+// Get followed by a Del would let two requests succeed.
+//
+// The key is always removed for good: SetSoftDelete doesn't apply, since a consumed
+// value must not be restorable. The delete is audited like a Del.
+//
+// Parameters:
+//   - ctx: Context for timeout control and request cancellation
+//   - key: Redis key to read and delete
+//
+// Returns:
+//   - string: The stored value
+//   - error: cache.ErrCacheNil if the key doesn't exist, or a Redis error
+//
+// Example:
+//
+//	userID, err := cache.GetDel(ctx, "reset-token:"+token)
+//	if errors.Is(err, cache.ErrCacheNil) {
+//	    // token unknown, expired or already redeemed
+//	}
+func (r *RedisCache) GetDel(ctx context.Context, key string) (string, error) {
+	var value string
+	var err error
+	if r.options.LegacyCommands {
+		value, err = getDelScript.Run(ctx, r.client, []string{key}).Text()
+	} else {
+		value, err = r.client.GetDel(ctx, key).Result()
+	}
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return "", cache.ErrCacheNil
+		}
+		return "", err
+	}
+	r.audit(ctx, AuditOpDel, []string{key}, "", 1)
+	return value, nil
+}
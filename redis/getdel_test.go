@@ -0,0 +1,78 @@
+package redis_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/zeroxsolutions/banshee/redis"
+	"github.com/zeroxsolutions/barbatos/cache"
+	"github.com/zeroxsolutions/strike/builderutil"
+	"github.com/zeroxsolutions/strike/ssutil"
+)
+
+// TestRedisCache_GetDel verifies that among concurrent GetDel calls exactly one receives the
+// value and the others see cache.ErrCacheNil, on both the native command and the Lua emulation.
+func TestRedisCache_GetDel(t *testing.T) {
+	paths := []struct {
+		name string
+		opts builderutil.Lister[redis.RedisCacheOptions]
+	}{
+		{"Native", redis.NewRedisCacheOptions()},
+		{"Legacy", redis.NewRedisCacheOptions().SetLegacyCommands(true)},
+	}
+
+	for _, path := range paths {
+		t.Run(path.name, func(t *testing.T) {
+			redisCache := initRedisCache(t, path.opts)
+
+			defer func(redisCache cache.Cache) {
+				if err := redisCache.Close(); err != nil {
+					t.Log("Close Redis cache connection err", err)
+				}
+			}(redisCache)
+
+			getDel := redisCache.(*redis.RedisCache)
+
+			ctx := context.Background()
+			key := ssutil.MakeString(10)
+			value := ssutil.MakeString(12)
+
+			if err := getDel.Set(ctx, key, value); err != nil {
+				t.Fatal(err)
+			}
+
+			const callers = 20
+			var wg sync.WaitGroup
+			var mu sync.Mutex
+			var winners, misses int
+			for i := 0; i < callers; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					v, err := getDel.GetDel(ctx, key)
+					mu.Lock()
+					defer mu.Unlock()
+					switch {
+					case err == nil && v == value:
+						winners++
+					case errors.Is(err, cache.ErrCacheNil):
+						misses++
+					default:
+						t.Error("unexpected result:", v, err)
+					}
+				}()
+			}
+			wg.Wait()
+
+			if winners != 1 || misses != callers-1 {
+				t.Fatal("exactly one caller should receive the value:", winners, misses)
+			}
+
+			if _, err := getDel.Get(ctx, key); !errors.Is(err, cache.ErrCacheNil) {
+				t.Fatal("the key should be deleted:", err)
+			}
+		})
+	}
+}
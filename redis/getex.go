@@ -0,0 +1,94 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/zeroxsolutions/banshee"
+	"github.com/zeroxsolutions/barbatos/cache"
+)
+
+var _ banshee.GetExCache = (*RedisCache)(nil)
+
+// getExScript emulates GETEX for servers older than Redis 6.2.
+//
+// KEYS[1] = key, ARGV[1] = "PX" with ARGV[2] = TTL in milliseconds, "PERSIST", or absent to leave
+// the TTL unchanged.
+// Returns the value, or nil when the key doesn't exist.
+var getExScript = redis.NewScript(`
+local value = redis.call('GET', KEYS[1])
+if not value then
+	return false
+end
+if ARGV[1] == 'PX' then
+	redis.call('PEXPIRE', KEYS[1], ARGV[2])
+elseif ARGV[1] == 'PERSIST' then
+	redis.call('PERSIST', KEYS[1])
+end
+return value
+`)
+
+// GetEx retrieves the value stored under key and updates its TTL in the same round trip using
+// GETEX (Redis 6.2+, or a Lua emulation with SetLegacyCommands(true)).
+//
+// TTL semantics:
+//   - ttl > 0 sets a new expiration of ttl
+//   - ttl <= 0 leaves the current expiration unchanged
+//   - Use GetExPersist to remove the expiration
+//
+// Parameters:
+//   - ctx: Context for timeout control and request cancellation
+//   - key: Redis key to read
+//   - ttl: New time-to-live, or 0 to keep the current one
+//
+// Returns:
+//   - string: The stored value
+//   - error: cache.ErrCacheNil if the key doesn't exist, or a Redis error
+//
+// Example:
+//
+//	session, err := cache.GetEx(ctx, "session:42", 30*time.Minute)
+func (r *RedisCache) GetEx(ctx context.Context, key string, ttl time.Duration) (string, error) {
+	if ttl <= 0 {
+		return r.getEx(ctx, key)
+	}
+	return r.getEx(ctx, key, "PX", ttl.Milliseconds())
+}
+
+// GetExPersist retrieves the value stored under key and removes its expiration in the same
+// round trip using GETEX PERSIST (Redis 6.2+, or a Lua emulation with SetLegacyCommands(true)).
+//
+// Parameters:
+//   - ctx: Context for timeout control and request cancellation
+//   - key: Redis key to read
+//
+// Returns:
+//   - string: The stored value
+//   - error: cache.ErrCacheNil if the key doesn't exist, or a Redis error
+//
+// Example:
+//
+//	value, err := cache.GetExPersist(ctx, "feature:flags")
+func (r *RedisCache) GetExPersist(ctx context.Context, key string) (string, error) {
+	return r.getEx(ctx, key, "PERSIST")
+}
+
+// getEx runs GETEX with the given TTL arguments, natively or through getExScript.
+func (r *RedisCache) getEx(ctx context.Context, key string, args ...interface{}) (string, error) {
+	var value string
+	var err error
+	if r.options.LegacyCommands {
+		value, err = getExScript.Run(ctx, r.client, []string{key}, args...).Text()
+	} else {
+		value, err = r.client.Do(ctx, append([]interface{}{"GETEX", key}, args...)...).Text()
+	}
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return "", cache.ErrCacheNil
+		}
+		return "", err
+	}
+	return value, nil
+}
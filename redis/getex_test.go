@@ -0,0 +1,99 @@
+package redis_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/zeroxsolutions/banshee/redis"
+	"github.com/zeroxsolutions/barbatos/cache"
+	"github.com/zeroxsolutions/strike/builderutil"
+	"github.com/zeroxsolutions/strike/ssutil"
+)
+
+// TestRedisCache_GetEx verifies that each GetEx variant returns the value and leaves the expected
+// TTL, on both the native command and the Lua emulation.
+func TestRedisCache_GetEx(t *testing.T) {
+	paths := []struct {
+		name string
+		opts builderutil.Lister[redis.RedisCacheOptions]
+	}{
+		{"Native", redis.NewRedisCacheOptions()},
+		{"Legacy", redis.NewRedisCacheOptions().SetLegacyCommands(true)},
+	}
+
+	client := initRedisClient(t)
+	defer client.Close()
+
+	for _, path := range paths {
+		t.Run(path.name, func(t *testing.T) {
+			redisCache := initRedisCache(t, path.opts)
+
+			defer func(redisCache cache.Cache) {
+				if err := redisCache.Close(); err != nil {
+					t.Log("Close Redis cache connection err", err)
+				}
+			}(redisCache)
+
+			getEx := redisCache.(*redis.RedisCache)
+
+			ctx := context.Background()
+			key := ssutil.MakeString(10)
+			value := ssutil.MakeString(12)
+
+			if err := getEx.SetWithExpiration(ctx, key, value, time.Hour); err != nil {
+				t.Fatal(err)
+			}
+
+			defer func() {
+				if err := getEx.Del(ctx, key); err != nil {
+					t.Error(err)
+				}
+			}()
+
+			assert := func(t *testing.T, v string, err error, min, max time.Duration) {
+				if err != nil {
+					t.Fatal(err)
+				}
+				if v != value {
+					t.Log("unexpected value:", v)
+					t.FailNow()
+				}
+				ttl, err := client.PTTL(ctx, key).Result()
+				if err != nil {
+					t.Fatal(err)
+				}
+				if ttl < min || ttl > max {
+					t.Log("unexpected TTL:", ttl)
+					t.FailNow()
+				}
+			}
+
+			t.Run("Set", func(t *testing.T) {
+				v, err := getEx.GetEx(ctx, key, time.Minute)
+				assert(t, v, err, 59*time.Second, time.Minute)
+			})
+
+			t.Run("Unchanged", func(t *testing.T) {
+				v, err := getEx.GetEx(ctx, key, 0)
+				assert(t, v, err, 59*time.Second, time.Minute)
+			})
+
+			t.Run("Persist", func(t *testing.T) {
+				v, err := getEx.GetExPersist(ctx, key)
+				assert(t, v, err, -1, -1)
+			})
+
+			t.Run("Missing", func(t *testing.T) {
+				if _, err := getEx.GetEx(ctx, ssutil.MakeString(10), time.Minute); err != cache.ErrCacheNil {
+					t.Log(err)
+					t.FailNow()
+				}
+				if _, err := getEx.GetExPersist(ctx, ssutil.MakeString(10)); err != cache.ErrCacheNil {
+					t.Log(err)
+					t.FailNow()
+				}
+			})
+		})
+	}
+}
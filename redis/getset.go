@@ -0,0 +1,118 @@
+package redis
+
+import (
+	"context"
+	"errors"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/zeroxsolutions/banshee"
+	"github.com/zeroxsolutions/banshee/internal/valueutil"
+	"github.com/zeroxsolutions/barbatos/cache"
+)
+
+var _ banshee.GetSetCache = (*RedisCache)(nil)
+
+// getSetKeepTTLScript emulates SET with the KEEPTTL and GET options for servers older than
+// Redis 6.2.
+//
+// KEYS[1] = key, ARGV[1] = new value.
+// Returns the previous value, or nil when the key didn't exist.
+var getSetKeepTTLScript = redis.NewScript(`
+local old = redis.call('GET', KEYS[1])
+local ttl = redis.call('PTTL', KEYS[1])
+if ttl > 0 then
+	redis.call('SET', KEYS[1], ARGV[1], 'PX', ttl)
+else
+	redis.call('SET', KEYS[1], ARGV[1])
+end
+return old
+`)
+
+// GetSet atomically stores value under key and returns the value it replaced, e.g. to read and
+// reset a counter in one step. It uses SET with the GET option (Redis 6.2+, or GETSET with
+// SetLegacyCommands(true)).
+//
+// Behavior:
+//   - The new value is written whether or not the key existed: a missing key is created and
+//     cache.ErrCacheNil is returned, as Get does
+//   - The key ends up without a TTL, like after Set; see GetSetKeepTTL to keep it
+//   - Values are converted as by Set; nil values fail with ErrNilValue
+//
+// Parameters:
+//   - ctx: Context for timeout control and request cancellation
+//   - key: Redis key to swap
+//   - value: Value replacing the current one
+//
+// Returns:
+//   - string: The previous value
+//   - error: cache.ErrCacheNil if there was no previous value (the new one is still written),
+//     ErrNilValue, or a Redis error
+//
+// Example:
+//
+//	// Read and reset the error counter of the last interval.
+//	count, err := cache.GetSet(ctx, "errors:api", 0)
+func (r *RedisCache) GetSet(ctx context.Context, key string, value interface{}) (string, error) {
+	if valueutil.IsNil(value) {
+		return "", ErrNilValue
+	}
+	var cmd *redis.StringCmd
+	if r.options.LegacyCommands {
+		cmd = r.client.GetSet(ctx, key, value)
+	} else {
+		cmd = redis.NewStringCmd(ctx, "set", key, value, "get")
+		_ = r.client.Process(ctx, cmd)
+	}
+	return getSetResult(cmd.Result())
+}
+
+// GetSetKeepTTL atomically stores value under key and returns the value it replaced, keeping the
+// key's remaining TTL, e.g. to flip a feature flag without extending or dropping its expiration.
+// It uses SET with the KEEPTTL and GET options (Redis 6.2+, or a Lua emulation with
+// SetLegacyCommands(true)).
+//
+// Behavior:
+//   - A key with a TTL keeps its remaining TTL; a key without one stays persistent
+//   - The new value is written whether or not the key existed: a missing key is created without
+//     a TTL and cache.ErrCacheNil is returned; use RotateKeepTTL to leave missing keys missing
+//   - Values are converted as by Set; nil values fail with ErrNilValue
+//
+// Parameters:
+//   - ctx: Context for timeout control and request cancellation
+//   - key: Redis key to swap
+//   - value: Value replacing the current one
+//
+// Returns:
+//   - string: The previous value
+//   - error: cache.ErrCacheNil if there was no previous value (the new one is still written),
+//     ErrNilValue, or a Redis error
+//
+// Example:
+//
+//	previous, err := cache.GetSetKeepTTL(ctx, "flag:new-checkout", "on")
+//	if err == nil && previous != "on" {
+//	    log.Println("new checkout enabled")
+//	}
+func (r *RedisCache) GetSetKeepTTL(ctx context.Context, key string, value interface{}) (string, error) {
+	if valueutil.IsNil(value) {
+		return "", ErrNilValue
+	}
+	if r.options.LegacyCommands {
+		return getSetResult(getSetKeepTTLScript.Run(ctx, r.client, []string{key}, value).Text())
+	}
+	cmd := redis.NewStringCmd(ctx, "set", key, value, "keepttl", "get")
+	_ = r.client.Process(ctx, cmd)
+	return getSetResult(cmd.Result())
+}
+
+// getSetResult returns the previous value replied by a GetSet command, mapping a missing one to
+// cache.ErrCacheNil.
+func getSetResult(old string, err error) (string, error) {
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return "", cache.ErrCacheNil
+		}
+		return "", err
+	}
+	return old, nil
+}
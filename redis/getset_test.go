@@ -0,0 +1,129 @@
+package redis_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/zeroxsolutions/banshee/redis"
+	"github.com/zeroxsolutions/barbatos/cache"
+	"github.com/zeroxsolutions/strike/ssutil"
+)
+
+// TestRedisCache_GetSet verifies that GetSet returns the replaced value, writes the new value even
+// for a missing key, and behaves the same with legacy commands.
+func TestRedisCache_GetSet(t *testing.T) {
+	for name, opts := range map[string]*redis.RedisCacheOptionsBuilder{
+		"Native": redis.NewRedisCacheOptions(),
+		"Legacy": redis.NewRedisCacheOptions().SetLegacyCommands(true),
+	} {
+		t.Run(name, func(t *testing.T) {
+			redisCache := initRedisCache(t, opts)
+
+			defer func(redisCache cache.Cache) {
+				if err := redisCache.Close(); err != nil {
+					t.Log("Close Redis cache connection err", err)
+				}
+			}(redisCache)
+
+			client := initRedisClient(t)
+			defer client.Close()
+
+			swapper := redisCache.(*redis.RedisCache)
+
+			ctx := context.Background()
+			key := ssutil.MakeString(10)
+
+			defer func() {
+				if err := swapper.Del(ctx, key); err != nil {
+					t.Error(err)
+				}
+			}()
+
+			if _, err := swapper.GetSet(ctx, key, "first"); !errors.Is(err, cache.ErrCacheNil) {
+				t.Fatal(err)
+			}
+			if value, err := swapper.Get(ctx, key); err != nil || value != "first" {
+				t.Fatal("the new value should be written for a missing key:", value, err)
+			}
+
+			if err := swapper.SetWithExpiration(ctx, key, 41, time.Minute); err != nil {
+				t.Fatal(err)
+			}
+			old, err := swapper.GetSet(ctx, key, 0)
+			if err != nil || old != "41" {
+				t.Fatal(old, err)
+			}
+			if value, err := swapper.Get(ctx, key); err != nil || value != "0" {
+				t.Fatal(value, err)
+			}
+			if ttl, err := client.PTTL(ctx, key).Result(); err != nil || ttl != -1 {
+				t.Fatal("the swapped key should not keep its TTL:", ttl, err)
+			}
+
+			if _, err := swapper.GetSet(ctx, key, nil); !errors.Is(err, redis.ErrNilValue) {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+// TestRedisCache_GetSetKeepTTL verifies that GetSetKeepTTL returns the replaced value and keeps the
+// key's TTL, writes the new value even for a missing key, and behaves the same with legacy
+// commands.
+func TestRedisCache_GetSetKeepTTL(t *testing.T) {
+	for name, opts := range map[string]*redis.RedisCacheOptionsBuilder{
+		"Native": redis.NewRedisCacheOptions(),
+		"Legacy": redis.NewRedisCacheOptions().SetLegacyCommands(true),
+	} {
+		t.Run(name, func(t *testing.T) {
+			redisCache := initRedisCache(t, opts)
+
+			defer func(redisCache cache.Cache) {
+				if err := redisCache.Close(); err != nil {
+					t.Log("Close Redis cache connection err", err)
+				}
+			}(redisCache)
+
+			client := initRedisClient(t)
+			defer client.Close()
+
+			swapper := redisCache.(*redis.RedisCache)
+
+			ctx := context.Background()
+			key := ssutil.MakeString(10)
+
+			defer func() {
+				if err := swapper.Del(ctx, key); err != nil {
+					t.Error(err)
+				}
+			}()
+
+			if _, err := swapper.GetSetKeepTTL(ctx, key, "off"); !errors.Is(err, cache.ErrCacheNil) {
+				t.Fatal(err)
+			}
+			if ttl, err := client.PTTL(ctx, key).Result(); err != nil || ttl != -1 {
+				t.Fatal("a missing key should be created without a TTL:", ttl, err)
+			}
+
+			if err := swapper.SetWithExpiration(ctx, key, "off", time.Minute); err != nil {
+				t.Fatal(err)
+			}
+			old, err := swapper.GetSetKeepTTL(ctx, key, "on")
+			if err != nil || old != "off" {
+				t.Fatal(old, err)
+			}
+			if value, err := swapper.Get(ctx, key); err != nil || value != "on" {
+				t.Fatal(value, err)
+			}
+			if ttl, err := client.PTTL(ctx, key).Result(); err != nil || ttl <= 59*time.Second || ttl > time.Minute {
+				t.Fatal("the swapped key should keep its TTL:", ttl, err)
+			}
+
+			if _, err := swapper.GetSetKeepTTL(ctx, key, nil); !errors.Is(err, redis.ErrNilValue) {
+				t.Fatal(err)
+			}
+		})
+	}
+}
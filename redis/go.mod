@@ -5,6 +5,7 @@ go 1.18
 require (
 	github.com/redis/go-redis/v9 v9.7.0
 	github.com/zeroxsolutions/alex v0.0.1
+	github.com/zeroxsolutions/banshee v0.0.2
 	github.com/zeroxsolutions/barbatos v0.0.1
 	github.com/zeroxsolutions/strike v0.0.1
 )
@@ -13,3 +14,5 @@ require (
 	github.com/cespare/xxhash/v2 v2.2.0 // indirect
 	github.com/dgryski/go-rendezvous v0.0.0-20200823014737-9f7001d12a5f // indirect
 )
+
+replace github.com/zeroxsolutions/banshee => ../
@@ -0,0 +1,105 @@
+package redis
+
+import (
+	"context"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/zeroxsolutions/banshee"
+	"github.com/zeroxsolutions/barbatos/cache"
+)
+
+var _ banshee.HashCache = (*RedisCache)(nil)
+
+// HGetAll retrieves every field of the hash stored under key.
+//
+// Parameters:
+//   - ctx: Context for timeout control and request cancellation
+//   - key: Redis key of the hash
+//
+// Returns:
+//   - map[string]string: The fields and values of the hash
+//   - error: cache.ErrCacheNil if the key doesn't exist, or a Redis error
+//
+// Example:
+//
+//	fields, err := cache.HGetAll(ctx, "user:42")
+func (r *RedisCache) HGetAll(ctx context.Context, key string) (map[string]string, error) {
+	fields, err := r.client.HGetAll(ctx, key).Result()
+	if err != nil {
+		return nil, err
+	}
+	if len(fields) == 0 {
+		return nil, cache.ErrCacheNil
+	}
+	return fields, nil
+}
+
+// HGetAllMany retrieves the fields of several hashes in a single pipelined round trip.
+// Redis reports a missing key as an empty hash, so keys without fields are omitted.
+//
+// Parameters:
+//   - ctx: Context for timeout control and request cancellation
+//   - keys: Redis keys of the hashes
+//
+// Returns:
+//   - map[string]map[string]string: The fields of each existing hash, keyed by key
+//   - error: An error if the Redis operation fails
+//
+// Example:
+//
+//	hashes, err := cache.HGetAllMany(ctx, []string{"user:1", "user:2"})
+func (r *RedisCache) HGetAllMany(ctx context.Context, keys []string) (map[string]map[string]string, error) {
+	cmds := make([]*redis.MapStringStringCmd, len(keys))
+	_, err := r.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for i, key := range keys {
+			cmds[i] = pipe.HGetAll(ctx, key)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	hashes := make(map[string]map[string]string, len(keys))
+	for i, cmd := range cmds {
+		if fields := cmd.Val(); len(fields) > 0 {
+			hashes[keys[i]] = fields
+		}
+	}
+	return hashes, nil
+}
+
+// HSetMany writes the fields of several hashes in a single pipelined round trip.
+//
+// Behavior:
+//   - All the fields of one key are written by a single HSET, so each hash is updated atomically
+//     and readers never see it half written
+//   - Atomicity is per key only: readers may see some hashes updated and others not yet, and if
+//     the pipeline fails midway some keys stay unwritten; writing several hashes all-or-nothing
+//     requires wrapping the HSETs in a MULTI/EXEC transaction
+//   - Fields not listed are left untouched; keys with no fields are skipped
+//
+// Parameters:
+//   - ctx: Context for timeout control and request cancellation
+//   - entries: Fields to write, keyed by the Redis key of their hash
+//
+// Returns:
+//   - error: An error if any of the writes fails
+//
+// Example:
+//
+//	err := cache.HSetMany(ctx, map[string]map[string]interface{}{
+//	    "user:1": {"name": "alice", "age": 31},
+//	    "user:2": {"name": "bob", "age": 27},
+//	})
+func (r *RedisCache) HSetMany(ctx context.Context, entries map[string]map[string]interface{}) error {
+	_, err := r.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for key, fields := range entries {
+			if len(fields) == 0 {
+				continue
+			}
+			pipe.HSet(ctx, key, fields)
+		}
+		return nil
+	})
+	return err
+}
@@ -0,0 +1,135 @@
+package redis_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/zeroxsolutions/banshee/redis"
+	"github.com/zeroxsolutions/banshee/typed"
+	"github.com/zeroxsolutions/barbatos/cache"
+	"github.com/zeroxsolutions/strike/ssutil"
+)
+
+// TestRedisCache_HGetAll verifies single and pipelined hash reads, including missing keys, and
+// loading them into structs.
+func TestRedisCache_HGetAll(t *testing.T) {
+	redisCache := initRedisCache(t)
+
+	defer func(redisCache cache.Cache) {
+		if err := redisCache.Close(); err != nil {
+			t.Log("Close Redis cache connection err", err)
+		}
+	}(redisCache)
+
+	client := initRedisClient(t)
+	defer client.Close()
+
+	hashes := redisCache.(*redis.RedisCache)
+
+	ctx := context.Background()
+	first := ssutil.MakeString(10)
+	second := ssutil.MakeString(10)
+	missing := ssutil.MakeString(10)
+
+	if err := client.HSet(ctx, first, "name", "alice", "age", "31").Err(); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.HSet(ctx, second, "name", "bob").Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	defer func() {
+		if err := hashes.Del(ctx, first, second); err != nil {
+			t.Error(err)
+		}
+	}()
+
+	fields, err := hashes.HGetAll(ctx, first)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if fields["name"] != "alice" || fields["age"] != "31" {
+		t.Log("unexpected fields:", fields)
+		t.FailNow()
+	}
+
+	if _, err := hashes.HGetAll(ctx, missing); err != cache.ErrCacheNil {
+		t.Log(err)
+		t.FailNow()
+	}
+
+	type user struct {
+		Name string `cache:"name"`
+		Age  int    `cache:"age"`
+	}
+
+	users, err := typed.GetHashObjects[user](ctx, hashes, []string{first, second, missing})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(users) != 2 || users[first] != (user{Name: "alice", Age: 31}) || users[second] != (user{Name: "bob"}) {
+		t.Logf("unexpected users: %+v", users)
+		t.FailNow()
+	}
+}
+
+// TestRedisCache_HSetMany verifies that several multi-field hashes are written in one call and
+// that existing fields not listed are kept.
+func TestRedisCache_HSetMany(t *testing.T) {
+	redisCache := initRedisCache(t)
+
+	defer func(redisCache cache.Cache) {
+		if err := redisCache.Close(); err != nil {
+			t.Log("Close Redis cache connection err", err)
+		}
+	}(redisCache)
+
+	client := initRedisClient(t)
+	defer client.Close()
+
+	hashes := redisCache.(*redis.RedisCache)
+
+	ctx := context.Background()
+	first := ssutil.MakeString(10)
+	second := ssutil.MakeString(10)
+	third := ssutil.MakeString(10)
+	empty := ssutil.MakeString(10)
+
+	defer func() {
+		if err := hashes.Del(ctx, first, second, third); err != nil {
+			t.Error(err)
+		}
+	}()
+
+	if err := client.HSet(ctx, first, "email", "alice@example.com").Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	err := hashes.HSetMany(ctx, map[string]map[string]interface{}{
+		first:  {"name": "alice", "age": 31},
+		second: {"name": "bob", "age": 27, "admin": true},
+		third:  {"name": "carol"},
+		empty:  {},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	written, err := hashes.HGetAllMany(ctx, []string{first, second, third, empty})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string]map[string]string{
+		first:  {"name": "alice", "age": "31", "email": "alice@example.com"},
+		second: {"name": "bob", "age": "27", "admin": "1"},
+		third:  {"name": "carol"},
+	}
+	if !reflect.DeepEqual(written, expected) {
+		t.Log("unexpected hashes:", written)
+		t.FailNow()
+	}
+}
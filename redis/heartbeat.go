@@ -0,0 +1,71 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/zeroxsolutions/banshee"
+	"github.com/zeroxsolutions/barbatos/cache"
+)
+
+var _ banshee.HeartbeatCache = (*RedisCache)(nil)
+
+// heartbeatScript replaces a heartbeat timestamp and sets its TTL, returning the previous one.
+//
+// KEYS[1] = heartbeat key, ARGV[1] = now in unix milliseconds, ARGV[2] = TTL in milliseconds
+// Returns the previous timestamp, or nil when the key doesn't exist.
+var heartbeatScript = redis.NewScript(`
+local previous = redis.call('GET', KEYS[1])
+redis.call('SET', KEYS[1], ARGV[1], 'PX', ARGV[2])
+return previous
+`)
+
+// Heartbeat atomically records a heartbeat at key, as the current time in unix milliseconds with
+// the given TTL, and returns the time of the previous heartbeat. Reading the previous heartbeat,
+// writing the new one and setting the TTL run in a single Lua script, so a lease can't be left
+// without a TTL and two beats can't both read the same previous time.
+//
+// Behavior:
+//   - The first heartbeat, or the first after the previous one expired, is stored and
+//     cache.ErrCacheNil is returned
+//   - Every heartbeat resets the TTL, so the key expires ttl after the last beat
+//   - Timestamps come from the local clock; readers can parse them with strconv.ParseInt and
+//     time.UnixMilli
+//
+// Parameters:
+//   - ctx: Context for timeout control and request cancellation
+//   - key: Redis key holding the heartbeat, e.g. "lease:worker-7"
+//   - ttl: Time after which the lease lapses without a new heartbeat, must be positive
+//
+// Returns:
+//   - time.Time: The time of the previous heartbeat
+//   - error: cache.ErrCacheNil on the first heartbeat (the heartbeat is still stored),
+//     ErrInvalidExpiration, an error if the previous value isn't a timestamp, or a Redis error
+//
+// Example:
+//
+//	previous, err := cache.Heartbeat(ctx, "lease:"+workerID, 30*time.Second)
+//	if err == nil && time.Since(previous) > 20*time.Second {
+//	    log.Printf("worker %s missed a heartbeat", workerID)
+//	}
+func (r *RedisCache) Heartbeat(ctx context.Context, key string, ttl time.Duration) (time.Time, error) {
+	if ttl <= 0 {
+		return time.Time{}, ErrInvalidExpiration
+	}
+	previous, err := heartbeatScript.Run(ctx, r.client, []string{key}, time.Now().UnixMilli(), ttl.Milliseconds()).Text()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return time.Time{}, cache.ErrCacheNil
+		}
+		return time.Time{}, err
+	}
+	ms, err := strconv.ParseInt(previous, 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("redis: previous heartbeat of %q is not a timestamp: %w", key, err)
+	}
+	return time.UnixMilli(ms), nil
+}
@@ -0,0 +1,63 @@
+package redis_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/zeroxsolutions/banshee/redis"
+	"github.com/zeroxsolutions/barbatos/cache"
+	"github.com/zeroxsolutions/strike/ssutil"
+)
+
+// TestRedisCache_Heartbeat verifies that Heartbeat reports the first beat as a miss, returns the
+// previous beat afterwards, and resets the TTL on every beat.
+func TestRedisCache_Heartbeat(t *testing.T) {
+	redisCache := initRedisCache(t)
+
+	defer func(redisCache cache.Cache) {
+		if err := redisCache.Close(); err != nil {
+			t.Log("Close Redis cache connection err", err)
+		}
+	}(redisCache)
+
+	client := initRedisClient(t)
+	defer client.Close()
+
+	leases := redisCache.(*redis.RedisCache)
+
+	ctx := context.Background()
+	key := "lease:" + ssutil.MakeString(10)
+
+	defer func() {
+		if err := leases.Del(ctx, key); err != nil {
+			t.Error(err)
+		}
+	}()
+
+	first := time.Now().Truncate(time.Millisecond)
+	if _, err := leases.Heartbeat(ctx, key, time.Minute); !errors.Is(err, cache.ErrCacheNil) {
+		t.Fatal("the first heartbeat should report no previous one:", err)
+	}
+
+	if err := client.PExpire(ctx, key, time.Second).Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	previous, err := leases.Heartbeat(ctx, key, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if previous.Before(first) || previous.After(time.Now()) {
+		t.Fatal("unexpected previous heartbeat:", previous, first)
+	}
+
+	if ttl, err := client.PTTL(ctx, key).Result(); err != nil || ttl <= time.Second || ttl > time.Minute {
+		t.Fatal("the heartbeat should reset the TTL:", ttl, err)
+	}
+
+	if _, err := leases.Heartbeat(ctx, key, 0); !errors.Is(err, redis.ErrInvalidExpiration) {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,99 @@
+package redis
+
+import (
+	"bufio"
+	"context"
+	"strconv"
+	"strings"
+)
+
+// ServerInfo holds the commonly used fields of the INFO command output.
+// Fields belonging to sections that were not requested are left at their zero value.
+type ServerInfo struct {
+	Version          string            // Version is the server version (redis_version), from the "server" section.
+	Mode             string            // Mode is "standalone", "sentinel", or "cluster" (redis_mode), from the "server" section.
+	UptimeSeconds    int64             // UptimeSeconds is the server uptime (uptime_in_seconds), from the "server" section.
+	ConnectedClients int64             // ConnectedClients is the number of client connections, from the "clients" section.
+	UsedMemory       int64             // UsedMemory is the memory allocated by the server in bytes, from the "memory" section.
+	MaxMemory        int64             // MaxMemory is the configured memory limit in bytes (0 for none), from the "memory" section.
+	KeyspaceHits     int64             // KeyspaceHits is the number of successful key lookups, from the "stats" section.
+	KeyspaceMisses   int64             // KeyspaceMisses is the number of failed key lookups, from the "stats" section.
+	Role             string            // Role is "master" or "slave", from the "replication" section.
+	Raw              map[string]string // Raw holds every field of the output, including the parsed ones.
+}
+
+// HitRate returns the fraction of key lookups that found a key, between 0 and 1.
+// It returns 0 when no lookup has been recorded or the "stats" section was not requested.
+func (i ServerInfo) HitRate() float64 {
+	total := i.KeyspaceHits + i.KeyspaceMisses
+	if total == 0 {
+		return 0
+	}
+	return float64(i.KeyspaceHits) / float64(total)
+}
+
+// Info runs INFO for the requested sections and parses the result into a ServerInfo.
+// Without sections, the server's default sections are returned, which include every field
+// parsed into ServerInfo.
+//
+// Parameters:
+//   - ctx: Context for timeout control and request cancellation
+//   - sections: Optional INFO sections such as "server", "memory", or "stats"
+//
+// Returns:
+//   - ServerInfo: The parsed server information
+//   - error: *PermissionError (matching ErrPermissionDenied) if the server refuses INFO, or a Redis error
+//
+// Example:
+//
+//	info, err := cache.Info(ctx, "server", "stats")
+//	log.Printf("redis %s, hit rate %.2f", info.Version, info.HitRate())
+func (r *RedisCache) Info(ctx context.Context, sections ...string) (ServerInfo, error) {
+	text, err := r.client.Info(ctx, sections...).Result()
+	if err != nil {
+		return ServerInfo{}, permissionError("INFO", err)
+	}
+	return ParseInfo(text), nil
+}
+
+// ParseInfo parses the text returned by the INFO command. Lines that are empty, section
+// headers, or not in "field:value" form are skipped; numeric fields that fail to parse are
+// left at zero but remain available in Raw.
+//
+// Parameters:
+//   - text: Output of the INFO command
+//
+// Returns:
+//   - ServerInfo: The parsed server information
+func ParseInfo(text string) ServerInfo {
+	info := ServerInfo{Raw: map[string]string{}}
+	scanner := bufio.NewScanner(strings.NewReader(text))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		field, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		info.Raw[field] = value
+	}
+
+	info.Version = info.Raw["redis_version"]
+	info.Mode = info.Raw["redis_mode"]
+	info.Role = info.Raw["role"]
+	info.UptimeSeconds = parseInfoInt(info.Raw["uptime_in_seconds"])
+	info.ConnectedClients = parseInfoInt(info.Raw["connected_clients"])
+	info.UsedMemory = parseInfoInt(info.Raw["used_memory"])
+	info.MaxMemory = parseInfoInt(info.Raw["maxmemory"])
+	info.KeyspaceHits = parseInfoInt(info.Raw["keyspace_hits"])
+	info.KeyspaceMisses = parseInfoInt(info.Raw["keyspace_misses"])
+	return info
+}
+
+// parseInfoInt parses an integer INFO field, returning 0 for missing or malformed values.
+func parseInfoInt(value string) int64 {
+	n, _ := strconv.ParseInt(value, 10, 64)
+	return n
+}
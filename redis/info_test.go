@@ -0,0 +1,158 @@
+package redis_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/zeroxsolutions/banshee/redis"
+	"github.com/zeroxsolutions/barbatos/cache"
+)
+
+// Canned INFO outputs from several Redis versions, trimmed to the relevant sections. Redis
+// separates lines with CRLF.
+var infoFixtures = map[string]string{
+	"5.0": strings.Join([]string{
+		"# Server",
+		"redis_version:5.0.14",
+		"redis_mode:standalone",
+		"uptime_in_seconds:86400",
+		"",
+		"# Clients",
+		"connected_clients:12",
+		"",
+		"# Memory",
+		"used_memory:1048576",
+		"used_memory_human:1.00M",
+		"maxmemory:0",
+		"",
+		"# Stats",
+		"keyspace_hits:300",
+		"keyspace_misses:100",
+		"",
+		"# Replication",
+		"role:master",
+		"connected_slaves:0",
+		"",
+		"# Keyspace",
+		"db0:keys=10,expires=2,avg_ttl=0",
+	}, "\r\n"),
+	"6.2": strings.Join([]string{
+		"# Server",
+		"redis_version:6.2.14",
+		"redis_mode:standalone",
+		"uptime_in_seconds:3600",
+		"",
+		"# Clients",
+		"connected_clients:3",
+		"",
+		"# Memory",
+		"used_memory:2097152",
+		"maxmemory:536870912",
+		"maxmemory_policy:allkeys-lru",
+		"",
+		"# Stats",
+		"keyspace_hits:0",
+		"keyspace_misses:0",
+		"",
+		"# Replication",
+		"role:slave",
+		"master_host:10.0.0.1",
+	}, "\r\n"),
+	"7.2": strings.Join([]string{
+		"# Server",
+		"redis_version:7.2.4",
+		"redis_mode:cluster",
+		"uptime_in_seconds:60",
+		"",
+		"# Clients",
+		"connected_clients:1",
+		"",
+		"# Memory",
+		"used_memory:1024",
+		"maxmemory:1073741824",
+		"",
+		"# Stats",
+		"keyspace_hits:9",
+		"keyspace_misses:1",
+		"",
+		"# Replication",
+		"role:master",
+	}, "\r\n"),
+}
+
+// TestParseInfo verifies the parsed fields of the canned INFO fixtures.
+func TestParseInfo(t *testing.T) {
+	tests := []struct {
+		version string
+		want    redis.ServerInfo
+		hitRate float64
+	}{
+		{"5.0", redis.ServerInfo{Version: "5.0.14", Mode: "standalone", UptimeSeconds: 86400, ConnectedClients: 12, UsedMemory: 1048576, MaxMemory: 0, KeyspaceHits: 300, KeyspaceMisses: 100, Role: "master"}, 0.75},
+		{"6.2", redis.ServerInfo{Version: "6.2.14", Mode: "standalone", UptimeSeconds: 3600, ConnectedClients: 3, UsedMemory: 2097152, MaxMemory: 536870912, Role: "slave"}, 0},
+		{"7.2", redis.ServerInfo{Version: "7.2.4", Mode: "cluster", UptimeSeconds: 60, ConnectedClients: 1, UsedMemory: 1024, MaxMemory: 1073741824, KeyspaceHits: 9, KeyspaceMisses: 1, Role: "master"}, 0.9},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			info := redis.ParseInfo(infoFixtures[tt.version])
+
+			raw := info.Raw
+			info.Raw = nil
+			if !reflect.DeepEqual(info, tt.want) {
+				t.Logf("unexpected info: %+v", info)
+				t.FailNow()
+			}
+
+			if info.HitRate() != tt.hitRate {
+				t.Log("unexpected hit rate:", info.HitRate())
+				t.FailNow()
+			}
+
+			if raw["redis_version"] != tt.want.Version || strings.HasPrefix(raw["redis_version"], "#") {
+				t.Log("unexpected raw fields:", raw)
+				t.FailNow()
+			}
+		})
+	}
+
+	if raw := redis.ParseInfo(infoFixtures["5.0"]).Raw; raw["db0"] != "keys=10,expires=2,avg_ttl=0" {
+		t.Log("unparsed field not kept in Raw:", raw["db0"])
+		t.FailNow()
+	}
+}
+
+// TestRedisCache_Info runs INFO against the test server.
+func TestRedisCache_Info(t *testing.T) {
+	redisCache := initRedisCache(t)
+
+	defer func(redisCache cache.Cache) {
+		if err := redisCache.Close(); err != nil {
+			t.Log("Close Redis cache connection err", err)
+		}
+	}(redisCache)
+
+	info, err := redisCache.(*redis.RedisCache).Info(context.Background(), "clients")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if info.ConnectedClients < 1 {
+		t.Logf("unexpected info: %+v", info)
+		t.FailNow()
+	}
+}
+
+// TestPermissionError verifies that refused commands match ErrPermissionDenied and unwrap to
+// the server error.
+func TestPermissionError(t *testing.T) {
+	serverErr := errors.New("NOPERM this user has no permissions to run the 'info' command")
+
+	var err error = &redis.PermissionError{Command: "INFO", Err: serverErr}
+
+	if !errors.Is(err, redis.ErrPermissionDenied) || !errors.Is(err, serverErr) {
+		t.FailNow()
+	}
+}
@@ -0,0 +1,229 @@
+package redis
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"math"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/zeroxsolutions/banshee/internal/valueutil"
+)
+
+const (
+	// leaseSuffix names the side key holding the token of the caller recomputing a value.
+	leaseSuffix = ":lease"
+
+	// leaseMinBackoff and leaseMaxBackoff bound the polling interval of GetOrWaitLease.
+	leaseMinBackoff = 10 * time.Millisecond
+	leaseMaxBackoff = 200 * time.Millisecond
+)
+
+const (
+	leaseStateHit     = 1
+	leaseStateGranted = 2
+	leaseStatePending = 3
+)
+
+// leaseGetScript reads the value and, on a miss, tries to grant the lease in the same step so
+// a value written between the read and the grant can never be missed.
+//
+// KEYS[1] = value key, KEYS[2] = lease key, ARGV[1] = token, ARGV[2] = lease TTL in milliseconds
+// Returns {1, value} on a hit, {2} when the lease was granted, {3} when it is held by someone else.
+var leaseGetScript = redis.NewScript(`
+local value = redis.call('GET', KEYS[1])
+if value then
+	return {1, value}
+end
+if redis.call('SET', KEYS[2], ARGV[1], 'NX', 'PX', ARGV[2]) then
+	return {2}
+end
+return {3}
+`)
+
+// leaseFulfillScript writes the value and releases the lease, but only while the lease is still
+// held by the caller's token.
+//
+// KEYS[1] = value key, KEYS[2] = lease key, ARGV[1] = token, ARGV[2] = value, ARGV[3] = TTL in milliseconds
+var leaseFulfillScript = redis.NewScript(`
+if redis.call('GET', KEYS[2]) ~= ARGV[1] then
+	return 0
+end
+if tonumber(ARGV[3]) > 0 then
+	redis.call('SET', KEYS[1], ARGV[2], 'PX', ARGV[3])
+else
+	redis.call('SET', KEYS[1], ARGV[2])
+end
+redis.call('DEL', KEYS[2])
+return 1
+`)
+
+// leaseReleaseScript deletes the lease key only while it still holds the caller's token.
+//
+// KEYS[1] = lease key, ARGV[1] = token
+var leaseReleaseScript = redis.NewScript(`
+if redis.call('GET', KEYS[1]) ~= ARGV[1] then
+	return 0
+end
+return redis.call('DEL', KEYS[1])
+`)
+
+// Lease is the right, granted to exactly one caller, to recompute a missing value.
+// The holder must call Fulfill with the recomputed value or Abandon if it gives up; otherwise
+// the lease lapses after its TTL and the next reader is granted a new one.
+type Lease struct {
+	cache *RedisCache
+	key   string
+	token string
+}
+
+// Fulfill stores value under the leased key with the given TTL and releases the lease.
+// A ttl of 0 stores the value without expiration. A nil value fails with ErrNilValue, whatever
+// SetNilValueDeletes says, and keeps the lease held: call Abandon to release it.
+//
+// Parameters:
+//   - ctx: Context for timeout control and request cancellation
+//   - value: Recomputed value to store
+//   - ttl: Expiration of the stored value
+//
+// Returns:
+//   - error: ErrNilValue, ErrLeaseExpired if the lease lapsed before fulfillment, or a Redis error
+func (l *Lease) Fulfill(ctx context.Context, value interface{}, ttl time.Duration) error {
+	if valueutil.IsNil(value) {
+		return ErrNilValue
+	}
+	fulfilled, err := leaseFulfillScript.Run(
+		ctx, l.cache.client, []string{l.key, l.key + leaseSuffix}, l.token, value, millis(ttl),
+	).Int64()
+	if err != nil {
+		return err
+	}
+	if fulfilled == 0 {
+		return ErrLeaseExpired
+	}
+	return nil
+}
+
+// Abandon releases the lease without writing a value, letting the next reader acquire it.
+//
+// Parameters:
+//   - ctx: Context for timeout control and request cancellation
+//
+// Returns:
+//   - error: ErrLeaseExpired if the lease was no longer held, or a Redis error
+func (l *Lease) Abandon(ctx context.Context) error {
+	released, err := leaseReleaseScript.Run(ctx, l.cache.client, []string{l.key + leaseSuffix}, l.token).Int64()
+	if err != nil {
+		return err
+	}
+	if released == 0 {
+		return ErrLeaseExpired
+	}
+	return nil
+}
+
+// GetWithLease reads key and, on a miss, grants a recompute lease to exactly one caller across
+// all processes sharing the Redis instance. This suppresses recompute stampedes when a hot key
+// expires: one caller rebuilds the value while the others wait for it instead of hammering the
+// underlying data source.
+//
+// The read and the lease grant run in a single Lua script. The lease is a side key
+// (key + ":lease") created with SET NX PX, holding a random token that Fulfill and Abandon
+// check before acting, so a caller whose lease lapsed can never overwrite a newer one.
+//
+// Outcomes:
+//   - Hit: the value is returned with a nil lease
+//   - Miss, lease granted: an empty value and a non-nil lease are returned
+//   - Miss, lease held elsewhere: ErrLeasePending is returned
+//
+// Parameters:
+//   - ctx: Context for timeout control and request cancellation
+//   - key: Redis key of the cached value
+//   - leaseTTL: How long the lease stays valid if its holder neither fulfills nor abandons it
+//
+// Returns:
+//   - string: The cached value on a hit
+//   - *Lease: The granted lease on a miss
+//   - error: ErrLeasePending, or a Redis error
+//
+// Example:
+//
+//	value, lease, err := cache.GetWithLease(ctx, "report:daily", 10*time.Second)
+//	switch {
+//	case err == nil && lease == nil:
+//	    return value, nil
+//	case err == nil:
+//	    value, err := buildReport(ctx)
+//	    if err != nil {
+//	        _ = lease.Abandon(ctx)
+//	        return "", err
+//	    }
+//	    return value, lease.Fulfill(ctx, value, time.Hour)
+//	}
+func (r *RedisCache) GetWithLease(ctx context.Context, key string, leaseTTL time.Duration) (string, *Lease, error) {
+	token, err := newToken()
+	if err != nil {
+		return "", nil, err
+	}
+	result, err := leaseGetScript.Run(
+		ctx, r.client, []string{key, key + leaseSuffix}, token, millis(leaseTTL),
+	).Slice()
+	if err != nil {
+		return "", nil, err
+	}
+	switch result[0].(int64) {
+	case leaseStateHit:
+		return result[1].(string), nil, nil
+	case leaseStateGranted:
+		return "", &Lease{cache: r, key: key, token: token}, nil
+	default:
+		return "", nil, ErrLeasePending
+	}
+}
+
+// GetOrWaitLease behaves like GetWithLease but, while another caller holds the lease, keeps
+// polling with exponential backoff (10ms doubling up to 200ms) until the value appears, the
+// lease becomes available, or maxWait elapses.
+//
+// Parameters:
+//   - ctx: Context for timeout control and request cancellation
+//   - key: Redis key of the cached value
+//   - leaseTTL: How long a granted lease stays valid
+//   - maxWait: Maximum time spent waiting for another caller's lease
+//
+// Returns:
+//   - string: The cached value on a hit
+//   - *Lease: The granted lease on a miss
+//   - error: ErrLeasePending if maxWait elapsed, the context error, or a Redis error
+func (r *RedisCache) GetOrWaitLease(ctx context.Context, key string, leaseTTL, maxWait time.Duration) (string, *Lease, error) {
+	deadline := time.Now().Add(maxWait)
+	for attempt := 0; ; attempt++ {
+		value, lease, err := r.GetWithLease(ctx, key, leaseTTL)
+		if err != ErrLeasePending {
+			return value, lease, err
+		}
+		backoff := time.Duration(math.Min(
+			float64(leaseMaxBackoff), float64(leaseMinBackoff)*math.Pow(2, float64(attempt)),
+		))
+		if time.Now().Add(backoff).After(deadline) {
+			return "", nil, ErrLeasePending
+		}
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return "", nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// newToken returns a random 128-bit hex token identifying the holder of a lease or lock.
+func newToken() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,113 @@
+package redis_test
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/zeroxsolutions/banshee/redis"
+	"github.com/zeroxsolutions/barbatos/cache"
+	"github.com/zeroxsolutions/strike/ssutil"
+)
+
+// TestRedisCache_Lease runs many concurrent misses on the same key and verifies that exactly one
+// caller is granted the lease while the others wait and then read the fulfilled value.
+func TestRedisCache_Lease(t *testing.T) {
+	redisCache := initRedisCache(t)
+
+	defer func(redisCache cache.Cache) {
+		if err := redisCache.Close(); err != nil {
+			t.Log("Close Redis cache connection err", err)
+		}
+	}(redisCache)
+
+	leases := redisCache.(*redis.RedisCache)
+
+	ctx := context.Background()
+	key := ssutil.MakeString(10)
+	value := ssutil.MakeString(12)
+
+	defer func() {
+		if err := leases.Del(ctx, key); err != nil {
+			t.Error(err)
+		}
+	}()
+
+	var loads int64
+	var wg sync.WaitGroup
+	for range make([]int, 20) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			v, lease, err := leases.GetOrWaitLease(ctx, key, 5*time.Second, 5*time.Second)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			if lease != nil {
+				atomic.AddInt64(&loads, 1)
+				time.Sleep(100 * time.Millisecond)
+				if err := lease.Fulfill(ctx, value, time.Minute); err != nil {
+					t.Error(err)
+				}
+				return
+			}
+			if v != value {
+				t.Error("unexpected value:", v)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if loads != 1 {
+		t.Log("expected exactly one lease holder, got", loads)
+		t.FailNow()
+	}
+}
+
+// TestRedisCache_LeaseAbandon verifies that an abandoned lease is immediately available to the
+// next caller and that a pending lease is reported as ErrLeasePending.
+func TestRedisCache_LeaseAbandon(t *testing.T) {
+	redisCache := initRedisCache(t)
+
+	defer func(redisCache cache.Cache) {
+		if err := redisCache.Close(); err != nil {
+			t.Log("Close Redis cache connection err", err)
+		}
+	}(redisCache)
+
+	leases := redisCache.(*redis.RedisCache)
+
+	ctx := context.Background()
+	key := ssutil.MakeString(10)
+
+	_, lease, err := leases.GetWithLease(ctx, key, time.Minute)
+	if err != nil || lease == nil {
+		t.Fatal("expected a lease", err)
+	}
+
+	if _, _, err := leases.GetWithLease(ctx, key, time.Minute); err != redis.ErrLeasePending {
+		t.Log(err)
+		t.FailNow()
+	}
+
+	if err := lease.Abandon(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := lease.Fulfill(ctx, "late", time.Minute); err != redis.ErrLeaseExpired {
+		t.Log(err)
+		t.FailNow()
+	}
+
+	_, next, err := leases.GetWithLease(ctx, key, time.Minute)
+	if err != nil || next == nil {
+		t.Fatal("expected a new lease", err)
+	}
+
+	if err := next.Abandon(ctx); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,67 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/zeroxsolutions/banshee"
+	"github.com/zeroxsolutions/barbatos/cache"
+)
+
+var _ banshee.ListCache = (*RedisCache)(nil)
+
+// lPopNScript emulates LPOP with a count for servers older than Redis 6.2.
+//
+// KEYS[1] = list key, ARGV[1] = maximum number of items to pop.
+// Returns the popped items, or nil when the key doesn't exist.
+var lPopNScript = redis.NewScript(`
+local items = redis.call('LRANGE', KEYS[1], 0, tonumber(ARGV[1]) - 1)
+if #items == 0 then
+	return false
+end
+redis.call('LTRIM', KEYS[1], #items, -1)
+return items
+`)
+
+// LPopN atomically removes and returns up to count items from the head of the list stored under
+// key using LPOP with a count (Redis 6.2+, or a Lua emulation with SetLegacyCommands(true)).
+// It lets batch workers claim several jobs in one round trip; no other client can receive the
+// same items.
+//
+// Parameters:
+//   - ctx: Context for timeout control and request cancellation
+//   - key: Redis key of the list
+//   - count: Maximum number of items to pop, must be positive
+//
+// Returns:
+//   - []string: The popped items in list order; fewer than count when the list is shorter
+//   - error: cache.ErrCacheNil if the list doesn't exist, or a Redis error
+//
+// Example:
+//
+//	jobs, err := cache.LPopN(ctx, "jobs:pending", 50)
+//	if errors.Is(err, cache.ErrCacheNil) {
+//	    // queue is empty
+//	}
+func (r *RedisCache) LPopN(ctx context.Context, key string, count int64) ([]string, error) {
+	if count <= 0 {
+		return nil, fmt.Errorf("redis: LPopN count must be positive, got %d", count)
+	}
+
+	var items []string
+	var err error
+	if r.options.LegacyCommands {
+		items, err = lPopNScript.Run(ctx, r.client, []string{key}, count).StringSlice()
+	} else {
+		items, err = r.client.LPopCount(ctx, key, int(count)).Result()
+	}
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, cache.ErrCacheNil
+		}
+		return nil, err
+	}
+	return items, nil
+}
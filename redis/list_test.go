@@ -0,0 +1,79 @@
+package redis_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/zeroxsolutions/banshee/redis"
+	"github.com/zeroxsolutions/barbatos/cache"
+	"github.com/zeroxsolutions/strike/builderutil"
+	"github.com/zeroxsolutions/strike/ssutil"
+)
+
+// TestRedisCache_LPopN verifies that LPopN pops full and partial batches in order and reports an
+// exhausted list as a miss, on both the native command and the Lua emulation.
+func TestRedisCache_LPopN(t *testing.T) {
+	paths := []struct {
+		name string
+		opts builderutil.Lister[redis.RedisCacheOptions]
+	}{
+		{"Native", redis.NewRedisCacheOptions()},
+		{"Legacy", redis.NewRedisCacheOptions().SetLegacyCommands(true)},
+	}
+
+	client := initRedisClient(t)
+	defer client.Close()
+
+	for _, path := range paths {
+		t.Run(path.name, func(t *testing.T) {
+			redisCache := initRedisCache(t, path.opts)
+
+			defer func(redisCache cache.Cache) {
+				if err := redisCache.Close(); err != nil {
+					t.Log("Close Redis cache connection err", err)
+				}
+			}(redisCache)
+
+			lists := redisCache.(*redis.RedisCache)
+
+			ctx := context.Background()
+			key := ssutil.MakeString(10)
+
+			if err := client.RPush(ctx, key, "a", "b", "c", "d", "e").Err(); err != nil {
+				t.Fatal(err)
+			}
+
+			defer func() {
+				if err := client.Del(ctx, key).Err(); err != nil {
+					t.Error(err)
+				}
+			}()
+
+			items, err := lists.LPopN(ctx, key, 3)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(items, []string{"a", "b", "c"}) {
+				t.Fatal("unexpected full batch:", items)
+			}
+
+			items, err = lists.LPopN(ctx, key, 3)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(items, []string{"d", "e"}) {
+				t.Fatal("unexpected partial batch:", items)
+			}
+
+			if _, err := lists.LPopN(ctx, key, 3); !errors.Is(err, cache.ErrCacheNil) {
+				t.Fatal("expected cache.ErrCacheNil, got", err)
+			}
+
+			if _, err := lists.LPopN(ctx, key, 0); err == nil {
+				t.Fatal("expected an error for a zero count")
+			}
+		})
+	}
+}
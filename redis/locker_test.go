@@ -0,0 +1,73 @@
+package redis_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/zeroxsolutions/banshee/redis"
+	"github.com/zeroxsolutions/barbatos/cache"
+	"github.com/zeroxsolutions/strike/ssutil"
+)
+
+// TestLocker verifies that a held lock can't be acquired again, that releasing it frees it, and
+// that an expired lock taken over by someone else is never released by its former holder.
+func TestLocker(t *testing.T) {
+	redisCache := initRedisCache(t)
+
+	defer func(redisCache cache.Cache) {
+		if err := redisCache.Close(); err != nil {
+			t.Log("Close Redis cache connection err", err)
+		}
+	}(redisCache)
+
+	locker := redis.NewLocker(redisCache.(*redis.RedisCache))
+
+	ctx := context.Background()
+	key := ssutil.MakeString(10)
+
+	defer func() {
+		if err := redisCache.Del(ctx, key); err != nil {
+			t.Error(err)
+		}
+	}()
+
+	lock, err := locker.Acquire(ctx, key, time.Minute)
+	if err != nil || lock.Key() != key {
+		t.Fatal(err)
+	}
+	if _, err := locker.Acquire(ctx, key, time.Minute); !errors.Is(err, redis.ErrLockNotAcquired) {
+		t.Fatal("a held lock should not be acquired again:", err)
+	}
+	if err := lock.Release(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if err := lock.Release(ctx); !errors.Is(err, redis.ErrLockExpired) {
+		t.Fatal("a released lock should not be released again:", err)
+	}
+
+	expired, err := locker.Acquire(ctx, key, 100*time.Millisecond)
+	if err != nil {
+		t.Fatal("a released lock should be acquirable:", err)
+	}
+	time.Sleep(200 * time.Millisecond)
+
+	current, err := locker.Acquire(ctx, key, time.Minute)
+	if err != nil {
+		t.Fatal("an expired lock should be acquirable:", err)
+	}
+	if err := expired.Release(ctx); !errors.Is(err, redis.ErrLockExpired) {
+		t.Fatal("an expired lock should not release its successor:", err)
+	}
+	if _, err := locker.Acquire(ctx, key, time.Minute); !errors.Is(err, redis.ErrLockNotAcquired) {
+		t.Fatal("the successor should still hold the lock:", err)
+	}
+	if err := current.Release(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := locker.Acquire(ctx, key, 0); err == nil {
+		t.Fatal("a non-positive TTL should be rejected")
+	}
+}
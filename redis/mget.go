@@ -0,0 +1,46 @@
+package redis
+
+import (
+	"context"
+
+	"github.com/zeroxsolutions/banshee"
+)
+
+var _ banshee.MultiGetter = (*RedisCache)(nil)
+
+// MGet returns the values of the given keys with a single MGET, so loading N keys costs one
+// round trip instead of N.
+//
+// Missing keys are omitted from the result, and so are keys holding a non-string type, so a key
+// present in the map with "" holds an empty string. A key passed twice appears once.
+//
+// Parameters:
+//   - ctx: Context for timeout control and request cancellation
+//   - keys: Redis keys to read
+//
+// Returns:
+//   - map[string]string: The values of the existing keys, keyed by key; empty when none exist
+//   - error: A Redis error; missing keys are never an error
+//
+// Example:
+//
+//	profiles, err := cache.MGet(ctx, "user:1", "user:2", "user:3")
+//	if profile, ok := profiles["user:2"]; ok {
+//	    // use profile
+//	}
+func (r *RedisCache) MGet(ctx context.Context, keys ...string) (map[string]string, error) {
+	values := make(map[string]string, len(keys))
+	if len(keys) == 0 {
+		return values, nil
+	}
+	fetched, err := r.client.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, err
+	}
+	for i, value := range fetched {
+		if s, ok := value.(string); ok {
+			values[keys[i]] = s
+		}
+	}
+	return values, nil
+}
@@ -0,0 +1,56 @@
+package redis_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/zeroxsolutions/banshee/redis"
+	"github.com/zeroxsolutions/barbatos/cache"
+	"github.com/zeroxsolutions/strike/ssutil"
+)
+
+// TestRedisCache_MGet verifies that MGet returns the values of the existing keys, keeps empty
+// strings, and omits missing keys.
+func TestRedisCache_MGet(t *testing.T) {
+	redisCache := initRedisCache(t)
+
+	defer func(redisCache cache.Cache) {
+		if err := redisCache.Close(); err != nil {
+			t.Log("Close Redis cache connection err", err)
+		}
+	}(redisCache)
+
+	getter := redisCache.(*redis.RedisCache)
+
+	ctx := context.Background()
+	key := ssutil.MakeString(10)
+	empty := ssutil.MakeString(10)
+	missing := ssutil.MakeString(10)
+
+	defer func() {
+		if err := getter.Del(ctx, key, empty); err != nil {
+			t.Error(err)
+		}
+	}()
+
+	if err := getter.Set(ctx, key, "value"); err != nil {
+		t.Fatal(err)
+	}
+	if err := getter.Set(ctx, empty, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	values, err := getter.MGet(ctx, key, empty, missing)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if expected := map[string]string{key: "value", empty: ""}; !reflect.DeepEqual(values, expected) {
+		t.Fatal("unexpected values:", values)
+	}
+
+	if values, err := getter.MGet(ctx); err != nil || len(values) != 0 {
+		t.Fatal(values, err)
+	}
+}
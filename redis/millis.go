@@ -0,0 +1,14 @@
+package redis
+
+import "time"
+
+// millis converts d to the whole milliseconds sent with PEXPIRE, SET PX and the scripts taking a
+// TTL. Truncating a positive duration under a millisecond would send 0, which Redis treats as
+// "delete the key now" or "keep it forever" depending on the command, so such durations are
+// rounded up to one millisecond instead.
+func millis(d time.Duration) int64 {
+	if d > 0 && d < time.Millisecond {
+		return 1
+	}
+	return d.Milliseconds()
+}
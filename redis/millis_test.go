@@ -0,0 +1,24 @@
+package redis
+
+import (
+	"testing"
+	"time"
+)
+
+// TestMillis verifies that positive durations under a millisecond are rounded up rather than
+// truncated to 0.
+func TestMillis(t *testing.T) {
+	for d, want := range map[time.Duration]int64{
+		0:                       0,
+		-time.Second:            -1000,
+		time.Nanosecond:         1,
+		999 * time.Microsecond:  1,
+		time.Millisecond:        1,
+		1500 * time.Microsecond: 1,
+		time.Minute:             60000,
+	} {
+		if got := millis(d); got != want {
+			t.Errorf("millis(%v) = %d, want %d", d, got, want)
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package redis
+
+import (
+	"context"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/zeroxsolutions/banshee"
+	"github.com/zeroxsolutions/banshee/internal/valueutil"
+)
+
+var _ banshee.MultiSetter = (*RedisCache)(nil)
+
+// MSet stores every value of pairs under its key, without expiration, with a single MSET. The
+// write is atomic: either every key is set or none is.
+//
+// Values are converted as by Set. Nil values, including nil pointers and nil slices, fail the
+// whole call with ErrNilValue before anything is written, whatever SetNilValueDeletes says.
+//
+// Parameters:
+//   - ctx: Context for timeout control and request cancellation
+//   - pairs: Values to store, keyed by key
+//
+// Returns:
+//   - error: ErrNilValue, or a Redis error
+//
+// Example:
+//
+//	err := cache.MSet(ctx, map[string]interface{}{"user:1": profile1, "user:2": profile2})
+func (r *RedisCache) MSet(ctx context.Context, pairs map[string]interface{}) error {
+	if len(pairs) == 0 {
+		return nil
+	}
+	args := make([]interface{}, 0, 2*len(pairs))
+	for key, value := range pairs {
+		if valueutil.IsNil(value) {
+			return ErrNilValue
+		}
+		args = append(args, key, value)
+	}
+	return r.client.MSet(ctx, args...).Err()
+}
+
+// MSetWithExpiration stores every value of pairs under its key with the given expiration. MSET
+// can't set TTLs, so one SET with PX is pipelined per key: all keys cost a single round trip, and
+// each key is set atomically, but the batch as a whole is not.
+//
+// Values are converted as by Set, and nil values fail the whole call with ErrNilValue before
+// anything is written. If ctx was derived with WithTTLOverride, the overriding TTL replaces
+// expiration.
+//
+// Parameters:
+//   - ctx: Context for timeout control and request cancellation
+//   - pairs: Values to store, keyed by key
+//   - expiration: Time-to-live of every key, 0 for none
+//
+// Returns:
+//   - error: ErrNilValue, a *PartialWriteError (matching ErrPartialWrite) listing the keys that
+//     weren't written when some SETs failed, or a Redis error
+//
+// Example:
+//
+//	err := cache.MSetWithExpiration(ctx, warmup, 10*time.Minute)
+//	var partial *redis.PartialWriteError
+//	if errors.As(err, &partial) {
+//	    for key, err := range partial.Failed {
+//	        log.Printf("warm-up of %s failed: %v", key, err)
+//	    }
+//	}
+func (r *RedisCache) MSetWithExpiration(ctx context.Context, pairs map[string]interface{}, expiration time.Duration) error {
+	if len(pairs) == 0 {
+		return nil
+	}
+	for _, value := range pairs {
+		if valueutil.IsNil(value) {
+			return ErrNilValue
+		}
+	}
+	if ttl, ok := ttlOverrideFromContext(ctx); ok {
+		expiration = ttl
+	}
+	keys := make([]string, 0, len(pairs))
+	cmds := make([]redis.Cmder, 0, len(pairs))
+	_, err := r.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for key, value := range pairs {
+			keys = append(keys, key)
+			cmds = append(cmds, pipe.Set(ctx, key, value, expiration))
+		}
+		return nil
+	})
+	if err == nil {
+		return nil
+	}
+	if partial := partialWriteError(keys, cmds); partial != nil {
+		return partial
+	}
+	return err
+}
@@ -0,0 +1,88 @@
+package redis_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/zeroxsolutions/banshee/redis"
+	"github.com/zeroxsolutions/barbatos/cache"
+	"github.com/zeroxsolutions/strike/ssutil"
+)
+
+// TestRedisCache_MSet verifies that MSet and MSetWithExpiration store every key with the expected
+// TTL, and that nil values are rejected before anything is written.
+func TestRedisCache_MSet(t *testing.T) {
+	redisCache := initRedisCache(t)
+
+	defer func(redisCache cache.Cache) {
+		if err := redisCache.Close(); err != nil {
+			t.Log("Close Redis cache connection err", err)
+		}
+	}(redisCache)
+
+	client := initRedisClient(t)
+	defer client.Close()
+
+	setter := redisCache.(*redis.RedisCache)
+
+	ctx := context.Background()
+	prefix := ssutil.MakeString(10)
+
+	defer func() {
+		if err := setter.DelWithPattern(ctx, prefix+":*"); err != nil {
+			t.Error(err)
+		}
+	}()
+
+	persistent := map[string]interface{}{prefix + ":a": "value-a", prefix + ":b": 42}
+	if err := setter.MSet(ctx, persistent); err != nil {
+		t.Fatal(err)
+	}
+
+	expiring := map[string]interface{}{prefix + ":c": "value-c", prefix + ":d": "value-d"}
+	if err := setter.MSetWithExpiration(ctx, expiring, time.Minute); err != nil {
+		t.Fatal(err)
+	}
+
+	for key, expected := range map[string]string{
+		prefix + ":a": "value-a",
+		prefix + ":b": "42",
+		prefix + ":c": "value-c",
+		prefix + ":d": "value-d",
+	} {
+		if value, err := setter.Get(ctx, key); err != nil || value != expected {
+			t.Fatal(key, value, err)
+		}
+	}
+
+	for key := range persistent {
+		if ttl, err := client.PTTL(ctx, key).Result(); err != nil || ttl != -1 {
+			t.Fatal("MSet should not set a TTL:", key, ttl, err)
+		}
+	}
+	for key := range expiring {
+		if ttl, err := client.PTTL(ctx, key).Result(); err != nil || ttl <= 0 || ttl > time.Minute {
+			t.Fatal("unexpected TTL:", key, ttl, err)
+		}
+	}
+
+	rejected := map[string]interface{}{prefix + ":e": "value", prefix + ":f": nil}
+	if err := setter.MSet(ctx, rejected); !errors.Is(err, redis.ErrNilValue) {
+		t.Fatal(err)
+	}
+	if err := setter.MSetWithExpiration(ctx, rejected, time.Minute); !errors.Is(err, redis.ErrNilValue) {
+		t.Fatal(err)
+	}
+	if n, err := client.Exists(ctx, prefix+":e").Result(); err != nil || n != 0 {
+		t.Fatal("nothing should be written when a value is nil:", n, err)
+	}
+
+	if err := setter.MSet(ctx, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := setter.MSetWithExpiration(ctx, nil, time.Minute); err != nil {
+		t.Fatal(err)
+	}
+}
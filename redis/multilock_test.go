@@ -0,0 +1,147 @@
+package redis_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/zeroxsolutions/banshee/redis"
+	"github.com/zeroxsolutions/barbatos/cache"
+	"github.com/zeroxsolutions/strike/ssutil"
+)
+
+// TestRedisCache_MultiLock verifies all-or-nothing acquisition, release of partially acquired
+// locks, and CAS-checked unlocking.
+func TestRedisCache_MultiLock(t *testing.T) {
+	redisCache := initRedisCache(t)
+
+	defer func(redisCache cache.Cache) {
+		if err := redisCache.Close(); err != nil {
+			t.Log("Close Redis cache connection err", err)
+		}
+	}(redisCache)
+
+	locks := redisCache.(*redis.RedisCache)
+
+	ctx := context.Background()
+	a, b, c := ssutil.MakeString(10), ssutil.MakeString(10), ssutil.MakeString(10)
+
+	defer func() {
+		if err := locks.Del(ctx, a, b, c); err != nil {
+			t.Error(err)
+		}
+	}()
+
+	unlockB, err := locks.MultiLock(ctx, []string{b}, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := locks.MultiLock(ctx, []string{c, b, a}, time.Minute); !errors.Is(err, redis.ErrLockNotAcquired) {
+		t.Fatal(err)
+	}
+	for _, key := range []string{a, c} {
+		if _, err := locks.Get(ctx, key); !errors.Is(err, cache.ErrCacheNil) {
+			t.Fatal("a partially acquired lock was kept:", key, err)
+		}
+	}
+
+	if err := unlockB(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	unlock, err := locks.MultiLock(ctx, []string{c, b, a, b}, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A lock taken over by someone else is left alone and reported.
+	if err := locks.Set(ctx, a, "someone else"); err != nil {
+		t.Fatal(err)
+	}
+	if err := unlock(ctx); !errors.Is(err, redis.ErrLockExpired) {
+		t.Fatal(err)
+	}
+	if value, err := locks.Get(ctx, a); err != nil || value != "someone else" {
+		t.Fatal(value, err)
+	}
+	for _, key := range []string{b, c} {
+		if _, err := locks.Get(ctx, key); !errors.Is(err, cache.ErrCacheNil) {
+			t.Fatal("lock not released:", key, err)
+		}
+	}
+
+	if _, err := locks.MultiLock(ctx, []string{a}, 0); err == nil {
+		t.Fatal("expected an error for a zero TTL")
+	}
+}
+
+// TestRedisCache_MultiLock_Contention verifies that two instances locking overlapping key sets,
+// listed in opposite orders, make progress without deadlock and never hold a key together.
+func TestRedisCache_MultiLock_Contention(t *testing.T) {
+	first := initRedisCache(t).(*redis.RedisCache)
+	second := initRedisCache(t).(*redis.RedisCache)
+
+	defer func() {
+		for _, c := range []*redis.RedisCache{first, second} {
+			if err := c.Close(); err != nil {
+				t.Log("Close Redis cache connection err", err)
+			}
+		}
+	}()
+
+	a, b, c := ssutil.MakeString(10), ssutil.MakeString(10), ssutil.MakeString(10)
+	holders := map[string]*int32{a: new(int32), b: new(int32), c: new(int32)}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	run := func(instance *redis.RedisCache, keys []string) error {
+		for done := 0; done < 20; {
+			unlock, err := instance.MultiLock(ctx, keys, 5*time.Second)
+			if errors.Is(err, redis.ErrLockNotAcquired) {
+				time.Sleep(time.Millisecond)
+				continue
+			}
+			if err != nil {
+				return err
+			}
+			for _, key := range keys {
+				if atomic.AddInt32(holders[key], 1) != 1 {
+					t.Error("two holders of", key)
+				}
+			}
+			time.Sleep(time.Millisecond)
+			for _, key := range keys {
+				atomic.AddInt32(holders[key], -1)
+			}
+			if err := unlock(ctx); err != nil {
+				return err
+			}
+			done++
+		}
+		return nil
+	}
+
+	var wg sync.WaitGroup
+	errs := make([]error, 2)
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		errs[0] = run(first, []string{a, b, c})
+	}()
+	go func() {
+		defer wg.Done()
+		errs[1] = run(second, []string{c, b})
+	}()
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+}
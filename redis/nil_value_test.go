@@ -0,0 +1,155 @@
+package redis_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/zeroxsolutions/banshee"
+	"github.com/zeroxsolutions/banshee/redis"
+	"github.com/zeroxsolutions/barbatos/cache"
+	"github.com/zeroxsolutions/strike/ssutil"
+)
+
+// TestRedisCache_NilValue verifies that nil interfaces, nil pointers and typed-nil slices are
+// rejected by default and delete the key with SetNilValueDeletes(true), through Set,
+// SetWithExpiration and SetAndPublish.
+func TestRedisCache_NilValue(t *testing.T) {
+	type profile struct{ Name string }
+
+	values := []struct {
+		name  string
+		value interface{}
+	}{
+		{"Interface", nil},
+		{"Pointer", (*profile)(nil)},
+		{"Slice", []string(nil)},
+		{"Bytes", []byte(nil)},
+	}
+
+	modes := []struct {
+		name    string
+		deletes bool
+	}{
+		{"Reject", false},
+		{"Delete", true},
+	}
+
+	for _, mode := range modes {
+		t.Run(mode.name, func(t *testing.T) {
+			redisCache := initRedisCache(t, redis.NewRedisCacheOptions().SetNilValueDeletes(mode.deletes))
+
+			defer func(redisCache cache.Cache) {
+				if err := redisCache.Close(); err != nil {
+					t.Log("Close Redis cache connection err", err)
+				}
+			}(redisCache)
+
+			ctx := context.Background()
+
+			for _, v := range values {
+				t.Run(v.name, func(t *testing.T) {
+					key := ssutil.MakeString(10)
+
+					defer func() {
+						if err := redisCache.Del(ctx, key); err != nil {
+							t.Error(err)
+						}
+					}()
+
+					writes := map[string]func() error{
+						"Set":               func() error { return redisCache.Set(ctx, key, v.value) },
+						"SetWithExpiration": func() error { return redisCache.SetWithExpiration(ctx, key, v.value, time.Minute) },
+						"SetAndPublish": func() error {
+							return redisCache.(*redis.RedisCache).SetAndPublish(ctx, key, v.value, time.Minute, key)
+						},
+					}
+					for name, write := range writes {
+						if err := redisCache.Set(ctx, key, "previous"); err != nil {
+							t.Fatal(err)
+						}
+
+						err := write()
+						stored, getErr := redisCache.Get(ctx, key)
+
+						if mode.deletes {
+							if err != nil {
+								t.Fatal(name, err)
+							}
+							if !errors.Is(getErr, cache.ErrCacheNil) {
+								t.Fatal(name, "the key should be deleted:", stored, getErr)
+							}
+							continue
+						}
+						if !errors.Is(err, redis.ErrNilValue) {
+							t.Fatal(name, err)
+						}
+						if getErr != nil || stored != "previous" {
+							t.Fatal(name, "the key should be untouched:", stored, getErr)
+						}
+					}
+				})
+			}
+		})
+	}
+}
+
+// TestRedisCache_NilValueRejected verifies that conditional writes reject nil values with the
+// same error as banshee.Batch, whatever SetNilValueDeletes says, and leave the key untouched.
+func TestRedisCache_NilValueRejected(t *testing.T) {
+	for _, deletes := range []bool{false, true} {
+		redisCache := initRedisCache(t, redis.NewRedisCacheOptions().SetNilValueDeletes(deletes))
+		nilCache := redisCache.(*redis.RedisCache)
+
+		ctx := context.Background()
+		key := ssutil.MakeString(10)
+		leased := ssutil.MakeString(10)
+
+		if err := nilCache.Set(ctx, key, "previous"); err != nil {
+			t.Fatal(err)
+		}
+		_, lease, err := nilCache.GetWithLease(ctx, leased, time.Minute)
+		if err != nil || lease == nil {
+			t.Fatal("expected a lease", err)
+		}
+
+		writes := map[string]func() error{
+			"SetNX": func() error {
+				_, err := nilCache.SetNX(ctx, key+":nx", []byte(nil), time.Minute)
+				return err
+			},
+			"SetNXMany": func() error {
+				_, err := nilCache.SetNXMany(ctx, []string{key + ":nx"}, nil, time.Minute)
+				return err
+			},
+			"RotateKeepTTL": func() error {
+				_, err := nilCache.RotateKeepTTL(ctx, key, (*string)(nil))
+				return err
+			},
+			"Fulfill": func() error { return lease.Fulfill(ctx, nil, time.Minute) },
+		}
+		for name, write := range writes {
+			if err := write(); !errors.Is(err, redis.ErrNilValue) || !errors.Is(err, banshee.ErrNilValue) {
+				t.Fatal(name, deletes, err)
+			}
+		}
+
+		if stored, err := nilCache.Get(ctx, key); err != nil || stored != "previous" {
+			t.Fatal("the key should be untouched:", stored, err)
+		}
+		if _, err := nilCache.Get(ctx, key+":nx"); !errors.Is(err, cache.ErrCacheNil) {
+			t.Fatal("nothing should be stored:", err)
+		}
+		if err := lease.Fulfill(ctx, "value", time.Minute); err != nil {
+			t.Fatal("a rejected value should keep the lease:", err)
+		}
+
+		if err := nilCache.Del(ctx, key, leased); err != nil {
+			t.Error(err)
+		}
+		if err := nilCache.Close(); err != nil {
+			t.Log("Close Redis cache connection err", err)
+		}
+	}
+}
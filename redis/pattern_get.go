@@ -0,0 +1,59 @@
+package redis
+
+import (
+	"context"
+
+	"github.com/zeroxsolutions/banshee"
+)
+
+// getByPatternBatchSize is the number of keys GetByPattern requests per SCAN call and fetches per MGET.
+const getByPatternBatchSize = 100
+
+var _ banshee.PatternGetter = (*RedisCache)(nil)
+
+// GetByPattern returns the values of every key matching pattern. Keys are walked with SCAN and
+// their values fetched with one MGET per batch, so loading N keys costs about N/100 round trips
+// instead of the N+1 of Keys followed by Get per key.
+//
+// The result is not a snapshot: SCAN guarantees every key present for the whole walk is visited,
+// but keys created or deleted during it may or may not be, values may change between batches,
+// and keys that vanish between SCAN and MGET are omitted. Keys holding a non-string type are
+// omitted as well, and so are tombstones on caches built with SetSoftDelete.
+//
+// Parameters:
+//   - ctx: Context for timeout control and request cancellation
+//   - pattern: Glob-style pattern of the keys to read
+//
+// Returns:
+//   - map[string]string: The values keyed by key; empty when nothing matches
+//   - error: A Redis error
+//
+// Example:
+//
+//	flags, err := cache.GetByPattern(ctx, "feature:*")
+func (r *RedisCache) GetByPattern(ctx context.Context, pattern string) (map[string]string, error) {
+	values := map[string]string{}
+	var cursor uint64
+	for {
+		keys, next, err := r.client.Scan(ctx, cursor, pattern, getByPatternBatchSize).Result()
+		if err != nil {
+			return nil, err
+		}
+		keys = r.liveKeys(keys)
+		if len(keys) > 0 {
+			fetched, err := r.client.MGet(ctx, keys...).Result()
+			if err != nil {
+				return nil, err
+			}
+			for i, value := range fetched {
+				if s, ok := value.(string); ok {
+					values[keys[i]] = s
+				}
+			}
+		}
+		if next == 0 {
+			return values, nil
+		}
+		cursor = next
+	}
+}
@@ -0,0 +1,61 @@
+package redis_test
+
+import (
+	"context"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/zeroxsolutions/banshee/redis"
+	"github.com/zeroxsolutions/barbatos/cache"
+	"github.com/zeroxsolutions/strike/ssutil"
+)
+
+// TestRedisCache_GetByPattern verifies that every matching key is returned with its value across
+// several SCAN batches, and that other keys are not.
+func TestRedisCache_GetByPattern(t *testing.T) {
+	redisCache := initRedisCache(t)
+
+	defer func(redisCache cache.Cache) {
+		if err := redisCache.Close(); err != nil {
+			t.Log("Close Redis cache connection err", err)
+		}
+	}(redisCache)
+
+	ctx := context.Background()
+	prefix := ssutil.MakeString(10)
+
+	expected := map[string]string{}
+	for i := 0; i < 250; i++ {
+		key := prefix + ":feature:" + strconv.Itoa(i)
+		expected[key] = "value-" + strconv.Itoa(i)
+		if err := redisCache.Set(ctx, key, expected[key]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := redisCache.Set(ctx, prefix+":other", "x"); err != nil {
+		t.Fatal(err)
+	}
+
+	defer func() {
+		if err := redisCache.DelWithPattern(ctx, prefix+":*"); err != nil {
+			t.Error(err)
+		}
+	}()
+
+	values, err := redisCache.(*redis.RedisCache).GetByPattern(ctx, prefix+":feature:*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(values, expected) {
+		t.Fatalf("expected %d values, got %d", len(expected), len(values))
+	}
+
+	values, err = redisCache.(*redis.RedisCache).GetByPattern(ctx, prefix+":missing:*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(values) != 0 {
+		t.Fatal("unexpected values:", values)
+	}
+}
@@ -0,0 +1,100 @@
+package redis
+
+import (
+	"context"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/zeroxsolutions/banshee"
+	"github.com/zeroxsolutions/banshee/internal/valueutil"
+)
+
+var _ banshee.PipelineCache = (*RedisCache)(nil)
+
+// redisPipeline queues commands on a plain pipeline.
+type redisPipeline struct {
+	cache *RedisCache
+	pipe  redis.Pipeliner
+	dels  []queuedDel
+}
+
+// Set queues a SET without expiration.
+func (p *redisPipeline) Set(ctx context.Context, key string, value interface{}) error {
+	return p.SetWithExpiration(ctx, key, value, 0)
+}
+
+// SetWithExpiration queues a SET with the given expiration, honoring WithTTLOverride. Nil values
+// fail with ErrNilValue, or queue a DEL when the cache was built with SetNilValueDeletes(true).
+func (p *redisPipeline) SetWithExpiration(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
+	if valueutil.IsNil(value) {
+		if p.cache.options.NilValueDeletes {
+			return p.Del(ctx, key)
+		}
+		return ErrNilValue
+	}
+	if ttl, ok := ttlOverrideFromContext(ctx); ok {
+		expiration = ttl
+	}
+	p.pipe.Set(ctx, key, value, expiration)
+	return nil
+}
+
+// Del queues a DEL, or a soft delete on caches built with SetSoftDelete.
+func (p *redisPipeline) Del(ctx context.Context, keys ...string) error {
+	p.dels = append(p.dels, p.cache.queueDel(ctx, p.pipe, keys))
+	return nil
+}
+
+// Incr queues an INCR; the returned *redis.IntCmd holds its reply once the pipeline ran.
+func (p *redisPipeline) Incr(ctx context.Context, key string) banshee.IntResult {
+	return p.pipe.Incr(ctx, key)
+}
+
+// Pipeline calls fn to queue commands and sends them to Redis in a single round trip when fn
+// returns, e.g. to write several keys, delete another and bump a counter for the price of one
+// network latency.
+//
+// Behavior:
+//   - The commands are not atomic: other clients may observe some of them applied and not
+//     others; use Tx when they must be applied together
+//   - Every queued command runs, even when an earlier one fails; the error of the first failed
+//     command is returned, and each Incr result reports its own
+//   - Deletes are recorded in the audit stream, if enabled, once the pipeline ran without error;
+//     caches built with SetSoftDelete rename the deleted keys to tombstones, as Del does
+//   - Nil values are rejected with ErrNilValue when queued, or queue a DEL with
+//     SetNilValueDeletes(true), as with SetWithExpiration
+//
+// Parameters:
+//   - ctx: Context for timeout control and request cancellation
+//   - fn: Function queuing the commands; returning an error discards them
+//
+// Returns:
+//   - error: The error returned by fn, the error of the first failed command, or a connection
+//     error
+//
+// Example:
+//
+//	var views banshee.IntResult
+//	err := cache.Pipeline(ctx, func(p banshee.CachePipeline) error {
+//	    _ = p.SetWithExpiration(ctx, "page:42", html, time.Hour)
+//	    _ = p.Del(ctx, "page:42:draft")
+//	    views = p.Incr(ctx, "page:42:views")
+//	    return nil
+//	})
+//	if err == nil {
+//	    log.Println("views:", views.Val())
+//	}
+func (r *RedisCache) Pipeline(ctx context.Context, fn func(p banshee.CachePipeline) error) error {
+	pipeline := &redisPipeline{cache: r}
+	_, err := r.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipeline.pipe = pipe
+		return fn(pipeline)
+	})
+	if err != nil {
+		return err
+	}
+	for _, del := range pipeline.dels {
+		r.audit(ctx, AuditOpDel, del.keys, "", del.count())
+	}
+	return nil
+}
@@ -0,0 +1,152 @@
+package redis_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/zeroxsolutions/banshee"
+	"github.com/zeroxsolutions/banshee/redis"
+	"github.com/zeroxsolutions/barbatos/cache"
+	"github.com/zeroxsolutions/strike/ssutil"
+)
+
+// TestRedisCache_Pipeline verifies that queued commands are all sent when the callback returns,
+// that Incr results are filled in, and that an error returned by the callback discards them.
+func TestRedisCache_Pipeline(t *testing.T) {
+	redisCache := initRedisCache(t)
+
+	defer func(redisCache cache.Cache) {
+		if err := redisCache.Close(); err != nil {
+			t.Log("Close Redis cache connection err", err)
+		}
+	}(redisCache)
+
+	client := initRedisClient(t)
+	defer client.Close()
+
+	ctx := context.Background()
+	key := ssutil.MakeString(10)
+	expiring := ssutil.MakeString(10)
+	stale := ssutil.MakeString(10)
+	counter := ssutil.MakeString(10)
+	defer func() {
+		_ = redisCache.Del(ctx, key, expiring, stale, counter)
+	}()
+
+	if err := redisCache.Set(ctx, stale, "v0"); err != nil {
+		t.Fatal(err)
+	}
+
+	pipelineCache := redisCache.(banshee.PipelineCache)
+	errAbort := errors.New("abort")
+	err := pipelineCache.Pipeline(ctx, func(p banshee.CachePipeline) error {
+		_ = p.Set(ctx, key, "discarded")
+		return errAbort
+	})
+	if !errors.Is(err, errAbort) {
+		t.Fatalf("Pipeline err = %v", err)
+	}
+	if _, err := redisCache.Get(ctx, key); !errors.Is(err, cache.ErrCacheNil) {
+		t.Fatalf("aborted command sent: %v", err)
+	}
+
+	var first, second banshee.IntResult
+	err = pipelineCache.Pipeline(ctx, func(p banshee.CachePipeline) error {
+		if err := p.Set(ctx, key, "v1"); err != nil {
+			return err
+		}
+		if err := p.SetWithExpiration(ctx, expiring, "v2", time.Minute); err != nil {
+			return err
+		}
+		if err := p.Del(ctx, stale); err != nil {
+			return err
+		}
+		first = p.Incr(ctx, counter)
+		second = p.Incr(ctx, counter)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if value, err := redisCache.Get(ctx, key); err != nil || value != "v1" {
+		t.Fatalf("%s = %q, %v", key, value, err)
+	}
+	if ttl, err := client.PTTL(ctx, expiring).Result(); err != nil || ttl <= 0 || ttl > time.Minute {
+		t.Fatalf("%s TTL = %v, %v", expiring, ttl, err)
+	}
+	if _, err := redisCache.Get(ctx, stale); !errors.Is(err, cache.ErrCacheNil) {
+		t.Fatalf("stale not deleted: %v", err)
+	}
+	if first.Err() != nil || first.Val() != 1 || second.Err() != nil || second.Val() != 2 {
+		t.Fatalf("Incr results = %d, %d", first.Val(), second.Val())
+	}
+
+	err = pipelineCache.Pipeline(ctx, func(p banshee.CachePipeline) error {
+		first = p.Incr(ctx, key)
+		second = p.Incr(ctx, counter)
+		return nil
+	})
+	if err == nil || first.Err() == nil {
+		t.Fatal("incrementing a non-integer should fail")
+	}
+	if second.Err() != nil || second.Val() != 3 {
+		t.Fatalf("a failed command shouldn't stop the others: %d, %v", second.Val(), second.Err())
+	}
+}
+
+// TestRedisCache_PipelineNilValue verifies that nil values are rejected when queued, or queue a
+// delete with SetNilValueDeletes(true), and that deletes of an aborted pipeline are not audited.
+func TestRedisCache_PipelineNilValue(t *testing.T) {
+	stream := ssutil.MakeString(10)
+	redisCache := initRedisCache(t, redis.NewRedisCacheOptions().SetAuditStream(stream, 10))
+
+	defer func(redisCache cache.Cache) {
+		if err := redisCache.Close(); err != nil {
+			t.Log("Close Redis cache connection err", err)
+		}
+	}(redisCache)
+
+	deleting := initRedisCache(t, redis.NewRedisCacheOptions().SetNilValueDeletes(true))
+	defer deleting.Close()
+
+	client := initRedisClient(t)
+	defer client.Close()
+
+	ctx := context.Background()
+	key := ssutil.MakeString(10)
+	defer func() {
+		_ = client.Del(ctx, key, stream)
+	}()
+
+	if err := redisCache.Set(ctx, key, "v1"); err != nil {
+		t.Fatal(err)
+	}
+
+	err := redisCache.(banshee.PipelineCache).Pipeline(ctx, func(p banshee.CachePipeline) error {
+		if err := p.Del(ctx, key); err != nil {
+			return err
+		}
+		return p.Set(ctx, key, nil)
+	})
+	if !errors.Is(err, redis.ErrNilValue) {
+		t.Fatalf("Pipeline err = %v", err)
+	}
+	if value, err := redisCache.Get(ctx, key); err != nil || value != "v1" {
+		t.Fatalf("aborted pipeline ran: %q, %v", value, err)
+	}
+	if entries, err := client.XLen(ctx, stream).Result(); err != nil || entries != 0 {
+		t.Fatalf("aborted pipeline audited: %d, %v", entries, err)
+	}
+
+	err = deleting.(banshee.PipelineCache).Pipeline(ctx, func(p banshee.CachePipeline) error {
+		return p.SetWithExpiration(ctx, key, nil, time.Minute)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := redisCache.Get(ctx, key); !errors.Is(err, cache.ErrCacheNil) {
+		t.Fatalf("nil value should delete the key: %v", err)
+	}
+}
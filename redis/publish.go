@@ -0,0 +1,58 @@
+package redis
+
+import (
+	"context"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/zeroxsolutions/banshee"
+	"github.com/zeroxsolutions/banshee/internal/valueutil"
+)
+
+var _ banshee.PublishingCache = (*RedisCache)(nil)
+
+// SetAndPublish stores value under key and publishes the key name on channel in one MULTI/EXEC
+// transaction. Writers that both update a value and notify subscribers (for example to invalidate
+// local caches) can no longer lose the notification by dying between two separate calls: either
+// both commands are executed by Redis or neither is.
+//
+// The message payload is the key that changed; subscribers re-read the value if they need it.
+// Pub/Sub delivery itself is still best-effort: Redis does not persist messages, so subscribers
+// that are disconnected at publish time never see them.
+//
+// The TTL override carried by contexts from WithTTLOverride applies as it does for SetWithExpiration.
+// Nil values are handled as by SetWithExpiration too: they fail with ErrNilValue, or, with
+// SetNilValueDeletes(true), delete the key and still publish its name.
+//
+// Parameters:
+//   - ctx: Context for timeout control and request cancellation
+//   - key: Redis key to store the value under
+//   - value: Value to store
+//   - ttl: Expiration of the stored value (0 for none)
+//   - channel: Pub/Sub channel notified with the key name
+//
+// Returns:
+//   - error: ErrNilValue, Redis connection error or command execution error
+//
+// Example:
+//
+//	err := cache.SetAndPublish(ctx, "config:flags", flagsJSON, 0, "invalidate:config")
+func (r *RedisCache) SetAndPublish(ctx context.Context, key string, value interface{}, ttl time.Duration, channel string) error {
+	if override, ok := ttlOverrideFromContext(ctx); ok {
+		ttl = override
+	}
+	deletes := valueutil.IsNil(value)
+	if deletes && !r.options.NilValueDeletes {
+		return ErrNilValue
+	}
+	_, err := r.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		if deletes {
+			pipe.Del(ctx, key)
+		} else {
+			pipe.Set(ctx, key, value, ttl)
+		}
+		pipe.Publish(ctx, channel, key)
+		return nil
+	})
+	return err
+}
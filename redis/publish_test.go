@@ -0,0 +1,65 @@
+package redis_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/zeroxsolutions/banshee/redis"
+	"github.com/zeroxsolutions/barbatos/cache"
+	"github.com/zeroxsolutions/strike/ssutil"
+)
+
+// TestRedisCache_SetAndPublish verifies that the value is written and a subscriber receives the
+// key on the channel.
+func TestRedisCache_SetAndPublish(t *testing.T) {
+	redisCache := initRedisCache(t)
+
+	defer func(redisCache cache.Cache) {
+		if err := redisCache.Close(); err != nil {
+			t.Log("Close Redis cache connection err", err)
+		}
+	}(redisCache)
+
+	subscriber := initRedisClient(t)
+	defer subscriber.Close()
+
+	ctx := context.Background()
+	key := ssutil.MakeString(10)
+	value := ssutil.MakeString(12)
+	channel := "invalidate:" + ssutil.MakeString(6)
+
+	pubsub := subscriber.Subscribe(ctx, channel)
+	defer pubsub.Close()
+
+	if _, err := pubsub.Receive(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := redisCache.(*redis.RedisCache).SetAndPublish(ctx, key, value, time.Minute, channel); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case msg := <-pubsub.Channel():
+		if msg.Payload != key {
+			t.Log("unexpected payload:", msg.Payload)
+			t.FailNow()
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("no message received")
+	}
+
+	v, err := redisCache.Get(ctx, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if v != value {
+		t.FailNow()
+	}
+
+	if err := redisCache.Del(ctx, key); err != nil {
+		t.Error(err)
+	}
+}
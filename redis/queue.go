@@ -0,0 +1,168 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/zeroxsolutions/banshee"
+	"github.com/zeroxsolutions/barbatos/cache"
+)
+
+var _ banshee.ReliableQueue = (*RedisCache)(nil)
+
+// reserveScript moves the reservations whose visibility deadline passed back to the head of the
+// ready list, then pops the next job and reserves it under a new token.
+//
+// KEYS[1] = ready list, KEYS[2] = reservations sorted set, KEYS[3] = reserved jobs hash,
+// ARGV[1] = now in unix milliseconds, ARGV[2] = visibility in milliseconds, ARGV[3] = new token
+// Returns the reserved job, or nil when no job is ready.
+var reserveScript = redis.NewScript(`
+local now = tonumber(ARGV[1])
+local due = redis.call('ZRANGEBYSCORE', KEYS[2], '-inf', now)
+for _, token in ipairs(due) do
+	local job = redis.call('HGET', KEYS[3], token)
+	if job then
+		redis.call('LPUSH', KEYS[1], job)
+		redis.call('HDEL', KEYS[3], token)
+	end
+	redis.call('ZREM', KEYS[2], token)
+end
+local job = redis.call('LPOP', KEYS[1])
+if not job then
+	return false
+end
+redis.call('ZADD', KEYS[2], now + tonumber(ARGV[2]), ARGV[3])
+redis.call('HSET', KEYS[3], ARGV[3], job)
+return job
+`)
+
+// ackScript drops a reservation and its job.
+//
+// KEYS[1] = reservations sorted set, KEYS[2] = reserved jobs hash, ARGV[1] = token
+// Returns 1 if the job was reserved under the token, 0 otherwise.
+var ackScript = redis.NewScript(`
+if redis.call('ZREM', KEYS[1], ARGV[1]) == 0 then
+	return 0
+end
+redis.call('HDEL', KEYS[2], ARGV[1])
+return 1
+`)
+
+// Enqueue appends jobs to the tail of the reliable queue named queue, to be handed out by
+// Reserve in order.
+//
+// Parameters:
+//   - ctx: Context for timeout control and request cancellation
+//   - queue: Name of the queue, also the Redis key of its ready list
+//   - jobs: Jobs to append; nothing is sent when empty
+//
+// Returns:
+//   - error: A Redis error
+//
+// Example:
+//
+//	err := cache.Enqueue(ctx, "jobs:emails", payload)
+func (r *RedisCache) Enqueue(ctx context.Context, queue string, jobs ...string) error {
+	if len(jobs) == 0 {
+		return nil
+	}
+	args := make([]interface{}, len(jobs))
+	for i, job := range jobs {
+		args[i] = job
+	}
+	return r.client.RPush(ctx, queue, args...).Err()
+}
+
+// Reserve pops the job at the head of queue and keeps it reserved, invisible to other consumers,
+// for visibility, SQS-style. The consumer acknowledges the job with Ack once it is processed; a
+// job that isn't acknowledged in time is requeued at the head of the queue and delivered again.
+// Moving expired reservations back and popping the next job run in a single Lua script.
+//
+// Delivery is at-least-once: a job is never lost while its consumer crashes, but it is delivered
+// again when processing outlasts the visibility timeout or the Ack fails, so jobs must be
+// idempotent. Expired reservations are only requeued by the next Reserve on the queue.
+//
+// Storage: the ready list is stored under queue, the reservation deadlines in the sorted set
+// queue+":reserved" and the reserved jobs in the hash queue+":reserved:jobs". With Redis Cluster
+// the queue name must contain a hash tag, e.g. "{jobs}:emails", so that all three share a slot.
+// Deadlines come from the local clock, so consumers on different hosts need synchronised clocks.
+//
+// Parameters:
+//   - ctx: Context for timeout control and request cancellation
+//   - queue: Name of the queue
+//   - visibility: How long the job stays reserved, must be at least a millisecond
+//
+// Returns:
+//   - job: The reserved job
+//   - ackToken: Token to pass to Ack once the job is processed
+//   - err: cache.ErrCacheNil if no job is ready, an error for an invalid visibility, or a Redis
+//     error
+//
+// Example:
+//
+//	job, token, err := cache.Reserve(ctx, "jobs:emails", time.Minute)
+//	if errors.Is(err, cache.ErrCacheNil) {
+//	    // queue is empty
+//	}
+//	if err == nil && send(job) == nil {
+//	    err = cache.Ack(ctx, "jobs:emails", token)
+//	}
+func (r *RedisCache) Reserve(ctx context.Context, queue string, visibility time.Duration) (string, string, error) {
+	if visibility < time.Millisecond {
+		return "", "", errors.New("redis: reserve visibility must be at least a millisecond")
+	}
+	token, err := newToken()
+	if err != nil {
+		return "", "", err
+	}
+	keys := []string{queue, reservedKey(queue), reservedJobsKey(queue)}
+	job, err := reserveScript.Run(ctx, r.client, keys, time.Now().UnixMilli(), visibility.Milliseconds(), token).Text()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return "", "", cache.ErrCacheNil
+		}
+		return "", "", err
+	}
+	return job, token, nil
+}
+
+// Ack acknowledges the job reserved from queue under ackToken, so that it is never delivered
+// again. A job whose visibility timeout elapsed can still be acknowledged as long as no Reserve
+// requeued it in the meantime.
+//
+// Parameters:
+//   - ctx: Context for timeout control and request cancellation
+//   - queue: Name of the queue the job was reserved from
+//   - ackToken: Token returned by Reserve
+//
+// Returns:
+//   - error: ErrReservationExpired if the job is no longer reserved under the token, or a Redis
+//     error
+//
+// Example:
+//
+//	if err := cache.Ack(ctx, "jobs:emails", token); errors.Is(err, redis.ErrReservationExpired) {
+//	    // the job was requeued and will be processed again
+//	}
+func (r *RedisCache) Ack(ctx context.Context, queue, ackToken string) error {
+	acked, err := ackScript.Run(ctx, r.client, []string{reservedKey(queue), reservedJobsKey(queue)}, ackToken).Int64()
+	if err != nil {
+		return err
+	}
+	if acked == 0 {
+		return ErrReservationExpired
+	}
+	return nil
+}
+
+// reservedKey returns the key of the sorted set holding the reservation deadlines of queue.
+func reservedKey(queue string) string {
+	return queue + ":reserved"
+}
+
+// reservedJobsKey returns the key of the hash holding the reserved jobs of queue by token.
+func reservedJobsKey(queue string) string {
+	return queue + ":reserved:jobs"
+}
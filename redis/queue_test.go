@@ -0,0 +1,82 @@
+package redis_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/zeroxsolutions/banshee/redis"
+	"github.com/zeroxsolutions/barbatos/cache"
+	"github.com/zeroxsolutions/strike/ssutil"
+)
+
+// TestRedisCache_ReserveAck verifies that a reserved job is hidden until acknowledged, that an
+// unacknowledged job is delivered again after its visibility timeout, and that a stale token can
+// no longer acknowledge it.
+func TestRedisCache_ReserveAck(t *testing.T) {
+	redisCache := initRedisCache(t)
+
+	defer func(redisCache cache.Cache) {
+		if err := redisCache.Close(); err != nil {
+			t.Log("Close Redis cache connection err", err)
+		}
+	}(redisCache)
+
+	queue := redisCache.(*redis.RedisCache)
+
+	ctx := context.Background()
+	name := ssutil.MakeString(10)
+
+	defer func() {
+		if err := queue.Del(ctx, name, name+":reserved", name+":reserved:jobs"); err != nil {
+			t.Error(err)
+		}
+	}()
+
+	if _, _, err := queue.Reserve(ctx, name, time.Second); !errors.Is(err, cache.ErrCacheNil) {
+		t.Fatal("an empty queue should return cache.ErrCacheNil:", err)
+	}
+
+	if err := queue.Enqueue(ctx, name, "job-1", "job-2"); err != nil {
+		t.Fatal(err)
+	}
+
+	job, token, err := queue.Reserve(ctx, name, 200*time.Millisecond)
+	if err != nil || job != "job-1" || token == "" {
+		t.Fatal(job, token, err)
+	}
+
+	// job-1 is invisible while reserved.
+	job, token2, err := queue.Reserve(ctx, name, time.Minute)
+	if err != nil || job != "job-2" {
+		t.Fatal(job, err)
+	}
+	if err := queue.Ack(ctx, name, token2); err != nil {
+		t.Fatal(err)
+	}
+	if err := queue.Ack(ctx, name, token2); !errors.Is(err, redis.ErrReservationExpired) {
+		t.Fatal("a second Ack should return ErrReservationExpired:", err)
+	}
+	if _, _, err := queue.Reserve(ctx, name, time.Minute); !errors.Is(err, cache.ErrCacheNil) {
+		t.Fatal("a reserved job should be invisible:", err)
+	}
+
+	// job-1 was never acknowledged, so it is delivered again after its visibility timeout.
+	time.Sleep(300 * time.Millisecond)
+
+	job, token3, err := queue.Reserve(ctx, name, time.Minute)
+	if err != nil || job != "job-1" || token3 == token {
+		t.Fatal("an expired reservation should be redelivered:", job, err)
+	}
+	if err := queue.Ack(ctx, name, token); !errors.Is(err, redis.ErrReservationExpired) {
+		t.Fatal("the token of an expired reservation should be rejected:", err)
+	}
+	if err := queue.Ack(ctx, name, token3); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, err := queue.Reserve(ctx, name, 0); err == nil {
+		t.Fatal("a zero visibility should be rejected")
+	}
+}
@@ -0,0 +1,74 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/zeroxsolutions/banshee"
+)
+
+var _ banshee.RateMeter = (*RedisCache)(nil)
+
+// rateScript records an event in a sorted set of event timestamps, trims the events that left the
+// window, and counts the rest. The key expires one window after the last event, since all its
+// events are out of the window by then.
+//
+// KEYS[1] = events key, ARGV[1] = now in unix milliseconds, ARGV[2] = window in milliseconds,
+// ARGV[3] = unique event member
+// Returns the number of events within the window.
+var rateScript = redis.NewScript(`
+local now = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+redis.call('ZREMRANGEBYSCORE', KEYS[1], '-inf', now - window)
+redis.call('ZADD', KEYS[1], now, ARGV[3])
+redis.call('PEXPIRE', KEYS[1], window)
+return redis.call('ZCARD', KEYS[1])
+`)
+
+// Rate records one event for key and returns the live event rate over the last window, in events
+// per second, e.g. the request rate of a client watched by an anomaly detector. Recording,
+// trimming and counting run in a single Lua script, so concurrent callers all count each other.
+//
+// Behavior:
+//   - Events are kept in a sorted set scored by their time; each call first removes the events
+//     older than window, so the set only ever holds the events of the last window
+//   - The key expires one window after the last event, so idle keys free their memory
+//   - Timestamps come from the local clock, so callers on different hosts need synchronised
+//     clocks for their events to be trimmed consistently
+//
+// Memory cost: the sorted set holds one member per event within the window, a 32-character ID
+// and its score, which takes roughly 100 bytes with the sorted-set overhead. A key seeing N events
+// per second over a window of W seconds thus uses about 100 * N * W bytes; prefer short windows
+// for busy keys.
+//
+// Parameters:
+//   - ctx: Context for timeout control and request cancellation
+//   - key: Redis key holding the events
+//   - window: Length of the sliding window, must be at least a millisecond
+//
+// Returns:
+//   - float64: Events per second over the window, including the one recorded
+//   - error: An error if window is too short, or a Redis error
+//
+// Example:
+//
+//	rate, err := cache.Rate(ctx, "rate:login:"+userID, 10*time.Second)
+//	if err == nil && rate > 5 {
+//	    // More than 5 login attempts per second over the last 10 seconds
+//	}
+func (r *RedisCache) Rate(ctx context.Context, key string, window time.Duration) (float64, error) {
+	if window < time.Millisecond {
+		return 0, errors.New("redis: rate window must be at least a millisecond")
+	}
+	member, err := newToken()
+	if err != nil {
+		return 0, err
+	}
+	count, err := rateScript.Run(ctx, r.client, []string{key}, time.Now().UnixMilli(), window.Milliseconds(), member).Int64()
+	if err != nil {
+		return 0, err
+	}
+	return float64(count) / window.Seconds(), nil
+}
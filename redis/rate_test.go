@@ -0,0 +1,66 @@
+package redis_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/zeroxsolutions/banshee/redis"
+	"github.com/zeroxsolutions/barbatos/cache"
+	"github.com/zeroxsolutions/strike/ssutil"
+)
+
+// TestRedisCache_Rate verifies that Rate counts the events of the window, trims older ones, and
+// lets the key expire.
+func TestRedisCache_Rate(t *testing.T) {
+	redisCache := initRedisCache(t)
+
+	defer func(redisCache cache.Cache) {
+		if err := redisCache.Close(); err != nil {
+			t.Log("Close Redis cache connection err", err)
+		}
+	}(redisCache)
+
+	client := initRedisClient(t)
+	defer client.Close()
+
+	meter := redisCache.(*redis.RedisCache)
+
+	ctx := context.Background()
+	key := ssutil.MakeString(10)
+	window := 500 * time.Millisecond
+
+	defer func() {
+		if err := meter.Del(ctx, key); err != nil {
+			t.Error(err)
+		}
+	}()
+
+	var rate float64
+	var err error
+	for i := 0; i < 5; i++ {
+		if rate, err = meter.Rate(ctx, key, window); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if rate != 10 {
+		t.Fatal("5 events over half a second should be 10 per second, got", rate)
+	}
+
+	if ttl, err := client.PTTL(ctx, key).Result(); err != nil || ttl <= 0 || ttl > window {
+		t.Fatal("the events should expire with the window:", ttl, err)
+	}
+
+	time.Sleep(window + 100*time.Millisecond)
+
+	if rate, err := meter.Rate(ctx, key, window); err != nil || rate != 2 {
+		t.Fatal("events older than the window should be trimmed:", rate, err)
+	}
+	if n, err := client.ZCard(ctx, key).Result(); err != nil || n != 1 {
+		t.Fatal(n, err)
+	}
+
+	if _, err := meter.Rate(ctx, key, 0); err == nil {
+		t.Fatal("a window shorter than a millisecond should be rejected")
+	}
+}
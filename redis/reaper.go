@@ -0,0 +1,105 @@
+package redis
+
+import (
+	"context"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// reapBatchSize is the SCAN COUNT hint used by ReapStaleLocks and the number of keys checked per
+// script call.
+const reapBatchSize = 100
+
+// reapStaleLocksScript deletes the given lock keys that have been idle for at least the threshold
+// and have no TTL, or a remaining TTL above the maximum lock TTL when one is given. OBJECT
+// IDLETIME and PTTL do not count as accesses, so checking a lock never keeps it alive, and the
+// checks and the delete happen atomically, so a lock refreshed in between is never removed.
+//
+// KEYS = lock keys, ARGV[1] = idle threshold in seconds, ARGV[2] = maximum lock TTL in
+// milliseconds, 0 for none
+// Returns the number of deleted keys.
+var reapStaleLocksScript = redis.NewScript(`
+local maxTTL = tonumber(ARGV[2])
+local reaped = 0
+for _, key in ipairs(KEYS) do
+	local idle = redis.call('OBJECT', 'IDLETIME', key)
+	if idle and idle >= tonumber(ARGV[1]) then
+		local ttl = redis.call('PTTL', key)
+		if ttl == -1 or (maxTTL > 0 and ttl > maxTTL) then
+			reaped = reaped + redis.call('DEL', key)
+		end
+	end
+end
+return reaped
+`)
+
+// ReapStaleLocks deletes abandoned lock keys under prefix and returns how many were reaped. It is an
+// operational safety valve for locks left behind by crashed holders when their TTL is missing or
+// was set far too long; it is not part of normal locking.
+//
+// How abandonment is determined:
+//   - A lock is abandoned when no command has accessed it for at least olderThan, as reported by
+//     OBJECT IDLETIME (one-second resolution), and its TTL can't be trusted to free it: it has no
+//     TTL, or a remaining TTL above SetMaxLockTTL when configured
+//   - Locks within the maximum TTL are never reaped, however long they have been idle: a holder
+//     of a Locker or MultiLock lock doesn't touch it while working, and the lock frees itself
+//     when its TTL elapses anyway
+//
+// The checks and the delete run in one Lua script per batch, so a lock refreshed concurrently is
+// kept. Keys are walked with SCAN and the context is checked between batches.
+//
+// Caches built with SetDelWithPatternDisabled(true) return ErrOperationDisabled without reaping.
+//
+// OBJECT IDLETIME is unavailable when the server's maxmemory-policy is an LFU policy; the call then
+// fails with the server error and reaps nothing.
+//
+// Parameters:
+//   - ctx: Context for timeout control and request cancellation
+//   - prefix: Prefix of the lock keys, e.g. "lock:"
+//   - olderThan: Minimum idle time of an abandoned lock
+//
+// Returns:
+//   - int: Number of lock keys deleted
+//   - error: ErrOperationDisabled, or an error if the context is done or Redis fails; locks reaped
+//     before it are counted
+//
+// Example:
+//
+//	reaped, err := cache.ReapStaleLocks(ctx, "lock:", 10*time.Minute)
+func (r *RedisCache) ReapStaleLocks(ctx context.Context, prefix string, olderThan time.Duration) (int, error) {
+	if err := r.CheckPatternDelete(); err != nil {
+		return 0, err
+	}
+	threshold := int64(olderThan / time.Second)
+	if threshold < 1 {
+		threshold = 1
+	}
+
+	reaped := 0
+	var cursor uint64
+	for {
+		if err := ctx.Err(); err != nil {
+			return reaped, err
+		}
+		keys, next, err := r.client.Scan(ctx, cursor, prefix+"*", reapBatchSize).Result()
+		if err != nil {
+			return reaped, err
+		}
+		if len(keys) > 0 {
+			n, err := reapStaleLocksScript.Run(ctx, r.client, keys, threshold, r.options.MaxLockTTL.Milliseconds()).Int()
+			if err != nil {
+				if reaped > 0 {
+					r.audit(ctx, AuditOpReapStaleLocks, nil, prefix+"*", int64(reaped))
+				}
+				return reaped, err
+			}
+			reaped += n
+		}
+		if next == 0 {
+			r.audit(ctx, AuditOpReapStaleLocks, nil, prefix+"*", int64(reaped))
+			return reaped, nil
+		}
+		cursor = next
+	}
+}
@@ -0,0 +1,95 @@
+package redis_test
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/zeroxsolutions/banshee/redis"
+	"github.com/zeroxsolutions/barbatos/cache"
+	"github.com/zeroxsolutions/strike/ssutil"
+)
+
+// TestRedisCache_ReapStaleLocks seeds idle and recently refreshed lock keys and verifies that only
+// the idle ones without a trustworthy TTL are reaped, while an idle live lock survives. It waits for OBJECT IDLETIME to advance, so it only runs when
+// REDIS_IDLETIME is set.
+func TestRedisCache_ReapStaleLocks(t *testing.T) {
+	if os.Getenv("REDIS_IDLETIME") == "" {
+		t.Skip("REDIS_IDLETIME not set; skipping OBJECT IDLETIME test")
+	}
+
+	redisCache := initRedisCache(t, redis.NewRedisCacheOptions().SetMaxLockTTL(time.Hour))
+
+	defer func(redisCache cache.Cache) {
+		if err := redisCache.Close(); err != nil {
+			t.Log("Close Redis cache connection err", err)
+		}
+	}(redisCache)
+
+	reaper := redisCache.(*redis.RedisCache)
+
+	ctx := context.Background()
+	prefix := "lock:" + ssutil.MakeString(10) + ":"
+
+	defer func() {
+		if err := reaper.DelWithPattern(ctx, prefix+"*"); err != nil {
+			t.Error(err)
+		}
+	}()
+
+	stale := []string{prefix + "persistent", prefix + "long-ttl"}
+	fresh := []string{prefix + "refreshed", prefix + "new", prefix + "live"}
+
+	live, err := redis.NewLocker(reaper).Acquire(ctx, fresh[2], time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := reaper.Set(ctx, stale[0], "token"); err != nil {
+		t.Fatal(err)
+	}
+	if err := reaper.SetWithExpiration(ctx, stale[1], "token", 24*time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	if err := reaper.SetWithExpiration(ctx, fresh[0], "token", time.Minute); err != nil {
+		t.Fatal(err)
+	}
+
+	time.Sleep(2500 * time.Millisecond)
+
+	if _, err := reaper.Expire(ctx, fresh[0], time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	if err := reaper.SetWithExpiration(ctx, fresh[1], "token", time.Minute); err != nil {
+		t.Fatal(err)
+	}
+
+	reaped, err := reaper.ReapStaleLocks(ctx, prefix, 2*time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if reaped != len(stale) {
+		t.Log("expected", len(stale), "reaped locks, got", reaped)
+		t.FailNow()
+	}
+
+	for _, key := range stale {
+		if _, err := reaper.Get(ctx, key); err != cache.ErrCacheNil {
+			t.Log("stale lock not reaped:", key)
+			t.FailNow()
+		}
+	}
+
+	for _, key := range fresh {
+		if _, err := reaper.Get(ctx, key); err != nil {
+			t.Log("fresh lock reaped:", key)
+			t.FailNow()
+		}
+	}
+
+	if err := live.Release(ctx); err != nil {
+		t.Fatal("the live lock should still be held:", err)
+	}
+}
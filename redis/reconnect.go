@@ -0,0 +1,112 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultReconnectJitter is the reconnect jitter window applied when no explicit value is configured.
+// It is deliberately small: enough to de-synchronise a fleet reconnecting after a failover without
+// noticeably delaying recovery of a single instance.
+const DefaultReconnectJitter = 250 * time.Millisecond
+
+// jitteredDialer wraps a dial function so that, once a connection loss has been observed, each
+// new connection waits a random delay in [0, window) before dialing.
+//
+// A loss is recorded when a dial fails or when an established connection returns a non-timeout
+// read/write error. The first successful dial after a loss clears it, so a healthy pool growing
+// under load never pays the delay, and the initial connection at startup is immediate.
+//
+// Each dialer draws its delays from its own source, seeded when it is created, so that processes
+// started together don't all pick the same delays as they would from an unseeded global source.
+type jitteredDialer struct {
+	window time.Duration
+	dial   func(ctx context.Context, network, addr string) (net.Conn, error)
+	lost   int32
+	randMu sync.Mutex // randMu guards rand, which isn't safe for concurrent use.
+	rand   *rand.Rand
+}
+
+// newJitteredDialer creates a jitteredDialer using a plain net.Dialer with the go-redis defaults.
+func newJitteredDialer(window time.Duration) *jitteredDialer {
+	netDialer := &net.Dialer{
+		Timeout:   5 * time.Second,
+		KeepAlive: 5 * time.Minute,
+	}
+	return &jitteredDialer{
+		window: window,
+		dial:   netDialer.DialContext,
+		rand:   rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+// DialContext waits the jittered delay if the dialer is reconnecting, then dials.
+// It is suitable for use as redis.Options.Dialer.
+func (d *jitteredDialer) DialContext(ctx context.Context, network, addr string) (net.Conn, error) {
+	if atomic.LoadInt32(&d.lost) == 1 && d.window > 0 {
+		timer := time.NewTimer(d.delay())
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+	conn, err := d.dial(ctx, network, addr)
+	if err != nil {
+		d.markLost()
+		return nil, err
+	}
+	atomic.StoreInt32(&d.lost, 0)
+	return &lossDetectingConn{Conn: conn, dialer: d}, nil
+}
+
+// delay returns a random delay in [0, window).
+func (d *jitteredDialer) delay() time.Duration {
+	d.randMu.Lock()
+	defer d.randMu.Unlock()
+	return time.Duration(d.rand.Int63n(int64(d.window)))
+}
+
+// markLost records that a connection was lost, so the next dials are jittered.
+func (d *jitteredDialer) markLost() {
+	atomic.StoreInt32(&d.lost, 1)
+}
+
+// lossDetectingConn reports broken connections back to its jitteredDialer.
+type lossDetectingConn struct {
+	net.Conn
+	dialer *jitteredDialer
+}
+
+// Read reads from the underlying connection, recording a loss on non-timeout errors.
+func (c *lossDetectingConn) Read(b []byte) (int, error) {
+	n, err := c.Conn.Read(b)
+	c.observe(err)
+	return n, err
+}
+
+// Write writes to the underlying connection, recording a loss on non-timeout errors.
+func (c *lossDetectingConn) Write(b []byte) (int, error) {
+	n, err := c.Conn.Write(b)
+	c.observe(err)
+	return n, err
+}
+
+// observe marks the dialer as reconnecting when err indicates a broken connection.
+// Deadline expiries are expected for blocking commands and are not treated as a loss.
+func (c *lossDetectingConn) observe(err error) {
+	if err == nil {
+		return
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return
+	}
+	c.dialer.markLost()
+}
@@ -0,0 +1,120 @@
+package redis
+
+import (
+	"context"
+	"math/rand"
+	"net"
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestJitteredDialer_SpreadsReconnects simulates many connections redialing at once after a
+// loss and asserts the dials are spread across the configured window.
+func TestJitteredDialer_SpreadsReconnects(t *testing.T) {
+	const (
+		window      = 500 * time.Millisecond
+		connections = 50
+	)
+
+	var mu sync.Mutex
+	var dialedAt []time.Time
+
+	dialer := &jitteredDialer{
+		window: window,
+		rand:   rand.New(rand.NewSource(time.Now().UnixNano())),
+		dial: func(ctx context.Context, network, addr string) (net.Conn, error) {
+			mu.Lock()
+			dialedAt = append(dialedAt, time.Now())
+			mu.Unlock()
+			client, server := net.Pipe()
+			_ = server.Close()
+			return client, nil
+		},
+	}
+	dialer.markLost()
+
+	start := time.Now()
+	var wg sync.WaitGroup
+	for range make([]int, connections) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := dialer.DialContext(context.Background(), "tcp", "redis:6379"); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	earliest, latest := window, time.Duration(0)
+	for _, at := range dialedAt {
+		delay := at.Sub(start)
+		if delay < earliest {
+			earliest = delay
+		}
+		if delay > latest {
+			latest = delay
+		}
+	}
+
+	if latest > window+100*time.Millisecond {
+		t.Log("dial delayed beyond the window:", latest)
+		t.FailNow()
+	}
+
+	if latest-earliest < window/2 {
+		t.Log("dials were not spread across the window:", earliest, latest)
+		t.FailNow()
+	}
+}
+
+// TestJitteredDialer_ImmediateWhenHealthy verifies that dials are not delayed before any loss.
+func TestJitteredDialer_ImmediateWhenHealthy(t *testing.T) {
+	dialer := &jitteredDialer{
+		window: time.Hour,
+		rand:   rand.New(rand.NewSource(time.Now().UnixNano())),
+		dial: func(ctx context.Context, network, addr string) (net.Conn, error) {
+			client, _ := net.Pipe()
+			return client, nil
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	if _, err := dialer.DialContext(ctx, "tcp", "redis:6379"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestJitteredDialer_LossDetection verifies that a broken connection switches the dialer into
+// reconnecting mode and that the wait respects context cancellation.
+func TestJitteredDialer_LossDetection(t *testing.T) {
+	dialer := &jitteredDialer{
+		window: time.Hour,
+		rand:   rand.New(rand.NewSource(time.Now().UnixNano())),
+		dial: func(ctx context.Context, network, addr string) (net.Conn, error) {
+			client, server := net.Pipe()
+			_ = server.Close()
+			return client, nil
+		},
+	}
+
+	conn, err := dialer.DialContext(context.Background(), "tcp", "redis:6379")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := conn.Read(make([]byte, 1)); err == nil {
+		t.FailNow()
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	if _, err := dialer.DialContext(ctx, "tcp", "redis:6379"); err != context.DeadlineExceeded {
+		t.Log(err)
+		t.FailNow()
+	}
+}
@@ -11,7 +11,9 @@ import (
 
 	"github.com/redis/go-redis/v9"
 	"github.com/zeroxsolutions/alex"
+	"github.com/zeroxsolutions/banshee/internal/valueutil"
 	"github.com/zeroxsolutions/barbatos/cache"
+	"github.com/zeroxsolutions/strike/builderutil"
 )
 
 // NewRedisCache creates a new Redis-based cache implementation with the provided configuration.
@@ -22,19 +24,28 @@ import (
 //   - Creates a Redis client with the provided configuration
 //   - Tests the connection using a PING command
 //   - Returns an error if connection fails
+//   - With SetRequirePrimary(true), returns ErrReadOnlyReplica if the server is a replica
 //   - Wraps the client in a RedisCache struct implementing the Cache interface
 //
 // Configuration options include:
-//   - Addr: Redis server address (host:port format)
+//   - Addr: Redis server address (host:port format, or a socket path with SetNetwork("unix"))
 //   - Password: Redis authentication password (if required)
 //   - DB: Redis database number to use (0-15 typically)
 //
+// Optional behaviour is configured with builders created by NewRedisCacheOptions;
+// without any options the cache behaves exactly as a plain Redis client.
+//
 // Parameters:
 //   - config: Pointer to alex.RedisConfig containing Redis connection settings
+//   - opts: Optional RedisCacheOptions builders applied in order
 //
 // Returns:
 //   - cache.Cache: A Redis cache implementation ready for use
-//   - error: Connection error if Redis is unreachable or authentication fails
+//   - error: Connection error if Redis is unreachable or authentication fails, or
+//     ErrReadOnlyReplica if a primary is required and the server is a replica
+//
+// Writes the server refuses because it is a read-only replica fail with a *ReadOnlyError
+// matching ErrReadOnlyReplica, rather than the raw READONLY error.
 //
 // Example:
 //
@@ -48,19 +59,43 @@ import (
 //	    log.Fatal("Failed to connect to Redis:", err)
 //	}
 //	defer cache.Close()
-func NewRedisCache(config *alex.RedisConfig) (cache.Cache, error) {
-	client := redis.NewClient(
-		&redis.Options{
-			Addr:     config.Addr,
-			Password: config.Password,
-			DB:       config.DB,
-		},
-	)
-	_, err := client.Ping(context.Background()).Result()
+func NewRedisCache(config *alex.RedisConfig, opts ...builderutil.Lister[RedisCacheOptions]) (cache.Cache, error) {
+	options, err := builderutil.Build(append([]builderutil.Lister[RedisCacheOptions]{defaultRedisCacheOptions()}, opts...)...)
+	if err != nil {
+		return nil, err
+	}
+	redisOptions := &redis.Options{
+		Network:  options.Network,
+		Addr:     config.Addr,
+		Password: config.Password,
+		DB:       config.DB,
+	}
+	if options.ReconnectJitter > 0 {
+		redisOptions.Dialer = newJitteredDialer(options.ReconnectJitter).DialContext
+	}
+	if options.NoTouch {
+		redisOptions.OnConnect = func(ctx context.Context, cn *redis.Conn) error {
+			cmd := redis.NewStatusCmd(ctx, "CLIENT", "NO-TOUCH", "ON")
+			if err := cn.Process(ctx, cmd); err != nil {
+				return err
+			}
+			return cmd.Err()
+		}
+	}
+	client := redis.NewClient(redisOptions)
+	client.AddHook(readOnlyHook{})
+	if options.ServerTimeCallback != nil {
+		client.AddHook(&serverTimeHook{client: client, callback: options.ServerTimeCallback})
+	}
+	_, err = client.Ping(context.Background()).Result()
+	if err == nil && options.RequirePrimary {
+		err = checkPrimary(context.Background(), client)
+	}
 	if err != nil {
+		_ = client.Close()
 		return nil, err
 	}
-	return &RedisCache{client: client}, nil
+	return &RedisCache{client: client, options: options, ownsClient: true}, nil
 }
 
 // RedisCache implements the Cache interface using Redis as the backend storage.
@@ -78,7 +113,9 @@ func NewRedisCache(config *alex.RedisConfig) (cache.Cache, error) {
 // Thread safety: All operations are thread-safe as they delegate to the
 // underlying Redis client which handles concurrent access properly.
 type RedisCache struct {
-	client *redis.Client
+	client     *redis.Client
+	options    *RedisCacheOptions
+	ownsClient bool // ownsClient makes Close close the client; false for views over a shared client.
 }
 
 // IsConnected verifies the current connection status to the Redis server.
@@ -115,8 +152,9 @@ func (r *RedisCache) IsConnected(ctx context.Context) bool {
 }
 
 // Keys retrieves all Redis keys matching the specified pattern.
-// This method uses Redis KEYS command to find keys that match the given pattern
-// using Redis glob-style pattern matching.
+// This method iterates with the Redis SCAN command (MATCH pattern, COUNT configured with
+// SetScanCount) rather than KEYS, so the server keeps serving other clients between batches
+// however large the keyspace is.
 //
 // Pattern syntax supports:
 //   - '*' matches zero or more characters
@@ -127,18 +165,22 @@ func (r *RedisCache) IsConnected(ctx context.Context) bool {
 //   - '\' escapes special characters
 //
 // Performance considerations:
-//   - KEYS command can be slow on large databases (O(N) complexity)
-//   - May block Redis server during execution on large datasets
-//   - Consider using SCAN for production environments with large key sets
+//   - The whole keyspace is still walked, in one round trip per batch: a larger COUNT means fewer
+//     round trips but longer individual SCAN calls
+//   - Keys added or removed during the iteration may or may not be included; duplicates reported
+//     by SCAN are removed
 //   - Use specific patterns to limit the result set
 //
+// The context is checked between batches: a cancelled call returns the keys collected so far
+// together with the context error. Caches built with SetSoftDelete leave tombstones out.
+//
 // Parameters:
 //   - ctx: Context for timeout control and request cancellation
 //   - pattern: Glob-style pattern to match keys against
 //
 // Returns:
 //   - []string: Slice of keys matching the pattern (empty if no matches)
-//   - error: Redis connection error or command execution error
+//   - error: Context error, Redis connection error or command execution error
 //
 // Examples:
 //
@@ -147,11 +189,7 @@ func (r *RedisCache) IsConnected(ctx context.Context) bool {
 //	keys, err := cache.Keys(ctx, "temp:???")         // 3-character temp keys
 //	keys, err := cache.Keys(ctx, "cache:[0-9]*")     // Numbered cache keys
 func (r *RedisCache) Keys(ctx context.Context, pattern string) ([]string, error) {
-	keys, err := r.client.Keys(ctx, pattern).Result()
-	if err != nil {
-		return nil, err
-	}
-	return keys, nil
+	return r.ScanKeys(ctx, pattern, r.options.ScanCount)
 }
 
 // Get retrieves the string value associated with the specified key from Redis.
@@ -215,6 +253,7 @@ func (r *RedisCache) Get(ctx context.Context, key string) (string, error) {
 //   - Numbers are converted to string representation
 //   - Complex types should be serialized by the caller
 //   - Binary data should be base64 encoded or use Redis binary-safe commands
+//   - Nil values are rejected with ErrNilValue, or delete the key with SetNilValueDeletes(true)
 //
 // Parameters:
 //   - ctx: Context for timeout control and request cancellation
@@ -222,7 +261,7 @@ func (r *RedisCache) Get(ctx context.Context, key string) (string, error) {
 //   - value: Value to store (will be converted to string by Redis client)
 //
 // Returns:
-//   - error: Redis connection error or command execution error
+//   - error: ErrNilValue, Redis connection error or command execution error
 //
 // Example:
 //
@@ -262,7 +301,7 @@ func (r *RedisCache) Set(ctx context.Context, key string, value interface{}) err
 //   - expiration: Duration after which the key should automatically expire
 //
 // Returns:
-//   - error: Redis connection error or command execution error
+//   - error: ErrNilValue, Redis connection error or command execution error
 //
 // Examples:
 //
@@ -277,7 +316,21 @@ func (r *RedisCache) Set(ctx context.Context, key string, value interface{}) err
 //
 //	// No expiration (equivalent to Set)
 //	err := cache.SetWithExpiration(ctx, "permanent_config", config, 0)
+//
+// If ctx was derived with WithTTLOverride, the overriding TTL replaces expiration.
+//
+// Nil values, including nil pointers and nil slices, fail with ErrNilValue, or delete the key when
+// the cache was built with SetNilValueDeletes(true).
 func (r *RedisCache) SetWithExpiration(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
+	if valueutil.IsNil(value) {
+		if r.options.NilValueDeletes {
+			return r.Del(ctx, key)
+		}
+		return ErrNilValue
+	}
+	if ttl, ok := ttlOverrideFromContext(ctx); ok {
+		expiration = ttl
+	}
 	err := r.client.Set(ctx, key, value, expiration).Err()
 	if err != nil {
 		return err
@@ -320,30 +373,29 @@ func (r *RedisCache) SetWithExpiration(ctx context.Context, key string, value in
 //
 //	// Safe to call with non-existent keys
 //	err := cache.Del(ctx, "might_not_exist") // No error if key doesn't exist
+//
+// Caches built with SetSoftDelete rename the keys to tombstones instead; see Restore and HardDel.
 func (r *RedisCache) Del(ctx context.Context, keys ...string) error {
-	err := r.client.Del(ctx, keys...).Err()
-	if err != nil {
-		return err
-	}
-	return nil
+	_, err := r.DelCount(ctx, keys...)
+	return err
 }
 
 // DelWithPattern deletes all Redis keys matching the specified pattern.
-// This method combines the Keys and Del operations to provide pattern-based bulk deletion.
-// It's a convenience method for cleaning up multiple related keys at once.
+// It's a convenience method for cleaning up multiple related keys at once; use
+// DelWithPatternCount to also learn how many keys were deleted.
 //
 // Operation steps:
-//  1. Uses Keys() to find all keys matching the pattern
-//  2. If no keys found, returns successfully without Redis calls
-//  3. Uses Del() to remove all found keys atomically
+//  1. Walks the keys matching the pattern with SCAN
+//  2. Deletes them with UNLINK in batches of SetDelBatchSize keys (DefaultDelBatchSize by default)
+//  3. Stops between batches once the context is done, keeping the keys already deleted
 //  4. Returns any errors from either operation
 //
 // Performance and safety considerations:
-//   - Can be expensive on large databases due to Keys() operation
-//   - May block Redis during execution with large result sets
+//   - Walks the whole keyspace with SCAN, which takes time on large databases
+//   - UNLINK frees the memory of large values in the background, so big deletes don't stall Redis
+//   - The deletion is not atomic: keys written during the walk may or may not be deleted
 //   - Use specific patterns to limit scope and improve performance
 //   - Be extremely careful with broad patterns like "*"
-//   - Consider using Redis SCAN-based approaches for large datasets
 //
 // Pattern matching uses same rules as Keys():
 //   - '*' matches any number of characters
@@ -374,19 +426,14 @@ func (r *RedisCache) Del(ctx context.Context, keys ...string) error {
 // Warning: Use patterns carefully in production:
 //
 //	cache.DelWithPattern(ctx, "*") // DANGEROUS: Deletes ALL keys!
+//
+// Caches built with SetDelWithPatternDisabled(true) return ErrOperationDisabled instead. Caches
+// built with SetSoftDelete rename the matching keys to tombstones, skipping existing tombstones.
+// Caches built with SetLegacyCommands(true), for servers older than Redis 4, collect all matching
+// keys first and delete them with a single DEL.
 func (r *RedisCache) DelWithPattern(ctx context.Context, pattern string) error {
-	keys, err := r.Keys(ctx, pattern)
-	if err != nil {
-		return err
-	}
-	if len(keys) == 0 {
-		return nil
-	}
-	err = r.Del(ctx, keys...)
-	if err != nil {
-		return err
-	}
-	return nil
+	_, err := r.DelWithPatternCount(ctx, pattern)
+	return err
 }
 
 // Close gracefully shuts down the Redis connection and releases all associated resources.
@@ -405,7 +452,8 @@ func (r *RedisCache) DelWithPattern(ctx context.Context, pattern string) error {
 //   - The cache instance becomes unusable after closing
 //
 // Connection pool considerations:
-//   - If using a shared Redis client, closing may affect other users
+//   - Caches built with NewRedisCacheFromClient leave the shared client open, unless built with
+//     SetCloseClient(true)
 //   - For applications with multiple cache instances, consider connection sharing
 //   - Connection pools are properly drained before closing
 //
@@ -431,5 +479,8 @@ func (r *RedisCache) DelWithPattern(ctx context.Context, pattern string) error {
 //	    }
 //	}
 func (r *RedisCache) Close() error {
+	if !r.ownsClient {
+		return nil
+	}
 	return r.client.Close()
 }
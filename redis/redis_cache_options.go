@@ -0,0 +1,518 @@
+package redis
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/zeroxsolutions/strike/builderutil"
+)
+
+// RedisCacheOptions holds the optional behaviour switches applied when constructing a RedisCache.
+// NewRedisCache applies the package defaults first and then the caller's builders, so callers only
+// need to set the options they care about. This struct is populated through RedisCacheOptionsBuilder.
+type RedisCacheOptions struct {
+	Network         string        // Network is the dial network: "tcp" (default) or "unix".
+	NoTouch         bool          // NoTouch issues CLIENT NO-TOUCH ON for every pooled connection (Redis 7.2+).
+	BloomFallback   bool          // BloomFallback serves the BF* methods from plain bitmaps instead of RedisBloom.
+	ReconnectJitter time.Duration // ReconnectJitter is the maximum random delay before redialing after a connection loss.
+	LegacyCommands  bool          // LegacyCommands emulates commands missing from older servers with Lua scripts.
+	NilValueDeletes bool          // NilValueDeletes makes Set and SetWithExpiration delete the key for nil values.
+	ScanCount       int64         // ScanCount is the COUNT hint of the SCAN calls made by Keys.
+	DelBatchSize    int64         // DelBatchSize is the number of keys DelWithPattern removes per UNLINK.
+	CloseClient     bool          // CloseClient makes Close close a client passed to NewRedisCacheFromClient.
+	RequirePrimary  bool          // RequirePrimary makes construction fail when the server is a replica.
+	MaxLockTTL      time.Duration // MaxLockTTL is the longest TTL of a healthy lock; ReapStaleLocks may reap locks with longer ones.
+
+	ExpirePatternPause time.Duration // ExpirePatternPause is the pause ExpirePattern takes between batches.
+
+	SoftDeleteRetention time.Duration // SoftDeleteRetention makes Del and DelWithPattern keep tombstones this long; 0 deletes for good.
+	TombstonePrefix     string        // TombstonePrefix is prepended to the keys holding tombstones.
+
+	DelWithPatternDisabled bool           // DelWithPatternDisabled makes pattern-wide deletes and expirations fail with ErrOperationDisabled.
+	ConfigSetEnabled       bool           // ConfigSetEnabled allows ConfigSet to change the server configuration.
+	ConfigAllowlist        []string       // ConfigAllowlist restricts ConfigSet to the listed parameters.
+	ServerTimeCallback     ServerTimeFunc // ServerTimeCallback receives the server-side execution time of slow-logged commands.
+	AuditStream            string         // AuditStream is the stream receiving audit entries; empty disables auditing.
+	AuditMaxLen            int64          // AuditMaxLen is the number of audit entries kept in the stream.
+	AuditErrorCallback     AuditErrorFunc // AuditErrorCallback receives the errors raised while writing audit entries.
+}
+
+// RedisCacheOptionsBuilder provides a builder pattern for constructing RedisCacheOptions.
+// It accumulates option functions that are applied in order by NewRedisCache.
+// This builder implements the builderutil.Lister interface to work with the functional options pattern.
+type RedisCacheOptionsBuilder struct {
+	Opts []func(*RedisCacheOptions) error // Opts contains the list of option functions to be applied
+}
+
+// SetNetwork configures the network used to reach Redis, as accepted by net.Dial.
+// Use "unix" to connect through a Unix domain socket, in which case config.Addr is the socket path:
+//
+//	config := &alex.RedisConfig{Addr: "/var/run/redis/redis.sock"}
+//	cache, err := redis.NewRedisCache(config, redis.NewRedisCacheOptions().SetNetwork("unix"))
+//
+// The startup PING is sent over the configured network, so a wrong socket path fails construction.
+// Empty means "tcp", where config.Addr is a host:port address.
+//
+// Parameters:
+//   - network: "tcp" or "unix"
+//
+// Returns:
+//   - *RedisCacheOptionsBuilder: The builder instance for method chaining
+func (b *RedisCacheOptionsBuilder) SetNetwork(network string) *RedisCacheOptionsBuilder {
+	b.Opts = append(b.Opts, func(o *RedisCacheOptions) error {
+		if network != "" && network != "tcp" && network != "unix" {
+			return errors.New("redis: network must be tcp or unix")
+		}
+		o.Network = network
+		return nil
+	})
+	return b
+}
+
+// SetNoTouch configures whether connections opened by the cache run CLIENT NO-TOUCH ON.
+// With NO-TOUCH enabled, commands sent by this cache do not update the LRU/LFU access time of
+// the keys they read, so large analytics scans don't make cold keys look hot and push genuinely
+// hot production keys out under eviction pressure.
+//
+// The flag is a per-connection setting, so it is applied from the OnConnect hook of every
+// connection in the pool rather than once at startup. Build a dedicated cache instance (an
+// "analytics view") with this option instead of enabling it on the cache serving regular
+// traffic. CLIENT NO-TOUCH requires Redis 7.2 or newer; on older servers every connection
+// attempt fails and NewRedisCache returns the server error.
+//
+// Parameters:
+//   - noTouch: true to enable CLIENT NO-TOUCH on every connection
+//
+// Returns:
+//   - *RedisCacheOptionsBuilder: The builder instance for method chaining
+func (b *RedisCacheOptionsBuilder) SetNoTouch(noTouch bool) *RedisCacheOptionsBuilder {
+	b.Opts = append(b.Opts, func(o *RedisCacheOptions) error {
+		o.NoTouch = noTouch
+		return nil
+	})
+	return b
+}
+
+// SetBloomFallback configures whether the BF* methods use the pure-Redis bloom filter
+// implementation instead of the RedisBloom module commands. Enable it on servers where the
+// module is not loaded; the filters are built from plain bitmaps and work on any Redis.
+//
+// Filters written by one implementation cannot be read by the other, so every cache sharing
+// a filter key must use the same setting.
+//
+// Parameters:
+//   - fallback: true to use the bitmap implementation, false to use BF.* commands
+//
+// Returns:
+//   - *RedisCacheOptionsBuilder: The builder instance for method chaining
+func (b *RedisCacheOptionsBuilder) SetBloomFallback(fallback bool) *RedisCacheOptionsBuilder {
+	b.Opts = append(b.Opts, func(o *RedisCacheOptions) error {
+		o.BloomFallback = fallback
+		return nil
+	})
+	return b
+}
+
+// SetReconnectJitter configures the window of the randomized delay applied before redialing once a
+// connection loss has been observed. When Redis fails over, every instance notices at the same
+// moment; spreading their reconnects over the window keeps them from overwhelming the new master.
+//
+// The delay is only applied while the cache is reconnecting: the initial connection and normal pool
+// growth dial immediately. The default is DefaultReconnectJitter; pass 0 to disable the jitter.
+//
+// Parameters:
+//   - window: Upper bound of the uniformly distributed reconnect delay
+//
+// Returns:
+//   - *RedisCacheOptionsBuilder: The builder instance for method chaining
+func (b *RedisCacheOptionsBuilder) SetReconnectJitter(window time.Duration) *RedisCacheOptionsBuilder {
+	b.Opts = append(b.Opts, func(o *RedisCacheOptions) error {
+		o.ReconnectJitter = window
+		return nil
+	})
+	return b
+}
+
+// SetLegacyCommands configures whether methods relying on commands or command options added in
+// recent Redis versions run a Lua emulation instead. Enable it when the server is older than the
+// version a method documents as its requirement; the emulations run atomically on any Redis that
+// supports scripting, at the cost of slightly more server-side work. DelWithPattern, which needs
+// UNLINK (Redis 4+), removes all matching keys with a single DEL instead.
+//
+// Parameters:
+//   - legacy: true to use the Lua emulations, false to use the native commands
+//
+// Returns:
+//   - *RedisCacheOptionsBuilder: The builder instance for method chaining
+func (b *RedisCacheOptionsBuilder) SetLegacyCommands(legacy bool) *RedisCacheOptionsBuilder {
+	b.Opts = append(b.Opts, func(o *RedisCacheOptions) error {
+		o.LegacyCommands = legacy
+		return nil
+	})
+	return b
+}
+
+// SetDelWithPatternDisabled configures whether pattern-wide deletes and expirations are
+// hard-disabled. When disabled, DelWithPattern, DelWithPatternCount, ExpirePattern,
+// ReapStaleLocks and Purge return ErrOperationDisabled without touching Redis, and so do
+// client-side helpers that check CheckPatternDelete, such as bulk.DelWhere. Callers must delete
+// explicit key lists with Del instead. ExtendTTLPattern, which only lengthens TTLs, stays
+// available.
+//
+// This is a safety guard for production builds, not a permission model: it protects against
+// accidents in code using this cache instance, not against other clients of the same server.
+//
+// Parameters:
+//   - disabled: true to reject pattern-based deletes
+//
+// Returns:
+//   - *RedisCacheOptionsBuilder: The builder instance for method chaining
+func (b *RedisCacheOptionsBuilder) SetDelWithPatternDisabled(disabled bool) *RedisCacheOptionsBuilder {
+	b.Opts = append(b.Opts, func(o *RedisCacheOptions) error {
+		o.DelWithPatternDisabled = disabled
+		return nil
+	})
+	return b
+}
+
+// SetConfigSetEnabled configures whether ConfigSet may change the server configuration at runtime.
+// It is disabled by default, in which case ConfigSet returns ErrOperationDisabled without touching
+// Redis: a wrong maxmemory or maxmemory-policy affects every client of the server, so only caches
+// built for admin tooling should enable it. Prefer SetConfigAllowlist, which limits the parameters
+// that can be changed and takes precedence over this option. ConfigGet is always available.
+//
+// Parameters:
+//   - enabled: true to allow CONFIG SET
+//
+// Returns:
+//   - *RedisCacheOptionsBuilder: The builder instance for method chaining
+func (b *RedisCacheOptionsBuilder) SetConfigSetEnabled(enabled bool) *RedisCacheOptionsBuilder {
+	b.Opts = append(b.Opts, func(o *RedisCacheOptions) error {
+		o.ConfigSetEnabled = enabled
+		return nil
+	})
+	return b
+}
+
+// SetAuditStream enables the audit trail of destructive operations. Every successful Del,
+// DelWithPattern, ExtendTTLPattern, and ReapStaleLocks appends an entry to the Redis stream named
+// stream, even when no key was affected, so "who deleted these entries and when" can be answered
+// later with XRANGE or XREVRANGE. A pattern-based operation interrupted by an error is recorded
+// with the number of keys it affected before failing.
+//
+// Each entry holds the following fields:
+//   - ts: Unix time of the operation in milliseconds
+//   - op: One of the AuditOp constants
+//   - keys: JSON array of the deleted keys, for Del
+//   - pattern: The pattern, for the pattern-based operations
+//   - count: Number of keys affected
+//   - actor: The caller set with WithAuditActor, or empty
+//
+// The stream is capped to maxLen entries with XADD MAXLEN, trimming the oldest entries first.
+// Writing the entry costs one extra round trip per audited operation. A failed write never fails
+// the audited operation; it is reported to the callback set with SetAuditErrorCallback.
+//
+// Parameters:
+//   - stream: Name of the audit stream
+//   - maxLen: Maximum number of entries kept, must be positive
+//
+// Returns:
+//   - *RedisCacheOptionsBuilder: The builder instance for method chaining
+func (b *RedisCacheOptionsBuilder) SetAuditStream(stream string, maxLen int64) *RedisCacheOptionsBuilder {
+	b.Opts = append(b.Opts, func(o *RedisCacheOptions) error {
+		if stream == "" {
+			return errors.New("redis: audit stream name must not be empty")
+		}
+		if maxLen <= 0 {
+			return errors.New("redis: audit stream length must be positive")
+		}
+		o.AuditStream = stream
+		o.AuditMaxLen = maxLen
+		return nil
+	})
+	return b
+}
+
+// SetAuditErrorCallback configures the function receiving the errors raised while writing audit
+// entries, e.g. to log them or increment a metric. Panics raised by the callback are recovered
+// and discarded.
+//
+// Parameters:
+//   - callback: Function receiving the audited operation and the error, or nil to ignore errors
+//
+// Returns:
+//   - *RedisCacheOptionsBuilder: The builder instance for method chaining
+func (b *RedisCacheOptionsBuilder) SetAuditErrorCallback(callback AuditErrorFunc) *RedisCacheOptionsBuilder {
+	b.Opts = append(b.Opts, func(o *RedisCacheOptions) error {
+		o.AuditErrorCallback = callback
+		return nil
+	})
+	return b
+}
+
+// SetConfigAllowlist restricts ConfigSet to the listed configuration parameters and enables it for
+// them. Changing any other parameter fails with ErrConfigNotAllowed without touching Redis, which
+// lets operational jobs adjust a few well-understood settings without being able to change
+// arbitrary configuration. The allowlist takes precedence over SetConfigSetEnabled. Parameter
+// names are compared case-insensitively.
+//
+// Parameters:
+//   - parameters: Names of the parameters ConfigSet may change, e.g. "notify-keyspace-events"
+//
+// Returns:
+//   - *RedisCacheOptionsBuilder: The builder instance for method chaining
+func (b *RedisCacheOptionsBuilder) SetConfigAllowlist(parameters ...string) *RedisCacheOptionsBuilder {
+	b.Opts = append(b.Opts, func(o *RedisCacheOptions) error {
+		o.ConfigAllowlist = append([]string(nil), parameters...)
+		return nil
+	})
+	return b
+}
+
+// SetServerTimeCallback configures a callback receiving the execution time Redis measured for each
+// command, which separates server time from the network and client time seen by the caller.
+//
+// The time is read from SLOWLOG after every command, so it comes with constraints:
+//   - Overhead: every command costs an extra SLOWLOG GET round trip; enable it for diagnosis, not
+//     permanently on hot paths
+//   - Availability: only commands slower than the server's slowlog-log-slower-than threshold are
+//     logged and reported; set it to 0 to report every command
+//   - Pipelined and transactional commands are not reported
+//   - Servers or proxies that disable SLOWLOG (some managed offerings) never report anything
+//
+// Panics raised by the callback are recovered and discarded.
+//
+// Parameters:
+//   - callback: Function receiving the command name and its server-side duration, or nil to disable
+//
+// Returns:
+//   - *RedisCacheOptionsBuilder: The builder instance for method chaining
+func (b *RedisCacheOptionsBuilder) SetServerTimeCallback(callback ServerTimeFunc) *RedisCacheOptionsBuilder {
+	b.Opts = append(b.Opts, func(o *RedisCacheOptions) error {
+		o.ServerTimeCallback = callback
+		return nil
+	})
+	return b
+}
+
+// SetNilValueDeletes configures what Set, SetWithExpiration and SetAndPublish, and the writes
+// queued in Tx and Pipeline, do with nil values: a nil interface, a nil pointer, or a nil slice,
+// map, channel, or function. Conditional and multi-key writes, such as SetNX, SetXX, MSet, GetSet,
+// RotateKeepTTL and Lease.Fulfill, reject nil values with ErrNilValue in both modes.
+//
+// Modes:
+//   - false (default): the write fails with ErrNilValue and the key is left untouched, instead of
+//     silently storing the client's formatting of nil
+//   - true: the write deletes the key, so Set(ctx, key, nil) means "no value" and a later Get
+//     returns cache.ErrCacheNil
+//
+// Parameters:
+//   - deletes: true to delete keys written with nil values, false to reject such writes
+//
+// Returns:
+//   - *RedisCacheOptionsBuilder: The builder instance for method chaining
+func (b *RedisCacheOptionsBuilder) SetNilValueDeletes(deletes bool) *RedisCacheOptionsBuilder {
+	b.Opts = append(b.Opts, func(o *RedisCacheOptions) error {
+		o.NilValueDeletes = deletes
+		return nil
+	})
+	return b
+}
+
+// SetMaxLockTTL configures the longest TTL a healthy lock is ever given. ReapStaleLocks only
+// reaps idle locks without a TTL or with a remaining TTL above it, since those were set by a
+// faulty holder; locks within it are left to expire on their own. The default of 0 reaps idle
+// locks without a TTL only.
+//
+// Parameters:
+//   - maxTTL: Longest TTL of a healthy lock, must not be negative
+//
+// Returns:
+//   - *RedisCacheOptionsBuilder: The builder instance for method chaining
+func (b *RedisCacheOptionsBuilder) SetMaxLockTTL(maxTTL time.Duration) *RedisCacheOptionsBuilder {
+	b.Opts = append(b.Opts, func(o *RedisCacheOptions) error {
+		if maxTTL < 0 {
+			return errors.New("redis: max lock TTL must not be negative")
+		}
+		o.MaxLockTTL = maxTTL
+		return nil
+	})
+	return b
+}
+
+// SetScanCount configures the COUNT hint of the SCAN calls Keys iterates with, DefaultScanCount
+// by default. A larger count needs fewer round trips but makes each SCAN call run longer.
+//
+// Parameters:
+//   - count: Number of keys each SCAN call is asked to examine, must be positive
+//
+// Returns:
+//   - *RedisCacheOptionsBuilder: The builder instance for method chaining
+func (b *RedisCacheOptionsBuilder) SetScanCount(count int64) *RedisCacheOptionsBuilder {
+	b.Opts = append(b.Opts, func(o *RedisCacheOptions) error {
+		if count <= 0 {
+			return errors.New("redis: scan count must be positive")
+		}
+		o.ScanCount = count
+		return nil
+	})
+	return b
+}
+
+// SetCloseClient configures whether Close on a cache built with NewRedisCacheFromClient also
+// closes the client it wraps. By default it doesn't, since the client is usually shared by other
+// cache views; enable it on the one view that owns the client. Caches built with NewRedisCache
+// always close the client they created.
+//
+// Parameters:
+//   - closeClient: true to close the wrapped client on Close
+//
+// Returns:
+//   - *RedisCacheOptionsBuilder: The builder instance for method chaining
+func (b *RedisCacheOptionsBuilder) SetCloseClient(closeClient bool) *RedisCacheOptionsBuilder {
+	b.Opts = append(b.Opts, func(o *RedisCacheOptions) error {
+		o.CloseClient = closeClient
+		return nil
+	})
+	return b
+}
+
+// SetRequirePrimary configures whether construction checks, with the ROLE command, that the
+// server is a primary, failing with ErrReadOnlyReplica when it is a replica. Enable it for caches
+// that write, so that a writer pointed at a read replica fails at startup rather than on every
+// write. Without it, writes to a replica fail with a *ReadOnlyError matching ErrReadOnlyReplica.
+// The default of false skips the check, which costs one round trip.
+//
+// Servers where ROLE is denied or renamed fail construction with a *PermissionError.
+//
+// Parameters:
+//   - requirePrimary: true to fail construction against a replica
+//
+// Returns:
+//   - *RedisCacheOptionsBuilder: The builder instance for method chaining
+func (b *RedisCacheOptionsBuilder) SetRequirePrimary(requirePrimary bool) *RedisCacheOptionsBuilder {
+	b.Opts = append(b.Opts, func(o *RedisCacheOptions) error {
+		o.RequirePrimary = requirePrimary
+		return nil
+	})
+	return b
+}
+
+// SetExpirePatternPause configures a pause ExpirePattern takes after each SCAN batch, throttling
+// it so that refreshing a large set of keys doesn't compete with regular traffic. Together with
+// SetScanCount, which sizes the batches, it bounds the rate at which keys are updated. The
+// default of 0 doesn't pause.
+//
+// Parameters:
+//   - pause: Pause between batches, must not be negative
+//
+// Returns:
+//   - *RedisCacheOptionsBuilder: The builder instance for method chaining
+func (b *RedisCacheOptionsBuilder) SetExpirePatternPause(pause time.Duration) *RedisCacheOptionsBuilder {
+	b.Opts = append(b.Opts, func(o *RedisCacheOptions) error {
+		if pause < 0 {
+			return errors.New("redis: expire pattern pause must not be negative")
+		}
+		o.ExpirePatternPause = pause
+		return nil
+	})
+	return b
+}
+
+// SetDelBatchSize configures how many keys DelWithPattern removes per UNLINK call,
+// DefaultDelBatchSize by default. Larger batches need fewer round trips, smaller ones keep each
+// call short and let a cancelled context stop the delete sooner.
+//
+// Parameters:
+//   - size: Number of keys per batch, must be positive
+//
+// Returns:
+//   - *RedisCacheOptionsBuilder: The builder instance for method chaining
+func (b *RedisCacheOptionsBuilder) SetDelBatchSize(size int64) *RedisCacheOptionsBuilder {
+	b.Opts = append(b.Opts, func(o *RedisCacheOptions) error {
+		if size <= 0 {
+			return errors.New("redis: delete batch size must be positive")
+		}
+		o.DelBatchSize = size
+		return nil
+	})
+	return b
+}
+
+// SetSoftDelete configures Del and DelWithPattern to move keys aside instead of deleting them,
+// giving operators an undo window after a bad delete. A soft-deleted key is renamed to a
+// tombstone that expires after retention; reads of the key miss as for a deleted key, Restore
+// moves tombstones back, and Purge removes them for good. Deletes queued in Tx and Pipeline
+// create tombstones as well; HardDel always deletes immediately.
+// The default of 0 deletes keys for good.
+//
+// Parameters:
+//   - retention: How long tombstones are kept, 0 to disable soft deletes or at least a millisecond,
+//     the resolution of the tombstone expirations
+//
+// Returns:
+//   - *RedisCacheOptionsBuilder: The builder instance for method chaining
+func (b *RedisCacheOptionsBuilder) SetSoftDelete(retention time.Duration) *RedisCacheOptionsBuilder {
+	b.Opts = append(b.Opts, func(o *RedisCacheOptions) error {
+		if retention < 0 || (retention > 0 && retention < time.Millisecond) {
+			return errors.New("redis: soft delete retention must be 0 or at least a millisecond")
+		}
+		o.SoftDeleteRetention = retention
+		return nil
+	})
+	return b
+}
+
+// SetTombstonePrefix configures the prefix of the keys holding tombstones, DefaultTombstonePrefix
+// by default. Soft-deleted keys are stored under prefix + "data:" + key, and their TTL under
+// prefix + "ttl:" + key.
+//
+// Parameters:
+//   - prefix: Tombstone key prefix, must not be empty or contain glob metacharacters
+//
+// Returns:
+//   - *RedisCacheOptionsBuilder: The builder instance for method chaining
+func (b *RedisCacheOptionsBuilder) SetTombstonePrefix(prefix string) *RedisCacheOptionsBuilder {
+	b.Opts = append(b.Opts, func(o *RedisCacheOptions) error {
+		if prefix == "" || strings.ContainsAny(prefix, `*?[]\`) {
+			return errors.New("redis: tombstone prefix must be non-empty and free of glob metacharacters")
+		}
+		o.TombstonePrefix = prefix
+		return nil
+	})
+	return b
+}
+
+// List returns the slice of option functions accumulated by the builder.
+// This method implements the builderutil.Lister interface, allowing the builder
+// to be used with the builderutil.Build function.
+//
+// Returns:
+//   - []func(*RedisCacheOptions) error: A slice of option functions that can be applied to configure RedisCacheOptions
+func (b *RedisCacheOptionsBuilder) List() []func(*RedisCacheOptions) error {
+	return b.Opts
+}
+
+// NewRedisCacheOptions creates and returns a new instance of RedisCacheOptionsBuilder.
+// This function provides a convenient way to initialize the builder passed to NewRedisCache.
+//
+// Returns:
+//   - *RedisCacheOptionsBuilder: A new instance of RedisCacheOptionsBuilder ready to be configured
+//
+// Example:
+//
+//	analytics, err := redis.NewRedisCache(config, redis.NewRedisCacheOptions().SetNoTouch(true))
+func NewRedisCacheOptions() *RedisCacheOptionsBuilder {
+	return &RedisCacheOptionsBuilder{}
+}
+
+// defaultRedisCacheOptions returns the builder holding the package defaults.
+// NewRedisCache applies it before any caller-supplied builders.
+func defaultRedisCacheOptions() builderutil.Lister[RedisCacheOptions] {
+	return NewRedisCacheOptions().
+		SetReconnectJitter(DefaultReconnectJitter).
+		SetScanCount(DefaultScanCount).
+		SetDelBatchSize(DefaultDelBatchSize).
+		SetTombstonePrefix(DefaultTombstonePrefix)
+}
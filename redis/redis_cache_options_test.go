@@ -0,0 +1,227 @@
+package redis_test
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+	"github.com/zeroxsolutions/alex"
+	"github.com/zeroxsolutions/banshee/redis"
+	"github.com/zeroxsolutions/barbatos/cache"
+	"github.com/zeroxsolutions/strike/ssutil"
+)
+
+// TestRedisCache_NoTouch verifies that reads issued through a NO-TOUCH cache do not reset
+// the idle time of the keys they access. CLIENT NO-TOUCH needs Redis 7.2+, so the test only
+// runs when REDIS_NO_TOUCH is set.
+func TestRedisCache_NoTouch(t *testing.T) {
+	if os.Getenv("REDIS_NO_TOUCH") == "" {
+		t.Skip("REDIS_NO_TOUCH not set; skipping CLIENT NO-TOUCH test")
+	}
+
+	redisCache := initRedisCache(t)
+
+	defer func(redisCache cache.Cache) {
+		if err := redisCache.Close(); err != nil {
+			t.Log("Close Redis cache connection err", err)
+		}
+	}(redisCache)
+
+	analyticsCache := initRedisCache(t, redis.NewRedisCacheOptions().SetNoTouch(true))
+
+	defer func(analyticsCache cache.Cache) {
+		if err := analyticsCache.Close(); err != nil {
+			t.Log("Close Redis cache connection err", err)
+		}
+	}(analyticsCache)
+
+	inspector := goredis.NewClient(&goredis.Options{Addr: os.Getenv("REDIS_ADDRESS"), Password: os.Getenv("REDIS_PASSWORD")})
+	defer inspector.Close()
+
+	key := ssutil.MakeString(10)
+
+	if err := redisCache.Set(context.Background(), key, ssutil.MakeString(12)); err != nil {
+		t.Fatal(err)
+	}
+
+	time.Sleep(2 * time.Second)
+
+	if _, err := analyticsCache.Get(context.Background(), key); err != nil {
+		t.Fatal(err)
+	}
+
+	idle, err := inspector.ObjectIdleTime(context.Background(), key).Result()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if idle < 2*time.Second {
+		t.Log("idle time was reset to", idle)
+		t.FailNow()
+	}
+
+	if err := redisCache.Del(context.Background(), key); err != nil {
+		t.Error(err)
+	}
+}
+
+// TestRedisCache_DelWithPatternDisabled verifies that a cache built with pattern deletes disabled
+// rejects DelWithPattern and the other pattern-wide operations while explicit Del keeps working.
+func TestRedisCache_DelWithPatternDisabled(t *testing.T) {
+	redisCache := initRedisCache(t, redis.NewRedisCacheOptions().SetDelWithPatternDisabled(true))
+
+	defer func(redisCache cache.Cache) {
+		if err := redisCache.Close(); err != nil {
+			t.Log("Close Redis cache connection err", err)
+		}
+	}(redisCache)
+
+	key := "key" + ssutil.MakeString(10)
+
+	if err := redisCache.Set(context.Background(), key, ssutil.MakeString(12)); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := redisCache.DelWithPattern(context.Background(), "key*"); err != redis.ErrOperationDisabled {
+		t.Log(err)
+		t.FailNow()
+	}
+
+	guarded := redisCache.(*redis.RedisCache)
+
+	if _, err := guarded.ExpirePattern(context.Background(), "key*", time.Millisecond); err != redis.ErrOperationDisabled {
+		t.Log("ExpirePattern:", err)
+		t.FailNow()
+	}
+
+	if _, err := guarded.ExtendTTLPattern(context.Background(), key, time.Hour); err != nil {
+		t.Log("ExtendTTLPattern should stay available:", err)
+		t.FailNow()
+	}
+
+	if _, err := guarded.ReapStaleLocks(context.Background(), "key", time.Second); err != redis.ErrOperationDisabled {
+		t.Log("ReapStaleLocks:", err)
+		t.FailNow()
+	}
+
+	if _, err := guarded.Purge(context.Background(), "key*"); err != redis.ErrOperationDisabled {
+		t.Log("Purge:", err)
+		t.FailNow()
+	}
+
+	if _, err := redisCache.Get(context.Background(), key); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := redisCache.Del(context.Background(), key); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := redisCache.Get(context.Background(), key); err != cache.ErrCacheNil {
+		t.Log(err)
+		t.FailNow()
+	}
+}
+
+// TestRedisCache_UnixSocket verifies that the cache connects through a Unix domain socket.
+// The test only runs when REDIS_SOCKET holds the socket path.
+func TestRedisCache_UnixSocket(t *testing.T) {
+	socket := os.Getenv("REDIS_SOCKET")
+	if socket == "" {
+		t.Skip("REDIS_SOCKET not set; skipping Unix socket test")
+	}
+
+	redisCache, err := redis.NewRedisCache(
+		&alex.RedisConfig{Addr: socket, Password: os.Getenv("REDIS_PASSWORD")},
+		redis.NewRedisCacheOptions().SetNetwork("unix"),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer func(redisCache cache.Cache) {
+		if err := redisCache.Close(); err != nil {
+			t.Log("Close Redis cache connection err", err)
+		}
+	}(redisCache)
+
+	if isConnected := redisCache.IsConnected(context.Background()); !isConnected {
+		t.FailNow()
+	}
+}
+
+// TestRedisCache_InvalidNetwork verifies that unsupported networks are rejected at construction.
+func TestRedisCache_InvalidNetwork(t *testing.T) {
+	_, err := redis.NewRedisCache(
+		&alex.RedisConfig{Addr: os.Getenv("REDIS_ADDRESS")},
+		redis.NewRedisCacheOptions().SetNetwork("udp"),
+	)
+	if err == nil {
+		t.FailNow()
+	}
+}
+
+// TestRedisCache_InvalidSoftDelete verifies that negative or sub-millisecond retentions and
+// tombstone prefixes with glob metacharacters are rejected at construction.
+func TestRedisCache_InvalidSoftDelete(t *testing.T) {
+	for _, opts := range []*redis.RedisCacheOptionsBuilder{
+		redis.NewRedisCacheOptions().SetSoftDelete(-time.Second),
+		redis.NewRedisCacheOptions().SetSoftDelete(time.Microsecond),
+		redis.NewRedisCacheOptions().SetTombstonePrefix(""),
+		redis.NewRedisCacheOptions().SetTombstonePrefix("tomb*:"),
+	} {
+		if _, err := redis.NewRedisCache(&alex.RedisConfig{Addr: os.Getenv("REDIS_ADDRESS")}, opts); err == nil {
+			t.FailNow()
+		}
+	}
+}
+
+// TestRedisCache_InvalidScanCount verifies that non-positive SCAN counts are rejected at
+// construction.
+func TestRedisCache_InvalidScanCount(t *testing.T) {
+	_, err := redis.NewRedisCache(
+		&alex.RedisConfig{Addr: os.Getenv("REDIS_ADDRESS")},
+		redis.NewRedisCacheOptions().SetScanCount(0),
+	)
+	if err == nil {
+		t.FailNow()
+	}
+}
+
+// TestRedisCache_InvalidDelBatchSize verifies that non-positive delete batch sizes are rejected at
+// construction.
+func TestRedisCache_InvalidDelBatchSize(t *testing.T) {
+	_, err := redis.NewRedisCache(
+		&alex.RedisConfig{Addr: os.Getenv("REDIS_ADDRESS")},
+		redis.NewRedisCacheOptions().SetDelBatchSize(0),
+	)
+	if err == nil {
+		t.FailNow()
+	}
+}
+
+// TestRedisCache_InvalidExpirePatternPause verifies that negative pauses are rejected at
+// construction.
+func TestRedisCache_InvalidExpirePatternPause(t *testing.T) {
+	_, err := redis.NewRedisCache(
+		&alex.RedisConfig{Addr: os.Getenv("REDIS_ADDRESS")},
+		redis.NewRedisCacheOptions().SetExpirePatternPause(-time.Second),
+	)
+	if err == nil {
+		t.FailNow()
+	}
+}
+
+// TestRedisCache_InvalidMaxLockTTL verifies that negative maximum lock TTLs are rejected at
+// construction.
+func TestRedisCache_InvalidMaxLockTTL(t *testing.T) {
+	_, err := redis.NewRedisCache(
+		&alex.RedisConfig{Addr: os.Getenv("REDIS_ADDRESS")},
+		redis.NewRedisCacheOptions().SetMaxLockTTL(-time.Second),
+	)
+	if err == nil {
+		t.FailNow()
+	}
+}
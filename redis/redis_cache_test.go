@@ -10,16 +10,18 @@ import (
 	"testing"
 	"time"
 
+	goredis "github.com/redis/go-redis/v9"
 	"github.com/zeroxsolutions/alex"
 	"github.com/zeroxsolutions/banshee/redis"
 	"github.com/zeroxsolutions/barbatos/cache"
+	"github.com/zeroxsolutions/strike/builderutil"
 	"github.com/zeroxsolutions/strike/ssutil"
 )
 
 // initRedisCache initializes a Redis cache instance using environment variables
-// and returns a cache.Cache implementation. It will terminate the test if configuration
-// fails.
-func initRedisCache(t *testing.T) cache.Cache {
+// and returns a cache.Cache implementation. Optional RedisCacheOptions builders are
+// forwarded to the constructor. It will terminate the test if configuration fails.
+func initRedisCache(t *testing.T, opts ...builderutil.Lister[redis.RedisCacheOptions]) cache.Cache {
 	addr := os.Getenv("REDIS_ADDRESS")
 	password := os.Getenv("REDIS_PASSWORD")
 	dbRaw := os.Getenv("REDIS_DB")
@@ -42,13 +44,31 @@ func initRedisCache(t *testing.T) cache.Cache {
 	}
 
 	// Create a Redis cache instance, terminating the test on error.
-	redisCache, err := redis.NewRedisCache(&redisCacheConfig)
+	redisCache, err := redis.NewRedisCache(&redisCacheConfig, opts...)
 	if err != nil {
 		t.Fatal(err)
 	}
 	return redisCache
 }
 
+// initRedisClient creates a plain go-redis client for the same server and database as
+// initRedisCache, letting tests inspect state the cache API doesn't expose.
+func initRedisClient(t *testing.T) *goredis.Client {
+	db := 0
+	if dbRaw := os.Getenv("REDIS_DB"); dbRaw != "" {
+		dbConverted, err := strconv.Atoi(dbRaw)
+		if err != nil {
+			t.Fatal(err)
+		}
+		db = dbConverted
+	}
+	return goredis.NewClient(&goredis.Options{
+		Addr:     os.Getenv("REDIS_ADDRESS"),
+		Password: os.Getenv("REDIS_PASSWORD"),
+		DB:       db,
+	})
+}
+
 // TestRedisCache groups multiple test cases to validate Redis cache behavior.
 func TestRedisCache(t *testing.T) {
 
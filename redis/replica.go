@@ -0,0 +1,56 @@
+package redis
+
+import (
+	"context"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// readOnlyHook is a go-redis hook turning the READONLY errors of a replica into *ReadOnlyError,
+// on the command itself so that Result and Err report it too.
+type readOnlyHook struct{}
+
+// DialHook passes dials through unchanged.
+func (readOnlyHook) DialHook(next redis.DialHook) redis.DialHook {
+	return next
+}
+
+// ProcessHook runs the command and classifies its error.
+func (readOnlyHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		err := next(ctx, cmd)
+		classifyReadOnly(cmd)
+		return readOnlyError(err)
+	}
+}
+
+// ProcessPipelineHook runs the pipeline and classifies the error of each command.
+func (readOnlyHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		err := next(ctx, cmds)
+		for _, cmd := range cmds {
+			classifyReadOnly(cmd)
+		}
+		return readOnlyError(err)
+	}
+}
+
+// classifyReadOnly replaces the error of cmd with a *ReadOnlyError when it is a READONLY error.
+func classifyReadOnly(cmd redis.Cmder) {
+	if err := cmd.Err(); err != nil {
+		cmd.SetErr(readOnlyError(err))
+	}
+}
+
+// checkPrimary returns ErrReadOnlyReplica when ROLE reports that the server is a replica. The
+// first element of the ROLE reply is "master", "slave" or "sentinel".
+func checkPrimary(ctx context.Context, client *redis.Client) error {
+	role, err := client.Do(ctx, "ROLE").Slice()
+	if err != nil {
+		return permissionError("ROLE", err)
+	}
+	if len(role) > 0 && role[0] == "slave" {
+		return ErrReadOnlyReplica
+	}
+	return nil
+}
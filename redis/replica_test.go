@@ -0,0 +1,53 @@
+package redis_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/zeroxsolutions/alex"
+	"github.com/zeroxsolutions/banshee/redis"
+	"github.com/zeroxsolutions/strike/ssutil"
+)
+
+// TestRedisCache_ReadOnlyReplica points a writer at a replica and verifies that writes fail with
+// ErrReadOnlyReplica and that SetRequirePrimary rejects the replica at construction. The test
+// only runs when REDIS_REPLICA_ADDRESS is set to the address of a replica of REDIS_ADDRESS.
+func TestRedisCache_ReadOnlyReplica(t *testing.T) {
+	replica := os.Getenv("REDIS_REPLICA_ADDRESS")
+	if replica == "" {
+		t.Skip("REDIS_REPLICA_ADDRESS not set; skipping read-only replica test")
+	}
+	config := &alex.RedisConfig{Addr: replica, Password: os.Getenv("REDIS_PASSWORD")}
+
+	redisCache, err := redis.NewRedisCache(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer redisCache.Close()
+
+	ctx := context.Background()
+	key := ssutil.MakeString(10)
+
+	err = redisCache.Set(ctx, key, "value")
+	var readOnly *redis.ReadOnlyError
+	if !errors.Is(err, redis.ErrReadOnlyReplica) || !errors.As(err, &readOnly) {
+		t.Fatal("a write to a replica should return ErrReadOnlyReplica:", err)
+	}
+
+	if _, err := redis.NewRedisCache(config, redis.NewRedisCacheOptions().SetRequirePrimary(true)); !errors.Is(err, redis.ErrReadOnlyReplica) {
+		t.Fatal("a replica should be rejected when a primary is required:", err)
+	}
+
+	primary, err := redis.NewRedisCache(
+		&alex.RedisConfig{Addr: os.Getenv("REDIS_ADDRESS"), Password: os.Getenv("REDIS_PASSWORD")},
+		redis.NewRedisCacheOptions().SetRequirePrimary(true),
+	)
+	if err != nil {
+		t.Fatal("the primary should be accepted:", err)
+	}
+	if err := primary.Close(); err != nil {
+		t.Log("Close Redis cache connection err", err)
+	}
+}
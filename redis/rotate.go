@@ -0,0 +1,71 @@
+package redis
+
+import (
+	"context"
+	"errors"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/zeroxsolutions/banshee"
+	"github.com/zeroxsolutions/banshee/internal/valueutil"
+	"github.com/zeroxsolutions/barbatos/cache"
+)
+
+var _ banshee.RotateCache = (*RedisCache)(nil)
+
+// rotateScript replaces a value and reapplies the TTL it had.
+//
+// KEYS[1] = key, ARGV[1] = new value.
+// Returns the previous value, or nil when the key doesn't exist.
+var rotateScript = redis.NewScript(`
+local old = redis.call('GET', KEYS[1])
+if not old then
+	return false
+end
+local ttl = redis.call('PTTL', KEYS[1])
+if ttl > 0 then
+	redis.call('SET', KEYS[1], ARGV[1], 'PX', ttl)
+else
+	redis.call('SET', KEYS[1], ARGV[1])
+end
+return old
+`)
+
+// RotateKeepTTL atomically replaces the value stored under key with newValue and returns the
+// previous value, keeping the key's remaining TTL. Unlike GETSET, which makes the key persistent,
+// the rotated value expires when the previous one would have, e.g. at the end of an API key's
+// validity window.
+//
+// Behavior:
+//   - A key with a TTL keeps its remaining TTL, to the millisecond
+//   - A key without a TTL stays persistent
+//   - A missing key is left missing: nothing is stored and cache.ErrCacheNil is returned
+//   - A nil newValue fails with ErrNilValue, whatever SetNilValueDeletes says
+//
+// Parameters:
+//   - ctx: Context for timeout control and request cancellation
+//   - key: Redis key to rotate
+//   - newValue: Value replacing the current one
+//
+// Returns:
+//   - string: The value that was replaced
+//   - error: cache.ErrCacheNil if the key doesn't exist, ErrNilValue, or a Redis error
+//
+// Example:
+//
+//	oldKey, err := cache.RotateKeepTTL(ctx, "apikey:tenant:42", newKey)
+//	if err == nil {
+//	    revoke(oldKey)
+//	}
+func (r *RedisCache) RotateKeepTTL(ctx context.Context, key string, newValue interface{}) (string, error) {
+	if valueutil.IsNil(newValue) {
+		return "", ErrNilValue
+	}
+	old, err := rotateScript.Run(ctx, r.client, []string{key}, newValue).Text()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return "", cache.ErrCacheNil
+		}
+		return "", err
+	}
+	return old, nil
+}
@@ -0,0 +1,88 @@
+package redis_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/zeroxsolutions/banshee/redis"
+	"github.com/zeroxsolutions/barbatos/cache"
+	"github.com/zeroxsolutions/strike/ssutil"
+)
+
+// TestRedisCache_RotateKeepTTL verifies that rotation returns the previous value, stores the new
+// one and preserves the TTL, and that missing keys are left untouched.
+func TestRedisCache_RotateKeepTTL(t *testing.T) {
+	redisCache := initRedisCache(t)
+
+	defer func(redisCache cache.Cache) {
+		if err := redisCache.Close(); err != nil {
+			t.Log("Close Redis cache connection err", err)
+		}
+	}(redisCache)
+
+	client := initRedisClient(t)
+	defer client.Close()
+
+	rotate := redisCache.(*redis.RedisCache)
+
+	ctx := context.Background()
+	key := ssutil.MakeString(10)
+	persistent := ssutil.MakeString(10)
+	missing := ssutil.MakeString(10)
+
+	defer func() {
+		if err := rotate.Del(ctx, key, persistent, missing); err != nil {
+			t.Error(err)
+		}
+	}()
+
+	t.Run("KeepTTL", func(t *testing.T) {
+		if err := rotate.SetWithExpiration(ctx, key, "old", time.Minute); err != nil {
+			t.Fatal(err)
+		}
+
+		old, err := rotate.RotateKeepTTL(ctx, key, "new")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if old != "old" {
+			t.Log("unexpected previous value:", old)
+			t.FailNow()
+		}
+		if v, err := rotate.Get(ctx, key); err != nil || v != "new" {
+			t.Fatal(v, err)
+		}
+		ttl, err := client.PTTL(ctx, key).Result()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ttl < 59*time.Second || ttl > time.Minute {
+			t.Log("unexpected TTL:", ttl)
+			t.FailNow()
+		}
+	})
+
+	t.Run("Persistent", func(t *testing.T) {
+		if err := rotate.Set(ctx, persistent, "old"); err != nil {
+			t.Fatal(err)
+		}
+
+		if old, err := rotate.RotateKeepTTL(ctx, persistent, "new"); err != nil || old != "old" {
+			t.Fatal(old, err)
+		}
+		if ttl, err := client.PTTL(ctx, persistent).Result(); err != nil || ttl != -1*time.Nanosecond {
+			t.Fatal(ttl, err)
+		}
+	})
+
+	t.Run("Missing", func(t *testing.T) {
+		if _, err := rotate.RotateKeepTTL(ctx, missing, "new"); !errors.Is(err, cache.ErrCacheNil) {
+			t.Fatal(err)
+		}
+		if n, err := client.Exists(ctx, missing).Result(); err != nil || n != 0 {
+			t.Fatal(n, err)
+		}
+	})
+}
@@ -0,0 +1,85 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/zeroxsolutions/banshee"
+)
+
+var _ banshee.KeySampler = (*RedisCache)(nil)
+
+// sampleKeysRounds is the maximum number of RANDOMKEY batches SampleKeys sends to replace the
+// duplicates of earlier batches.
+const sampleKeysRounds = 3
+
+// SampleKeys returns up to n distinct keys picked at random with RANDOMKEY, e.g. for an audit job
+// estimating the distribution of value sizes and TTLs without scanning the whole keyspace.
+//
+// The RANDOMKEY calls are pipelined, one per missing key, so a sample costs a single round trip
+// unless duplicates have to be replaced. Duplicates are dropped and replaced by up to
+// sampleKeysRounds batches in total, stopping early once a batch finds no new key.
+//
+// The sample is approximate:
+//   - It may hold fewer than n keys, always when the database holds fewer than n keys, and
+//     possibly when most keys were already drawn
+//   - RANDOMKEY picks keys uniformly from the keyspace as a whole, so it can't be restricted to a
+//     prefix, and includes soft-delete tombstones and keys of other caches sharing the database
+//
+// Parameters:
+//   - ctx: Context for timeout control and request cancellation
+//   - n: Maximum number of keys to return, must be positive
+//
+// Returns:
+//   - []string: Distinct keys in the order they were drawn; empty for an empty database
+//   - error: An error if n isn't positive, or a Redis error
+//
+// Example:
+//
+//	keys, err := cache.SampleKeys(ctx, 1000)
+//	for _, key := range keys {
+//	    ttl, _ := cache.TTL(ctx, key)
+//	    // record ttl
+//	}
+func (r *RedisCache) SampleKeys(ctx context.Context, n int) ([]string, error) {
+	if n <= 0 {
+		return nil, fmt.Errorf("redis: SampleKeys n must be positive, got %d", n)
+	}
+	seen := make(map[string]struct{}, n)
+	keys := make([]string, 0, n)
+	for round := 0; round < sampleKeysRounds && len(keys) < n; round++ {
+		cmds := make([]*redis.StringCmd, n-len(keys))
+		_, err := r.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+			for i := range cmds {
+				cmds[i] = pipe.RandomKey(ctx)
+			}
+			return nil
+		})
+		if err != nil && !errors.Is(err, redis.Nil) {
+			return nil, err
+		}
+		found := false
+		for _, cmd := range cmds {
+			key, err := cmd.Result()
+			if errors.Is(err, redis.Nil) {
+				// The database is empty.
+				return keys, nil
+			}
+			if err != nil {
+				return nil, err
+			}
+			if _, ok := seen[key]; ok {
+				continue
+			}
+			seen[key] = struct{}{}
+			keys = append(keys, key)
+			found = true
+		}
+		if !found {
+			break
+		}
+	}
+	return keys, nil
+}
@@ -0,0 +1,71 @@
+package redis_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/zeroxsolutions/banshee/redis"
+	"github.com/zeroxsolutions/barbatos/cache"
+	"github.com/zeroxsolutions/strike/ssutil"
+)
+
+// TestRedisCache_SampleKeys verifies that SampleKeys returns distinct existing keys, never more
+// than requested nor more than the database holds.
+func TestRedisCache_SampleKeys(t *testing.T) {
+	redisCache := initRedisCache(t)
+
+	defer func(redisCache cache.Cache) {
+		if err := redisCache.Close(); err != nil {
+			t.Log("Close Redis cache connection err", err)
+		}
+	}(redisCache)
+
+	client := initRedisClient(t)
+	defer client.Close()
+
+	sampler := redisCache.(*redis.RedisCache)
+
+	ctx := context.Background()
+	prefix := ssutil.MakeString(10)
+
+	defer func() {
+		if err := sampler.DelWithPattern(ctx, prefix+":*"); err != nil {
+			t.Error(err)
+		}
+	}()
+
+	for i := 0; i < 5; i++ {
+		if err := sampler.Set(ctx, prefix+":"+ssutil.MakeString(6), "value"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	keys, err := sampler.SampleKeys(ctx, 3)
+	if err != nil || len(keys) == 0 || len(keys) > 3 {
+		t.Fatal("unexpected sample:", keys, err)
+	}
+
+	seen := make(map[string]bool)
+	for _, key := range keys {
+		if seen[key] {
+			t.Fatal("duplicate key in sample:", key)
+		}
+		seen[key] = true
+		if n, err := client.Exists(ctx, key).Result(); err != nil || n != 1 {
+			t.Fatal("sampled key should exist:", key, err)
+		}
+	}
+
+	size, err := client.DBSize(ctx).Result()
+	if err != nil {
+		t.Fatal(err)
+	}
+	keys, err = sampler.SampleKeys(ctx, int(size)+100)
+	if err != nil || len(keys) == 0 || int64(len(keys)) > size {
+		t.Fatal("a sample can't hold more keys than the database:", len(keys), size, err)
+	}
+
+	if _, err := sampler.SampleKeys(ctx, 0); err == nil {
+		t.Fatal("a non-positive n should be rejected")
+	}
+}
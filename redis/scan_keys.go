@@ -0,0 +1,255 @@
+package redis
+
+import (
+	"context"
+
+	"github.com/zeroxsolutions/banshee"
+)
+
+var _ banshee.KeyWalker = (*RedisCache)(nil)
+
+// DefaultScanCount is the COUNT hint Keys uses when none is configured with SetScanCount.
+const DefaultScanCount = 1000
+
+// ScanKeys returns the keys matching pattern by iterating with SCAN instead of KEYS, so the server
+// keeps serving other clients between batches however large the keyspace is.
+//
+// Behavior:
+//   - Each SCAN call asks for about count keys; the cursor is followed until it returns to 0
+//   - SCAN may report a key more than once; duplicates are removed
+//   - Caches built with SetSoftDelete leave tombstones out
+//   - Keys added or removed during the iteration may or may not be included
+//   - The context is checked between iterations: a cancelled call returns the keys collected so
+//     far together with the context error
+//
+// Parameters:
+//   - ctx: Context for timeout control and request cancellation
+//   - pattern: Glob-style pattern, as accepted by Keys
+//   - count: COUNT hint of each SCAN call; values below 1 let Redis use its default of 10
+//
+// Returns:
+//   - []string: The matching keys, in no particular order
+//   - error: The context error if cancelled, or a Redis error
+//
+// Example:
+//
+//	keys, err := cache.ScanKeys(ctx, "session:*", 1000)
+func (r *RedisCache) ScanKeys(ctx context.Context, pattern string, count int64) ([]string, error) {
+	if count < 1 {
+		count = 0
+	}
+	keys := []string{}
+	seen := map[string]struct{}{}
+	var cursor uint64
+	for {
+		if err := ctx.Err(); err != nil {
+			return keys, err
+		}
+		batch, next, err := r.client.Scan(ctx, cursor, pattern, count).Result()
+		if err != nil {
+			return keys, err
+		}
+		for _, key := range r.liveKeys(batch) {
+			if _, ok := seen[key]; !ok {
+				seen[key] = struct{}{}
+				keys = append(keys, key)
+			}
+		}
+		if next == 0 {
+			return keys, nil
+		}
+		cursor = next
+	}
+}
+
+// KeysIter streams the keys matching pattern over a channel as each SCAN batch arrives, so a
+// keyspace of any size can be processed without holding every key in memory.
+//
+// Behavior:
+//   - A goroutine follows the SCAN cursor with the COUNT hint count, sending every matching key on
+//     the key channel, and closes it once the cursor returns to 0 or an error stops it
+//   - The error channel then receives the terminal error, if any, and is closed: a Redis error,
+//     or the context error when ctx is done before the iteration completes
+//   - SCAN may report a key more than once; keys are not deduplicated, which would take memory
+//     proportional to the keyspace
+//   - Caches built with SetSoftDelete leave tombstones out
+//   - Keys added or removed during the iteration may or may not be included
+//
+// The goroutine blocks until each key is received: consumers must either drain the key channel
+// until it is closed or cancel ctx to stop early, or the goroutine and its connection leak. After
+// cancelling, the error channel still delivers the context error.
+//
+// Parameters:
+//   - ctx: Context whose cancellation stops the iteration
+//   - pattern: Glob-style pattern, as accepted by Keys
+//   - count: COUNT hint of each SCAN call; values below 1 let Redis use its default of 10
+//
+// Returns:
+//   - <-chan string: The matching keys, closed when the iteration ends
+//   - <-chan error: The terminal error, if any, closed after the key channel
+//
+// Example:
+//
+//	ctx, cancel := context.WithCancel(ctx)
+//	defer cancel()
+//	keys, errs := cache.KeysIter(ctx, "session:*", 1000)
+//	for key := range keys {
+//	    process(key)
+//	}
+//	if err := <-errs; err != nil {
+//	    return err
+//	}
+func (r *RedisCache) KeysIter(ctx context.Context, pattern string, count int64) (<-chan string, <-chan error) {
+	if count < 1 {
+		count = 0
+	}
+	keys := make(chan string)
+	errs := make(chan error, 1)
+	go func() {
+		defer close(errs)
+		defer close(keys)
+		var cursor uint64
+		for {
+			if err := ctx.Err(); err != nil {
+				errs <- err
+				return
+			}
+			batch, next, err := r.client.Scan(ctx, cursor, pattern, count).Result()
+			if err != nil {
+				errs <- err
+				return
+			}
+			for _, key := range r.liveKeys(batch) {
+				select {
+				case keys <- key:
+				case <-ctx.Done():
+					errs <- ctx.Err()
+					return
+				}
+			}
+			if next == 0 {
+				return
+			}
+			cursor = next
+		}
+	}()
+	return keys, errs
+}
+
+// KeyIterator walks the keys matching a pattern one SCAN batch at a time, fetching the next batch
+// only when the current one is consumed. It holds no goroutine or connection between calls, so
+// abandoning it early leaks nothing. A KeyIterator is not safe for concurrent use.
+type KeyIterator struct {
+	ctx     context.Context
+	cache   *RedisCache
+	pattern string
+
+	batch  []string
+	key    string
+	cursor uint64
+	done   bool
+	err    error
+}
+
+// ScanKeysIterator returns an iterator over the keys matching pattern, backed by SCAN with the
+// COUNT hint configured with SetScanCount. Memory use is bounded by one batch however many keys
+// match. As with every SCAN, keys may be reported more than once, and keys added or removed
+// during the iteration may or may not be included. Caches built with SetSoftDelete leave
+// tombstones out.
+//
+// Parameters:
+//   - ctx: Context of the SCAN calls; once it is done, Next returns false and Err its error
+//   - pattern: Glob-style pattern, as accepted by Keys
+//
+// Returns:
+//   - *KeyIterator: The iterator, positioned before the first key
+//
+// Example:
+//
+//	it := cache.ScanKeysIterator(ctx, "session:*")
+//	for it.Next() {
+//	    process(it.Key())
+//	}
+//	if err := it.Err(); err != nil {
+//	    return err
+//	}
+func (r *RedisCache) ScanKeysIterator(ctx context.Context, pattern string) *KeyIterator {
+	return &KeyIterator{ctx: ctx, cache: r, pattern: pattern}
+}
+
+// Next advances to the next key, fetching a new batch when needed. It returns false once every
+// key has been visited or an error stopped the iteration; Err tells the two apart.
+func (it *KeyIterator) Next() bool {
+	for len(it.batch) == 0 {
+		if it.done || it.err != nil {
+			return false
+		}
+		if it.err = it.ctx.Err(); it.err != nil {
+			return false
+		}
+		it.batch, it.cursor, it.err = it.cache.client.Scan(it.ctx, it.cursor, it.pattern, it.cache.options.ScanCount).Result()
+		if it.err != nil {
+			return false
+		}
+		it.batch = it.cache.liveKeys(it.batch)
+		it.done = it.cursor == 0
+	}
+	it.key, it.batch = it.batch[0], it.batch[1:]
+	return true
+}
+
+// Key returns the key Next advanced to.
+func (it *KeyIterator) Key() string {
+	return it.key
+}
+
+// Err returns the error that stopped the iteration, or nil if it completed or is still running.
+func (it *KeyIterator) Err() error {
+	return it.err
+}
+
+// WalkKeys calls fn with the keys matching pattern one SCAN batch at a time, using the COUNT hint
+// configured with SetScanCount, so memory use is bounded by one batch however many keys match.
+//
+// Behavior:
+//   - Batches SCAN returns empty are skipped; fn is never called with an empty slice
+//   - SCAN may report a key more than once, and keys added or removed during the walk may or may
+//     not be included
+//   - Caches built with SetSoftDelete leave tombstones out
+//   - The context is checked between batches, and the walk stops at the first error fn returns
+//
+// Parameters:
+//   - ctx: Context for timeout control and request cancellation
+//   - pattern: Glob-style pattern, as accepted by Keys
+//   - fn: Function called with each batch; the slice must not be retained after fn returns
+//
+// Returns:
+//   - error: The context error if cancelled, a Redis error, or the error returned by fn
+//
+// Example:
+//
+//	err := cache.WalkKeys(ctx, "session:*", func(keys []string) error {
+//	    return archive(ctx, keys)
+//	})
+func (r *RedisCache) WalkKeys(ctx context.Context, pattern string, fn func(keys []string) error) error {
+	var cursor uint64
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		keys, next, err := r.client.Scan(ctx, cursor, pattern, r.options.ScanCount).Result()
+		if err != nil {
+			return err
+		}
+		keys = r.liveKeys(keys)
+		if len(keys) > 0 {
+			if err := fn(keys); err != nil {
+				return err
+			}
+		}
+		if next == 0 {
+			return nil
+		}
+		cursor = next
+	}
+}
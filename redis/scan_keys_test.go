@@ -0,0 +1,282 @@
+package redis_test
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strconv"
+	"testing"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+	"github.com/zeroxsolutions/banshee/redis"
+	"github.com/zeroxsolutions/barbatos/cache"
+	"github.com/zeroxsolutions/strike/ssutil"
+)
+
+// TestRedisCache_ScanKeys verifies that ScanKeys finds every matching key across many SCAN
+// iterations, and returns partial results with the error when its context is cancelled.
+func TestRedisCache_ScanKeys(t *testing.T) {
+	redisCache := initRedisCache(t)
+
+	defer func(redisCache cache.Cache) {
+		if err := redisCache.Close(); err != nil {
+			t.Log("Close Redis cache connection err", err)
+		}
+	}(redisCache)
+
+	client := initRedisClient(t)
+	defer client.Close()
+
+	scanner := redisCache.(*redis.RedisCache)
+
+	ctx := context.Background()
+	prefix := ssutil.MakeString(10) + ":"
+
+	expected := make([]string, 250)
+	_, err := client.Pipelined(ctx, func(pipe goredis.Pipeliner) error {
+		for i := range expected {
+			expected[i] = prefix + strconv.Itoa(i)
+			pipe.Set(ctx, expected[i], i, 0)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer func() {
+		if err := scanner.Del(ctx, expected...); err != nil {
+			t.Error(err)
+		}
+	}()
+
+	keys, err := scanner.ScanKeys(ctx, prefix+"*", 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sort.Strings(keys)
+	sort.Strings(expected)
+	if len(keys) != len(expected) {
+		t.Fatal("unexpected number of keys:", len(keys))
+	}
+	for i := range keys {
+		if keys[i] != expected[i] {
+			t.Fatal("unexpected key:", keys[i])
+		}
+	}
+
+	if keys, err := scanner.ScanKeys(ctx, ssutil.MakeString(10)+"*", 10); err != nil || len(keys) != 0 {
+		t.Fatal(keys, err)
+	}
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	if _, err := scanner.ScanKeys(cancelled, prefix+"*", 10); !errors.Is(err, context.Canceled) {
+		t.Fatal(err)
+	}
+}
+
+// TestRedisCache_Keys_Scan verifies that Keys finds every key of a large key set through SCAN, and
+// that cancelling it mid-iteration returns the keys collected so far with the context error.
+func TestRedisCache_Keys_Scan(t *testing.T) {
+	redisCache := initRedisCache(t, redis.NewRedisCacheOptions().SetScanCount(1))
+
+	defer func(redisCache cache.Cache) {
+		if err := redisCache.Close(); err != nil {
+			t.Log("Close Redis cache connection err", err)
+		}
+	}(redisCache)
+
+	client := initRedisClient(t)
+	defer client.Close()
+
+	ctx := context.Background()
+	prefix := ssutil.MakeString(10) + ":"
+
+	const total = 10000
+	expected := make([]string, total)
+	_, err := client.Pipelined(ctx, func(pipe goredis.Pipeliner) error {
+		for i := range expected {
+			expected[i] = prefix + strconv.Itoa(i)
+			pipe.Set(ctx, expected[i], i, 0)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer func() {
+		if err := client.Del(ctx, expected...).Err(); err != nil {
+			t.Error(err)
+		}
+	}()
+
+	large := initRedisCache(t)
+	defer large.Close()
+
+	keys, err := large.Keys(ctx, prefix+"*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(keys) != total {
+		t.Fatal("unexpected number of keys:", len(keys))
+	}
+
+	// Servers ignoring the COUNT hint, such as some test doubles, answer in a single batch and
+	// leave nothing to cancel.
+	if _, cursor, err := client.Scan(ctx, 0, prefix+"*", 1).Result(); err != nil || cursor == 0 {
+		t.Skip("the server ignores the SCAN COUNT hint")
+	}
+
+	cancelled, cancel := context.WithCancel(ctx)
+	time.AfterFunc(5*time.Millisecond, cancel)
+	partial, err := redisCache.Keys(cancelled, prefix+"*")
+	if !errors.Is(err, context.Canceled) {
+		t.Fatal("expected the iteration to be cancelled:", err)
+	}
+	if len(partial) >= total {
+		t.Fatal("expected partial results, got", len(partial))
+	}
+	for _, key := range partial {
+		if len(key) <= len(prefix) || key[:len(prefix)] != prefix {
+			t.Fatal("unexpected key:", key)
+		}
+	}
+}
+
+// TestRedisCache_KeysIter verifies that KeysIter streams every matching key, and that cancelling
+// the context stops the iteration with the context error.
+func TestRedisCache_KeysIter(t *testing.T) {
+	redisCache := initRedisCache(t)
+
+	defer func(redisCache cache.Cache) {
+		if err := redisCache.Close(); err != nil {
+			t.Log("Close Redis cache connection err", err)
+		}
+	}(redisCache)
+
+	client := initRedisClient(t)
+	defer client.Close()
+
+	scanner := redisCache.(*redis.RedisCache)
+
+	ctx := context.Background()
+	prefix := ssutil.MakeString(10) + ":"
+
+	expected := make([]string, 500)
+	_, err := client.Pipelined(ctx, func(pipe goredis.Pipeliner) error {
+		for i := range expected {
+			expected[i] = prefix + strconv.Itoa(i)
+			pipe.Set(ctx, expected[i], i, 0)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer func() {
+		if err := client.Del(ctx, expected...).Err(); err != nil {
+			t.Error(err)
+		}
+	}()
+
+	seen := map[string]bool{}
+	keys, errs := scanner.KeysIter(ctx, prefix+"*", 50)
+	for key := range keys {
+		seen[key] = true
+	}
+	if err := <-errs; err != nil {
+		t.Fatal(err)
+	}
+	if len(seen) != len(expected) {
+		t.Fatal("unexpected number of keys:", len(seen))
+	}
+	for _, key := range expected {
+		if !seen[key] {
+			t.Fatal("missing key:", key)
+		}
+	}
+
+	cancelled, cancel := context.WithCancel(ctx)
+	keys, errs = scanner.KeysIter(cancelled, prefix+"*", 50)
+	if _, ok := <-keys; !ok {
+		t.Fatal("expected a first key")
+	}
+	cancel()
+	for range keys {
+	}
+	if err := <-errs; !errors.Is(err, context.Canceled) {
+		t.Fatal(err)
+	}
+}
+
+// TestRedisCache_ScanKeysIterator verifies that the iterator visits every matching key, can be
+// abandoned early, and stops with the context error once cancelled.
+func TestRedisCache_ScanKeysIterator(t *testing.T) {
+	redisCache := initRedisCache(t, redis.NewRedisCacheOptions().SetScanCount(50))
+
+	defer func(redisCache cache.Cache) {
+		if err := redisCache.Close(); err != nil {
+			t.Log("Close Redis cache connection err", err)
+		}
+	}(redisCache)
+
+	client := initRedisClient(t)
+	defer client.Close()
+
+	scanner := redisCache.(*redis.RedisCache)
+
+	ctx := context.Background()
+	prefix := ssutil.MakeString(10) + ":"
+
+	expected := make([]string, 500)
+	_, err := client.Pipelined(ctx, func(pipe goredis.Pipeliner) error {
+		for i := range expected {
+			expected[i] = prefix + strconv.Itoa(i)
+			pipe.Set(ctx, expected[i], i, 0)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer func() {
+		if err := client.Del(ctx, expected...).Err(); err != nil {
+			t.Error(err)
+		}
+	}()
+
+	seen := map[string]bool{}
+	it := scanner.ScanKeysIterator(ctx, prefix+"*")
+	for it.Next() {
+		seen[it.Key()] = true
+	}
+	if err := it.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if len(seen) != len(expected) {
+		t.Fatal("unexpected number of keys:", len(seen))
+	}
+	if it.Next() {
+		t.Fatal("a completed iterator should stay completed")
+	}
+
+	empty := scanner.ScanKeysIterator(ctx, ssutil.MakeString(10)+"*")
+	if empty.Next() || empty.Err() != nil {
+		t.Fatal("expected no keys and no error:", empty.Err())
+	}
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	stopped := scanner.ScanKeysIterator(cancelled, prefix+"*")
+	if stopped.Next() {
+		t.Fatal("a cancelled iterator should not advance")
+	}
+	if !errors.Is(stopped.Err(), context.Canceled) {
+		t.Fatal(stopped.Err())
+	}
+}
@@ -0,0 +1,102 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// slowLogLookback is the number of SLOWLOG entries searched for the command that just completed.
+const slowLogLookback = 16
+
+// ServerTimeFunc receives the execution time Redis itself measured for a command, excluding
+// network and client-side queueing. command is the lowercase command name, e.g. "get".
+type ServerTimeFunc func(ctx context.Context, command string, serverTime time.Duration)
+
+// serverTimeHook is a go-redis hook reporting the server-side duration of every command that
+// Redis recorded in its slow log.
+type serverTimeHook struct {
+	client   *redis.Client
+	callback ServerTimeFunc
+	nextID   int64 // nextID is the lowest slow log entry ID not reported yet, accessed atomically.
+}
+
+// DialHook passes dials through unchanged.
+func (h *serverTimeHook) DialHook(next redis.DialHook) redis.DialHook {
+	return next
+}
+
+// ProcessHook runs the command and then looks up its SLOWLOG entry.
+func (h *serverTimeHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		start := time.Now()
+		err := next(ctx, cmd)
+		if err == nil && cmd.Name() != "slowlog" {
+			h.report(ctx, cmd, start)
+		}
+		return err
+	}
+}
+
+// ProcessPipelineHook passes pipelines through unchanged: their commands can't be matched to
+// individual slow log entries reliably.
+func (h *serverTimeHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return next
+}
+
+// report finds the most recent slow log entry for cmd written since start and passes its duration
+// to the callback. Entries are matched on the command name and first argument, which is enough
+// to tell apart the commands of one caller but may pick up an identical command sent by another
+// client at the same time. Entries already reported, or older than one reported, are skipped, so
+// a fast command that wasn't logged never picks up the entry of an earlier slow one; a newest
+// entry older than the last one reported means the server restarted and the IDs started over.
+func (h *serverTimeHook) report(ctx context.Context, cmd redis.Cmder, start time.Time) {
+	entries, err := h.client.SlowLogGet(ctx, slowLogLookback).Result()
+	if err != nil || len(entries) == 0 {
+		return
+	}
+	nextID := atomic.LoadInt64(&h.nextID)
+	if entries[0].ID+1 < nextID {
+		atomic.CompareAndSwapInt64(&h.nextID, nextID, 0)
+		nextID = 0
+	}
+	args := cmd.Args()
+	since := start.Truncate(time.Second)
+	for _, entry := range entries {
+		if entry.ID < nextID {
+			return
+		}
+		if entry.Time.Before(since) || len(entry.Args) == 0 || !strings.EqualFold(entry.Args[0], cmd.Name()) {
+			continue
+		}
+		if len(args) > 1 && (len(entry.Args) < 2 || entry.Args[1] != fmt.Sprint(args[1])) {
+			continue
+		}
+		h.markReported(entry.ID)
+		h.invoke(ctx, cmd.Name(), entry.Duration)
+		return
+	}
+}
+
+// markReported raises nextID past id, unless a concurrent report already did.
+func (h *serverTimeHook) markReported(id int64) {
+	for {
+		nextID := atomic.LoadInt64(&h.nextID)
+		if id < nextID || atomic.CompareAndSwapInt64(&h.nextID, nextID, id+1) {
+			return
+		}
+	}
+}
+
+// invoke calls the user callback, discarding panics so a faulty callback can't crash the command
+// that triggered it; the measurement is diagnostic and losing one is harmless.
+func (h *serverTimeHook) invoke(ctx context.Context, command string, serverTime time.Duration) {
+	defer func() {
+		_ = recover()
+	}()
+	h.callback(ctx, command, serverTime)
+}
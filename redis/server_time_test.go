@@ -0,0 +1,96 @@
+package redis_test
+
+import (
+	"context"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/zeroxsolutions/banshee/redis"
+	"github.com/zeroxsolutions/barbatos/cache"
+	"github.com/zeroxsolutions/strike/ssutil"
+)
+
+// TestRedisCache_ServerTime lowers the slow log threshold so every command is logged and verifies
+// that the server-side duration of a write reaches the callback. It changes server configuration
+// and requires SLOWLOG, so it only runs when REDIS_SLOWLOG is set.
+func TestRedisCache_ServerTime(t *testing.T) {
+	if os.Getenv("REDIS_SLOWLOG") == "" {
+		t.Skip("REDIS_SLOWLOG not set; skipping server time test")
+	}
+
+	ctx := context.Background()
+
+	admin := initRedisClient(t)
+	defer admin.Close()
+
+	previous, err := admin.ConfigGet(ctx, "slowlog-log-slower-than").Result()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := admin.ConfigSet(ctx, "slowlog-log-slower-than", "0").Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	defer func() {
+		if err := admin.ConfigSet(ctx, "slowlog-log-slower-than", previous["slowlog-log-slower-than"]).Err(); err != nil {
+			t.Error(err)
+		}
+	}()
+
+	var mu sync.Mutex
+	reported := map[string]time.Duration{}
+
+	redisCache := initRedisCache(t, redis.NewRedisCacheOptions().SetServerTimeCallback(
+		func(ctx context.Context, command string, serverTime time.Duration) {
+			mu.Lock()
+			defer mu.Unlock()
+			reported[command] = serverTime
+		},
+	))
+
+	defer func(redisCache cache.Cache) {
+		if err := redisCache.Close(); err != nil {
+			t.Log("Close Redis cache connection err", err)
+		}
+	}(redisCache)
+
+	key := ssutil.MakeString(10)
+
+	if err := redisCache.Set(ctx, key, ssutil.MakeString(12)); err != nil {
+		t.Fatal(err)
+	}
+
+	defer func() {
+		if err := redisCache.Del(ctx, key); err != nil {
+			t.Error(err)
+		}
+	}()
+
+	mu.Lock()
+	if serverTime, ok := reported["set"]; !ok || serverTime < 0 {
+		mu.Unlock()
+		t.Log("no server time reported for set:", reported)
+		t.FailNow()
+	}
+	delete(reported, "set")
+	mu.Unlock()
+
+	// A command fast enough to stay out of the slow log must not pick up the earlier entry.
+	if err := admin.ConfigSet(ctx, "slowlog-log-slower-than", "10000000").Err(); err != nil {
+		t.Fatal(err)
+	}
+	if err := redisCache.Set(ctx, key, ssutil.MakeString(12)); err != nil {
+		t.Fatal(err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	if serverTime, ok := reported["set"]; ok {
+		t.Log("an earlier slow log entry was reported for a fast set:", serverTime)
+		t.FailNow()
+	}
+}
@@ -0,0 +1,83 @@
+package redis
+
+import (
+	"context"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/zeroxsolutions/banshee"
+	"github.com/zeroxsolutions/banshee/internal/valueutil"
+)
+
+var _ banshee.SetNXCache = (*RedisCache)(nil)
+
+// SetNX stores value under key only if key doesn't exist, using SET NX with an optional PX.
+// WithTTLOverride applies as for SetWithExpiration. Nil values fail with ErrNilValue, whatever
+// SetNilValueDeletes says.
+//
+// Parameters:
+//   - ctx: Context for timeout control and request cancellation
+//   - key: Redis key to store
+//   - value: Value to store
+//   - ttl: Time to live of the key, 0 for none
+//
+// Returns:
+//   - bool: true if the value was stored, false if key already existed
+//   - error: ErrNilValue, or a Redis error
+//
+// Example:
+//
+//	first, err := cache.SetNX(ctx, "webhook:evt_123", "1", 24*time.Hour)
+func (r *RedisCache) SetNX(ctx context.Context, key string, value interface{}, ttl time.Duration) (bool, error) {
+	if valueutil.IsNil(value) {
+		return false, ErrNilValue
+	}
+	if override, ok := ttlOverrideFromContext(ctx); ok {
+		ttl = override
+	}
+	return r.client.SetNX(ctx, key, value, ttl).Result()
+}
+
+// SetNXMany runs SetNX for every key in one pipelined round trip. Each key is set atomically, but
+// other clients may observe the batch partially applied. A nil value fails with ErrNilValue before
+// anything is written.
+//
+// Parameters:
+//   - ctx: Context for timeout control and request cancellation
+//   - keys: Redis keys to store
+//   - value: Value stored under every key
+//   - ttl: Time to live of the keys, 0 for none
+//
+// Returns:
+//   - []bool: Per key, in order, whether the value was stored
+//   - error: ErrNilValue, or a Redis error
+//
+// Example:
+//
+//	stored, err := cache.SetNXMany(ctx, []string{"evt:1", "evt:2"}, "1", time.Hour)
+func (r *RedisCache) SetNXMany(ctx context.Context, keys []string, value interface{}, ttl time.Duration) ([]bool, error) {
+	if valueutil.IsNil(value) {
+		return nil, ErrNilValue
+	}
+	if override, ok := ttlOverrideFromContext(ctx); ok {
+		ttl = override
+	}
+	if len(keys) == 0 {
+		return []bool{}, nil
+	}
+	cmds := make([]*redis.BoolCmd, len(keys))
+	_, err := r.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for i, key := range keys {
+			cmds[i] = pipe.SetNX(ctx, key, value, ttl)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	stored := make([]bool, len(keys))
+	for i, cmd := range cmds {
+		stored[i] = cmd.Val()
+	}
+	return stored, nil
+}
@@ -0,0 +1,60 @@
+package redis_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/zeroxsolutions/banshee/redis"
+	"github.com/zeroxsolutions/barbatos/cache"
+	"github.com/zeroxsolutions/strike/ssutil"
+)
+
+// TestRedisCache_SetNX verifies that only the first write of a key is stored, with its TTL, and
+// that the batch variant reports per-key results.
+func TestRedisCache_SetNX(t *testing.T) {
+	redisCache := initRedisCache(t)
+
+	defer func(redisCache cache.Cache) {
+		if err := redisCache.Close(); err != nil {
+			t.Log("Close Redis cache connection err", err)
+		}
+	}(redisCache)
+
+	client := initRedisClient(t)
+	defer client.Close()
+
+	setNX := redisCache.(*redis.RedisCache)
+
+	ctx := context.Background()
+	key := ssutil.MakeString(10)
+	other := ssutil.MakeString(10)
+
+	defer func() {
+		if err := setNX.Del(ctx, key, other); err != nil {
+			t.Error(err)
+		}
+	}()
+
+	if stored, err := setNX.SetNX(ctx, key, "first", time.Minute); err != nil || !stored {
+		t.Fatal(stored, err)
+	}
+	if stored, err := setNX.SetNX(ctx, key, "second", time.Minute); err != nil || stored {
+		t.Fatal(stored, err)
+	}
+	if v, err := setNX.Get(ctx, key); err != nil || v != "first" {
+		t.Fatal(v, err)
+	}
+	if ttl, err := client.PTTL(ctx, key).Result(); err != nil || ttl <= 0 || ttl > time.Minute {
+		t.Fatal(ttl, err)
+	}
+
+	stored, err := setNX.SetNXMany(ctx, []string{key, other}, "batch", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(stored, []bool{false, true}) {
+		t.Fatal("unexpected results:", stored)
+	}
+}
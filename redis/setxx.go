@@ -0,0 +1,37 @@
+package redis
+
+import (
+	"context"
+	"time"
+
+	"github.com/zeroxsolutions/banshee"
+	"github.com/zeroxsolutions/banshee/internal/valueutil"
+)
+
+var _ banshee.SetXXCache = (*RedisCache)(nil)
+
+// SetXX stores value under key only if key exists, using SET XX with an optional PX, so an update
+// racing with a delete can't recreate the key. WithTTLOverride applies as for SetWithExpiration.
+//
+// Parameters:
+//   - ctx: Context for timeout control and request cancellation
+//   - key: Redis key to store
+//   - value: Value to store
+//   - ttl: Time to live of the key, 0 for none
+//
+// Returns:
+//   - bool: true if the value was stored, false if key didn't exist
+//   - error: ErrNilValue, or a Redis error
+//
+// Example:
+//
+//	updated, err := cache.SetXX(ctx, "session:abc", payload, 30*time.Minute)
+func (r *RedisCache) SetXX(ctx context.Context, key string, value interface{}, ttl time.Duration) (bool, error) {
+	if valueutil.IsNil(value) {
+		return false, ErrNilValue
+	}
+	if override, ok := ttlOverrideFromContext(ctx); ok {
+		ttl = override
+	}
+	return r.client.SetXX(ctx, key, value, ttl).Result()
+}
@@ -0,0 +1,61 @@
+package redis_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/zeroxsolutions/banshee/redis"
+	"github.com/zeroxsolutions/barbatos/cache"
+	"github.com/zeroxsolutions/strike/ssutil"
+)
+
+// TestRedisCache_SetXX verifies that SetXX only overwrites keys that exist, with the given TTL.
+func TestRedisCache_SetXX(t *testing.T) {
+	redisCache := initRedisCache(t)
+
+	defer func(redisCache cache.Cache) {
+		if err := redisCache.Close(); err != nil {
+			t.Log("Close Redis cache connection err", err)
+		}
+	}(redisCache)
+
+	client := initRedisClient(t)
+	defer client.Close()
+
+	setXX := redisCache.(*redis.RedisCache)
+
+	ctx := context.Background()
+	key := ssutil.MakeString(10)
+
+	defer func() {
+		if err := setXX.Del(ctx, key); err != nil {
+			t.Error(err)
+		}
+	}()
+
+	if stored, err := setXX.SetXX(ctx, key, "first", time.Minute); err != nil || stored {
+		t.Fatal(stored, err)
+	}
+	if _, err := setXX.Get(ctx, key); !errors.Is(err, cache.ErrCacheNil) {
+		t.Fatal("SetXX created a missing key:", err)
+	}
+
+	if err := setXX.Set(ctx, key, "first"); err != nil {
+		t.Fatal(err)
+	}
+	if stored, err := setXX.SetXX(ctx, key, "second", time.Minute); err != nil || !stored {
+		t.Fatal(stored, err)
+	}
+	if v, err := setXX.Get(ctx, key); err != nil || v != "second" {
+		t.Fatal(v, err)
+	}
+	if ttl, err := client.PTTL(ctx, key).Result(); err != nil || ttl <= 0 || ttl > time.Minute {
+		t.Fatal(ttl, err)
+	}
+
+	if _, err := setXX.SetXX(ctx, key, nil, time.Minute); !errors.Is(err, redis.ErrNilValue) {
+		t.Fatal(err)
+	}
+}
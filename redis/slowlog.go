@@ -0,0 +1,90 @@
+package redis
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	// slowLogMaxArgs is the number of leading arguments kept in a SlowLogEntry: the command
+	// name and, for most commands, the key. Later arguments often hold cached values.
+	slowLogMaxArgs = 2
+
+	// slowLogMaxArgLen is the length at which kept arguments are truncated.
+	slowLogMaxArgLen = 128
+)
+
+// SlowLogEntry is one entry of the server's slow log, with its arguments reduced so that it can
+// be attached to incident reports without leaking cached values.
+type SlowLogEntry struct {
+	ID          int64         // ID is the unique, increasing identifier of the entry.
+	Time        time.Time     // Time is when the command was executed.
+	Duration    time.Duration // Duration is the server-side execution time.
+	Args        []string      // Args holds the command name and at most one argument, each truncated.
+	OmittedArgs int           // OmittedArgs is the number of arguments dropped from Args.
+	ClientAddr  string        // ClientAddr is the address of the client that sent the command.
+	ClientName  string        // ClientName is the name set by the client with CLIENT SETNAME, if any.
+}
+
+// SlowLog returns the n most recent slow log entries, newest first, for inclusion in incident
+// reports. Only the command name and its first argument are kept, truncated to 128 bytes; the
+// remaining arguments are counted in OmittedArgs but not returned.
+//
+// Parameters:
+//   - ctx: Context for timeout control and request cancellation
+//   - n: Maximum number of entries to return
+//
+// Returns:
+//   - []SlowLogEntry: The slow log entries
+//   - error: *PermissionError (matching ErrPermissionDenied) if the server refuses SLOWLOG, or a Redis error
+//
+// Example:
+//
+//	entries, err := cache.SlowLog(ctx, 20)
+//	for _, entry := range entries {
+//	    log.Printf("%s %v took %s", entry.Time, entry.Args, entry.Duration)
+//	}
+func (r *RedisCache) SlowLog(ctx context.Context, n int64) ([]SlowLogEntry, error) {
+	logs, err := r.client.SlowLogGet(ctx, n).Result()
+	if err != nil {
+		return nil, permissionError("SLOWLOG", err)
+	}
+	entries := make([]SlowLogEntry, len(logs))
+	for i, log := range logs {
+		args := log.Args
+		omitted := 0
+		if len(args) > slowLogMaxArgs {
+			omitted = len(args) - slowLogMaxArgs
+			args = args[:slowLogMaxArgs]
+		}
+		kept := make([]string, len(args))
+		for j, arg := range args {
+			if len(arg) > slowLogMaxArgLen {
+				arg = arg[:slowLogMaxArgLen]
+			}
+			kept[j] = arg
+		}
+		entries[i] = SlowLogEntry{
+			ID:          log.ID,
+			Time:        log.Time,
+			Duration:    log.Duration,
+			Args:        kept,
+			OmittedArgs: omitted,
+			ClientAddr:  log.ClientAddr,
+			ClientName:  log.ClientName,
+		}
+	}
+	return entries, nil
+}
+
+// SlowLogReset clears the server's slow log, e.g. after an incident snapshot was taken.
+//
+// Parameters:
+//   - ctx: Context for timeout control and request cancellation
+//
+// Returns:
+//   - error: *PermissionError (matching ErrPermissionDenied) if the server refuses SLOWLOG, or a Redis error
+func (r *RedisCache) SlowLogReset(ctx context.Context) error {
+	err := r.client.Do(ctx, "SLOWLOG", "RESET").Err()
+	return permissionError("SLOWLOG", err)
+}
@@ -0,0 +1,70 @@
+package redis_test
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/zeroxsolutions/banshee/redis"
+	"github.com/zeroxsolutions/barbatos/cache"
+)
+
+// TestRedisCache_SlowLog triggers a slow DEBUG SLEEP and verifies that it appears in the parsed
+// slow log, then resets the log. It requires SLOWLOG and DEBUG, so it only runs when
+// REDIS_SLOWLOG is set.
+func TestRedisCache_SlowLog(t *testing.T) {
+	if os.Getenv("REDIS_SLOWLOG") == "" {
+		t.Skip("REDIS_SLOWLOG not set; skipping SLOWLOG test")
+	}
+
+	redisCache := initRedisCache(t)
+
+	defer func(redisCache cache.Cache) {
+		if err := redisCache.Close(); err != nil {
+			t.Log("Close Redis cache connection err", err)
+		}
+	}(redisCache)
+
+	client := initRedisClient(t)
+	defer client.Close()
+
+	slowLog := redisCache.(*redis.RedisCache)
+	ctx := context.Background()
+
+	if err := client.Do(ctx, "DEBUG", "SLEEP", "0.05").Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := slowLog.SlowLog(ctx, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	found := false
+	for _, entry := range entries {
+		if len(entry.Args) > 0 && strings.EqualFold(entry.Args[0], "debug") {
+			found = entry.Duration > 0 && !entry.Time.IsZero() && len(entry.Args) <= 2
+			break
+		}
+	}
+
+	if !found {
+		t.Logf("DEBUG SLEEP not found in slow log: %+v", entries)
+		t.FailNow()
+	}
+
+	if err := slowLog.SlowLogReset(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err = slowLog.SlowLog(ctx, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(entries) != 0 {
+		t.Logf("slow log not reset: %+v", entries)
+		t.FailNow()
+	}
+}
@@ -0,0 +1,228 @@
+package redis
+
+import (
+	"context"
+	"strings"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// DefaultTombstonePrefix is the prefix of the tombstone keys when none is configured.
+const DefaultTombstonePrefix = "tombstone:"
+
+// softDeleteScript renames keys to tombstones expiring after the retention, remembering the TTL
+// each key had.
+//
+// KEYS = key, tombstone, TTL key, repeated for every key; ARGV[1] = retention in milliseconds
+// Returns the number of keys soft-deleted.
+var softDeleteScript = redis.NewScript(`
+local deleted = 0
+for i = 1, #KEYS, 3 do
+	local ttl = redis.call('PTTL', KEYS[i])
+	if ttl ~= -2 then
+		redis.call('RENAME', KEYS[i], KEYS[i + 1])
+		redis.call('PEXPIRE', KEYS[i + 1], ARGV[1])
+		if ttl > 0 then
+			redis.call('SET', KEYS[i + 2], ttl, 'PX', ARGV[1])
+		else
+			redis.call('DEL', KEYS[i + 2])
+		end
+		deleted = deleted + 1
+	end
+end
+return deleted
+`)
+
+// restoreScript moves a tombstone back to its key with the TTL the key had when deleted, unless
+// the key was written again since.
+//
+// KEYS[1] = tombstone, KEYS[2] = TTL key, KEYS[3] = key
+// Returns 1 if the key was restored, 0 otherwise.
+var restoreScript = redis.NewScript(`
+if redis.call('EXISTS', KEYS[1]) == 0 or redis.call('EXISTS', KEYS[3]) == 1 then
+	return 0
+end
+redis.call('RENAME', KEYS[1], KEYS[3])
+local ttl = redis.call('GET', KEYS[2])
+if ttl then
+	redis.call('PEXPIRE', KEYS[3], ttl)
+else
+	redis.call('PERSIST', KEYS[3])
+end
+redis.call('DEL', KEYS[2])
+return 1
+`)
+
+// HardDel deletes keys for good, even on a cache built with SetSoftDelete.
+//
+// Parameters:
+//   - ctx: Context for timeout control and request cancellation
+//   - keys: Redis keys to delete
+//
+// Returns:
+//   - error: Redis connection error or command execution error
+//
+// Example:
+//
+//	err := cache.HardDel(ctx, "session:abc")
+func (r *RedisCache) HardDel(ctx context.Context, keys ...string) error {
+	count, err := r.client.Del(ctx, keys...).Result()
+	if err != nil {
+		return err
+	}
+	r.audit(ctx, AuditOpDel, keys, "", count)
+	return nil
+}
+
+// Restore moves the tombstones of the soft-deleted keys matching pattern back in place, with the
+// TTL each key had when it was deleted. Tombstones are walked with SCAN; each is restored
+// atomically, but keys deleted or restored concurrently may or may not be included.
+//
+// Behavior:
+//   - pattern matches the original key names, e.g. "session:*"
+//   - A key written again since it was soft-deleted is left alone, and so is its tombstone
+//   - Tombstones already expired are gone: only keys deleted within the retention come back
+//
+// Parameters:
+//   - ctx: Context for timeout control and request cancellation
+//   - pattern: Glob-style pattern of the keys to restore
+//
+// Returns:
+//   - int64: Number of keys restored
+//   - error: An error if the context is done or Redis fails
+//
+// Example:
+//
+//	restored, err := cache.Restore(ctx, "catalog:*")
+func (r *RedisCache) Restore(ctx context.Context, pattern string) (int64, error) {
+	var restored int64
+	err := r.scanTombstones(ctx, pattern, func(keys []string) error {
+		for _, key := range keys {
+			n, err := restoreScript.Run(ctx, r.client, []string{r.tombstoneKey(key), r.tombstoneTTLKey(key), key}).Int64()
+			if err != nil {
+				return err
+			}
+			restored += n
+		}
+		return nil
+	})
+	return restored, err
+}
+
+// Purge removes the tombstones of the soft-deleted keys matching pattern for good, ending their
+// undo window early.
+//
+// Caches built with SetDelWithPatternDisabled(true) return ErrOperationDisabled without purging.
+// Purges are audited like DelWithPattern, under AuditOpPurge.
+//
+// Parameters:
+//   - ctx: Context for timeout control and request cancellation
+//   - pattern: Glob-style pattern of the original key names
+//
+// Returns:
+//   - int64: Number of tombstones removed
+//   - error: ErrOperationDisabled, or an error if the context is done or Redis fails; tombstones
+//     removed before it are counted
+//
+// Example:
+//
+//	purged, err := cache.Purge(ctx, "*")
+func (r *RedisCache) Purge(ctx context.Context, pattern string) (int64, error) {
+	if err := r.CheckPatternDelete(); err != nil {
+		return 0, err
+	}
+	var purged int64
+	err := r.scanTombstones(ctx, pattern, func(keys []string) error {
+		var tombstones []*redis.IntCmd
+		_, err := r.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+			for _, key := range keys {
+				tombstones = append(tombstones, pipe.Del(ctx, r.tombstoneKey(key)))
+				pipe.Del(ctx, r.tombstoneTTLKey(key))
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		for _, cmd := range tombstones {
+			purged += cmd.Val()
+		}
+		return nil
+	})
+	if err == nil || purged > 0 {
+		r.audit(ctx, AuditOpPurge, nil, pattern, purged)
+	}
+	return purged, err
+}
+
+// softDel renames keys to tombstones and returns how many existed.
+func (r *RedisCache) softDel(ctx context.Context, keys []string) (int64, error) {
+	return softDeleteScript.Run(ctx, r.client, r.softDelKeys(keys), r.options.SoftDeleteRetention.Milliseconds()).Int64()
+}
+
+// softDelKeys returns the KEYS of softDeleteScript for keys.
+func (r *RedisCache) softDelKeys(keys []string) []string {
+	scriptKeys := make([]string, 0, 3*len(keys))
+	for _, key := range keys {
+		scriptKeys = append(scriptKeys, key, r.tombstoneKey(key), r.tombstoneTTLKey(key))
+	}
+	return scriptKeys
+}
+
+// scanTombstones calls fn with the original names of the tombstoned keys matching pattern, one
+// SCAN batch at a time.
+func (r *RedisCache) scanTombstones(ctx context.Context, pattern string, fn func(keys []string) error) error {
+	prefix := r.tombstoneKey("")
+	var cursor uint64
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		tombstones, next, err := r.client.Scan(ctx, cursor, prefix+pattern, 100).Result()
+		if err != nil {
+			return err
+		}
+		keys := make([]string, len(tombstones))
+		for i, tombstone := range tombstones {
+			keys[i] = strings.TrimPrefix(tombstone, prefix)
+		}
+		if len(keys) > 0 {
+			if err := fn(keys); err != nil {
+				return err
+			}
+		}
+		if next == 0 {
+			return nil
+		}
+		cursor = next
+	}
+}
+
+// liveKeys filters the tombstones out of keys, in place, on caches built with SetSoftDelete.
+func (r *RedisCache) liveKeys(keys []string) []string {
+	if r.options.SoftDeleteRetention <= 0 {
+		return keys
+	}
+	live := keys[:0]
+	for _, key := range keys {
+		if !r.isTombstone(key) {
+			live = append(live, key)
+		}
+	}
+	return live
+}
+
+// isTombstone reports whether key belongs to the tombstone namespace.
+func (r *RedisCache) isTombstone(key string) bool {
+	return strings.HasPrefix(key, r.options.TombstonePrefix)
+}
+
+// tombstoneKey returns the key holding the soft-deleted value of key.
+func (r *RedisCache) tombstoneKey(key string) string {
+	return r.options.TombstonePrefix + "data:" + key
+}
+
+// tombstoneTTLKey returns the key holding the TTL key had when it was soft-deleted.
+func (r *RedisCache) tombstoneTTLKey(key string) string {
+	return r.options.TombstonePrefix + "ttl:" + key
+}
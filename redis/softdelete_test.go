@@ -0,0 +1,319 @@
+package redis_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/zeroxsolutions/banshee"
+	"github.com/zeroxsolutions/banshee/redis"
+	"github.com/zeroxsolutions/barbatos/cache"
+	"github.com/zeroxsolutions/strike/ssutil"
+)
+
+// TestRedisCache_SoftDelete verifies that soft-deleted keys miss, come back with their values and
+// TTLs on Restore, can be purged, and that their tombstones expire on their own.
+func TestRedisCache_SoftDelete(t *testing.T) {
+	tombstones := ssutil.MakeString(10) + ":"
+	redisCache := initRedisCache(t, redis.NewRedisCacheOptions().
+		SetSoftDelete(2*time.Second).
+		SetTombstonePrefix(tombstones))
+
+	defer func(redisCache cache.Cache) {
+		if err := redisCache.Close(); err != nil {
+			t.Log("Close Redis cache connection err", err)
+		}
+	}(redisCache)
+
+	client := initRedisClient(t)
+	defer client.Close()
+
+	soft := redisCache.(*redis.RedisCache)
+
+	ctx := context.Background()
+	base := ssutil.MakeString(10) + ":"
+	plain, expiring, other := base+"plain", base+"expiring", ssutil.MakeString(10)
+
+	defer func() {
+		if err := soft.HardDel(ctx, plain, expiring, other); err != nil {
+			t.Error(err)
+		}
+		if _, err := soft.Purge(ctx, "*"); err != nil {
+			t.Error(err)
+		}
+	}()
+
+	if err := soft.Set(ctx, plain, "p"); err != nil {
+		t.Fatal(err)
+	}
+	if err := soft.SetWithExpiration(ctx, expiring, "e", time.Hour); err != nil {
+		t.Fatal(err)
+	}
+
+	expectMiss := func(keys ...string) {
+		t.Helper()
+		for _, key := range keys {
+			if _, err := soft.Get(ctx, key); !errors.Is(err, cache.ErrCacheNil) {
+				t.Fatal("expected a miss for", key, err)
+			}
+		}
+	}
+	expectRestored := func() {
+		t.Helper()
+		if value, err := soft.Get(ctx, plain); err != nil || value != "p" {
+			t.Fatal(value, err)
+		}
+		if value, err := soft.Get(ctx, expiring); err != nil || value != "e" {
+			t.Fatal(value, err)
+		}
+		if ttl, err := client.PTTL(ctx, plain).Result(); err != nil || ttl != -1 {
+			t.Fatal("the key without TTL should be restored without one:", ttl, err)
+		}
+		if ttl, err := client.PTTL(ctx, expiring).Result(); err != nil || ttl < 59*time.Minute || ttl > time.Hour {
+			t.Fatal("the TTL should be restored:", ttl, err)
+		}
+	}
+
+	if err := soft.Del(ctx, plain, expiring); err != nil {
+		t.Fatal(err)
+	}
+	expectMiss(plain, expiring)
+
+	restored, err := soft.Restore(ctx, base+"*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if restored != 2 {
+		t.Fatal("unexpected number of restored keys:", restored)
+	}
+	expectRestored()
+
+	if err := soft.DelWithPattern(ctx, base+"*"); err != nil {
+		t.Fatal(err)
+	}
+	expectMiss(plain, expiring)
+
+	// A key written again after its deletion is not overwritten by its tombstone.
+	if err := soft.Set(ctx, plain, "new"); err != nil {
+		t.Fatal(err)
+	}
+	if restored, err := soft.Restore(ctx, base+"*"); err != nil || restored != 1 {
+		t.Fatal(restored, err)
+	}
+	if value, err := soft.Get(ctx, plain); err != nil || value != "new" {
+		t.Fatal(value, err)
+	}
+	if err := soft.Set(ctx, plain, "p"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Purged tombstones can't be restored.
+	if err := soft.Del(ctx, plain); err != nil {
+		t.Fatal(err)
+	}
+	if purged, err := soft.Purge(ctx, base+"*"); err != nil || purged != 1 {
+		t.Fatal(purged, err)
+	}
+	if restored, err := soft.Restore(ctx, base+"*"); err != nil || restored != 0 {
+		t.Fatal(restored, err)
+	}
+
+	// Tombstones expire after the retention.
+	if err := soft.Set(ctx, other, "o"); err != nil {
+		t.Fatal(err)
+	}
+	if err := soft.Del(ctx, other, expiring); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(2500 * time.Millisecond)
+	if restored, err := soft.Restore(ctx, "*"); err != nil || restored != 0 {
+		t.Fatal(restored, err)
+	}
+	expectMiss(other, expiring)
+	if keys, err := client.Keys(ctx, tombstones+"*").Result(); err != nil || len(keys) != 0 {
+		t.Fatal("tombstones should have expired:", keys, err)
+	}
+
+	// HardDel leaves no tombstone.
+	if err := soft.Set(ctx, other, "o"); err != nil {
+		t.Fatal(err)
+	}
+	if err := soft.HardDel(ctx, other); err != nil {
+		t.Fatal(err)
+	}
+	if restored, err := soft.Restore(ctx, "*"); err != nil || restored != 0 {
+		t.Fatal(restored, err)
+	}
+}
+
+// TestRedisCache_SoftDeleteTxAndKeys verifies that deletes queued in a transaction are soft
+// deletes too, and that the key listings leave the tombstones out.
+func TestRedisCache_SoftDeleteTxAndKeys(t *testing.T) {
+	base := ssutil.MakeString(10) + ":"
+	redisCache := initRedisCache(t, redis.NewRedisCacheOptions().
+		SetSoftDelete(time.Minute).
+		SetTombstonePrefix(base+"tombstone:"))
+
+	defer func(redisCache cache.Cache) {
+		if err := redisCache.Close(); err != nil {
+			t.Log("Close Redis cache connection err", err)
+		}
+	}(redisCache)
+
+	soft := redisCache.(*redis.RedisCache)
+
+	ctx := context.Background()
+	deleted, kept := base+"deleted", base+"kept"
+
+	defer func() {
+		if err := soft.HardDel(ctx, deleted, kept); err != nil {
+			t.Error(err)
+		}
+		if _, err := soft.Purge(ctx, "*"); err != nil {
+			t.Error(err)
+		}
+	}()
+
+	if err := soft.Set(ctx, deleted, "d"); err != nil {
+		t.Fatal(err)
+	}
+	if err := soft.Set(ctx, kept, "k"); err != nil {
+		t.Fatal(err)
+	}
+
+	err := soft.Tx(ctx, func(tx banshee.CacheTx) error {
+		return tx.Del(ctx, deleted)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := soft.Get(ctx, deleted); !errors.Is(err, cache.ErrCacheNil) {
+		t.Fatal("expected a miss", err)
+	}
+
+	keys, err := soft.Keys(ctx, base+"*")
+	if err != nil || len(keys) != 1 || keys[0] != kept {
+		t.Fatal("Keys should leave tombstones out:", keys, err)
+	}
+	if keys, err := soft.ScanKeys(ctx, base+"*", 10); err != nil || len(keys) != 1 {
+		t.Fatal("ScanKeys should leave tombstones out:", keys, err)
+	}
+	if values, err := soft.GetByPattern(ctx, base+"*"); err != nil || len(values) != 1 || values[kept] != "k" {
+		t.Fatal("GetByPattern should leave tombstones out:", values, err)
+	}
+	if extended, err := soft.ExtendTTLPattern(ctx, base+"*", time.Hour); err != nil || extended != 0 {
+		t.Fatal("ExtendTTLPattern should leave tombstones out:", extended, err)
+	}
+	if updated, err := soft.ExpirePattern(ctx, base+"*", time.Hour); err != nil || updated != 1 {
+		t.Fatal("ExpirePattern should leave tombstones out:", updated, err)
+	}
+	if expiring, err := soft.KeysExpiringSoon(ctx, base+"*", 2*time.Hour, 0); err != nil || len(expiring) != 1 || expiring[0].Key != kept {
+		t.Fatal("KeysExpiringSoon should leave tombstones out:", expiring, err)
+	}
+
+	if restored, err := soft.Restore(ctx, deleted); err != nil || restored != 1 {
+		t.Fatal("a transactional delete should be restorable:", restored, err)
+	}
+	if value, err := soft.Get(ctx, deleted); err != nil || value != "d" {
+		t.Fatal(value, err)
+	}
+}
+
+// TestRedisCache_PurgeAudit verifies that purges are audited.
+func TestRedisCache_PurgeAudit(t *testing.T) {
+	base := ssutil.MakeString(10) + ":"
+	stream := "audit:" + ssutil.MakeString(10)
+	redisCache := initRedisCache(t, redis.NewRedisCacheOptions().
+		SetSoftDelete(time.Minute).
+		SetTombstonePrefix(base+"tombstone:").
+		SetAuditStream(stream, 10))
+
+	defer func(redisCache cache.Cache) {
+		if err := redisCache.Close(); err != nil {
+			t.Log("Close Redis cache connection err", err)
+		}
+	}(redisCache)
+
+	client := initRedisClient(t)
+	defer client.Close()
+
+	ctx := context.Background()
+	soft := redisCache.(*redis.RedisCache)
+
+	defer func() {
+		if err := client.Del(ctx, stream).Err(); err != nil {
+			t.Error(err)
+		}
+	}()
+
+	if err := soft.Set(ctx, base+"key", "value"); err != nil {
+		t.Fatal(err)
+	}
+	if err := soft.Del(ctx, base+"key"); err != nil {
+		t.Fatal(err)
+	}
+	if purged, err := soft.Purge(ctx, base+"*"); err != nil || purged != 1 {
+		t.Fatal(purged, err)
+	}
+
+	entries, err := client.XRange(ctx, stream, "-", "+").Result()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 {
+		t.Fatal("expected 2 audit entries, got", len(entries))
+	}
+	purge := entries[1].Values
+	if purge["op"] != redis.AuditOpPurge || purge["pattern"] != base+"*" || purge["count"] != "1" {
+		t.Fatal("unexpected Purge entry:", purge)
+	}
+}
+
+// TestRedisCache_SoftDeletePipeline verifies that deletes queued in a pipeline are soft deletes
+// too.
+func TestRedisCache_SoftDeletePipeline(t *testing.T) {
+	base := ssutil.MakeString(10) + ":"
+	redisCache := initRedisCache(t, redis.NewRedisCacheOptions().
+		SetSoftDelete(time.Minute).
+		SetTombstonePrefix(base+"tombstone:"))
+
+	defer func(redisCache cache.Cache) {
+		if err := redisCache.Close(); err != nil {
+			t.Log("Close Redis cache connection err", err)
+		}
+	}(redisCache)
+
+	soft := redisCache.(*redis.RedisCache)
+
+	ctx := context.Background()
+	key := base + "key"
+
+	defer func() {
+		if err := soft.HardDel(ctx, key); err != nil {
+			t.Error(err)
+		}
+		if _, err := soft.Purge(ctx, "*"); err != nil {
+			t.Error(err)
+		}
+	}()
+
+	if err := soft.Set(ctx, key, "v"); err != nil {
+		t.Fatal(err)
+	}
+	err := soft.Pipeline(ctx, func(p banshee.CachePipeline) error {
+		return p.Del(ctx, key, base+"missing")
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := soft.Get(ctx, key); !errors.Is(err, cache.ErrCacheNil) {
+		t.Fatal("expected a miss", err)
+	}
+	if restored, err := soft.Restore(ctx, base+"*"); err != nil || restored != 1 {
+		t.Fatal("a pipelined delete should be restorable:", restored, err)
+	}
+	if value, err := soft.Get(ctx, key); err != nil || value != "v" {
+		t.Fatal(value, err)
+	}
+}
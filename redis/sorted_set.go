@@ -0,0 +1,183 @@
+package redis
+
+import (
+	"context"
+	"math"
+	"strconv"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/zeroxsolutions/banshee"
+	"github.com/zeroxsolutions/barbatos/cache"
+)
+
+var _ banshee.SortedSetCache = (*RedisCache)(nil)
+
+// ZAdd adds member to the sorted set stored under key with score using ZADD, updating the score
+// of an existing member.
+//
+// Parameters:
+//   - ctx: Context for timeout control and request cancellation
+//   - key: Redis key of the sorted set
+//   - score: Score of the member
+//   - member: Member to add
+//
+// Returns:
+//   - error: A Redis error, e.g. WRONGTYPE when key holds another type
+//
+// Example:
+//
+//	err := cache.ZAdd(ctx, "leaderboard", 1250, "player:42")
+func (r *RedisCache) ZAdd(ctx context.Context, key string, score float64, member string) error {
+	return r.client.ZAdd(ctx, key, redis.Z{Score: score, Member: member}).Err()
+}
+
+// ZRangeByScore returns the members of the sorted set stored under key whose score lies between
+// min and max (inclusive), ordered by score, using ZRANGEBYSCORE. Infinite bounds map to -inf and
+// +inf.
+//
+// Parameters:
+//   - ctx: Context for timeout control and request cancellation
+//   - key: Redis key of the sorted set
+//   - min: Lowest score to return
+//   - max: Highest score to return
+//
+// Returns:
+//   - []string: The matching members; empty if the key doesn't exist
+//   - error: A Redis error
+//
+// Example:
+//
+//	top, err := cache.ZRangeByScore(ctx, "leaderboard", 1000, math.Inf(1))
+func (r *RedisCache) ZRangeByScore(ctx context.Context, key string, min, max float64) ([]string, error) {
+	return r.client.ZRangeByScore(ctx, key, &redis.ZRangeBy{Min: formatScore(min), Max: formatScore(max)}).Result()
+}
+
+// ZRemRangeByScore removes the members of the sorted set stored under key whose score lies
+// between min and max (inclusive) using ZREMRANGEBYSCORE.
+//
+// Parameters:
+//   - ctx: Context for timeout control and request cancellation
+//   - key: Redis key of the sorted set
+//   - min: Lowest score to remove
+//   - max: Highest score to remove
+//
+// Returns:
+//   - int64: Number of members removed
+//   - error: A Redis error
+//
+// Example:
+//
+//	pruned, err := cache.ZRemRangeByScore(ctx, "sessions", math.Inf(-1), float64(time.Now().Unix()))
+func (r *RedisCache) ZRemRangeByScore(ctx context.Context, key string, min, max float64) (int64, error) {
+	return r.client.ZRemRangeByScore(ctx, key, formatScore(min), formatScore(max)).Result()
+}
+
+// ZRem removes members from the sorted set stored under key using ZREM.
+//
+// Parameters:
+//   - ctx: Context for timeout control and request cancellation
+//   - key: Redis key of the sorted set
+//   - members: Members to remove
+//
+// Returns:
+//   - int64: Number of members removed
+//   - error: A Redis error
+//
+// Example:
+//
+//	removed, err := cache.ZRem(ctx, "leaderboard", "player:42")
+func (r *RedisCache) ZRem(ctx context.Context, key string, members ...string) (int64, error) {
+	if len(members) == 0 {
+		return 0, nil
+	}
+	args := make([]interface{}, len(members))
+	for i, member := range members {
+		args[i] = member
+	}
+	return r.client.ZRem(ctx, key, args...).Result()
+}
+
+// formatScore formats a score bound the way Redis expects it, including infinities.
+func formatScore(score float64) string {
+	switch {
+	case math.IsInf(score, 1):
+		return "+inf"
+	case math.IsInf(score, -1):
+		return "-inf"
+	default:
+		return strconv.FormatFloat(score, 'f', -1, 64)
+	}
+}
+
+// ZRange returns the members of the sorted set stored under key ranked start to stop (inclusive)
+// in ascending score order using ZRANGE. Negative ranks count from the end.
+//
+// Parameters:
+//   - ctx: Context for timeout control and request cancellation
+//   - key: Redis key of the sorted set
+//   - start: Rank of the first member to return
+//   - stop: Rank of the last member to return
+//
+// Returns:
+//   - []string: The members; empty if the key doesn't exist
+//   - error: A Redis error
+//
+// Example:
+//
+//	lowest, err := cache.ZRange(ctx, "leaderboard", 0, 9)
+func (r *RedisCache) ZRange(ctx context.Context, key string, start, stop int64) ([]string, error) {
+	return r.client.ZRange(ctx, key, start, stop).Result()
+}
+
+// ZCard returns the number of members of the sorted set stored under key using ZCARD.
+//
+// Parameters:
+//   - ctx: Context for timeout control and request cancellation
+//   - key: Redis key of the sorted set
+//
+// Returns:
+//   - int64: Number of members; 0 if the key doesn't exist
+//   - error: A Redis error
+func (r *RedisCache) ZCard(ctx context.Context, key string) (int64, error) {
+	return r.client.ZCard(ctx, key).Result()
+}
+
+// ZPopMin atomically removes and returns the member with the lowest score using ZPOPMIN.
+//
+// Parameters:
+//   - ctx: Context for timeout control and request cancellation
+//   - key: Redis key of the sorted set
+//
+// Returns:
+//   - string: The removed member
+//   - float64: Its score
+//   - error: cache.ErrCacheNil if the sorted set is empty, or a Redis error
+func (r *RedisCache) ZPopMin(ctx context.Context, key string) (string, float64, error) {
+	return popped(r.client.ZPopMin(ctx, key).Result())
+}
+
+// ZPopMax atomically removes and returns the member with the highest score using ZPOPMAX.
+//
+// Parameters:
+//   - ctx: Context for timeout control and request cancellation
+//   - key: Redis key of the sorted set
+//
+// Returns:
+//   - string: The removed member
+//   - float64: Its score
+//   - error: cache.ErrCacheNil if the sorted set is empty, or a Redis error
+func (r *RedisCache) ZPopMax(ctx context.Context, key string) (string, float64, error) {
+	return popped(r.client.ZPopMax(ctx, key).Result())
+}
+
+// popped converts the result of a single-member ZPOPMIN or ZPOPMAX.
+func popped(members []redis.Z, err error) (string, float64, error) {
+	if err != nil {
+		return "", 0, err
+	}
+	if len(members) == 0 {
+		return "", 0, cache.ErrCacheNil
+	}
+	member, _ := members[0].Member.(string)
+	return member, members[0].Score, nil
+}
@@ -0,0 +1,156 @@
+package redis_test
+
+import (
+	"context"
+	"errors"
+	"math"
+	"reflect"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/zeroxsolutions/banshee"
+	"github.com/zeroxsolutions/banshee/redis"
+	"github.com/zeroxsolutions/barbatos/cache"
+	"github.com/zeroxsolutions/strike/ssutil"
+)
+
+// TestRedisCache_SortedSet verifies adding, ranging and removing sorted set members, including
+// infinite bounds.
+func TestRedisCache_SortedSet(t *testing.T) {
+	redisCache := initRedisCache(t)
+
+	defer func(redisCache cache.Cache) {
+		if err := redisCache.Close(); err != nil {
+			t.Log("Close Redis cache connection err", err)
+		}
+	}(redisCache)
+
+	zsets := redisCache.(*redis.RedisCache)
+
+	ctx := context.Background()
+	key := ssutil.MakeString(10)
+
+	defer func() {
+		if err := zsets.Del(ctx, key); err != nil {
+			t.Error(err)
+		}
+	}()
+
+	for member, score := range map[string]float64{"c": 3, "a": 1, "b": 2.5} {
+		if err := zsets.ZAdd(ctx, key, score, member); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	members, err := zsets.ZRangeByScore(ctx, key, math.Inf(-1), math.Inf(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(members, []string{"a", "b", "c"}) {
+		t.Fatal("unexpected members:", members)
+	}
+
+	members, err = zsets.ZRangeByScore(ctx, key, 2.5, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(members, []string{"b", "c"}) {
+		t.Fatal("unexpected members:", members)
+	}
+
+	if removed, err := zsets.ZRemRangeByScore(ctx, key, math.Inf(-1), 1); err != nil || removed != 1 {
+		t.Fatal(removed, err)
+	}
+	if removed, err := zsets.ZRem(ctx, key, "b", "missing"); err != nil || removed != 1 {
+		t.Fatal(removed, err)
+	}
+
+	members, err = zsets.ZRangeByScore(ctx, key, math.Inf(-1), math.Inf(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(members, []string{"c"}) {
+		t.Fatal("unexpected members:", members)
+	}
+}
+
+// TestPriorityQueue_Redis verifies that pops through separate connections, standing in for
+// separate processes, hand out every item exactly once in priority order.
+func TestPriorityQueue_Redis(t *testing.T) {
+	ctx := context.Background()
+	key := ssutil.MakeString(10)
+
+	const workers = 4
+	queues := make([]*banshee.PriorityQueue, workers)
+	for i := range queues {
+		redisCache := initRedisCache(t)
+		defer func(redisCache cache.Cache) {
+			if err := redisCache.Close(); err != nil {
+				t.Log("Close Redis cache connection err", err)
+			}
+		}(redisCache)
+
+		queue, err := banshee.NewPriorityQueue(redisCache.(banshee.SortedSetCache), key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		queues[i] = queue
+	}
+
+	defer func() {
+		client := initRedisClient(t)
+		defer client.Close()
+		_ = client.Del(ctx, key).Err()
+	}()
+
+	const total = 200
+	for i := 0; i < total; i++ {
+		if err := queues[i%workers].Push(ctx, strconv.Itoa(i), float64(i%20)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if top, err := queues[0].Peek(ctx); err != nil || top != "99" {
+		t.Fatalf("Peek = %q, %v", top, err)
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	popped := map[string]int{}
+	for _, queue := range queues {
+		wg.Add(1)
+		go func(queue *banshee.PriorityQueue) {
+			defer wg.Done()
+			last := math.Inf(1)
+			for {
+				item, err := queue.Pop(ctx)
+				if errors.Is(err, cache.ErrCacheNil) {
+					return
+				}
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				n, _ := strconv.Atoi(item)
+				if priority := float64(n % 20); priority > last {
+					t.Errorf("popped priority %v after %v", priority, last)
+				} else {
+					last = priority
+				}
+				mu.Lock()
+				popped[item]++
+				mu.Unlock()
+			}
+		}(queue)
+	}
+	wg.Wait()
+
+	if len(popped) != total {
+		t.Fatalf("expected %d items, got %d", total, len(popped))
+	}
+	for item, n := range popped {
+		if n != 1 {
+			t.Fatalf("item %s popped %d times", item, n)
+		}
+	}
+}
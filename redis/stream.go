@@ -0,0 +1,77 @@
+package redis
+
+import (
+	"context"
+	"io"
+
+	"github.com/zeroxsolutions/barbatos/cache"
+)
+
+// getStreamChunkSize is the number of bytes GetStream reads per GETRANGE call.
+const getStreamChunkSize = 1 << 20
+
+// GetStream writes the value stored under key to w in chunks of 1 MiB read with GETRANGE, so that
+// large values, such as build artifacts of tens of megabytes, never have to be held in memory
+// whole. The value is stored as a single Redis string; no special chunked format is needed.
+//
+// Chunking assumptions:
+//   - The length is read with STRLEN first, and each chunk is a separate round trip, so a value of
+//     N MiB costs about N+1 round trips
+//   - The read is not atomic: if the value is overwritten while it is streamed, w may receive a mix
+//     of the old and new values, so stream values that are written once, or versioned keys
+//   - Exactly the length read up front is streamed: if the key expires or is overwritten with a
+//     shorter value meanwhile, streaming stops with ErrStreamTruncated
+//   - If w fails, streaming stops and the bytes already written are reported
+//
+// Parameters:
+//   - ctx: Context for timeout control and request cancellation
+//   - key: Redis key to read
+//   - w: Writer receiving the value
+//
+// Returns:
+//   - int64: Number of bytes written to w
+//   - error: cache.ErrCacheNil if the key doesn't exist, ErrStreamTruncated, the error of w, or a
+//     Redis error
+//
+// Example:
+//
+//	f, err := os.Create("artifact.tar")
+//	n, err := cache.GetStream(ctx, "artifact:build:42", f)
+func (r *RedisCache) GetStream(ctx context.Context, key string, w io.Writer) (int64, error) {
+	length, err := r.client.StrLen(ctx, key).Result()
+	if err != nil {
+		return 0, err
+	}
+	if length == 0 {
+		// STRLEN can't tell a missing key from an empty value.
+		exists, err := r.client.Exists(ctx, key).Result()
+		if err != nil {
+			return 0, err
+		}
+		if exists == 0 {
+			return 0, cache.ErrCacheNil
+		}
+		return 0, nil
+	}
+
+	var written int64
+	for written < length {
+		size := length - written
+		if size > getStreamChunkSize {
+			size = getStreamChunkSize
+		}
+		chunk, err := r.client.GetRange(ctx, key, written, written+size-1).Result()
+		if err != nil {
+			return written, err
+		}
+		n, err := io.WriteString(w, chunk)
+		written += int64(n)
+		if err != nil {
+			return written, err
+		}
+		if int64(len(chunk)) < size {
+			return written, ErrStreamTruncated
+		}
+	}
+	return written, nil
+}
@@ -0,0 +1,128 @@
+package redis_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/zeroxsolutions/banshee/redis"
+	"github.com/zeroxsolutions/barbatos/cache"
+	"github.com/zeroxsolutions/strike/ssutil"
+)
+
+// maxWriter records the largest write it receives. The buffer isn't embedded, so that
+// io.WriteString can't bypass Write through bytes.Buffer.WriteString.
+type maxWriter struct {
+	buf bytes.Buffer
+	max int
+}
+
+func (w *maxWriter) Write(p []byte) (int, error) {
+	if len(p) > w.max {
+		w.max = len(p)
+	}
+	return w.buf.Write(p)
+}
+
+// overwritingWriter overwrites key with a shorter value after its first write, as a concurrent
+// writer would while the value is streamed.
+type overwritingWriter struct {
+	buf       bytes.Buffer
+	overwrite func()
+}
+
+func (w *overwritingWriter) Write(p []byte) (int, error) {
+	if w.overwrite != nil {
+		w.overwrite()
+		w.overwrite = nil
+	}
+	return w.buf.Write(p)
+}
+
+// TestRedisCache_GetStream verifies that a multi-megabyte value is streamed whole in bounded
+// chunks, that empty and missing values are told apart, and that a value shrinking while it is
+// streamed is reported.
+func TestRedisCache_GetStream(t *testing.T) {
+	redisCache := initRedisCache(t)
+
+	defer func(redisCache cache.Cache) {
+		if err := redisCache.Close(); err != nil {
+			t.Log("Close Redis cache connection err", err)
+		}
+	}(redisCache)
+
+	stream := redisCache.(*redis.RedisCache)
+
+	ctx := context.Background()
+	key := ssutil.MakeString(10)
+	empty := ssutil.MakeString(10)
+	missing := ssutil.MakeString(10)
+
+	// 3.5 MiB, so the last chunk is partial.
+	value := strings.Repeat(ssutil.MakeString(64), 7<<19/64)
+
+	if err := stream.Set(ctx, key, value); err != nil {
+		t.Fatal(err)
+	}
+	if err := stream.Set(ctx, empty, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	defer func() {
+		if err := stream.Del(ctx, key, empty); err != nil {
+			t.Error(err)
+		}
+	}()
+
+	t.Run("Large", func(t *testing.T) {
+		var w maxWriter
+		n, err := stream.GetStream(ctx, key, &w)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if n != int64(len(value)) || w.buf.String() != value {
+			t.Fatal("unexpected content, bytes written:", n)
+		}
+		if w.max > 1<<20 {
+			t.Fatal("unexpected chunk size:", w.max)
+		}
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		var w bytes.Buffer
+		if n, err := stream.GetStream(ctx, empty, &w); err != nil || n != 0 {
+			t.Fatal(n, err)
+		}
+	})
+
+	t.Run("Truncated", func(t *testing.T) {
+		shrinking := ssutil.MakeString(10)
+		defer func() {
+			if err := stream.Del(ctx, shrinking); err != nil {
+				t.Error(err)
+			}
+		}()
+		if err := stream.Set(ctx, shrinking, value); err != nil {
+			t.Fatal(err)
+		}
+
+		w := overwritingWriter{overwrite: func() {
+			if err := stream.Set(ctx, shrinking, "short"); err != nil {
+				t.Error(err)
+			}
+		}}
+		n, err := stream.GetStream(ctx, shrinking, &w)
+		if !errors.Is(err, redis.ErrStreamTruncated) || n != 1<<20 {
+			t.Fatal(n, err)
+		}
+	})
+
+	t.Run("Missing", func(t *testing.T) {
+		var w bytes.Buffer
+		if _, err := stream.GetStream(ctx, missing, &w); !errors.Is(err, cache.ErrCacheNil) {
+			t.Fatal(err)
+		}
+	})
+}
@@ -0,0 +1,183 @@
+// Package topk tracks the approximate heaviest members of a stream, such as the most searched
+// terms, in a Redis sorted set trimmed to a bounded size.
+//
+// The result is approximate: once a set is full, a new member is trimmed again unless its weight
+// exceeds the lowest kept score, so members that become popular slowly after the set filled up
+// may be missed. Members with large total weight are kept reliably. Periodic decay makes old
+// weight count less than recent weight, so stale leaders eventually give way.
+package topk
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+	"github.com/zeroxsolutions/strike/builderutil"
+)
+
+// ErrInvalidFactor is returned by Decay and RunDecay for factors outside (0, 1].
+var ErrInvalidFactor = errors.New("topk: decay factor must be greater than 0 and at most 1")
+
+// recordScript adds weight to a member and trims the set to its maximum size.
+//
+// KEYS[1] = set, ARGV[1] = member, ARGV[2] = weight, ARGV[3] = max size
+var recordScript = goredis.NewScript(`
+redis.call('ZINCRBY', KEYS[1], ARGV[2], ARGV[1])
+local excess = redis.call('ZCARD', KEYS[1]) - tonumber(ARGV[3])
+if excess > 0 then
+	redis.call('ZREMRANGEBYRANK', KEYS[1], 0, excess - 1)
+end
+return 1
+`)
+
+// decayScript multiplies every score of the set by a factor.
+//
+// KEYS[1] = set, ARGV[1] = factor
+// Returns the number of members decayed.
+var decayScript = goredis.NewScript(`
+local members = redis.call('ZRANGE', KEYS[1], 0, -1, 'WITHSCORES')
+local factor = tonumber(ARGV[1])
+for i = 1, #members, 2 do
+	redis.call('ZADD', KEYS[1], tonumber(members[i + 1]) * factor, members[i])
+end
+return #members / 2
+`)
+
+// ScoredMember is a member of a set with its accumulated weight.
+type ScoredMember struct {
+	Member string  // Member is the tracked member, e.g. a search term.
+	Score  float64 // Score is the member's accumulated, possibly decayed, weight.
+}
+
+// Tracker records weighted members and reports the heaviest ones.
+type Tracker struct {
+	client  goredis.Cmdable
+	options *Options
+}
+
+// New creates a Tracker running its commands on client, typically a *redis.Client from
+// github.com/redis/go-redis/v9.
+//
+// Parameters:
+//   - client: Redis client
+//   - opts: Optional Options builders created with NewOptions
+//
+// Returns:
+//   - *Tracker: The tracker
+//   - error: An error if building the options fails
+//
+// Example:
+//
+//	searches, err := topk.New(client, topk.NewOptions().SetMaxSize(500))
+//	err = searches.Record(ctx, "searches", term, 1)
+//	leaders, err := searches.Top(ctx, "searches", 50)
+func New(client goredis.Cmdable, opts ...builderutil.Lister[Options]) (*Tracker, error) {
+	options, err := builderutil.Build(append([]builderutil.Lister[Options]{defaultOptions()}, opts...)...)
+	if err != nil {
+		return nil, err
+	}
+	return &Tracker{client: client, options: options}, nil
+}
+
+// Record adds weight to member in the set under key, then trims the set to the configured maximum
+// size by removing its lowest-scored members. Both steps run in one script, so the set never
+// exceeds its size between calls.
+//
+// Parameters:
+//   - ctx: Context for timeout control and request cancellation
+//   - key: Key of the sorted set
+//   - member: Member to record
+//   - weight: Weight to add, usually 1
+//
+// Returns:
+//   - error: A Redis error
+func (t *Tracker) Record(ctx context.Context, key, member string, weight float64) error {
+	return recordScript.Run(ctx, t.client, []string{key}, member, weight, t.options.MaxSize).Err()
+}
+
+// Top returns up to n members of the set under key with the highest scores, highest first.
+//
+// Parameters:
+//   - ctx: Context for timeout control and request cancellation
+//   - key: Key of the sorted set
+//   - n: Maximum number of members returned
+//
+// Returns:
+//   - []ScoredMember: The leaders, highest first; empty when the set is empty or n is not positive
+//   - error: A Redis error
+func (t *Tracker) Top(ctx context.Context, key string, n int) ([]ScoredMember, error) {
+	if n <= 0 {
+		return []ScoredMember{}, nil
+	}
+	scored, err := t.client.ZRevRangeWithScores(ctx, key, 0, int64(n-1)).Result()
+	if err != nil {
+		return nil, err
+	}
+	top := make([]ScoredMember, len(scored))
+	for i, z := range scored {
+		member, _ := z.Member.(string)
+		top[i] = ScoredMember{Member: member, Score: z.Score}
+	}
+	return top, nil
+}
+
+// Decay multiplies every score of the set under key by factor in one script, so recent weight
+// outweighs old weight. With a factor of 0.5 applied hourly, for instance, a search made an hour
+// ago counts half as much as one made now. The script touches every member, so its cost grows
+// with the configured maximum size.
+//
+// Parameters:
+//   - ctx: Context for timeout control and request cancellation
+//   - key: Key of the sorted set
+//   - factor: Multiplier in (0, 1]
+//
+// Returns:
+//   - error: ErrInvalidFactor, or a Redis error
+func (t *Tracker) Decay(ctx context.Context, key string, factor float64) error {
+	if factor <= 0 || factor > 1 {
+		return ErrInvalidFactor
+	}
+	return decayScript.Run(ctx, t.client, []string{key}, factor).Err()
+}
+
+// RunDecay calls Decay every interval until ctx is done. Run it in one process per key, typically
+// in its own goroutine; several processes decaying the same key compound the factor.
+//
+// Parameters:
+//   - ctx: Context whose cancellation stops the job
+//   - key: Key of the sorted set
+//   - factor: Multiplier in (0, 1]
+//   - interval: Time between decays, must be positive
+//
+// Returns:
+//   - error: ErrInvalidFactor, an error if interval isn't positive, the first Redis error, or the
+//     context error once ctx is done
+//
+// Example:
+//
+//	go func() {
+//	    if err := searches.RunDecay(ctx, "searches", 0.9, time.Hour); !errors.Is(err, context.Canceled) {
+//	        log.Println("search decay stopped:", err)
+//	    }
+//	}()
+func (t *Tracker) RunDecay(ctx context.Context, key string, factor float64, interval time.Duration) error {
+	if factor <= 0 || factor > 1 {
+		return ErrInvalidFactor
+	}
+	if interval <= 0 {
+		return errors.New("topk: decay interval must be positive")
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			if err := t.Decay(ctx, key, factor); err != nil {
+				return err
+			}
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package topk
+
+import (
+	"errors"
+
+	"github.com/zeroxsolutions/strike/builderutil"
+)
+
+// DefaultMaxSize is the number of members a set is trimmed to when no size is configured.
+const DefaultMaxSize = 1000
+
+// Options holds the settings of a Tracker.
+// This struct is populated through OptionsBuilder and consumed by New.
+type Options struct {
+	MaxSize int64 // MaxSize is the number of members kept per set after each Record.
+}
+
+// OptionsBuilder provides a builder pattern for constructing Options.
+// This builder implements the builderutil.Lister interface to work with the functional options pattern.
+type OptionsBuilder struct {
+	Opts []func(*Options) error // Opts contains the list of option functions to be applied
+}
+
+// SetMaxSize configures how many members each set keeps. Record trims the lowest-scored members
+// beyond it, so a member that never outscores the lowest kept one can't enter the set. Keep it
+// well above the number of leaders read with Top, e.g. 10 times, so rising members have room to
+// accumulate weight.
+//
+// Parameters:
+//   - maxSize: Number of members kept, at least 1
+//
+// Returns:
+//   - *OptionsBuilder: The builder instance for method chaining
+func (b *OptionsBuilder) SetMaxSize(maxSize int64) *OptionsBuilder {
+	b.Opts = append(b.Opts, func(o *Options) error {
+		if maxSize < 1 {
+			return errors.New("topk: max size must be at least 1")
+		}
+		o.MaxSize = maxSize
+		return nil
+	})
+	return b
+}
+
+// List returns the slice of option functions accumulated by the builder.
+// This method implements the builderutil.Lister interface.
+//
+// Returns:
+//   - []func(*Options) error: A slice of option functions that can be applied to configure Options
+func (b *OptionsBuilder) List() []func(*Options) error {
+	return b.Opts
+}
+
+// NewOptions creates and returns a new instance of OptionsBuilder.
+//
+// Returns:
+//   - *OptionsBuilder: A new instance of OptionsBuilder ready to be configured
+//
+// Example:
+//
+//	opts := topk.NewOptions().SetMaxSize(500)
+func NewOptions() *OptionsBuilder {
+	return &OptionsBuilder{}
+}
+
+// defaultOptions returns the builder holding the Tracker defaults.
+func defaultOptions() builderutil.Lister[Options] {
+	return NewOptions().SetMaxSize(DefaultMaxSize)
+}
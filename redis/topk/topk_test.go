@@ -0,0 +1,158 @@
+package topk_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strconv"
+	"testing"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+	"github.com/zeroxsolutions/banshee/redis/topk"
+	"github.com/zeroxsolutions/strike/builderutil"
+	"github.com/zeroxsolutions/strike/ssutil"
+)
+
+// initTracker creates a Tracker on the test Redis server and a unique key deleted when the test
+// ends.
+func initTracker(t *testing.T, opts ...builderutil.Lister[topk.Options]) (*topk.Tracker, string) {
+	db := 0
+	if dbRaw := os.Getenv("REDIS_DB"); dbRaw != "" {
+		dbConverted, err := strconv.Atoi(dbRaw)
+		if err != nil {
+			t.Fatal(err)
+		}
+		db = dbConverted
+	}
+	client := goredis.NewClient(&goredis.Options{
+		Addr:     os.Getenv("REDIS_ADDRESS"),
+		Password: os.Getenv("REDIS_PASSWORD"),
+		DB:       db,
+	})
+
+	key := ssutil.MakeString(10)
+	t.Cleanup(func() {
+		_ = client.Del(context.Background(), key).Err()
+		_ = client.Close()
+	})
+
+	tracker, err := topk.New(client, opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tracker, key
+}
+
+// members returns the members of scored, in order.
+func members(scored []topk.ScoredMember) []string {
+	names := make([]string, len(scored))
+	for i, s := range scored {
+		names[i] = s.Member
+	}
+	return names
+}
+
+// TestTracker_HeavyHitters feeds a skewed distribution, many rare members interleaved with a few
+// frequent ones, through a set much smaller than the number of distinct members, and verifies the
+// frequent ones lead.
+func TestTracker_HeavyHitters(t *testing.T) {
+	tracker, key := initTracker(t, topk.NewOptions().SetMaxSize(20))
+	ctx := context.Background()
+
+	heavy := map[string]int{"go": 50, "redis": 40, "cache": 30}
+	for i := 0; i < 500; i++ {
+		if err := tracker.Record(ctx, key, "rare-"+strconv.Itoa(i), 1); err != nil {
+			t.Fatal(err)
+		}
+		for member, count := range heavy {
+			if i%(500/count) == 0 {
+				if err := tracker.Record(ctx, key, member, 1); err != nil {
+					t.Fatal(err)
+				}
+			}
+		}
+	}
+
+	top, err := tracker.Top(ctx, key, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := members(top)
+	if len(got) != 3 || got[0] != "go" || got[1] != "redis" || got[2] != "cache" {
+		t.Fatal("unexpected leaders:", top)
+	}
+	if top[0].Score != 50 {
+		t.Fatal("unexpected score:", top[0])
+	}
+
+	all, err := tracker.Top(ctx, key, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all) != 20 {
+		t.Fatal("set should be trimmed to its max size:", len(all))
+	}
+}
+
+// TestTracker_Decay verifies that decay lets recent members overtake a stale leader.
+func TestTracker_Decay(t *testing.T) {
+	tracker, key := initTracker(t)
+	ctx := context.Background()
+
+	if err := tracker.Record(ctx, key, "stale", 100); err != nil {
+		t.Fatal(err)
+	}
+	if err := tracker.Record(ctx, key, "fresh", 60); err != nil {
+		t.Fatal(err)
+	}
+	if err := tracker.Decay(ctx, key, 0.5); err != nil {
+		t.Fatal(err)
+	}
+	if err := tracker.Record(ctx, key, "fresh", 60); err != nil {
+		t.Fatal(err)
+	}
+
+	top, err := tracker.Top(ctx, key, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(top) != 2 || top[0] != (topk.ScoredMember{Member: "fresh", Score: 90}) || top[1] != (topk.ScoredMember{Member: "stale", Score: 50}) {
+		t.Fatal("unexpected leaders:", top)
+	}
+
+	if err := tracker.Decay(ctx, key, 0); !errors.Is(err, topk.ErrInvalidFactor) {
+		t.Fatal(err)
+	}
+	if err := tracker.Decay(ctx, key, 1.5); !errors.Is(err, topk.ErrInvalidFactor) {
+		t.Fatal(err)
+	}
+}
+
+// TestTracker_RunDecay verifies that the decay job runs until its context is cancelled and that
+// non-positive intervals are rejected.
+func TestTracker_RunDecay(t *testing.T) {
+	tracker, key := initTracker(t)
+
+	if err := tracker.Record(context.Background(), key, "term", 8); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	if err := tracker.RunDecay(ctx, key, 0.5, 30*time.Millisecond); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatal(err)
+	}
+
+	top, err := tracker.Top(context.Background(), key, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(top) != 1 || top[0].Score >= 8 {
+		t.Fatal("score should have decayed:", top)
+	}
+
+	if err := tracker.RunDecay(context.Background(), key, 0.5, 0); err == nil {
+		t.Fatal("a non-positive interval should be rejected")
+	}
+}
@@ -0,0 +1,49 @@
+package redis
+
+import (
+	"context"
+	"time"
+
+	"github.com/zeroxsolutions/banshee"
+	"github.com/zeroxsolutions/barbatos/cache"
+)
+
+var _ banshee.TTLReader = (*RedisCache)(nil)
+
+// TTL returns how long key has left before it expires. It uses PTTL, so the result keeps
+// millisecond precision.
+//
+// Parameters:
+//   - ctx: Context for timeout control and request cancellation
+//   - key: Redis key to inspect
+//
+// Returns:
+//   - time.Duration: The remaining time-to-live, when error is nil
+//   - error: cache.ErrCacheNil if key doesn't exist, ErrNoExpiry if it exists without a
+//     time-to-live, other errors for failures
+//
+// Example:
+//
+//	ttl, err := cache.TTL(ctx, "session:42")
+//	switch {
+//	case errors.Is(err, cache.ErrCacheNil):
+//	    // Key not found
+//	case errors.Is(err, redis.ErrNoExpiry):
+//	    // Key never expires
+//	case err == nil && ttl < time.Minute:
+//	    // Refresh soon
+//	}
+func (r *RedisCache) TTL(ctx context.Context, key string) (time.Duration, error) {
+	ttl, err := r.client.PTTL(ctx, key).Result()
+	if err != nil {
+		return 0, err
+	}
+	// PTTL reports -2 for missing keys and -1 for keys without expiry.
+	switch ttl {
+	case -2:
+		return 0, cache.ErrCacheNil
+	case -1:
+		return 0, ErrNoExpiry
+	}
+	return ttl, nil
+}
@@ -0,0 +1,45 @@
+package redis
+
+import (
+	"context"
+	"time"
+)
+
+// ttlOverrideKey is the unexported context key under which WithTTLOverride stores its value.
+// Using a private struct type guarantees no collision with keys defined by other packages.
+type ttlOverrideKey struct{}
+
+// WithTTLOverride returns a copy of ctx that forces every Set and SetWithExpiration issued
+// with it to use ttl as the expiration, regardless of the duration passed by the caller.
+//
+// This helper is intended for tests and deterministic expiry checks: production code can keep
+// calling SetWithExpiration with long durations while a test shortens them to a few seconds
+// without touching the code under test. When the context carries no override, RedisCache
+// behaves exactly as before.
+//
+// Override semantics:
+//   - ttl > 0: the key expires after ttl, replacing the caller's expiration
+//   - ttl == 0: the key is stored without expiration, even if the caller asked for one
+//
+// Parameters:
+//   - ctx: Parent context to derive from
+//   - ttl: Expiration to apply to all writes made with the returned context
+//
+// Returns:
+//   - context.Context: A derived context carrying the TTL override
+//
+// Example:
+//
+//	ctx := redis.WithTTLOverride(context.Background(), 2*time.Second)
+//	// Stored for 2 seconds instead of 24 hours.
+//	err := cache.SetWithExpiration(ctx, "report:daily", report, 24*time.Hour)
+func WithTTLOverride(ctx context.Context, ttl time.Duration) context.Context {
+	return context.WithValue(ctx, ttlOverrideKey{}, ttl)
+}
+
+// ttlOverrideFromContext extracts the TTL override stored by WithTTLOverride.
+// The boolean result reports whether an override is present in ctx.
+func ttlOverrideFromContext(ctx context.Context) (time.Duration, bool) {
+	ttl, ok := ctx.Value(ttlOverrideKey{}).(time.Duration)
+	return ttl, ok
+}
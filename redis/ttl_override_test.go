@@ -0,0 +1,57 @@
+package redis_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/zeroxsolutions/banshee/redis"
+	"github.com/zeroxsolutions/barbatos/cache"
+	"github.com/zeroxsolutions/strike/ssutil"
+)
+
+// TestWithTTLOverride verifies that a context carrying a TTL override shortens writes
+// while a plain context keeps the caller's expiration.
+func TestWithTTLOverride(t *testing.T) {
+	redisCache := initRedisCache(t)
+
+	defer func(redisCache cache.Cache) {
+		if err := redisCache.Close(); err != nil {
+			t.Log("Close Redis cache connection err", err)
+		}
+	}(redisCache)
+
+	overrideCtx := redis.WithTTLOverride(context.Background(), time.Second)
+
+	overriddenKey := ssutil.MakeString(10)
+	plainKey := ssutil.MakeString(10)
+	value := ssutil.MakeString(12)
+
+	if err := redisCache.SetWithExpiration(overrideCtx, overriddenKey, value, time.Hour); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := redisCache.SetWithExpiration(context.Background(), plainKey, value, time.Hour); err != nil {
+		t.Fatal(err)
+	}
+
+	time.Sleep(2 * time.Second)
+
+	if _, err := redisCache.Get(context.Background(), overriddenKey); err != cache.ErrCacheNil {
+		t.Log(err)
+		t.FailNow()
+	}
+
+	v, err := redisCache.Get(context.Background(), plainKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if v != value {
+		t.FailNow()
+	}
+
+	if err := redisCache.Del(context.Background(), plainKey); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,63 @@
+package redis_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/zeroxsolutions/banshee/redis"
+	"github.com/zeroxsolutions/barbatos/cache"
+	"github.com/zeroxsolutions/strike/ssutil"
+)
+
+// TestRedisCache_TTL verifies that TTL reports the remaining time of expiring keys, ErrNoExpiry
+// for persistent keys, and cache.ErrCacheNil for missing keys.
+func TestRedisCache_TTL(t *testing.T) {
+	redisCache := initRedisCache(t)
+
+	defer func(redisCache cache.Cache) {
+		if err := redisCache.Close(); err != nil {
+			t.Log("Close Redis cache connection err", err)
+		}
+	}(redisCache)
+
+	inspector := redisCache.(*redis.RedisCache)
+
+	ctx := context.Background()
+	expiring := ssutil.MakeString(10)
+	persistent := ssutil.MakeString(10)
+
+	defer func() {
+		if err := inspector.Del(ctx, expiring, persistent); err != nil {
+			t.Error(err)
+		}
+	}()
+
+	if err := inspector.SetWithExpiration(ctx, expiring, "value", time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	if err := inspector.Set(ctx, persistent, "value"); err != nil {
+		t.Fatal(err)
+	}
+
+	ttl, err := inspector.TTL(ctx, expiring)
+	if err != nil || ttl <= 0 || ttl > time.Minute {
+		t.Fatal(ttl, err)
+	}
+
+	if err := inspector.SetWithExpiration(ctx, expiring, "value", 1500*time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+	if ttl, err := inspector.TTL(ctx, expiring); err != nil || ttl <= time.Second || ttl > 1500*time.Millisecond {
+		t.Fatal("the TTL should keep millisecond precision:", ttl, err)
+	}
+
+	if _, err := inspector.TTL(ctx, persistent); !errors.Is(err, redis.ErrNoExpiry) {
+		t.Fatal(err)
+	}
+
+	if _, err := inspector.TTL(ctx, ssutil.MakeString(10)); !errors.Is(err, cache.ErrCacheNil) {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,112 @@
+package redis
+
+import (
+	"context"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/zeroxsolutions/banshee"
+	"github.com/zeroxsolutions/banshee/internal/valueutil"
+)
+
+var _ banshee.TxCache = (*RedisCache)(nil)
+
+// redisTx queues writes on a MULTI/EXEC pipeline.
+type redisTx struct {
+	cache *RedisCache
+	pipe  redis.Pipeliner
+	dels  []queuedDel
+}
+
+// queuedDel is a delete queued in a transaction or pipeline, kept to audit it once they ran. cmd is
+// a DEL, or a soft delete script run.
+type queuedDel struct {
+	keys []string
+	cmd  redis.Cmder
+}
+
+// queueDel queues a delete of keys on pipe: a DEL, or a soft delete on caches built with
+// SetSoftDelete. EVALSHA can't fall back to EVAL once queued, so the script is sent in full.
+func (r *RedisCache) queueDel(ctx context.Context, pipe redis.Pipeliner, keys []string) queuedDel {
+	if r.options.SoftDeleteRetention > 0 {
+		cmd := softDeleteScript.Eval(ctx, pipe, r.softDelKeys(keys), r.options.SoftDeleteRetention.Milliseconds())
+		return queuedDel{keys: keys, cmd: cmd}
+	}
+	return queuedDel{keys: keys, cmd: pipe.Del(ctx, keys...)}
+}
+
+// count returns the number of keys the queued delete removed.
+func (d queuedDel) count() int64 {
+	switch cmd := d.cmd.(type) {
+	case *redis.IntCmd:
+		return cmd.Val()
+	case *redis.Cmd:
+		n, _ := cmd.Int64()
+		return n
+	default:
+		return 0
+	}
+}
+
+// Set queues a SET without expiration.
+func (t *redisTx) Set(ctx context.Context, key string, value interface{}) error {
+	return t.SetWithExpiration(ctx, key, value, 0)
+}
+
+// SetWithExpiration queues a SET with the given expiration, honoring WithTTLOverride. Nil values
+// fail with ErrNilValue, or queue a DEL when the cache was built with SetNilValueDeletes(true).
+func (t *redisTx) SetWithExpiration(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
+	if valueutil.IsNil(value) {
+		if t.cache.options.NilValueDeletes {
+			return t.Del(ctx, key)
+		}
+		return ErrNilValue
+	}
+	if ttl, ok := ttlOverrideFromContext(ctx); ok {
+		expiration = ttl
+	}
+	t.pipe.Set(ctx, key, value, expiration)
+	return nil
+}
+
+// Del queues a DEL, or renames the keys to tombstones when the cache was built with SetSoftDelete.
+func (t *redisTx) Del(ctx context.Context, keys ...string) error {
+	t.dels = append(t.dels, t.cache.queueDel(ctx, t.pipe, keys))
+	return nil
+}
+
+// Tx calls fn to queue writes and applies them atomically in one MULTI/EXEC round trip. Other
+// clients never observe a subset of the writes. As with any Redis transaction, a command failing
+// at execution time (e.g. on a key of the wrong type) does not roll back the others. Deletes are
+// recorded in the audit stream, if enabled, once the transaction succeeded; caches built with
+// SetSoftDelete rename the deleted keys to tombstones, as Del does. Nil values are handled
+// as by SetWithExpiration: they fail with ErrNilValue when queued, or queue a DEL with
+// SetNilValueDeletes(true).
+//
+// Parameters:
+//   - ctx: Context for timeout control and request cancellation
+//   - fn: Function queuing the writes; returning an error discards them
+//
+// Returns:
+//   - error: The error returned by fn, or an error if the transaction fails
+//
+// Example:
+//
+//	err := cache.Tx(ctx, func(tx banshee.CacheTx) error {
+//	    _ = tx.Set(ctx, "order:42", order)
+//	    return tx.Del(ctx, "cart:7")
+//	})
+func (r *RedisCache) Tx(ctx context.Context, fn func(tx banshee.CacheTx) error) error {
+	tx := &redisTx{cache: r}
+	_, err := r.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		tx.pipe = pipe
+		return fn(tx)
+	})
+	if err != nil {
+		return err
+	}
+	for _, del := range tx.dels {
+		r.audit(ctx, AuditOpDel, del.keys, "", del.count())
+	}
+	return nil
+}
@@ -0,0 +1,198 @@
+package redis_test
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/zeroxsolutions/banshee"
+	"github.com/zeroxsolutions/banshee/redis"
+	"github.com/zeroxsolutions/barbatos/cache"
+	"github.com/zeroxsolutions/strike/ssutil"
+)
+
+// TestRedisCache_Tx verifies that queued writes are applied together and that an error returned
+// by the callback discards them.
+func TestRedisCache_Tx(t *testing.T) {
+	redisCache := initRedisCache(t)
+
+	defer func(redisCache cache.Cache) {
+		if err := redisCache.Close(); err != nil {
+			t.Log("Close Redis cache connection err", err)
+		}
+	}(redisCache)
+
+	ctx := context.Background()
+	key := ssutil.MakeString(10)
+	stale := ssutil.MakeString(10)
+	defer func() {
+		_ = redisCache.Del(ctx, key, stale)
+	}()
+
+	if err := redisCache.Set(ctx, stale, "v0"); err != nil {
+		t.Fatal(err)
+	}
+
+	txCache := redisCache.(banshee.TxCache)
+	errAbort := errors.New("abort")
+	err := txCache.Tx(ctx, func(tx banshee.CacheTx) error {
+		_ = tx.Set(ctx, key, "discarded")
+		return errAbort
+	})
+	if !errors.Is(err, errAbort) {
+		t.Fatalf("Tx err = %v", err)
+	}
+	if _, err := redisCache.Get(ctx, key); !errors.Is(err, cache.ErrCacheNil) {
+		t.Fatalf("aborted write applied: %v", err)
+	}
+
+	err = txCache.Tx(ctx, func(tx banshee.CacheTx) error {
+		if err := tx.Set(ctx, key, "v1"); err != nil {
+			return err
+		}
+		return tx.Del(ctx, stale)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if value, err := redisCache.Get(ctx, key); err != nil || value != "v1" {
+		t.Fatalf("%s = %q, %v", key, value, err)
+	}
+	if _, err := redisCache.Get(ctx, stale); !errors.Is(err, cache.ErrCacheNil) {
+		t.Fatalf("stale not deleted: %v", err)
+	}
+}
+
+// TestRedisCache_TxNilValue verifies that nil values are rejected when queued, aborting the
+// transaction, or queue a delete with SetNilValueDeletes(true).
+func TestRedisCache_TxNilValue(t *testing.T) {
+	redisCache := initRedisCache(t)
+
+	defer func(redisCache cache.Cache) {
+		if err := redisCache.Close(); err != nil {
+			t.Log("Close Redis cache connection err", err)
+		}
+	}(redisCache)
+
+	deleting := initRedisCache(t, redis.NewRedisCacheOptions().SetNilValueDeletes(true))
+	defer deleting.Close()
+
+	ctx := context.Background()
+	key := ssutil.MakeString(10)
+	other := ssutil.MakeString(10)
+	defer func() {
+		_ = redisCache.Del(ctx, key, other)
+	}()
+
+	if err := redisCache.Set(ctx, key, "v1"); err != nil {
+		t.Fatal(err)
+	}
+
+	err := redisCache.(banshee.TxCache).Tx(ctx, func(tx banshee.CacheTx) error {
+		if err := tx.Set(ctx, other, "v2"); err != nil {
+			return err
+		}
+		return tx.Set(ctx, key, nil)
+	})
+	if !errors.Is(err, redis.ErrNilValue) {
+		t.Fatalf("Tx err = %v", err)
+	}
+	if _, err := redisCache.Get(ctx, other); !errors.Is(err, cache.ErrCacheNil) {
+		t.Fatalf("aborted write applied: %v", err)
+	}
+
+	err = deleting.(banshee.TxCache).Tx(ctx, func(tx banshee.CacheTx) error {
+		return tx.SetWithExpiration(ctx, key, []byte(nil), time.Minute)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := redisCache.Get(ctx, key); !errors.Is(err, cache.ErrCacheNil) {
+		t.Fatalf("nil value should delete the key: %v", err)
+	}
+}
+
+// TestBatch_CommitRedis verifies that a batch committed on Redis is applied atomically: a
+// concurrent reader never sees one key of a pair updated without the other.
+func TestBatch_CommitRedis(t *testing.T) {
+	redisCache := initRedisCache(t)
+
+	defer func(redisCache cache.Cache) {
+		if err := redisCache.Close(); err != nil {
+			t.Log("Close Redis cache connection err", err)
+		}
+	}(redisCache)
+
+	client := initRedisClient(t)
+	defer client.Close()
+
+	ctx := context.Background()
+	left := ssutil.MakeString(10)
+	right := ssutil.MakeString(10)
+	defer func() {
+		_ = redisCache.Del(ctx, left, right)
+	}()
+
+	const rounds = 200
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	var torn error
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			select {
+			case <-done:
+				return
+			default:
+			}
+			values, err := client.MGet(ctx, left, right).Result()
+			if err != nil {
+				torn = err
+				return
+			}
+			if values[0] != values[1] {
+				torn = errors.New("torn read: " + strconv.Quote(stringOrNil(values[0])) + " != " + strconv.Quote(stringOrNil(values[1])))
+				return
+			}
+		}
+	}()
+
+	for i := 0; i < rounds; i++ {
+		batch, err := banshee.NewBatch(redisCache)
+		if err != nil {
+			t.Fatal(err)
+		}
+		value := strconv.Itoa(i)
+		_ = batch.Set(left, value)
+		_ = batch.Set(right, value)
+		if i == 0 {
+			if n, err := client.Exists(ctx, left, right).Result(); err != nil || n != 0 {
+				t.Fatalf("written before Commit: %d, %v", n, err)
+			}
+		}
+		if err := batch.Commit(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}
+	close(done)
+	wg.Wait()
+
+	if torn != nil {
+		t.Fatal(torn)
+	}
+	if value, err := redisCache.Get(ctx, right); err != nil || value != strconv.Itoa(rounds-1) {
+		t.Fatalf("%s = %q, %v", right, value, err)
+	}
+}
+
+// stringOrNil formats an MGET result, which is nil for missing keys.
+func stringOrNil(value interface{}) string {
+	if value == nil {
+		return "<nil>"
+	}
+	return value.(string)
+}
@@ -0,0 +1,129 @@
+package redis_test
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/zeroxsolutions/banshee/redis"
+	"github.com/zeroxsolutions/barbatos/cache"
+	"github.com/zeroxsolutions/strike/ssutil"
+)
+
+// TestRedisCache_Versioned interleaves writers performing read-modify-write cycles and verifies
+// that no update is lost.
+func TestRedisCache_Versioned(t *testing.T) {
+	redisCache := initRedisCache(t)
+
+	defer func(redisCache cache.Cache) {
+		if err := redisCache.Close(); err != nil {
+			t.Log("Close Redis cache connection err", err)
+		}
+	}(redisCache)
+
+	versioned := redisCache.(*redis.RedisCache)
+
+	ctx := context.Background()
+	key := ssutil.MakeString(10)
+
+	defer func() {
+		if err := versioned.Del(ctx, key); err != nil {
+			t.Error(err)
+		}
+	}()
+
+	const (
+		writers   = 8
+		increases = 10
+	)
+
+	var wg sync.WaitGroup
+	for range make([]int, writers) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < increases; {
+				value, version, err := versioned.GetVersioned(ctx, key)
+				if errors.Is(err, cache.ErrCacheNil) {
+					value, version = "0", 0
+				} else if err != nil {
+					t.Error(err)
+					return
+				}
+				n, _ := strconv.Atoi(value)
+				_, err = versioned.SetVersioned(ctx, key, strconv.Itoa(n+1), version, time.Minute)
+				if errors.Is(err, redis.ErrVersionConflict) {
+					continue
+				}
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				i++
+			}
+		}()
+	}
+	wg.Wait()
+
+	value, version, err := versioned.GetVersioned(ctx, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if value != strconv.Itoa(writers*increases) || version != writers*increases {
+		t.Log("lost updates:", value, version)
+		t.FailNow()
+	}
+}
+
+// TestRedisCache_VersionedConflict verifies create-only semantics and the conflict error payload.
+func TestRedisCache_VersionedConflict(t *testing.T) {
+	redisCache := initRedisCache(t)
+
+	defer func(redisCache cache.Cache) {
+		if err := redisCache.Close(); err != nil {
+			t.Log("Close Redis cache connection err", err)
+		}
+	}(redisCache)
+
+	versioned := redisCache.(*redis.RedisCache)
+
+	ctx := context.Background()
+	key := ssutil.MakeString(10)
+
+	defer func() {
+		if err := versioned.Del(ctx, key); err != nil {
+			t.Error(err)
+		}
+	}()
+
+	if _, _, err := versioned.GetVersioned(ctx, key); err != cache.ErrCacheNil {
+		t.Log(err)
+		t.FailNow()
+	}
+
+	version, err := versioned.SetVersioned(ctx, key, "first", 0, time.Minute)
+	if err != nil || version != 1 {
+		t.Fatal(version, err)
+	}
+
+	_, err = versioned.SetVersioned(ctx, key, "second", 0, time.Minute)
+
+	var conflict *redis.VersionConflictError
+	if !errors.As(err, &conflict) || conflict.Current != 1 {
+		t.Log(err)
+		t.FailNow()
+	}
+
+	value, _, err := versioned.GetVersioned(ctx, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if value != "first" {
+		t.FailNow()
+	}
+}
@@ -0,0 +1,196 @@
+// Package reqcache memoizes cache reads for the lifetime of a context, typically one HTTP request,
+// so that layers reading the same key repeatedly hit the backend once.
+//
+// A handler injects the backend into the request context once:
+//
+//	ctx = reqcache.Inject(r.Context(), redisCache)
+//
+// and every layer then reads through reqcache.From(ctx). The memoized values are dropped with the
+// context; values written by other requests or processes meanwhile aren't seen until the next one.
+package reqcache
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/zeroxsolutions/banshee/internal/valueutil"
+	"github.com/zeroxsolutions/banshee/memory"
+	"github.com/zeroxsolutions/barbatos/cache"
+)
+
+// contextKey is the context key under which Inject stores the request cache.
+type contextKey struct{}
+
+// entry is a memoized Get result. done is closed once value and err are set.
+type entry struct {
+	done  chan struct{}
+	value string
+	err   error
+}
+
+// Cache is a cache.Cache memoizing the reads of a backend for one request. It is safe for
+// concurrent use by goroutines sharing the request context.
+type Cache struct {
+	backend cache.Cache
+
+	mu      sync.Mutex
+	entries map[string]*entry
+}
+
+var _ cache.Cache = (*Cache)(nil)
+
+// Inject returns a copy of ctx carrying a new request cache reading through c.
+//
+// Parameters:
+//   - ctx: Request context
+//   - c: Backend cache
+//
+// Returns:
+//   - context.Context: The context to pass down the request
+//
+// Example:
+//
+//	func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+//	    s.mux.ServeHTTP(w, r.WithContext(reqcache.Inject(r.Context(), s.cache)))
+//	}
+func Inject(ctx context.Context, c cache.Cache) context.Context {
+	return context.WithValue(ctx, contextKey{}, &Cache{backend: c, entries: map[string]*entry{}})
+}
+
+// From returns the request cache injected into ctx, or nil if Inject wasn't called on it or on one
+// of its parents.
+//
+// Parameters:
+//   - ctx: Context derived from the one returned by Inject
+//
+// Returns:
+//   - cache.Cache: The request cache, or nil
+//
+// Example:
+//
+//	user, err := reqcache.From(ctx).Get(ctx, "user:42")
+func From(ctx context.Context) cache.Cache {
+	c, ok := ctx.Value(contextKey{}).(*Cache)
+	if !ok {
+		return nil
+	}
+	return c
+}
+
+// IsConnected reports the connection status of the backend.
+func (c *Cache) IsConnected(ctx context.Context) bool {
+	return c.backend.IsConnected(ctx)
+}
+
+// Keys returns the keys matching pattern in the backend. The result isn't memoized.
+func (c *Cache) Keys(ctx context.Context, pattern string) ([]string, error) {
+	return c.backend.Keys(ctx, pattern)
+}
+
+// Get returns the value stored under key, reading the backend only on the first call for key.
+// Hits and cache.ErrCacheNil are memoized; other errors aren't, so the next call retries.
+// Concurrent first calls for the same key share a single backend read.
+func (c *Cache) Get(ctx context.Context, key string) (string, error) {
+	c.mu.Lock()
+	if e, ok := c.entries[key]; ok {
+		c.mu.Unlock()
+		<-e.done
+		return e.value, e.err
+	}
+	e := &entry{done: make(chan struct{})}
+	c.entries[key] = e
+	c.mu.Unlock()
+
+	e.value, e.err = c.backend.Get(ctx, key)
+	if e.err != nil && !errors.Is(e.err, cache.ErrCacheNil) {
+		c.forget(key, e)
+	}
+	close(e.done)
+	return e.value, e.err
+}
+
+// Set stores value under key in the backend and memoizes it for the rest of the request. Only
+// string and []byte values are memoized; for other values the key is read from the backend again,
+// since only the backend knows how it converted them.
+func (c *Cache) Set(ctx context.Context, key string, value interface{}) error {
+	return c.write(key, value, c.backend.Set(ctx, key, value))
+}
+
+// SetWithExpiration stores value under key in the backend with expiration, and memoizes it as
+// Set does for the rest of the request, regardless of the expiration.
+func (c *Cache) SetWithExpiration(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
+	return c.write(key, value, c.backend.SetWithExpiration(ctx, key, value, expiration))
+}
+
+// Del deletes keys from the backend and memoizes them as missing.
+func (c *Cache) Del(ctx context.Context, keys ...string) error {
+	err := c.backend.Del(ctx, keys...)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, key := range keys {
+		if err != nil {
+			delete(c.entries, key)
+			continue
+		}
+		c.entries[key] = completed("", cache.ErrCacheNil)
+	}
+	return err
+}
+
+// DelWithPattern deletes the keys matching pattern from the backend and forgets the memoized keys
+// matching it, so they are read again.
+func (c *Cache) DelWithPattern(ctx context.Context, pattern string) error {
+	err := c.backend.DelWithPattern(ctx, pattern)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for key := range c.entries {
+		if memory.Match(pattern, key) {
+			delete(c.entries, key)
+		}
+	}
+	return err
+}
+
+// Close does nothing: the backend outlives the request and is closed by its owner.
+func (c *Cache) Close() error {
+	return nil
+}
+
+// write memoizes a string or []byte value written to the backend. It forgets the key if the
+// write failed or the value has another type, since the stored value is then unknown: backends
+// may convert values differently, or reject or delete nil ones, including typed nils such as
+// []byte(nil).
+func (c *Cache) write(key string, value interface{}, err error) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err == nil && !valueutil.IsNil(value) {
+		switch v := value.(type) {
+		case string:
+			c.entries[key] = completed(v, nil)
+			return nil
+		case []byte:
+			c.entries[key] = completed(string(v), nil)
+			return nil
+		}
+	}
+	delete(c.entries, key)
+	return err
+}
+
+// forget removes the memoized entry of key if it is still e.
+func (c *Cache) forget(key string, e *entry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.entries[key] == e {
+		delete(c.entries, key)
+	}
+}
+
+// completed returns an entry holding a known result.
+func completed(value string, err error) *entry {
+	e := &entry{done: make(chan struct{}), value: value, err: err}
+	close(e.done)
+	return e
+}
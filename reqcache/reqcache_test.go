@@ -0,0 +1,186 @@
+package reqcache_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/zeroxsolutions/banshee/memory"
+	"github.com/zeroxsolutions/banshee/reqcache"
+	"github.com/zeroxsolutions/barbatos/cache"
+)
+
+// spyCache counts the Get calls reaching the in-memory cache per key.
+type spyCache struct {
+	*memory.Cache
+	mu   sync.Mutex
+	gets map[string]int
+	fail error
+}
+
+func newSpyCache() *spyCache {
+	return &spyCache{Cache: memory.New(), gets: map[string]int{}}
+}
+
+func (s *spyCache) Get(ctx context.Context, key string) (string, error) {
+	s.mu.Lock()
+	s.gets[key]++
+	fail := s.fail
+	s.mu.Unlock()
+	// Widen the window in which concurrent readers of the same key overlap.
+	time.Sleep(time.Millisecond)
+	if fail != nil {
+		return "", fail
+	}
+	return s.Cache.Get(ctx, key)
+}
+
+func (s *spyCache) count(key string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.gets[key]
+}
+
+// TestReqCache verifies that each distinct key, present or missing, is read from the backend
+// once per request, even by concurrent goroutines, and that a new request reads it again.
+func TestReqCache(t *testing.T) {
+	backend := newSpyCache()
+	if err := backend.Set(context.Background(), "user:1", "alice"); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := reqcache.Inject(context.Background(), backend)
+	c := reqcache.From(ctx)
+
+	var wg sync.WaitGroup
+	var failures int32
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if v, err := c.Get(ctx, "user:1"); err != nil || v != "alice" {
+				atomic.AddInt32(&failures, 1)
+			}
+			if _, err := c.Get(ctx, "user:2"); !errors.Is(err, cache.ErrCacheNil) {
+				atomic.AddInt32(&failures, 1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if failures != 0 {
+		t.Fatal("unexpected results:", failures)
+	}
+	if backend.count("user:1") != 1 || backend.count("user:2") != 1 {
+		t.Fatal("unexpected backend reads:", backend.gets)
+	}
+
+	next := reqcache.Inject(context.Background(), backend)
+	if _, err := reqcache.From(next).Get(next, "user:1"); err != nil {
+		t.Fatal(err)
+	}
+	if backend.count("user:1") != 2 {
+		t.Fatal("a new request should read the backend again:", backend.gets)
+	}
+}
+
+// TestReqCache_Writes verifies that writes update the backend and the values read later in the
+// same request.
+func TestReqCache_Writes(t *testing.T) {
+	backend := newSpyCache()
+	ctx := reqcache.Inject(context.Background(), backend)
+	c := reqcache.From(ctx)
+
+	if _, err := c.Get(ctx, "user:1"); !errors.Is(err, cache.ErrCacheNil) {
+		t.Fatal(err)
+	}
+	if err := c.Set(ctx, "user:1", "alice"); err != nil {
+		t.Fatal(err)
+	}
+	if v, err := c.Get(ctx, "user:1"); err != nil || v != "alice" {
+		t.Fatal(v, err)
+	}
+	if v, err := backend.Cache.Get(ctx, "user:1"); err != nil || v != "alice" {
+		t.Fatal("the backend should be updated:", v, err)
+	}
+
+	// Non-string values are read back from the backend, which decides how they are stored.
+	if err := c.SetWithExpiration(ctx, "user:1", true, time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	if v, err := c.Get(ctx, "user:1"); err != nil || v != "1" {
+		t.Fatal(v, err)
+	}
+
+	if err := c.Del(ctx, "user:1"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Get(ctx, "user:1"); !errors.Is(err, cache.ErrCacheNil) {
+		t.Fatal(err)
+	}
+
+	if err := backend.Cache.Set(ctx, "user:1", "bob"); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.DelWithPattern(ctx, "user:9*"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Get(ctx, "user:1"); !errors.Is(err, cache.ErrCacheNil) {
+		t.Fatal("keys outside the pattern should stay memoized:", err)
+	}
+	if err := c.DelWithPattern(ctx, "user:*"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Get(ctx, "user:1"); !errors.Is(err, cache.ErrCacheNil) {
+		t.Fatal(err)
+	}
+
+	if backend.count("user:1") != 3 {
+		t.Fatal("unexpected backend reads:", backend.gets)
+	}
+
+	// Typed nils are read back too: backends may reject them or delete the key.
+	if err := c.Set(ctx, "user:2", []byte(nil)); err != nil {
+		t.Fatal(err)
+	}
+	_, _ = c.Get(ctx, "user:2")
+	if backend.count("user:2") != 1 {
+		t.Fatal("a nil value should not be memoized:", backend.gets)
+	}
+}
+
+// TestReqCache_Errors verifies that backend errors other than misses are not memoized, and that
+// From returns nil without Inject.
+func TestReqCache_Errors(t *testing.T) {
+	backend := newSpyCache()
+	backend.fail = errors.New("connection refused")
+	ctx := reqcache.Inject(context.Background(), backend)
+	c := reqcache.From(ctx)
+
+	if _, err := c.Get(ctx, "user:1"); !errors.Is(err, backend.fail) {
+		t.Fatal(err)
+	}
+	backend.mu.Lock()
+	backend.fail = nil
+	backend.mu.Unlock()
+	if _, err := c.Get(ctx, "user:1"); !errors.Is(err, cache.ErrCacheNil) {
+		t.Fatal(err)
+	}
+	if backend.count("user:1") != 2 {
+		t.Fatal("errors should not be memoized:", backend.gets)
+	}
+
+	if err := c.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if !backend.IsConnected(ctx) {
+		t.Fatal("closing the request cache must not close the backend")
+	}
+
+	if reqcache.From(context.Background()) != nil {
+		t.Fatal("From should return nil without Inject")
+	}
+}
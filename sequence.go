@@ -0,0 +1,143 @@
+package banshee
+
+import (
+	"context"
+	"errors"
+	"math"
+	"strconv"
+	"sync"
+
+	"github.com/zeroxsolutions/barbatos/cache"
+	"github.com/zeroxsolutions/strike/builderutil"
+)
+
+// sequenceKeyPrefix is the prefix of the keys holding sequences.
+const sequenceKeyPrefix = "sequence:"
+
+// ErrSequenceUnsupported is returned by NewSequence when the cache implements neither
+// CounterCache nor SetNXCache, which a sequence needs to allocate and initialize IDs atomically.
+var ErrSequenceUnsupported = errors.New("banshee: sequence requires a cache implementing CounterCache and SetNXCache")
+
+// ErrSequenceExhausted is returned by Next once the sequence has reached math.MaxInt64.
+var ErrSequenceExhausted = errors.New("banshee: sequence exhausted")
+
+// sequenceStore is the set of capabilities a Sequence relies on.
+type sequenceStore interface {
+	cache.Cache
+	CounterCache
+	SetNXCache
+}
+
+// Sequence hands out unique, increasing int64 IDs shared by every process using the same cache
+// and name, such as invoice numbers. A Sequence is safe for concurrent use.
+type Sequence struct {
+	cache   sequenceStore
+	key     string
+	options *SequenceOptions
+
+	mu          sync.Mutex
+	initialized bool
+	next, last  int64 // next and last bound the IDs reserved locally in block mode.
+}
+
+// NewSequence creates a handle on the sequence called name. Handles created with the same name,
+// in this or other processes, draw from the same sequence.
+//
+// Behavior:
+//   - Next increments the counter on the server, so IDs are unique and, without blocks, strictly
+//     increasing in the order the calls complete
+//   - The counter is created at SetStart-1 with SETNX on first use, so racing handles can't reset
+//     a sequence another handle already advanced
+//   - With SetBlockSize(n), each handle reserves n IDs per round trip and hands them out locally;
+//     IDs stay unique but are only increasing per handle
+//
+// Parameters:
+//   - c: Cache implementing CounterCache and SetNXCache, such as redis.RedisCache or memory.Cache
+//   - name: Name of the sequence; the counter is stored under "sequence:" + name
+//   - opts: Optional SequenceOptions builders created with NewSequenceOptions
+//
+// Returns:
+//   - *Sequence: The sequence handle
+//   - error: ErrSequenceUnsupported if c lacks a required capability, or an error if building the
+//     options fails
+//
+// Example:
+//
+//	invoices, err := banshee.NewSequence(redisCache, "invoice",
+//	    banshee.NewSequenceOptions().SetStart(100000))
+//	number, err := invoices.Next(ctx)
+func NewSequence(c cache.Cache, name string, opts ...builderutil.Lister[SequenceOptions]) (*Sequence, error) {
+	store, ok := c.(sequenceStore)
+	if !ok {
+		return nil, ErrSequenceUnsupported
+	}
+	options, err := builderutil.Build(append([]builderutil.Lister[SequenceOptions]{defaultSequenceOptions()}, opts...)...)
+	if err != nil {
+		return nil, err
+	}
+	return &Sequence{cache: store, key: sequenceKeyPrefix + name, options: options}, nil
+}
+
+// Next returns the next ID of the sequence.
+//
+// Parameters:
+//   - ctx: Context for timeout control and request cancellation
+//
+// Returns:
+//   - int64: The next ID
+//   - error: ErrSequenceExhausted if no IDs are left, or an error from the cache
+func (s *Sequence) Next(ctx context.Context) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.next != 0 && s.next <= s.last {
+		id := s.next
+		s.next++
+		return id, nil
+	}
+	if err := s.init(ctx); err != nil {
+		return 0, err
+	}
+	last, applied, err := s.cache.IncrByCeil(ctx, s.key, s.options.BlockSize, math.MaxInt64)
+	if err != nil {
+		return 0, err
+	}
+	if !applied {
+		return 0, ErrSequenceExhausted
+	}
+	id := last - s.options.BlockSize + 1
+	s.next, s.last = id+1, last
+	return id, nil
+}
+
+// Current returns the highest ID allocated so far by any handle, including IDs reserved in blocks
+// but not handed out yet. It returns SetStart-1 if no ID was allocated.
+//
+// Parameters:
+//   - ctx: Context for timeout control and request cancellation
+//
+// Returns:
+//   - int64: The highest allocated ID
+//   - error: An error from the cache, or if the stored counter isn't an integer
+func (s *Sequence) Current(ctx context.Context) (int64, error) {
+	value, err := s.cache.Get(ctx, s.key)
+	if errors.Is(err, cache.ErrCacheNil) {
+		return s.options.Start - 1, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseInt(value, 10, 64)
+}
+
+// init creates the counter at Start-1 unless it exists. It runs once per handle; a failed attempt
+// is retried by the next call. The caller must hold s.mu.
+func (s *Sequence) init(ctx context.Context) error {
+	if s.initialized {
+		return nil
+	}
+	if _, err := s.cache.SetNX(ctx, s.key, s.options.Start-1, 0); err != nil {
+		return err
+	}
+	s.initialized = true
+	return nil
+}
@@ -0,0 +1,81 @@
+package banshee
+
+import "errors"
+
+// SequenceOptions holds the settings of a Sequence.
+// This struct is populated through SequenceOptionsBuilder and consumed by NewSequence.
+type SequenceOptions struct {
+	Start     int64 // Start is the first ID the sequence hands out when its key doesn't exist yet.
+	BlockSize int64 // BlockSize is the number of IDs reserved per round trip; 1 disables blocks.
+}
+
+// SequenceOptionsBuilder provides a builder pattern for constructing SequenceOptions.
+// This builder implements the builderutil.Lister interface to work with the functional options pattern.
+type SequenceOptionsBuilder struct {
+	Opts []func(*SequenceOptions) error // Opts contains the list of option functions to be applied
+}
+
+// SetStart configures the first ID of a new sequence, e.g. 100000 to keep invoice numbers at six
+// digits. It only applies when the sequence's key doesn't exist yet; an existing sequence is
+// never reset.
+//
+// Parameters:
+//   - start: First ID of the sequence
+//
+// Returns:
+//   - *SequenceOptionsBuilder: The builder instance for method chaining
+func (b *SequenceOptionsBuilder) SetStart(start int64) *SequenceOptionsBuilder {
+	b.Opts = append(b.Opts, func(o *SequenceOptions) error {
+		o.Start = start
+		return nil
+	})
+	return b
+}
+
+// SetBlockSize makes the sequence reserve blockSize IDs per round trip and hand them out locally.
+// This cuts the load on the cache by a factor of blockSize, at the cost of strict ordering: IDs
+// are unique across handles but interleave between processes, and IDs reserved by a handle that
+// goes away are never used.
+//
+// Parameters:
+//   - blockSize: Number of IDs reserved at once, at least 1
+//
+// Returns:
+//   - *SequenceOptionsBuilder: The builder instance for method chaining
+func (b *SequenceOptionsBuilder) SetBlockSize(blockSize int64) *SequenceOptionsBuilder {
+	b.Opts = append(b.Opts, func(o *SequenceOptions) error {
+		if blockSize < 1 {
+			return errors.New("banshee: sequence block size must be at least 1")
+		}
+		o.BlockSize = blockSize
+		return nil
+	})
+	return b
+}
+
+// List returns the slice of option functions accumulated by the builder.
+// This method implements the builderutil.Lister interface.
+//
+// Returns:
+//   - []func(*SequenceOptions) error: A slice of option functions that can be applied to configure SequenceOptions
+func (b *SequenceOptionsBuilder) List() []func(*SequenceOptions) error {
+	return b.Opts
+}
+
+// NewSequenceOptions creates and returns a new instance of SequenceOptionsBuilder.
+//
+// Returns:
+//   - *SequenceOptionsBuilder: A new instance of SequenceOptionsBuilder ready to be configured
+//
+// Example:
+//
+//	opts := banshee.NewSequenceOptions().SetStart(100000).SetBlockSize(50)
+func NewSequenceOptions() *SequenceOptionsBuilder {
+	return &SequenceOptionsBuilder{}
+}
+
+// defaultSequenceOptions returns the builder applied before user options: IDs start at 1 and are
+// reserved one at a time.
+func defaultSequenceOptions() *SequenceOptionsBuilder {
+	return NewSequenceOptions().SetStart(1).SetBlockSize(1)
+}
@@ -0,0 +1,110 @@
+package banshee_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/zeroxsolutions/banshee"
+	"github.com/zeroxsolutions/banshee/memory"
+)
+
+// TestSequence verifies that IDs start at the configured value, increase strictly, and that an
+// existing sequence is never reset.
+func TestSequence(t *testing.T) {
+	ctx := context.Background()
+	c := memory.New()
+
+	seq, err := banshee.NewSequence(c, "invoice", banshee.NewSequenceOptions().SetStart(100))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if current, err := seq.Current(ctx); err != nil || current != 99 {
+		t.Fatal(current, err)
+	}
+	for want := int64(100); want < 103; want++ {
+		if id, err := seq.Next(ctx); err != nil || id != want {
+			t.Fatalf("got %d, %v, want %d", id, err, want)
+		}
+	}
+
+	other, err := banshee.NewSequence(c, "invoice", banshee.NewSequenceOptions().SetStart(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id, err := other.Next(ctx); err != nil || id != 103 {
+		t.Fatal("a new handle must continue the sequence:", id, err)
+	}
+	if current, err := seq.Current(ctx); err != nil || current != 103 {
+		t.Fatal(current, err)
+	}
+}
+
+// TestSequence_Concurrent runs many goroutines over several handles and checks that IDs are
+// unique, and strictly increasing per goroutine without blocks.
+func TestSequence_Concurrent(t *testing.T) {
+	const handles, workers, perWorker = 4, 8, 50
+
+	for _, blockSize := range []int64{1, 7} {
+		c := memory.New()
+		ctx := context.Background()
+
+		var mu sync.Mutex
+		seen := map[int64]bool{}
+		var wg sync.WaitGroup
+		for h := 0; h < handles; h++ {
+			seq, err := banshee.NewSequence(c, "ids", banshee.NewSequenceOptions().SetBlockSize(blockSize))
+			if err != nil {
+				t.Fatal(err)
+			}
+			for w := 0; w < workers; w++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					var previous int64
+					for i := 0; i < perWorker; i++ {
+						id, err := seq.Next(ctx)
+						if err != nil {
+							t.Error(err)
+							return
+						}
+						if id <= previous {
+							t.Errorf("block size %d: id %d after %d", blockSize, id, previous)
+						}
+						previous = id
+						mu.Lock()
+						if seen[id] {
+							t.Errorf("block size %d: duplicate id %d", blockSize, id)
+						}
+						seen[id] = true
+						mu.Unlock()
+					}
+				}()
+			}
+		}
+		wg.Wait()
+
+		if len(seen) != handles*workers*perWorker {
+			t.Fatalf("block size %d: got %d ids", blockSize, len(seen))
+		}
+		if blockSize == 1 {
+			for id := int64(1); id <= handles*workers*perWorker; id++ {
+				if !seen[id] {
+					t.Fatalf("missing id %d", id)
+				}
+			}
+		}
+	}
+}
+
+// TestSequence_Errors verifies option validation and the capability check.
+func TestSequence_Errors(t *testing.T) {
+	if _, err := banshee.NewSequence(memory.New(), "ids", banshee.NewSequenceOptions().SetBlockSize(0)); err == nil {
+		t.Fatal("expected an error for a zero block size")
+	}
+	if _, err := banshee.NewSequence(plainCache{memory.New()}, "ids"); !errors.Is(err, banshee.ErrSequenceUnsupported) {
+		t.Fatal(err)
+	}
+}
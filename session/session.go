@@ -0,0 +1,260 @@
+// Package session stores HTTP sessions in a cache.
+//
+// Tokens are 32 random bytes encoded with unpadded base64url. They are never stored: each session
+// is kept under the following key:
+//
+//	<prefix> + <hex SHA-256 of the token>
+//
+// so a dump of the cache doesn't reveal usable tokens. The session data is JSON-encoded together
+// with the creation time. Errors returned by this package never include tokens.
+package session
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/zeroxsolutions/banshee"
+	"github.com/zeroxsolutions/barbatos/cache"
+	"github.com/zeroxsolutions/strike/builderutil"
+)
+
+// tokenSize is the number of random bytes in a token.
+const tokenSize = 32
+
+// ErrInvalidToken is returned for tokens that can't have been issued by a Store, e.g. truncated
+// or tampered with. No cache lookup is made for them.
+var ErrInvalidToken = errors.New("session: invalid token")
+
+// ErrNotFound is returned for well-formed tokens without a live session: unknown, destroyed, idle
+// for longer than the idle timeout, or older than the absolute lifetime.
+var ErrNotFound = errors.New("session: not found")
+
+// record is the stored form of a session.
+type record struct {
+	Data    map[string]any `json:"data"`
+	Created int64          `json:"created"` // Created is the creation time in unix milliseconds.
+}
+
+// Store creates and manages sessions in a cache.
+type Store struct {
+	cache   cache.Cache
+	options *Options
+}
+
+// NewStore creates a session Store over c.
+//
+// Behavior:
+//   - A session expires after the idle timeout unless Refresh or Update is called in time
+//   - With SetAbsoluteLifetime, a session also expires once it is that old, whatever its activity
+//   - Refresh uses Expire when c implements banshee.Expirer, and otherwise rewrites the session
+//   - Update uses SetXX when c implements banshee.SetXXCache, so a session destroyed concurrently
+//     stays destroyed; on other caches the write may recreate it
+//
+// Parameters:
+//   - c: Cache storing the sessions
+//   - opts: Optional Options builders created with NewOptions
+//
+// Returns:
+//   - *Store: The session store
+//   - error: An error if building the options fails
+//
+// Example:
+//
+//	sessions, err := session.NewStore(redisCache, session.NewOptions().SetAbsoluteLifetime(12*time.Hour))
+//	token, err := sessions.Create(ctx, map[string]any{"user_id": 42})
+func NewStore(c cache.Cache, opts ...builderutil.Lister[Options]) (*Store, error) {
+	options, err := builderutil.Build(append([]builderutil.Lister[Options]{defaultOptions()}, opts...)...)
+	if err != nil {
+		return nil, err
+	}
+	return &Store{cache: c, options: options}, nil
+}
+
+// Create stores a new session holding data and returns its token.
+//
+// Parameters:
+//   - ctx: Context for timeout control and request cancellation
+//   - data: Session data, which must be JSON-encodable
+//
+// Returns:
+//   - string: The session token, to hand to the client, e.g. in a cookie
+//   - error: An error if data can't be encoded, random bytes can't be read, or the cache fails
+func (s *Store) Create(ctx context.Context, data map[string]any) (string, error) {
+	raw := make([]byte, tokenSize)
+	if _, err := rand.Read(raw); err != nil {
+		return "", err
+	}
+	token := base64.RawURLEncoding.EncodeToString(raw)
+	rec := record{Data: data, Created: s.options.Clock.Now().UnixMilli()}
+	if err := s.write(ctx, s.key(raw), rec); err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// Get returns the data of the session identified by token. It doesn't extend the session.
+//
+// Parameters:
+//   - ctx: Context for timeout control and request cancellation
+//   - token: Session token returned by Create
+//
+// Returns:
+//   - map[string]any: The session data
+//   - error: ErrInvalidToken, ErrNotFound, or an error from the cache
+func (s *Store) Get(ctx context.Context, token string) (map[string]any, error) {
+	_, rec, err := s.read(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	return rec.Data, nil
+}
+
+// Refresh resets the idle timeout of the session identified by token, within its absolute
+// lifetime.
+//
+// Parameters:
+//   - ctx: Context for timeout control and request cancellation
+//   - token: Session token returned by Create
+//
+// Returns:
+//   - error: ErrInvalidToken, ErrNotFound, or an error from the cache
+func (s *Store) Refresh(ctx context.Context, token string) error {
+	key, rec, err := s.read(ctx, token)
+	if err != nil {
+		return err
+	}
+	if expirer, ok := s.cache.(banshee.Expirer); ok {
+		found, err := expirer.Expire(ctx, key, s.ttl(rec))
+		if err != nil {
+			return err
+		}
+		if !found {
+			return ErrNotFound
+		}
+		return nil
+	}
+	return s.write(ctx, key, rec)
+}
+
+// Update replaces the data of the session identified by token and resets its idle timeout, within
+// its absolute lifetime. When the cache implements banshee.SetXXCache, the write only applies if
+// the session still exists, so a session destroyed concurrently is not recreated and Update
+// returns ErrNotFound; on other caches the write may recreate it.
+//
+// Parameters:
+//   - ctx: Context for timeout control and request cancellation
+//   - token: Session token returned by Create
+//   - data: New session data, which must be JSON-encodable
+//
+// Returns:
+//   - error: ErrInvalidToken, ErrNotFound, an error if data can't be encoded, or an error from the
+//     cache
+func (s *Store) Update(ctx context.Context, token string, data map[string]any) error {
+	key, rec, err := s.read(ctx, token)
+	if err != nil {
+		return err
+	}
+	rec.Data = data
+	if setter, ok := s.cache.(banshee.SetXXCache); ok {
+		value, err := json.Marshal(rec)
+		if err != nil {
+			return err
+		}
+		stored, err := setter.SetXX(ctx, key, value, s.ttl(rec))
+		if err != nil {
+			return err
+		}
+		if !stored {
+			return ErrNotFound
+		}
+		return nil
+	}
+	return s.write(ctx, key, rec)
+}
+
+// Destroy deletes the session identified by token, e.g. on logout. Destroying an unknown session
+// is not an error.
+//
+// Parameters:
+//   - ctx: Context for timeout control and request cancellation
+//   - token: Session token returned by Create
+//
+// Returns:
+//   - error: ErrInvalidToken, or an error from the cache
+func (s *Store) Destroy(ctx context.Context, token string) error {
+	raw, err := decode(token)
+	if err != nil {
+		return err
+	}
+	return s.cache.Del(ctx, s.key(raw))
+}
+
+// read loads the live session identified by token, deleting it if it outlived its absolute
+// lifetime.
+func (s *Store) read(ctx context.Context, token string) (string, record, error) {
+	raw, err := decode(token)
+	if err != nil {
+		return "", record{}, err
+	}
+	key := s.key(raw)
+	value, err := s.cache.Get(ctx, key)
+	if errors.Is(err, cache.ErrCacheNil) {
+		return "", record{}, ErrNotFound
+	}
+	if err != nil {
+		return "", record{}, err
+	}
+	var rec record
+	if err := json.Unmarshal([]byte(value), &rec); err != nil {
+		return "", record{}, err
+	}
+	if s.ttl(rec) <= 0 {
+		if err := s.cache.Del(ctx, key); err != nil {
+			return "", record{}, err
+		}
+		return "", record{}, ErrNotFound
+	}
+	return key, rec, nil
+}
+
+// write stores rec under key with the TTL it is entitled to.
+func (s *Store) write(ctx context.Context, key string, rec record) error {
+	value, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+	return s.cache.SetWithExpiration(ctx, key, value, s.ttl(rec))
+}
+
+// ttl returns the idle timeout, capped by the time left before the absolute lifetime elapses.
+func (s *Store) ttl(rec record) time.Duration {
+	ttl := s.options.IdleTimeout
+	if s.options.AbsoluteLifetime > 0 {
+		left := time.UnixMilli(rec.Created).Add(s.options.AbsoluteLifetime).Sub(s.options.Clock.Now())
+		if left < ttl {
+			ttl = left
+		}
+	}
+	return ttl
+}
+
+// key returns the cache key of the session whose token decodes to raw.
+func (s *Store) key(raw []byte) string {
+	sum := sha256.Sum256(raw)
+	return s.options.Prefix + hex.EncodeToString(sum[:])
+}
+
+// decode returns the random bytes of token, or ErrInvalidToken.
+func decode(token string) ([]byte, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil || len(raw) != tokenSize {
+		return nil, ErrInvalidToken
+	}
+	return raw, nil
+}
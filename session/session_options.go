@@ -0,0 +1,134 @@
+package session
+
+import (
+	"errors"
+	"time"
+
+	"github.com/zeroxsolutions/banshee"
+	"github.com/zeroxsolutions/strike/builderutil"
+)
+
+const (
+	// DefaultPrefix is the key prefix used when none is configured.
+	DefaultPrefix = "session:"
+
+	// DefaultIdleTimeout is how long an unused session lives when no idle timeout is configured.
+	DefaultIdleTimeout = 30 * time.Minute
+)
+
+// Options holds the settings of a Store.
+// This struct is populated through OptionsBuilder and consumed by NewStore.
+type Options struct {
+	Prefix           string        // Prefix is prepended to every session key.
+	IdleTimeout      time.Duration // IdleTimeout is how long a session lives without Refresh or Update.
+	AbsoluteLifetime time.Duration // AbsoluteLifetime is the maximum age of a session; 0 disables it.
+	Clock            banshee.Clock // Clock dates sessions and enforces the absolute lifetime.
+}
+
+// OptionsBuilder provides a builder pattern for constructing Options.
+// This builder implements the builderutil.Lister interface to work with the functional options pattern.
+type OptionsBuilder struct {
+	Opts []func(*Options) error // Opts contains the list of option functions to be applied
+}
+
+// SetPrefix configures the prefix of every session key.
+//
+// Parameters:
+//   - prefix: Key prefix, e.g. "admin:session:"
+//
+// Returns:
+//   - *OptionsBuilder: The builder instance for method chaining
+func (b *OptionsBuilder) SetPrefix(prefix string) *OptionsBuilder {
+	b.Opts = append(b.Opts, func(o *Options) error {
+		o.Prefix = prefix
+		return nil
+	})
+	return b
+}
+
+// SetIdleTimeout configures how long a session lives after its creation or its last Refresh or
+// Update. The timeout is enforced by the cache TTL.
+//
+// Parameters:
+//   - timeout: Idle timeout, must be positive
+//
+// Returns:
+//   - *OptionsBuilder: The builder instance for method chaining
+func (b *OptionsBuilder) SetIdleTimeout(timeout time.Duration) *OptionsBuilder {
+	b.Opts = append(b.Opts, func(o *Options) error {
+		if timeout <= 0 {
+			return errors.New("session: idle timeout must be positive")
+		}
+		o.IdleTimeout = timeout
+		return nil
+	})
+	return b
+}
+
+// SetAbsoluteLifetime configures the maximum age of a session, however active it is, e.g. 12 hours
+// to force a daily login. Refresh and Update never extend a session past it.
+//
+// Parameters:
+//   - lifetime: Maximum session age, or 0 to disable the limit
+//
+// Returns:
+//   - *OptionsBuilder: The builder instance for method chaining
+func (b *OptionsBuilder) SetAbsoluteLifetime(lifetime time.Duration) *OptionsBuilder {
+	b.Opts = append(b.Opts, func(o *Options) error {
+		if lifetime < 0 {
+			return errors.New("session: absolute lifetime must not be negative")
+		}
+		o.AbsoluteLifetime = lifetime
+		return nil
+	})
+	return b
+}
+
+// SetClock configures the clock dating sessions and enforcing the absolute lifetime. The idle
+// timeout is enforced by the cache TTL, so with a fake clock use a cache sharing it, such as
+// memory.NewWithClock.
+//
+// Parameters:
+//   - clock: Clock telling the current time, must not be nil
+//
+// Returns:
+//   - *OptionsBuilder: The builder instance for method chaining
+func (b *OptionsBuilder) SetClock(clock banshee.Clock) *OptionsBuilder {
+	b.Opts = append(b.Opts, func(o *Options) error {
+		if clock == nil {
+			return errors.New("session: clock must not be nil")
+		}
+		o.Clock = clock
+		return nil
+	})
+	return b
+}
+
+// List returns the slice of option functions accumulated by the builder.
+// This method implements the builderutil.Lister interface.
+//
+// Returns:
+//   - []func(*Options) error: A slice of option functions that can be applied to configure Options
+func (b *OptionsBuilder) List() []func(*Options) error {
+	return b.Opts
+}
+
+// NewOptions creates and returns a new instance of OptionsBuilder.
+//
+// Returns:
+//   - *OptionsBuilder: A new instance of OptionsBuilder ready to be configured
+//
+// Example:
+//
+//	opts := session.NewOptions().SetIdleTimeout(time.Hour).SetAbsoluteLifetime(12 * time.Hour)
+func NewOptions() *OptionsBuilder {
+	return &OptionsBuilder{}
+}
+
+// defaultOptions returns the builder holding the Store defaults.
+func defaultOptions() builderutil.Lister[Options] {
+	return NewOptions().
+		SetPrefix(DefaultPrefix).
+		SetIdleTimeout(DefaultIdleTimeout).
+		SetClock(banshee.SystemClock)
+}
@@ -0,0 +1,250 @@
+package session_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/zeroxsolutions/banshee/memory"
+	"github.com/zeroxsolutions/banshee/session"
+)
+
+// manualClock is a banshee.Clock that only moves when the test changes now.
+type manualClock struct {
+	now time.Time
+}
+
+func (c *manualClock) Now() time.Time {
+	return c.now
+}
+
+// newStore creates a Store over an in-memory cache sharing a manual clock.
+func newStore(t *testing.T, opts *session.OptionsBuilder) (*session.Store, *memory.Cache, *manualClock) {
+	clock := &manualClock{now: time.Now()}
+	c := memory.NewWithClock(clock)
+	store, err := session.NewStore(c, opts.SetClock(clock))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return store, c, clock
+}
+
+// TestStore_Lifecycle verifies creating, reading, updating and destroying a session, and that the
+// token isn't used as the key.
+func TestStore_Lifecycle(t *testing.T) {
+	ctx := context.Background()
+	store, c, _ := newStore(t, session.NewOptions())
+
+	token, err := store.Create(ctx, map[string]any{"user_id": "42"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := store.Get(ctx, token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if data["user_id"] != "42" {
+		t.Fatal("unexpected data:", data)
+	}
+
+	keys, err := c.Keys(ctx, "*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(keys) != 1 || !strings.HasPrefix(keys[0], session.DefaultPrefix) || strings.Contains(keys[0], token) {
+		t.Fatal("unexpected keys:", keys)
+	}
+
+	if err := store.Update(ctx, token, map[string]any{"user_id": "42", "theme": "dark"}); err != nil {
+		t.Fatal(err)
+	}
+	if data, err := store.Get(ctx, token); err != nil || data["theme"] != "dark" {
+		t.Fatal(data, err)
+	}
+
+	if err := store.Destroy(ctx, token); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.Get(ctx, token); !errors.Is(err, session.ErrNotFound) {
+		t.Fatal(err)
+	}
+	if err := store.Refresh(ctx, token); !errors.Is(err, session.ErrNotFound) {
+		t.Fatal(err)
+	}
+	if err := store.Destroy(ctx, token); err != nil {
+		t.Fatal(err)
+	}
+
+	other, err := store.Create(ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if other == token {
+		t.Fatal("tokens should be unique")
+	}
+}
+
+// TestStore_IdleTimeout verifies that sessions expire when idle and that Refresh and Update push
+// the expiration back.
+func TestStore_IdleTimeout(t *testing.T) {
+	ctx := context.Background()
+	store, _, clock := newStore(t, session.NewOptions().SetIdleTimeout(10*time.Minute))
+
+	token, err := store.Create(ctx, map[string]any{"user_id": "42"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	clock.now = clock.now.Add(9 * time.Minute)
+	if err := store.Refresh(ctx, token); err != nil {
+		t.Fatal(err)
+	}
+	clock.now = clock.now.Add(9 * time.Minute)
+	if err := store.Update(ctx, token, map[string]any{"user_id": "43"}); err != nil {
+		t.Fatal(err)
+	}
+	clock.now = clock.now.Add(9 * time.Minute)
+	if _, err := store.Get(ctx, token); err != nil {
+		t.Fatal("the session should still be alive:", err)
+	}
+
+	clock.now = clock.now.Add(time.Minute)
+	if _, err := store.Get(ctx, token); !errors.Is(err, session.ErrNotFound) {
+		t.Fatal(err)
+	}
+}
+
+// TestStore_AbsoluteLifetime verifies that refreshing keeps a session alive only until its
+// absolute lifetime.
+func TestStore_AbsoluteLifetime(t *testing.T) {
+	ctx := context.Background()
+	store, _, clock := newStore(t, session.NewOptions().SetIdleTimeout(10*time.Minute).SetAbsoluteLifetime(25*time.Minute))
+
+	token, err := store.Create(ctx, map[string]any{"user_id": "42"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 2; i++ {
+		clock.now = clock.now.Add(9 * time.Minute)
+		if err := store.Refresh(ctx, token); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// 24 minutes old: alive, but the last refresh only granted 7 more minutes instead of 10.
+	clock.now = clock.now.Add(6 * time.Minute)
+	if _, err := store.Get(ctx, token); err != nil {
+		t.Fatal("the session should still be alive:", err)
+	}
+	clock.now = clock.now.Add(time.Minute)
+	if _, err := store.Get(ctx, token); !errors.Is(err, session.ErrNotFound) {
+		t.Fatal(err)
+	}
+}
+
+// TestStore_InvalidTokens verifies that malformed, tampered and unknown tokens are rejected
+// without leaking the token in errors.
+func TestStore_InvalidTokens(t *testing.T) {
+	ctx := context.Background()
+	store, _, _ := newStore(t, session.NewOptions())
+
+	token, err := store.Create(ctx, map[string]any{"user_id": "42"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tampered := []byte(token)
+	if tampered[0] == 'A' {
+		tampered[0] = 'B'
+	} else {
+		tampered[0] = 'A'
+	}
+
+	cases := map[string]struct {
+		token string
+		want  error
+	}{
+		"Empty":     {"", session.ErrInvalidToken},
+		"Garbage":   {"not a token!", session.ErrInvalidToken},
+		"Truncated": {token[:len(token)-2], session.ErrInvalidToken},
+		"Extended":  {token + "AA", session.ErrInvalidToken},
+		"Tampered":  {string(tampered), session.ErrNotFound},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := store.Get(ctx, c.token)
+			if !errors.Is(err, c.want) {
+				t.Fatal(err)
+			}
+			if c.token != "" && strings.Contains(err.Error(), c.token) {
+				t.Fatal("the error leaks the token")
+			}
+			if err := store.Refresh(ctx, c.token); !errors.Is(err, c.want) {
+				t.Fatal(err)
+			}
+			if err := store.Update(ctx, c.token, nil); !errors.Is(err, c.want) {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+// TestNewStore_InvalidOptions verifies option validation.
+func TestNewStore_InvalidOptions(t *testing.T) {
+	for name, opts := range map[string]*session.OptionsBuilder{
+		"IdleTimeout":      session.NewOptions().SetIdleTimeout(0),
+		"AbsoluteLifetime": session.NewOptions().SetAbsoluteLifetime(-time.Second),
+		"Clock":            session.NewOptions().SetClock(nil),
+	} {
+		if _, err := session.NewStore(memory.New(), opts); err == nil {
+			t.Error(name, "expected an error")
+		}
+	}
+}
+
+// destroyingCache deletes every key right after it is read, as a Destroy racing with the caller
+// would.
+type destroyingCache struct {
+	*memory.Cache
+	destroy bool
+}
+
+func (c *destroyingCache) Get(ctx context.Context, key string) (string, error) {
+	value, err := c.Cache.Get(ctx, key)
+	if err == nil && c.destroy {
+		if err := c.Cache.Del(ctx, key); err != nil {
+			return "", err
+		}
+	}
+	return value, err
+}
+
+// TestStore_UpdateDestroyed verifies that an Update racing with a Destroy doesn't recreate the
+// session.
+func TestStore_UpdateDestroyed(t *testing.T) {
+	ctx := context.Background()
+	c := &destroyingCache{Cache: memory.New()}
+	store, err := session.NewStore(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	token, err := store.Create(ctx, map[string]any{"user_id": "42"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c.destroy = true
+	if err := store.Update(ctx, token, map[string]any{"user_id": "42", "theme": "dark"}); !errors.Is(err, session.ErrNotFound) {
+		t.Fatal(err)
+	}
+
+	c.destroy = false
+	if _, err := store.Get(ctx, token); !errors.Is(err, session.ErrNotFound) {
+		t.Fatal("a destroyed session was recreated:", err)
+	}
+}
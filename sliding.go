@@ -0,0 +1,152 @@
+package banshee
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/zeroxsolutions/barbatos/cache"
+	"github.com/zeroxsolutions/strike/builderutil"
+)
+
+const (
+	// touchTimeout bounds each asynchronous TTL refresh, which outlives the Get that triggered it.
+	touchTimeout = 5 * time.Second
+
+	// touchSweepSize is the number of tracked keys above which stale throttling entries are swept.
+	touchSweepSize = 1024
+)
+
+// SlidingCache is a cache.Cache decorator giving keys sliding expiration: every read hit pushes
+// the key's expiration back to the configured TTL, so a key expires TTL after its last access
+// rather than after its last write.
+type SlidingCache struct {
+	cache   cache.Cache
+	ttl     time.Duration
+	options *SlidingOptions
+
+	mu        sync.Mutex
+	touched   map[string]time.Time
+	lastSweep time.Time
+}
+
+var _ cache.Cache = (*SlidingCache)(nil)
+
+// WithSlidingExpiration wraps c so that reads extend the TTL of the keys they hit.
+//
+// Behavior:
+//   - A Get hit refreshes the key's TTL to ttl in the background; the Get itself never waits for it
+//   - Misses and errors never refresh anything
+//   - The refresh uses Expire when c implements Expirer, and otherwise rewrites the value that was
+//     just read with SetWithExpiration, which can overwrite a concurrent write with the older value
+//   - Set stores values with ttl; SetWithExpiration keeps the explicit expiration for that write
+//   - SetTouchInterval limits refreshes to one per key per interval to cut write amplification
+//
+// Parameters:
+//   - c: Underlying cache
+//   - ttl: Idle time after which keys expire
+//   - opts: Optional SlidingOptions builders created with NewSlidingOptions
+//
+// Returns:
+//   - *SlidingCache: The sliding-expiration cache
+//   - error: An error if building the options fails
+//
+// Example:
+//
+//	sessions, err := banshee.WithSlidingExpiration(redisCache, 30*time.Minute,
+//	    banshee.NewSlidingOptions().SetTouchInterval(time.Minute))
+func WithSlidingExpiration(c cache.Cache, ttl time.Duration, opts ...builderutil.Lister[SlidingOptions]) (*SlidingCache, error) {
+	options, err := builderutil.Build(opts...)
+	if err != nil {
+		return nil, err
+	}
+	if options.Clock == nil {
+		options.Clock = SystemClock
+	}
+	return &SlidingCache{cache: c, ttl: ttl, options: options, touched: map[string]time.Time{}}, nil
+}
+
+// IsConnected reports the connection status of the underlying cache.
+func (s *SlidingCache) IsConnected(ctx context.Context) bool {
+	return s.cache.IsConnected(ctx)
+}
+
+// Keys returns the keys matching pattern in the underlying cache.
+func (s *SlidingCache) Keys(ctx context.Context, pattern string) ([]string, error) {
+	return s.cache.Keys(ctx, pattern)
+}
+
+// Get retrieves the value stored under key and, on a hit, schedules a TTL refresh.
+func (s *SlidingCache) Get(ctx context.Context, key string) (string, error) {
+	value, err := s.cache.Get(ctx, key)
+	if err != nil {
+		return "", err
+	}
+	if s.shouldTouch(key) {
+		go s.touch(key, value)
+	}
+	return value, nil
+}
+
+// Set stores value under key with the sliding TTL.
+func (s *SlidingCache) Set(ctx context.Context, key string, value interface{}) error {
+	return s.cache.SetWithExpiration(ctx, key, value, s.ttl)
+}
+
+// SetWithExpiration stores value under key with an explicit expiration for this write.
+// The next read hit still resets the TTL to the sliding duration.
+func (s *SlidingCache) SetWithExpiration(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
+	return s.cache.SetWithExpiration(ctx, key, value, expiration)
+}
+
+// Del deletes keys from the underlying cache.
+func (s *SlidingCache) Del(ctx context.Context, keys ...string) error {
+	return s.cache.Del(ctx, keys...)
+}
+
+// DelWithPattern deletes the keys matching pattern from the underlying cache.
+func (s *SlidingCache) DelWithPattern(ctx context.Context, pattern string) error {
+	return s.cache.DelWithPattern(ctx, pattern)
+}
+
+// Close closes the underlying cache.
+func (s *SlidingCache) Close() error {
+	return s.cache.Close()
+}
+
+// shouldTouch reports whether key is due for a refresh and records the refresh time.
+// Entries older than the interval are swept once the map grows, at most once per interval so that
+// inserts stay O(1) amortized, bounding its size by the number of keys read within two intervals.
+func (s *SlidingCache) shouldTouch(key string) bool {
+	if s.options.TouchInterval <= 0 {
+		return true
+	}
+	now := s.options.Clock.Now()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if last, ok := s.touched[key]; ok && now.Sub(last) < s.options.TouchInterval {
+		return false
+	}
+	if len(s.touched) >= touchSweepSize && now.Sub(s.lastSweep) >= s.options.TouchInterval {
+		s.lastSweep = now
+		for k, last := range s.touched {
+			if now.Sub(last) >= s.options.TouchInterval {
+				delete(s.touched, k)
+			}
+		}
+	}
+	s.touched[key] = now
+	return true
+}
+
+// touch refreshes the TTL of key. Failures are ignored: the key then simply expires on its
+// previous schedule.
+func (s *SlidingCache) touch(key, value string) {
+	ctx, cancel := context.WithTimeout(context.Background(), touchTimeout)
+	defer cancel()
+	if expirer, ok := s.cache.(Expirer); ok {
+		_, _ = expirer.Expire(ctx, key, s.ttl)
+		return
+	}
+	_ = s.cache.SetWithExpiration(ctx, key, value, s.ttl)
+}
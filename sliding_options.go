@@ -0,0 +1,80 @@
+package banshee
+
+import (
+	"errors"
+	"time"
+)
+
+// SlidingOptions holds the settings of a SlidingCache.
+// This struct is populated through SlidingOptionsBuilder and consumed by WithSlidingExpiration.
+type SlidingOptions struct {
+	TouchInterval time.Duration // TouchInterval is the minimum time between two TTL refreshes of the same key.
+	Clock         Clock         // Clock measures the touch interval; SystemClock when nil.
+}
+
+// SlidingOptionsBuilder provides a builder pattern for constructing SlidingOptions.
+// This builder implements the builderutil.Lister interface to work with the functional options pattern.
+type SlidingOptionsBuilder struct {
+	Opts []func(*SlidingOptions) error // Opts contains the list of option functions to be applied
+}
+
+// SetTouchInterval configures the minimum time between two TTL refreshes of the same key.
+// Hot keys read thousands of times per second otherwise cost one Expire per read; with an
+// interval, a key's effective idle timeout lies between ttl-interval and ttl. The default of 0
+// refreshes on every hit.
+//
+// Parameters:
+//   - interval: Minimum time between refreshes of one key, must not be negative
+//
+// Returns:
+//   - *SlidingOptionsBuilder: The builder instance for method chaining
+func (b *SlidingOptionsBuilder) SetTouchInterval(interval time.Duration) *SlidingOptionsBuilder {
+	b.Opts = append(b.Opts, func(o *SlidingOptions) error {
+		if interval < 0 {
+			return errors.New("banshee: touch interval must not be negative")
+		}
+		o.TouchInterval = interval
+		return nil
+	})
+	return b
+}
+
+// SetClock configures the clock measuring the touch interval, so tests can exercise throttling
+// without sleeping. The TTLs themselves are enforced by the underlying cache.
+//
+// Parameters:
+//   - clock: Clock telling the current time, must not be nil
+//
+// Returns:
+//   - *SlidingOptionsBuilder: The builder instance for method chaining
+func (b *SlidingOptionsBuilder) SetClock(clock Clock) *SlidingOptionsBuilder {
+	b.Opts = append(b.Opts, func(o *SlidingOptions) error {
+		if clock == nil {
+			return errors.New("banshee: clock must not be nil")
+		}
+		o.Clock = clock
+		return nil
+	})
+	return b
+}
+
+// List returns the slice of option functions accumulated by the builder.
+// This method implements the builderutil.Lister interface.
+//
+// Returns:
+//   - []func(*SlidingOptions) error: A slice of option functions that can be applied to configure SlidingOptions
+func (b *SlidingOptionsBuilder) List() []func(*SlidingOptions) error {
+	return b.Opts
+}
+
+// NewSlidingOptions creates and returns a new instance of SlidingOptionsBuilder.
+//
+// Returns:
+//   - *SlidingOptionsBuilder: A new instance of SlidingOptionsBuilder ready to be configured
+//
+// Example:
+//
+//	opts := banshee.NewSlidingOptions().SetTouchInterval(time.Minute)
+func NewSlidingOptions() *SlidingOptionsBuilder {
+	return &SlidingOptionsBuilder{}
+}
@@ -0,0 +1,99 @@
+package banshee_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/zeroxsolutions/banshee"
+	"github.com/zeroxsolutions/banshee/internal/fakecache"
+	"github.com/zeroxsolutions/barbatos/cache"
+)
+
+// plainCache hides the Expire method of the fake cache to exercise the re-Set fallback.
+type plainCache struct {
+	cache.Cache
+}
+
+// TestWithSlidingExpiration verifies that a key read repeatedly outlives its TTL while an idle key
+// expires, both with Expire and with the re-Set fallback.
+func TestWithSlidingExpiration(t *testing.T) {
+	backends := map[string]cache.Cache{
+		"Expire": fakecache.New(),
+		"Set":    plainCache{fakecache.New()},
+	}
+
+	for name, backend := range backends {
+		t.Run(name, func(t *testing.T) {
+			sliding, err := banshee.WithSlidingExpiration(backend, 100*time.Millisecond)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			ctx := context.Background()
+
+			for _, key := range []string{"active", "idle"} {
+				if err := sliding.Set(ctx, key, "value"); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			for deadline := time.Now().Add(300 * time.Millisecond); time.Now().Before(deadline); {
+				if _, err := sliding.Get(ctx, "active"); err != nil {
+					t.Fatal(err)
+				}
+				time.Sleep(20 * time.Millisecond)
+			}
+
+			if _, err := sliding.Get(ctx, "active"); err != nil {
+				t.Log("active key expired:", err)
+				t.FailNow()
+			}
+
+			if _, err := sliding.Get(ctx, "idle"); err != cache.ErrCacheNil {
+				t.Log("idle key still present:", err)
+				t.FailNow()
+			}
+		})
+	}
+}
+
+// TestWithSlidingExpiration_Throttle verifies that the touch interval limits Expire calls and that
+// misses never touch.
+func TestWithSlidingExpiration_Throttle(t *testing.T) {
+	backend := fakecache.New()
+
+	sliding, err := banshee.WithSlidingExpiration(backend, time.Minute, banshee.NewSlidingOptions().SetTouchInterval(time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+
+	if err := sliding.Set(ctx, "key", "value"); err != nil {
+		t.Fatal(err)
+	}
+
+	for range make([]int, 10) {
+		if _, err := sliding.Get(ctx, "key"); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := sliding.Get(ctx, "missing"); err != cache.ErrCacheNil {
+			t.Fatal(err)
+		}
+	}
+
+	time.Sleep(50 * time.Millisecond)
+
+	if calls := backend.ExpireCalls(); calls != 1 {
+		t.Log("expected a single Expire call, got", calls)
+		t.FailNow()
+	}
+}
+
+// TestWithSlidingExpiration_InvalidInterval verifies that a negative touch interval is rejected.
+func TestWithSlidingExpiration_InvalidInterval(t *testing.T) {
+	if _, err := banshee.WithSlidingExpiration(fakecache.New(), time.Minute, banshee.NewSlidingOptions().SetTouchInterval(-time.Second)); err == nil {
+		t.FailNow()
+	}
+}
@@ -0,0 +1,113 @@
+// Package stamped stores cache values together with the time they were written, so readers can
+// report how old a cached value is (for example "cached 2 minutes ago" in a UI).
+//
+// Values are stored in the following format:
+//
+//	"\x00stamped:v1:" + <unix nanoseconds of the write> + ":" + <value>
+//
+// The header makes stamped values backward-incompatible with plain reads: cache.Cache.Get
+// returns the header together with the value, and GetStamped rejects values written without
+// it with ErrNotStamped. Keys should therefore be written and read exclusively through this package.
+package stamped
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/zeroxsolutions/banshee/internal/valueutil"
+	"github.com/zeroxsolutions/barbatos/cache"
+)
+
+// header prefixes every stamped value. The leading NUL byte keeps ordinary text values
+// from being mistaken for stamped ones.
+const header = "\x00stamped:v1:"
+
+// ErrNotStamped is returned by GetStamped when the stored value does not carry a stamp header,
+// typically because it was written with a plain Set.
+var ErrNotStamped = errors.New("stamped: value is not stamped")
+
+// Cache wraps a cache.Cache to write and read time-stamped values.
+type Cache struct {
+	cache cache.Cache
+}
+
+// New creates a Cache storing stamped values in c.
+//
+// Parameters:
+//   - c: Underlying cache used for storage
+//
+// Returns:
+//   - *Cache: A stamped view over c
+//
+// Example:
+//
+//	stampedCache := stamped.New(redisCache)
+//	err := stampedCache.SetStamped(ctx, "dashboard:42", html, 10*time.Minute)
+func New(c cache.Cache) *Cache {
+	return &Cache{cache: c}
+}
+
+// SetStamped stores value under key together with the current time.
+// A ttl of 0 stores the value without expiration.
+//
+// Values are converted to strings the same way the Redis client does: strings and []byte are
+// stored as-is, numbers in base 10 without exponent, bools as "1" or "0", and
+// encoding.BinaryMarshaler implementations are marshaled.
+//
+// Parameters:
+//   - ctx: Context for timeout control and request cancellation
+//   - key: Cache key to store the value under
+//   - value: Value to store
+//   - ttl: Duration after which the key expires
+//
+// Returns:
+//   - error: Marshaling error or an error from the underlying cache
+func (c *Cache) SetStamped(ctx context.Context, key string, value interface{}, ttl time.Duration) error {
+	raw, err := valueutil.Stringify(value)
+	if err != nil {
+		return err
+	}
+	stamp := strconv.FormatInt(time.Now().UnixNano(), 10)
+	return c.cache.SetWithExpiration(ctx, key, header+stamp+":"+raw, ttl)
+}
+
+// GetStamped retrieves the value stored under key by SetStamped together with its write time.
+//
+// Parameters:
+//   - ctx: Context for timeout control and request cancellation
+//   - key: Cache key to read
+//
+// Returns:
+//   - string: The stored value without the stamp header
+//   - time.Time: The time the value was written
+//   - error: cache.ErrCacheNil if the key doesn't exist, ErrNotStamped for unstamped values,
+//     or an error from the underlying cache
+//
+// Example:
+//
+//	html, storedAt, err := stampedCache.GetStamped(ctx, "dashboard:42")
+//	if err == nil {
+//	    fmt.Printf("cached %s ago\n", time.Since(storedAt).Round(time.Second))
+//	}
+func (c *Cache) GetStamped(ctx context.Context, key string) (string, time.Time, error) {
+	raw, err := c.cache.Get(ctx, key)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	if !strings.HasPrefix(raw, header) {
+		return "", time.Time{}, ErrNotStamped
+	}
+	rest := raw[len(header):]
+	sep := strings.IndexByte(rest, ':')
+	if sep < 0 {
+		return "", time.Time{}, ErrNotStamped
+	}
+	nanos, err := strconv.ParseInt(rest[:sep], 10, 64)
+	if err != nil {
+		return "", time.Time{}, ErrNotStamped
+	}
+	return rest[sep+1:], time.Unix(0, nanos), nil
+}
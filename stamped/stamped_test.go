@@ -0,0 +1,73 @@
+package stamped_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/zeroxsolutions/banshee/internal/fakecache"
+	"github.com/zeroxsolutions/banshee/stamped"
+	"github.com/zeroxsolutions/barbatos/cache"
+)
+
+// TestCache_RoundTrip verifies that a stamped value reads back with its write time and age.
+func TestCache_RoundTrip(t *testing.T) {
+	stampedCache := stamped.New(fakecache.New())
+
+	ctx := context.Background()
+
+	before := time.Now()
+	if err := stampedCache.SetStamped(ctx, "key", "value:with:colons", time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	after := time.Now()
+
+	value, storedAt, err := stampedCache.GetStamped(ctx, "key")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if value != "value:with:colons" {
+		t.Log("unexpected value:", value)
+		t.FailNow()
+	}
+
+	if storedAt.Before(before) || storedAt.After(after) {
+		t.Log("unexpected stamp:", storedAt)
+		t.FailNow()
+	}
+
+	time.Sleep(20 * time.Millisecond)
+
+	_, storedAt, err = stampedCache.GetStamped(ctx, "key")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if age := time.Since(storedAt); age < 20*time.Millisecond {
+		t.Log("unexpected age:", age)
+		t.FailNow()
+	}
+}
+
+// TestCache_NotStamped verifies that plain values and missing keys are reported distinctly.
+func TestCache_NotStamped(t *testing.T) {
+	backend := fakecache.New()
+	stampedCache := stamped.New(backend)
+
+	ctx := context.Background()
+
+	if err := backend.Set(ctx, "plain", "value"); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, err := stampedCache.GetStamped(ctx, "plain"); err != stamped.ErrNotStamped {
+		t.Log(err)
+		t.FailNow()
+	}
+
+	if _, _, err := stampedCache.GetStamped(ctx, "missing"); err != cache.ErrCacheNil {
+		t.Log(err)
+		t.FailNow()
+	}
+}
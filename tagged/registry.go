@@ -0,0 +1,106 @@
+package tagged
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// ErrInvalidTag is returned when registering an empty tag or a tag containing ':'.
+var ErrInvalidTag = errors.New("tagged: tag must be non-empty and free of ':'")
+
+// Registry maps type tags to Go types, in both directions. A tag and a type can each be
+// registered once, so every tagged value decodes into exactly one type. The zero value is not
+// usable; create registries with NewRegistry. A Registry is safe for concurrent use.
+type Registry struct {
+	mu     sync.RWMutex
+	byTag  map[string]reflect.Type
+	byType map[reflect.Type]string
+}
+
+// DefaultRegistry is the registry used by RegisterType and by caches built without SetRegistry.
+var DefaultRegistry = NewRegistry()
+
+// NewRegistry creates an empty Registry.
+//
+// Returns:
+//   - *Registry: A registry without any type
+//
+// Example:
+//
+//	registry := tagged.NewRegistry()
+//	err := registry.RegisterType("user", User{})
+func NewRegistry() *Registry {
+	return &Registry{byTag: map[string]reflect.Type{}, byType: map[reflect.Type]string{}}
+}
+
+// RegisterType registers the type of sample under tag in DefaultRegistry. Call it from init
+// functions or at startup, before values of the type are written or read.
+//
+// Parameters:
+//   - tag: Short name stored in front of the values of the type, without ':'
+//   - sample: A value of the type; only its type is used
+//
+// Returns:
+//   - error: ErrInvalidTag, or an error if the tag or the type is already registered
+//
+// Example:
+//
+//	func init() {
+//	    if err := tagged.RegisterType("user", User{}); err != nil {
+//	        panic(err)
+//	    }
+//	}
+func RegisterType(tag string, sample interface{}) error {
+	return DefaultRegistry.RegisterType(tag, sample)
+}
+
+// RegisterType registers the type of sample under tag. Registering a pointer type, e.g.
+// &User{}, makes GetAny return pointers; registering the value type returns values. Tags are
+// stored with every value, so keep them short and never reuse a tag for another type while
+// values tagged with it may still be cached.
+//
+// Parameters:
+//   - tag: Short name stored in front of the values of the type, without ':'
+//   - sample: A value of the type; only its type is used
+//
+// Returns:
+//   - error: ErrInvalidTag, or an error if the tag or the type is already registered
+func (r *Registry) RegisterType(tag string, sample interface{}) error {
+	if tag == "" || strings.Contains(tag, ":") {
+		return ErrInvalidTag
+	}
+	if sample == nil {
+		return errors.New("tagged: sample must not be nil")
+	}
+	typ := reflect.TypeOf(sample)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if existing, ok := r.byTag[tag]; ok {
+		return fmt.Errorf("tagged: tag %q already registered for %s", tag, existing)
+	}
+	if existing, ok := r.byType[typ]; ok {
+		return fmt.Errorf("tagged: %s already registered with tag %q", typ, existing)
+	}
+	r.byTag[tag] = typ
+	r.byType[typ] = tag
+	return nil
+}
+
+// typeOf returns the type registered under tag.
+func (r *Registry) typeOf(tag string) (reflect.Type, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	typ, ok := r.byTag[tag]
+	return typ, ok
+}
+
+// tagOf returns the tag typ is registered with.
+func (r *Registry) tagOf(typ reflect.Type) (string, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	tag, ok := r.byType[typ]
+	return tag, ok
+}
@@ -0,0 +1,276 @@
+// Package tagged wraps a cache.Cache so that every value is stored with a short tag naming its
+// type, letting values of different types share one namespace without being decoded as the wrong
+// type. Types are registered with a tag in a Registry, by default DefaultRegistry through
+// RegisterType; Set tags and JSON-encodes values of registered types, GetAny decodes a value into
+// whatever type its tag names, and GetInto and GetAs refuse values tagged for another type.
+//
+// Tagged values are stored as:
+//
+//	"\x00tag:" + <tag> + ":" + JSON(<value>)
+//
+// Values without the prefix were written without the decorator. They are rejected with
+// ErrUntagged by the typed reads; Get returns them unchanged.
+package tagged
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/zeroxsolutions/barbatos/cache"
+	"github.com/zeroxsolutions/strike/builderutil"
+)
+
+// prefix marks tagged values. The leading NUL byte keeps ordinary text values from being
+// mistaken for tagged ones.
+const prefix = "\x00tag:"
+
+// ErrUnregisteredType is returned when writing or reading into a type that has no tag in the
+// registry.
+var ErrUnregisteredType = errors.New("tagged: type not registered")
+
+// ErrUnknownTag is returned by GetAny for values whose tag is not in the registry, typically
+// written by a newer version of the application.
+var ErrUnknownTag = errors.New("tagged: unknown type tag")
+
+// ErrUntagged is returned by the typed reads for values stored without a type tag.
+var ErrUntagged = errors.New("tagged: value has no type tag")
+
+// ErrTagMismatch is matched (via errors.Is) by the *TagMismatchError returned when a typed read
+// finds a value tagged for another type.
+var ErrTagMismatch = errors.New("tagged: type tag mismatch")
+
+// TagMismatchError reports a value read as one type but tagged as another.
+type TagMismatchError struct {
+	Key      string // Key is the key that was read.
+	Expected string // Expected is the tag of the type the caller asked for.
+	Actual   string // Actual is the tag stored with the value.
+}
+
+// Error implements the error interface.
+func (e *TagMismatchError) Error() string {
+	return fmt.Sprintf("tagged: %q holds a %q value, not %q", e.Key, e.Actual, e.Expected)
+}
+
+// Is reports whether target is ErrTagMismatch, so errors.Is matches any mismatch.
+func (e *TagMismatchError) Is(target error) bool {
+	return target == ErrTagMismatch
+}
+
+// Cache is a cache.Cache decorator storing values with a type tag.
+type Cache struct {
+	cache   cache.Cache
+	options *Options
+}
+
+var _ cache.Cache = (*Cache)(nil)
+
+// New creates a tagging Cache over c.
+//
+// Parameters:
+//   - c: Underlying cache used for storage
+//   - opts: Optional Options builders created with NewOptions
+//
+// Returns:
+//   - *Cache: The tagging cache
+//   - error: An error if building the options fails
+//
+// Example:
+//
+//	_ = tagged.RegisterType("user", User{})
+//	_ = tagged.RegisterType("org", Org{})
+//	entities, err := tagged.New(redisCache)
+//	err = entities.Set(ctx, "entity:42", User{Name: "alice"})
+//	value, err := entities.GetAny(ctx, "entity:42") // value is a User
+func New(c cache.Cache, opts ...builderutil.Lister[Options]) (*Cache, error) {
+	options, err := builderutil.Build(append([]builderutil.Lister[Options]{defaultOptions()}, opts...)...)
+	if err != nil {
+		return nil, err
+	}
+	return &Cache{cache: c, options: options}, nil
+}
+
+// IsConnected reports the connection status of the underlying cache.
+func (c *Cache) IsConnected(ctx context.Context) bool {
+	return c.cache.IsConnected(ctx)
+}
+
+// Keys returns the keys matching pattern in the underlying cache.
+func (c *Cache) Keys(ctx context.Context, pattern string) ([]string, error) {
+	return c.cache.Keys(ctx, pattern)
+}
+
+// Get retrieves the value stored under key, stripping its type tag, so the JSON encoding of the
+// value is returned. Untagged values are returned unchanged.
+func (c *Cache) Get(ctx context.Context, key string) (string, error) {
+	raw, err := c.cache.Get(ctx, key)
+	if err != nil {
+		return "", err
+	}
+	if _, payload, ok := split(raw); ok {
+		return payload, nil
+	}
+	return raw, nil
+}
+
+// GetAny retrieves the value stored under key and decodes it into the type its tag is registered
+// with, so values of different types can share a namespace.
+//
+// Parameters:
+//   - ctx: Context for timeout control and request cancellation
+//   - key: Cache key to read
+//
+// Returns:
+//   - interface{}: The decoded value, of the registered type (a pointer if a pointer was registered)
+//   - error: cache.ErrCacheNil if the key doesn't exist, ErrUntagged, ErrUnknownTag, a JSON
+//     decoding error, or an error from the underlying cache
+//
+// Example:
+//
+//	value, err := entities.GetAny(ctx, key)
+//	switch v := value.(type) {
+//	case User:
+//	    // ...
+//	case Org:
+//	    // ...
+//	}
+func (c *Cache) GetAny(ctx context.Context, key string) (interface{}, error) {
+	tag, payload, err := c.read(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	typ, ok := c.options.Registry.typeOf(tag)
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownTag, tag)
+	}
+	target := reflect.New(typ)
+	if err := json.Unmarshal([]byte(payload), target.Interface()); err != nil {
+		return nil, err
+	}
+	return target.Elem().Interface(), nil
+}
+
+// GetInto retrieves the value stored under key and decodes it into dst, after checking that its
+// tag is the one registered for the type dst points to.
+//
+// Parameters:
+//   - ctx: Context for timeout control and request cancellation
+//   - key: Cache key to read
+//   - dst: Non-nil pointer to a value of a registered type
+//
+// Returns:
+//   - error: cache.ErrCacheNil if the key doesn't exist, ErrUnregisteredType, ErrUntagged, a
+//     *TagMismatchError (matching ErrTagMismatch) if the value was tagged for another type, a
+//     JSON decoding error, or an error from the underlying cache
+//
+// Example:
+//
+//	var user User
+//	err := entities.GetInto(ctx, "entity:42", &user)
+//	if errors.Is(err, tagged.ErrTagMismatch) {
+//	    // entity:42 is not a user
+//	}
+func (c *Cache) GetInto(ctx context.Context, key string, dst interface{}) error {
+	target := reflect.ValueOf(dst)
+	if target.Kind() != reflect.Pointer || target.IsNil() {
+		return errors.New("tagged: destination must be a non-nil pointer")
+	}
+	expected, ok := c.options.Registry.tagOf(target.Type().Elem())
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrUnregisteredType, target.Type().Elem())
+	}
+	tag, payload, err := c.read(ctx, key)
+	if err != nil {
+		return err
+	}
+	if tag != expected {
+		return &TagMismatchError{Key: key, Expected: expected, Actual: tag}
+	}
+	return json.Unmarshal([]byte(payload), dst)
+}
+
+// GetAs is the generic form of GetInto, returning the decoded value instead of filling a pointer.
+//
+// Parameters:
+//   - ctx: Context for timeout control and request cancellation
+//   - c: Tagging cache to read from
+//   - key: Cache key to read
+//
+// Returns:
+//   - T: The decoded value
+//   - error: The errors of GetInto
+//
+// Example:
+//
+//	user, err := tagged.GetAs[User](ctx, entities, "entity:42")
+func GetAs[T any](ctx context.Context, c *Cache, key string) (T, error) {
+	var value T
+	if err := c.GetInto(ctx, key, &value); err != nil {
+		var zero T
+		return zero, err
+	}
+	return value, nil
+}
+
+// Set stores value under key with the tag of its type, without expiration.
+func (c *Cache) Set(ctx context.Context, key string, value interface{}) error {
+	return c.SetWithExpiration(ctx, key, value, 0)
+}
+
+// SetWithExpiration JSON-encodes value and stores it under key with the tag of its type.
+// Values of unregistered types fail with ErrUnregisteredType.
+func (c *Cache) SetWithExpiration(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
+	if value == nil {
+		return fmt.Errorf("%w: nil", ErrUnregisteredType)
+	}
+	tag, ok := c.options.Registry.tagOf(reflect.TypeOf(value))
+	if !ok {
+		return fmt.Errorf("%w: %T", ErrUnregisteredType, value)
+	}
+	payload, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	return c.cache.SetWithExpiration(ctx, key, prefix+tag+":"+string(payload), expiration)
+}
+
+// Del deletes keys from the underlying cache.
+func (c *Cache) Del(ctx context.Context, keys ...string) error {
+	return c.cache.Del(ctx, keys...)
+}
+
+// DelWithPattern deletes the keys matching pattern from the underlying cache.
+func (c *Cache) DelWithPattern(ctx context.Context, pattern string) error {
+	return c.cache.DelWithPattern(ctx, pattern)
+}
+
+// Close closes the underlying cache.
+func (c *Cache) Close() error {
+	return c.cache.Close()
+}
+
+// read returns the tag and JSON payload of the value stored under key.
+func (c *Cache) read(ctx context.Context, key string) (string, string, error) {
+	raw, err := c.cache.Get(ctx, key)
+	if err != nil {
+		return "", "", err
+	}
+	tag, payload, ok := split(raw)
+	if !ok {
+		return "", "", ErrUntagged
+	}
+	return tag, payload, nil
+}
+
+// split separates a stored value into its tag and payload, reporting false for untagged values.
+func split(raw string) (string, string, bool) {
+	if !strings.HasPrefix(raw, prefix) {
+		return "", "", false
+	}
+	tag, payload, ok := strings.Cut(raw[len(prefix):], ":")
+	return tag, payload, ok
+}
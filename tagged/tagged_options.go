@@ -0,0 +1,65 @@
+package tagged
+
+import (
+	"errors"
+
+	"github.com/zeroxsolutions/strike/builderutil"
+)
+
+// Options holds the settings of a tagging Cache.
+// This struct is populated through OptionsBuilder and consumed by New.
+type Options struct {
+	Registry *Registry // Registry maps tags to types; DefaultRegistry by default.
+}
+
+// OptionsBuilder provides a builder pattern for constructing Options.
+// This builder implements the builderutil.Lister interface to work with the functional options pattern.
+type OptionsBuilder struct {
+	Opts []func(*Options) error // Opts contains the list of option functions to be applied
+}
+
+// SetRegistry configures the registry mapping tags to types, DefaultRegistry by default. Use a
+// dedicated registry to keep the tags of independent namespaces apart.
+//
+// Parameters:
+//   - registry: Registry created with NewRegistry, must not be nil
+//
+// Returns:
+//   - *OptionsBuilder: The builder instance for method chaining
+func (b *OptionsBuilder) SetRegistry(registry *Registry) *OptionsBuilder {
+	b.Opts = append(b.Opts, func(o *Options) error {
+		if registry == nil {
+			return errors.New("tagged: registry must not be nil")
+		}
+		o.Registry = registry
+		return nil
+	})
+	return b
+}
+
+// List returns the slice of option functions accumulated by the builder.
+// This method implements the builderutil.Lister interface.
+//
+// Returns:
+//   - []func(*Options) error: A slice of option functions that can be applied to configure Options
+func (b *OptionsBuilder) List() []func(*Options) error {
+	return b.Opts
+}
+
+// NewOptions creates and returns a new instance of OptionsBuilder.
+//
+// Returns:
+//   - *OptionsBuilder: A new instance of OptionsBuilder ready to be configured
+//
+// Example:
+//
+//	opts := tagged.NewOptions().SetRegistry(registry)
+func NewOptions() *OptionsBuilder {
+	return &OptionsBuilder{}
+}
+
+// defaultOptions returns the builder holding the package defaults.
+// New applies it before any caller-supplied builders.
+func defaultOptions() builderutil.Lister[Options] {
+	return NewOptions().SetRegistry(DefaultRegistry)
+}
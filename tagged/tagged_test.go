@@ -0,0 +1,173 @@
+package tagged_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/zeroxsolutions/banshee/internal/fakecache"
+	"github.com/zeroxsolutions/banshee/tagged"
+	"github.com/zeroxsolutions/barbatos/cache"
+)
+
+type user struct {
+	Name string `json:"name"`
+}
+
+type org struct {
+	Name  string `json:"name"`
+	Seats int    `json:"seats"`
+}
+
+// newEntities returns a tagging cache whose registry knows user and *org.
+func newEntities(t *testing.T) *tagged.Cache {
+	registry := tagged.NewRegistry()
+	if err := registry.RegisterType("user", user{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := registry.RegisterType("org", &org{}); err != nil {
+		t.Fatal(err)
+	}
+
+	entities, err := tagged.New(fakecache.New(), tagged.NewOptions().SetRegistry(registry))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return entities
+}
+
+// TestCache_GetAny verifies that values of two registered types stored in one namespace decode
+// into their own types.
+func TestCache_GetAny(t *testing.T) {
+	entities := newEntities(t)
+	ctx := context.Background()
+
+	if err := entities.Set(ctx, "entity:1", user{Name: "alice"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := entities.Set(ctx, "entity:2", &org{Name: "acme", Seats: 5}); err != nil {
+		t.Fatal(err)
+	}
+
+	first, err := entities.GetAny(ctx, "entity:1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if u, ok := first.(user); !ok || u.Name != "alice" {
+		t.Fatalf("expected a user, got %#v", first)
+	}
+
+	second, err := entities.GetAny(ctx, "entity:2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if o, ok := second.(*org); !ok || o.Name != "acme" || o.Seats != 5 {
+		t.Fatalf("expected an *org, got %#v", second)
+	}
+
+	if raw, err := entities.Get(ctx, "entity:1"); err != nil || raw != `{"name":"alice"}` {
+		t.Fatal(raw, err)
+	}
+
+	if _, err := entities.GetAny(ctx, "missing"); !errors.Is(err, cache.ErrCacheNil) {
+		t.Fatal(err)
+	}
+}
+
+// TestCache_TypedGet verifies that typed reads decode values of their own type and reject values
+// tagged for another type, untagged values and unknown types.
+func TestCache_TypedGet(t *testing.T) {
+	entities := newEntities(t)
+	ctx := context.Background()
+
+	if err := entities.Set(ctx, "entity:1", user{Name: "alice"}); err != nil {
+		t.Fatal(err)
+	}
+
+	u, err := tagged.GetAs[user](ctx, entities, "entity:1")
+	if err != nil || u.Name != "alice" {
+		t.Fatal(u, err)
+	}
+
+	var o *org
+	err = entities.GetInto(ctx, "entity:1", &o)
+	var mismatch *tagged.TagMismatchError
+	if !errors.Is(err, tagged.ErrTagMismatch) || !errors.As(err, &mismatch) {
+		t.Fatal(err)
+	}
+	if mismatch.Key != "entity:1" || mismatch.Expected != "org" || mismatch.Actual != "user" {
+		t.Fatal(mismatch)
+	}
+
+	if _, err := tagged.GetAs[string](ctx, entities, "entity:1"); !errors.Is(err, tagged.ErrUnregisteredType) {
+		t.Fatal(err)
+	}
+
+	if err := entities.Set(ctx, "entity:3", "plain"); !errors.Is(err, tagged.ErrUnregisteredType) {
+		t.Fatal(err)
+	}
+}
+
+// TestCache_UntaggedAndUnknown verifies how values written without the decorator or with a tag
+// missing from the registry are reported.
+func TestCache_UntaggedAndUnknown(t *testing.T) {
+	backend := fakecache.New()
+	ctx := context.Background()
+
+	writers := tagged.NewRegistry()
+	if err := writers.RegisterType("user", user{}); err != nil {
+		t.Fatal(err)
+	}
+	writer, err := tagged.New(backend, tagged.NewOptions().SetRegistry(writers))
+	if err != nil {
+		t.Fatal(err)
+	}
+	reader, err := tagged.New(backend, tagged.NewOptions().SetRegistry(tagged.NewRegistry()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := writer.Set(ctx, "entity:1", user{Name: "alice"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := backend.Set(ctx, "legacy", "plain"); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := reader.GetAny(ctx, "entity:1"); !errors.Is(err, tagged.ErrUnknownTag) {
+		t.Fatal(err)
+	}
+	if _, err := writer.GetAny(ctx, "legacy"); !errors.Is(err, tagged.ErrUntagged) {
+		t.Fatal(err)
+	}
+	if raw, err := writer.Get(ctx, "legacy"); err != nil || raw != "plain" {
+		t.Fatal(raw, err)
+	}
+}
+
+// TestRegistry_RegisterType verifies that invalid and duplicate registrations are rejected.
+func TestRegistry_RegisterType(t *testing.T) {
+	registry := tagged.NewRegistry()
+
+	if err := registry.RegisterType("user", user{}); err != nil {
+		t.Fatal(err)
+	}
+
+	for name, register := range map[string]func() error{
+		"EmptyTag":     func() error { return registry.RegisterType("", org{}) },
+		"ColonInTag":   func() error { return registry.RegisterType("a:b", org{}) },
+		"NilSample":    func() error { return registry.RegisterType("nil", nil) },
+		"DuplicateTag": func() error { return registry.RegisterType("user", org{}) },
+		"DuplicateType": func() error {
+			return registry.RegisterType("person", user{})
+		},
+	} {
+		if err := register(); err == nil {
+			t.Error(name, "should be rejected")
+		}
+	}
+
+	if _, err := tagged.New(fakecache.New(), tagged.NewOptions().SetRegistry(nil)); err == nil {
+		t.Fatal("a nil registry should be rejected")
+	}
+}
@@ -0,0 +1,97 @@
+package typed_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/zeroxsolutions/banshee/typed"
+	"github.com/zeroxsolutions/barbatos/cache"
+)
+
+// hashes is a map-backed banshee.HashCache.
+type hashes map[string]map[string]string
+
+func (h hashes) HGetAll(ctx context.Context, key string) (map[string]string, error) {
+	fields, ok := h[key]
+	if !ok {
+		return nil, cache.ErrCacheNil
+	}
+	return fields, nil
+}
+
+func (h hashes) HGetAllMany(ctx context.Context, keys []string) (map[string]map[string]string, error) {
+	result := map[string]map[string]string{}
+	for _, key := range keys {
+		if fields, ok := h[key]; ok {
+			result[key] = fields
+		}
+	}
+	return result, nil
+}
+
+func (h hashes) HSetMany(ctx context.Context, entries map[string]map[string]interface{}) error {
+	for key, fields := range entries {
+		if h[key] == nil {
+			h[key] = map[string]string{}
+		}
+		for field, value := range fields {
+			h[key][field] = fmt.Sprint(value)
+		}
+	}
+	return nil
+}
+
+type user struct {
+	Name    string        `cache:"name"`
+	Age     int           `cache:"age"`
+	Admin   bool          `cache:"admin"`
+	Score   float64       `cache:"score"`
+	Timeout time.Duration `cache:"timeout"`
+	Ignored string        `cache:"-"`
+	Plain   string
+}
+
+// TestGetHashObjects verifies the mapping of hashes into structs, including missing keys and fields.
+func TestGetHashObjects(t *testing.T) {
+	c := hashes{
+		"user:1": {"name": "alice", "age": "31", "admin": "true", "score": "9.5", "timeout": "1000000000", "-": "x", "Plain": "x", "extra": "x"},
+		"user:2": {"name": "bob"},
+	}
+
+	users, err := typed.GetHashObjects[user](context.Background(), c, []string{"user:1", "user:2", "user:3"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(users) != 2 {
+		t.Log("expected 2 users, got", len(users))
+		t.FailNow()
+	}
+
+	if users["user:1"] != (user{Name: "alice", Age: 31, Admin: true, Score: 9.5, Timeout: time.Second}) {
+		t.Logf("unexpected user:1: %+v", users["user:1"])
+		t.FailNow()
+	}
+
+	if users["user:2"] != (user{Name: "bob"}) {
+		t.Logf("unexpected user:2: %+v", users["user:2"])
+		t.FailNow()
+	}
+}
+
+// TestGetHashObjects_Errors verifies that malformed values and non-struct types are rejected.
+func TestGetHashObjects_Errors(t *testing.T) {
+	c := hashes{"user:1": {"age": "thirty"}}
+
+	if _, err := typed.GetHashObjects[user](context.Background(), c, []string{"user:1"}); err == nil {
+		t.Log("expected a parse error")
+		t.FailNow()
+	}
+
+	if _, err := typed.GetHashObjects[string](context.Background(), c, []string{"user:1"}); err == nil {
+		t.Log("expected a non-struct error")
+		t.FailNow()
+	}
+}
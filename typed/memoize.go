@@ -0,0 +1,183 @@
+package typed
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/zeroxsolutions/barbatos/cache"
+	"github.com/zeroxsolutions/strike/builderutil"
+)
+
+const (
+	// memoValuePrefix marks a cached result, followed by its JSON encoding.
+	memoValuePrefix = "="
+
+	// memoErrorPrefix marks a cached negative result, followed by the index of the error in
+	// MemoizeOptions.NegativeErrors.
+	memoErrorPrefix = "!"
+
+	// memoStaleSuffix is appended to the key of a result to store its grace copy.
+	memoStaleSuffix = "#stale"
+)
+
+// Memoize wraps fn so that its results are cached in c under prefix+keyFn(k) for ttl.
+//
+// Behavior:
+//   - A call first reads the cache; on a hit the stored result is decoded and fn is not called
+//   - On a miss, fn is called and a successful result is stored as JSON with ttl (0 for none)
+//   - Concurrent calls for the same key within the process share a single call to fn; they also
+//     share its outcome, including an error caused by the first caller's context being cancelled;
+//     if fn panics, the panic propagates in the caller running it and is raised again in every
+//     caller waiting for it
+//   - Errors from fn are returned and not cached, unless SetNegativeCaching lists them
+//   - With SetStaleOnError, an expired result is returned instead of an error from fn during a
+//     grace window; its grace copy is stored under the result key + "#stale"
+//   - Cache failures never fail the call: a read error or undecodable entry counts as a miss, and
+//     a failed write only means the next call computes the value again
+//   - If building the options fails, every call of the returned function returns that error
+//
+// Parameters:
+//   - c: Cache storing the results
+//   - prefix: Prefix of the cache keys, e.g. "user:profile:"
+//   - ttl: Time to live of cached results
+//   - keyFn: Function mapping an argument to its cache key suffix
+//   - fn: Function to memoize
+//   - opts: Optional MemoizeOptions builders created with NewMemoizeOptions
+//
+// Returns:
+//   - func(ctx context.Context, k K) (V, error): The memoized function
+//
+// Example:
+//
+//	getProfile := typed.Memoize(redisCache, "profile:", 10*time.Minute,
+//	    func(id int64) string { return strconv.FormatInt(id, 10) },
+//	    loadProfile,
+//	    typed.NewMemoizeOptions().SetNegativeCaching(time.Minute, ErrNotFound))
+//
+//	profile, err := getProfile(ctx, 42)
+func Memoize[K comparable, V any](c cache.Cache, prefix string, ttl time.Duration, keyFn func(K) string, fn func(ctx context.Context, k K) (V, error), opts ...builderutil.Lister[MemoizeOptions]) func(ctx context.Context, k K) (V, error) {
+	options, err := builderutil.Build(opts...)
+	if err != nil {
+		return func(ctx context.Context, k K) (V, error) {
+			var zero V
+			return zero, err
+		}
+	}
+
+	group := &flightGroup[V]{}
+	return func(ctx context.Context, k K) (V, error) {
+		key := prefix + keyFn(k)
+		if value, err, ok := memoLookup[V](ctx, c, key, options); ok {
+			return value, err
+		}
+		return group.do(key, func() (V, error) {
+			value, err := fn(ctx, k)
+			if err == nil {
+				if raw, marshalErr := json.Marshal(value); marshalErr == nil {
+					_ = c.SetWithExpiration(ctx, key, memoValuePrefix+string(raw), ttl)
+					if options.StaleGrace > 0 && ttl > 0 {
+						_ = c.SetWithExpiration(ctx, key+memoStaleSuffix, memoValuePrefix+string(raw), ttl+options.StaleGrace)
+					}
+				}
+				return value, nil
+			}
+			for i, negative := range options.NegativeErrors {
+				if errors.Is(err, negative) {
+					_ = c.SetWithExpiration(ctx, key, memoErrorPrefix+strconv.Itoa(i), options.NegativeTTL)
+					return value, err
+				}
+			}
+			if options.StaleGrace > 0 {
+				if stale, _, ok := memoLookup[V](ctx, c, key+memoStaleSuffix, options); ok {
+					return stale, nil
+				}
+			}
+			return value, err
+		})
+	}
+}
+
+// memoLookup reads a cached result. ok is false when fn has to be called.
+func memoLookup[V any](ctx context.Context, c cache.Cache, key string, options *MemoizeOptions) (value V, err error, ok bool) {
+	raw, getErr := c.Get(ctx, key)
+	if getErr != nil {
+		return value, nil, false
+	}
+	switch {
+	case strings.HasPrefix(raw, memoValuePrefix):
+		if json.Unmarshal([]byte(raw[len(memoValuePrefix):]), &value) != nil {
+			var zero V
+			return zero, nil, false
+		}
+		return value, nil, true
+	case strings.HasPrefix(raw, memoErrorPrefix):
+		i, convErr := strconv.Atoi(raw[len(memoErrorPrefix):])
+		if convErr != nil || i < 0 || i >= len(options.NegativeErrors) {
+			return value, nil, false
+		}
+		return value, options.NegativeErrors[i], true
+	default:
+		return value, nil, false
+	}
+}
+
+// errFlightAborted is returned to the callers waiting on a call that neither returned nor
+// panicked, i.e. whose goroutine exited with runtime.Goexit.
+var errFlightAborted = errors.New("typed: memoized call aborted")
+
+// flightCall is an in-flight call shared by concurrent callers of the same key.
+type flightCall[V any] struct {
+	done      chan struct{}
+	value     V
+	err       error
+	recovered interface{} // recovered is the value fn panicked with, raised again in waiters.
+}
+
+// flightGroup deduplicates concurrent calls per key, so that only one of them runs.
+type flightGroup[V any] struct {
+	mu    sync.Mutex
+	calls map[string]*flightCall[V]
+}
+
+// do runs fn for key unless a call for key is already in flight, in which case it waits for that
+// call and returns its outcome. A panic in fn is raised again in every waiter.
+func (g *flightGroup[V]) do(key string, fn func() (V, error)) (V, error) {
+	g.mu.Lock()
+	if call, ok := g.calls[key]; ok {
+		g.mu.Unlock()
+		<-call.done
+		if call.recovered != nil {
+			panic(call.recovered)
+		}
+		return call.value, call.err
+	}
+	if g.calls == nil {
+		g.calls = map[string]*flightCall[V]{}
+	}
+	call := &flightCall[V]{done: make(chan struct{})}
+	g.calls[key] = call
+	g.mu.Unlock()
+
+	returned := false
+	defer func() {
+		if !returned {
+			call.err = errFlightAborted
+			call.recovered = recover()
+		}
+		g.mu.Lock()
+		delete(g.calls, key)
+		g.mu.Unlock()
+		close(call.done)
+		if call.recovered != nil {
+			panic(call.recovered)
+		}
+	}()
+	call.value, call.err = fn()
+	returned = true
+	return call.value, call.err
+}
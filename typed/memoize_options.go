@@ -0,0 +1,96 @@
+package typed
+
+import (
+	"errors"
+	"time"
+)
+
+// MemoizeOptions holds the settings of a memoized function.
+// This struct is populated through MemoizeOptionsBuilder and consumed by Memoize.
+type MemoizeOptions struct {
+	NegativeTTL    time.Duration // NegativeTTL is how long the errors in NegativeErrors are cached.
+	NegativeErrors []error       // NegativeErrors lists the errors cached as results, matched with errors.Is.
+	StaleGrace     time.Duration // StaleGrace is how long past its TTL a result may be served when fn fails.
+}
+
+// MemoizeOptionsBuilder provides a builder pattern for constructing MemoizeOptions.
+// This builder implements the builderutil.Lister interface to work with the functional options pattern.
+type MemoizeOptionsBuilder struct {
+	Opts []func(*MemoizeOptions) error // Opts contains the list of option functions to be applied
+}
+
+// SetNegativeCaching configures negative-result caching: when the wrapped function fails with an
+// error matching one of errs (per errors.Is), the failure is cached for ttl and later calls return
+// the matching entry of errs without calling the function. Use it for expected, stable failures
+// such as "not found", so repeated lookups of missing records don't hit the backend every time.
+// Other errors are never cached.
+//
+// Parameters:
+//   - ttl: How long a negative result is cached, must be positive
+//   - errs: Sentinel errors to cache, at least one
+//
+// Returns:
+//   - *MemoizeOptionsBuilder: The builder instance for method chaining
+func (b *MemoizeOptionsBuilder) SetNegativeCaching(ttl time.Duration, errs ...error) *MemoizeOptionsBuilder {
+	b.Opts = append(b.Opts, func(o *MemoizeOptions) error {
+		if ttl <= 0 {
+			return errors.New("typed: negative TTL must be positive")
+		}
+		if len(errs) == 0 {
+			return errors.New("typed: negative caching needs at least one error")
+		}
+		o.NegativeTTL = ttl
+		o.NegativeErrors = errs
+		return nil
+	})
+	return b
+}
+
+// SetStaleOnError configures serving stale results when the wrapped function fails: every
+// successful result is also stored as a grace copy living grace longer than the result itself.
+// When the result has expired and the function then fails, the grace copy is returned instead of
+// the error, so an upstream outage serves slightly stale data rather than failures.
+//
+// Grace-window semantics:
+//   - A result is fresh for ttl; between ttl and ttl+grace it is only served when fn fails
+//   - Once the grace copy expires, a failing fn surfaces its error again
+//   - Serving stale data doesn't refresh the grace copy: only a successful call does
+//   - Errors listed in SetNegativeCaching are answers, not failures, and are returned as such
+//   - It has no effect on results memoized without a TTL, which never expire
+//
+// Parameters:
+//   - grace: How long past the TTL a result may be served, must be positive
+//
+// Returns:
+//   - *MemoizeOptionsBuilder: The builder instance for method chaining
+func (b *MemoizeOptionsBuilder) SetStaleOnError(grace time.Duration) *MemoizeOptionsBuilder {
+	b.Opts = append(b.Opts, func(o *MemoizeOptions) error {
+		if grace <= 0 {
+			return errors.New("typed: stale grace must be positive")
+		}
+		o.StaleGrace = grace
+		return nil
+	})
+	return b
+}
+
+// List returns the slice of option functions accumulated by the builder.
+// This method implements the builderutil.Lister interface.
+//
+// Returns:
+//   - []func(*MemoizeOptions) error: A slice of option functions that can be applied to configure MemoizeOptions
+func (b *MemoizeOptionsBuilder) List() []func(*MemoizeOptions) error {
+	return b.Opts
+}
+
+// NewMemoizeOptions creates and returns a new instance of MemoizeOptionsBuilder.
+//
+// Returns:
+//   - *MemoizeOptionsBuilder: A new instance of MemoizeOptionsBuilder ready to be configured
+//
+// Example:
+//
+//	opts := typed.NewMemoizeOptions().SetNegativeCaching(time.Minute, sql.ErrNoRows)
+func NewMemoizeOptions() *MemoizeOptionsBuilder {
+	return &MemoizeOptionsBuilder{}
+}
@@ -0,0 +1,222 @@
+package typed_test
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/zeroxsolutions/banshee/memory"
+	"github.com/zeroxsolutions/banshee/typed"
+)
+
+type profile struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+var errNotFound = errors.New("not found")
+
+// countingLoader returns a profile loader counting its invocations. IDs below zero fail with
+// errNotFound and zero fails with a transient error.
+func countingLoader(calls *int64, delay time.Duration) func(ctx context.Context, id int) (profile, error) {
+	return func(ctx context.Context, id int) (profile, error) {
+		atomic.AddInt64(calls, 1)
+		time.Sleep(delay)
+		switch {
+		case id < 0:
+			return profile{}, errNotFound
+		case id == 0:
+			return profile{}, errors.New("transient")
+		}
+		return profile{ID: id, Name: "user" + strconv.Itoa(id)}, nil
+	}
+}
+
+// TestMemoize verifies that repeated calls are served from the cache until the TTL elapses.
+func TestMemoize(t *testing.T) {
+	var calls int64
+	get := typed.Memoize(memory.New(), "profile:", 50*time.Millisecond, strconv.Itoa, countingLoader(&calls, 0))
+
+	ctx := context.Background()
+	for i := 0; i < 3; i++ {
+		p, err := get(ctx, 7)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if p != (profile{ID: 7, Name: "user7"}) {
+			t.Fatalf("unexpected profile: %+v", p)
+		}
+	}
+	if calls != 1 {
+		t.Fatalf("expected 1 call, got %d", calls)
+	}
+
+	time.Sleep(60 * time.Millisecond)
+	if _, err := get(ctx, 7); err != nil {
+		t.Fatal(err)
+	}
+	if calls != 2 {
+		t.Fatalf("expected 2 calls after the TTL, got %d", calls)
+	}
+}
+
+// TestMemoize_Concurrent verifies that concurrent calls for one key share a single invocation.
+func TestMemoize_Concurrent(t *testing.T) {
+	var calls int64
+	get := typed.Memoize(memory.New(), "profile:", time.Minute, strconv.Itoa, countingLoader(&calls, 50*time.Millisecond))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if p, err := get(context.Background(), 3); err != nil || p.ID != 3 {
+				t.Errorf("unexpected result: %+v, %v", p, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if calls != 1 {
+		t.Fatalf("expected 1 call, got %d", calls)
+	}
+}
+
+// TestMemoize_Panic verifies that a panic in fn reaches the caller running it and every caller
+// waiting for it, instead of handing them a zero value.
+func TestMemoize_Panic(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	var once sync.Once
+	get := typed.Memoize(memory.New(), "profile:", time.Minute, strconv.Itoa,
+		func(ctx context.Context, id int) (profile, error) {
+			once.Do(func() { close(started) })
+			<-release
+			panic("boom")
+		})
+
+	var (
+		wg        sync.WaitGroup
+		mu        sync.Mutex
+		recovered []interface{}
+	)
+	call := func() {
+		defer wg.Done()
+		defer func() {
+			mu.Lock()
+			recovered = append(recovered, recover())
+			mu.Unlock()
+		}()
+		_, _ = get(context.Background(), 4)
+	}
+
+	wg.Add(1)
+	go call()
+	<-started
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go call()
+	}
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if len(recovered) != 6 {
+		t.Fatalf("expected 6 callers, got %d", len(recovered))
+	}
+	for _, r := range recovered {
+		if r != "boom" {
+			t.Fatalf("expected every caller to panic with boom, got %v", r)
+		}
+	}
+}
+
+// TestMemoize_Errors verifies that errors are not cached unless negative caching lists them.
+func TestMemoize_Errors(t *testing.T) {
+	var calls int64
+	get := typed.Memoize(memory.New(), "profile:", time.Minute, strconv.Itoa, countingLoader(&calls, 0),
+		typed.NewMemoizeOptions().SetNegativeCaching(50*time.Millisecond, errNotFound))
+
+	ctx := context.Background()
+	for i := 0; i < 2; i++ {
+		if _, err := get(ctx, 0); err == nil {
+			t.Fatal("expected the transient error")
+		}
+	}
+	if calls != 2 {
+		t.Fatalf("transient error cached: %d calls", calls)
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := get(ctx, -1); !errors.Is(err, errNotFound) {
+			t.Fatal("expected errNotFound, got", err)
+		}
+	}
+	if calls != 3 {
+		t.Fatalf("negative result not cached: %d calls", calls)
+	}
+
+	time.Sleep(60 * time.Millisecond)
+	if _, err := get(ctx, -1); !errors.Is(err, errNotFound) {
+		t.Fatal("expected errNotFound, got", err)
+	}
+	if calls != 4 {
+		t.Fatalf("negative result outlived its TTL: %d calls", calls)
+	}
+}
+
+// TestMemoize_InvalidOptions verifies that invalid options surface on every call.
+func TestMemoize_InvalidOptions(t *testing.T) {
+	var calls int64
+	get := typed.Memoize(memory.New(), "profile:", time.Minute, strconv.Itoa, countingLoader(&calls, 0),
+		typed.NewMemoizeOptions().SetNegativeCaching(0, errNotFound))
+
+	if _, err := get(context.Background(), 1); err == nil {
+		t.Fatal("expected an options error")
+	}
+	if calls != 0 {
+		t.Fatalf("fn called despite invalid options: %d calls", calls)
+	}
+}
+
+// TestMemoize_StaleOnError verifies that a failing function is answered with the expired result
+// within the grace window, and with its error afterwards.
+func TestMemoize_StaleOnError(t *testing.T) {
+	var failing int32
+	load := func(ctx context.Context, id int) (profile, error) {
+		if atomic.LoadInt32(&failing) == 1 {
+			return profile{}, errors.New("upstream down")
+		}
+		return profile{ID: id, Name: "user" + strconv.Itoa(id)}, nil
+	}
+	get := typed.Memoize(memory.New(), "profile:", 50*time.Millisecond, strconv.Itoa, load,
+		typed.NewMemoizeOptions().SetStaleOnError(200*time.Millisecond))
+
+	ctx := context.Background()
+	if _, err := get(ctx, 7); err != nil {
+		t.Fatal(err)
+	}
+
+	atomic.StoreInt32(&failing, 1)
+	time.Sleep(60 * time.Millisecond)
+	p, err := get(ctx, 7)
+	if err != nil {
+		t.Fatal("expected the stale result, got", err)
+	}
+	if p != (profile{ID: 7, Name: "user7"}) {
+		t.Fatalf("unexpected profile: %+v", p)
+	}
+
+	if _, err := get(ctx, 8); err == nil {
+		t.Fatal("expected the error for a key never loaded")
+	}
+
+	time.Sleep(250 * time.Millisecond)
+	if _, err := get(ctx, 7); err == nil {
+		t.Fatal("expected the error once the grace window elapsed")
+	}
+}